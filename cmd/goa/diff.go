@@ -0,0 +1,58 @@
+package main
+
+import (
+	"go/build"
+	"os"
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen/diff"
+)
+
+// compareDesigns evaluates the design packages at oldPath and newPath and
+// prints the changes made to the old design that result in the new design
+// either as text or as JSON. It returns true if any of the changes breaks
+// existing clients.
+func compareDesigns(oldPath, newPath string, asJSON, debug bool) (bool, error) {
+	old, err := snapshot(oldPath, debug)
+	if err != nil {
+		return false, err
+	}
+	new, err := snapshot(newPath, debug)
+	if err != nil {
+		return false, err
+	}
+	changes := diff.Compare(old, new)
+	if asJSON {
+		err = diff.WriteJSON(os.Stdout, changes)
+	} else {
+		err = diff.WriteText(os.Stdout, changes)
+	}
+	return diff.Breaking(changes), err
+}
+
+// snapshot evaluates the design package at path and returns its snapshot.
+func snapshot(path string, debug bool) (*diff.Snapshot, error) {
+	if _, err := build.Import(path, ".", 0); err != nil {
+		return nil, err
+	}
+	output, err := os.MkdirTemp(".", "goa-snapshot")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(output)
+
+	tmp := NewGenerator("snapshot", path, output)
+	if !debug {
+		defer tmp.Remove()
+	}
+	if err := tmp.Write(debug); err != nil {
+		return nil, err
+	}
+	if err := tmp.Compile(); err != nil {
+		return nil, err
+	}
+	if _, err := tmp.Run(); err != nil {
+		return nil, err
+	}
+	return diff.Load(filepath.Join(output, diff.SnapshotFile))
+}
@@ -33,6 +33,10 @@ type Generator struct {
 	// DesignVersion is either 2 or 3.
 	DesignVersion int
 
+	// Check indicates whether the generator compares the generated code
+	// with the code in the output directory instead of writing it.
+	Check bool
+
 	// bin is the filename of the generated generator.
 	bin string
 
@@ -117,9 +121,12 @@ func (g *Generator) Write(_ bool) error {
 	{
 		data := map[string]any{
 			"Command":       g.Command,
-			"CleanupDirs":   cleanupDirs(g.Command, g.Output),
+			"Check":         g.Check,
 			"DesignVersion": g.DesignVersion,
 		}
+		if !g.Check {
+			data["CleanupDirs"] = cleanupDirs(g.Command, g.Output)
+		}
 		ver := ""
 		if g.DesignVersion > 2 {
 			ver = "v" + strconv.Itoa(g.DesignVersion) + "/"
@@ -186,21 +193,25 @@ func (g *Generator) Compile() error {
 func (g *Generator) Run() ([]string, error) {
 	var cmdl string
 	{
-		args := make([]string, len(os.Args)-1)
+		args := make([]string, 0, len(os.Args)-1)
 		gopaths := filepath.SplitList(os.Getenv("GOPATH"))
 		if len(gopaths) == 0 {
 			gopaths = []string{build.Default.GOPATH}
 		}
-		for i, a := range os.Args[1:] {
+		for _, a := range os.Args[1:] {
+			if isCheckFlag(a) {
+				// The generated code must render the command line
+				// used to write it.
+				continue
+			}
+			arg := a
 			for _, p := range gopaths {
 				if strings.HasPrefix(a, p) {
-					args[i] = strings.Replace(a, p, "$(GOPATH)", 1)
+					arg = strings.Replace(a, p, "$(GOPATH)", 1)
 					break
 				}
 			}
-			if args[i] == "" {
-				args[i] = a
-			}
+			args = append(args, arg)
 		}
 		cmdl = " " + strings.Join(args, " ")
 		rawcmd := filepath.Base(os.Args[0])
@@ -254,6 +265,13 @@ func (g *Generator) runGoCmd(args ...string) error {
 	return nil
 }
 
+// isCheckFlag returns true if arg is the command line flag that enables the
+// check mode.
+func isCheckFlag(arg string) bool {
+	name, _, _ := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+	return strings.HasPrefix(arg, "-") && name == "check"
+}
+
 // cleanupDirs returns the paths of the subdirectories under gendir to delete
 // before generating code.
 func cleanupDirs(cmd, output string) []string {
@@ -328,12 +346,21 @@ const mainT = `func main() {
 {{- if gt .DesignVersion 2 }}
 	codegen.DesignVersion = ver
 {{- end }}
+{{- if .Check }}
+	diff, err := generator.Check(*out, {{ printf "%q" .Command }})
+	if err != nil {
+		fail(err.Error())
+	}
+
+	fmt.Print(diff)
+{{- else }}
 	outputs, err := generator.Generate(*out, {{ printf "%q" .Command }})
 	if err != nil {
 		fail(err.Error())
 	}
 
 	fmt.Println(strings.Join(outputs, "\n"))
+{{- end }}
 }
 
 func fail(msg string, vals ...any) {
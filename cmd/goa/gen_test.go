@@ -0,0 +1,24 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+)
+
+func TestCleanupDirs(t *testing.T) {
+	output := t.TempDir()
+	for _, dir := range []string{"http", "svc"} {
+		if err := os.MkdirAll(filepath.Join(output, codegen.Gendir, dir), 0750); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cases := map[string]int{"gen": 2, "mock": 0, "example": 0, "openapi": 0}
+	for cmd, expected := range cases {
+		if dirs := cleanupDirs(cmd, output); len(dirs) != expected {
+			t.Errorf("%s: got %d directories to delete %v, expected %d", cmd, len(dirs), dirs, expected)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"go/build"
 	"os"
@@ -13,9 +14,10 @@ import (
 
 func main() {
 	var (
-		cmd    string
-		path   string
-		offset int
+		cmd     string
+		path    string
+		newPath string
+		offset  int
 	)
 	{
 		if len(os.Args) == 1 {
@@ -26,13 +28,30 @@ func main() {
 		case "version":
 			fmt.Println("Goa version " + goa.Version())
 			os.Exit(0)
-		case "gen", "example":
+		case "gen", "example", "mock":
 			if len(os.Args) == 2 {
 				usage()
 			}
 			cmd = os.Args[1]
 			path = os.Args[2]
 			offset = 2
+		case "openapi":
+			if len(os.Args) < 4 || os.Args[2] != "import" {
+				usage()
+				break
+			}
+			cmd = os.Args[1]
+			path = os.Args[3]
+			offset = 3
+		case "diff":
+			if len(os.Args) < 4 {
+				usage()
+				break
+			}
+			cmd = os.Args[1]
+			path = os.Args[2]
+			newPath = os.Args[3]
+			offset = 3
 		default:
 			usage()
 		}
@@ -40,7 +59,10 @@ func main() {
 
 	var (
 		output = "."
+		check  bool
 		debug  bool
+		asJSON bool
+		args   []string
 	)
 	if len(os.Args) > offset+1 {
 		var (
@@ -48,7 +70,9 @@ func main() {
 			o    = fset.String("o", "", "output `directory`")
 			out  = fset.String("output", output, "output `directory`")
 		)
+		fset.BoolVar(&check, "check", false, "Check that the generated code is up to date")
 		fset.BoolVar(&debug, "debug", false, "Print debug information")
+		fset.BoolVar(&asJSON, "json", false, "Print changes as JSON")
 
 		fset.Usage = usage
 		if err := fset.Parse(os.Args[offset+1:]); err != nil {
@@ -60,9 +84,35 @@ func main() {
 		if output == "" {
 			output = *out
 		}
+		args = fset.Args()
 	}
 
-	if err := gen(cmd, path, output, debug); err != nil {
+	if err := validateFlags(cmd, check, asJSON); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+
+	if cmd == "diff" {
+		breaking, err := compare(path, newPath, asJSON, debug)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		if breaking {
+			os.Exit(2)
+		}
+		return
+	}
+
+	if cmd == "mock" {
+		if err := mock(path, output, args, debug); err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+
+	if err := gen(cmd, path, output, check, debug); err != nil {
 		fmt.Fprintln(os.Stderr, err.Error())
 		os.Exit(1)
 	}
@@ -70,22 +120,49 @@ func main() {
 
 // help with tests
 var (
-	usage = help
-	gen   = generate
+	usage   = help
+	gen     = generate
+	compare = compareDesigns
+	serve   = runMockServer
 )
 
-func generate(cmd, path, output string, debug bool) error {
+// validateFlags returns an error if a flag that only applies to a specific
+// command is given to another command.
+func validateFlags(cmd string, check, asJSON bool) error {
+	if check && cmd != "gen" {
+		return errors.New("the -check flag is only supported by the gen command")
+	}
+	if asJSON && cmd != "diff" {
+		return errors.New("the -json flag is only supported by the diff command")
+	}
+	return nil
+}
+
+func generate(cmd, path, output string, check, debug bool) error {
 	var (
 		files []string
 		err   error
 		tmp   *Generator
 	)
 
+	if cmd == "openapi" {
+		if files, err = importOpenAPI(path, output); err != nil {
+			return err
+		}
+		fmt.Println(strings.Join(files, "\n"))
+		return nil
+	}
+
 	if _, err = build.Import(path, ".", 0); err != nil {
 		goto fail
 	}
 
 	tmp = NewGenerator(cmd, path, output)
+	tmp.Check = check
+	if check && tmp.DesignVersion < 3 {
+		err = errors.New("the -check flag requires a design using Goa v3 or later")
+		goto fail
+	}
 
 	if err = tmp.Write(debug); err != nil {
 		goto fail
@@ -99,10 +176,20 @@ func generate(cmd, path, output string, debug bool) error {
 		goto fail
 	}
 
-	fmt.Println(strings.Join(files, "\n"))
 	if !debug {
 		tmp.Remove()
 	}
+	if check {
+		if len(files) > 0 {
+			fmt.Println(strings.Join(files, "\n"))
+			return errors.New("generated code is out of date")
+		}
+		return nil
+	}
+	if cmd != "mock" {
+		// The mock command generates code in a temporary directory.
+		fmt.Println(strings.Join(files, "\n"))
+	}
 	return nil
 fail:
 	if !debug && tmp != nil {
@@ -116,8 +203,11 @@ func help() {
 Learn more at https://goa.design.
 
 Usage:
-  goa gen PACKAGE [--output DIRECTORY] [--debug]
+  goa gen PACKAGE [--output DIRECTORY] [--check] [--debug]
   goa example PACKAGE [--output DIRECTORY] [--debug]
+  goa mock PACKAGE [--output DIRECTORY] [--debug] [-- MOCK_FLAGS]
+  goa openapi import FILE [--output DIRECTORY]
+  goa diff OLD_PACKAGE NEW_PACKAGE [--json] [--debug]
   goa version
 
 Commands:
@@ -125,17 +215,45 @@ Commands:
         Generate service interfaces, endpoints, transport code and OpenAPI spec.
   example
         Generate example server and client tool.
+  mock
+        Generate a mock server that answers requests with the design
+        examples in a temporary directory, then build and run it. The
+        code generated by gen is left untouched.
+        Clients may request design errors or scenarios using the
+        X-Goa-Mock-Error and X-Goa-Mock-Scenario HTTP headers (or
+        x-goa-mock-error and x-goa-mock-scenario gRPC metadata).
+  openapi import
+        Generate a design package from an OpenAPI 3 document.
+  diff
+        Report the changes between two versions of a design and exit with
+        status 2 if any change breaks existing clients.
   version
         Print version information.
 
 Args:
   PACKAGE
         Go import path to design package
+  FILE
+        Path to OpenAPI 3 document in JSON or YAML format
+  OLD_PACKAGE, NEW_PACKAGE
+        Go import paths to the design packages to compare
+  MOCK_FLAGS
+        Flags given to the mock server: -http-addr ADDRESS, -grpc-addr
+        ADDRESS and -scenarios FILE where FILE is a JSON file listing the
+        scenarios that override the default responses
 
 Flags:
   -o, -output DIRECTORY
         output directory, defaults to the current working directory
 
+  -check
+        Check that the code generated by gen is up to date without writing
+        it, print the unified diff of the changed, missing and extra files
+        and exit with a non-zero status if it is not
+
+  -json
+        Print the changes reported by diff as JSON
+
   -debug
         Print debug information (mainly intended for Goa developers)
 
@@ -2,6 +2,7 @@ package main
 
 import (
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -15,11 +16,14 @@ func TestCmdLine(t *testing.T) {
 		usageCalled  bool
 		cmd          string
 		path, output string
-		debug        bool
+		check, debug bool
 	)
 
 	usage = func() { usageCalled = true }
-	gen = func(c string, p, o string, d bool) error { cmd, path, output, debug = c, p, o, d; return nil }
+	gen = func(c string, p, o string, ch, d bool) error {
+		cmd, path, output, check, debug = c, p, o, ch, d
+		return nil
+	}
 	defer func() {
 		usage = help
 		gen = generate
@@ -31,18 +35,23 @@ func TestCmdLine(t *testing.T) {
 		ExpectedCommand string
 		ExpectedPath    string
 		ExpectedOutput  string
+		ExpectedCheck   bool
 		ExpectedDebug   bool
 	}{
-		"gen": {"gen " + testPkg, false, "gen", testPkg, ".", false},
+		"gen": {"gen " + testPkg, false, "gen", testPkg, ".", false, false},
+
+		"invalid":     {"invalid " + testPkg, true, "", "", ".", false, false},
+		"empty":       {"", true, "", "", ".", false, false},
+		"invalid gen": {"invalid gen" + testPkg, true, "", "", ".", false, false},
 
-		"invalid":     {"invalid " + testPkg, true, "", "", ".", false},
-		"empty":       {"", true, "", "", ".", false},
-		"invalid gen": {"invalid gen" + testPkg, true, "", "", ".", false},
+		"output":       {"gen " + testPkg + " -output " + testOutput, false, "gen", testPkg, testOutput, false, false},
+		"output short": {"gen " + testPkg + " -o " + testOutput, false, "gen", testPkg, testOutput, false, false},
 
-		"output":       {"gen " + testPkg + " -output " + testOutput, false, "gen", testPkg, testOutput, false},
-		"output short": {"gen " + testPkg + " -o " + testOutput, false, "gen", testPkg, testOutput, false},
+		"check": {"gen " + testPkg + " -check", false, "gen", testPkg, ".", true, false},
+		"debug": {"gen " + testPkg + " -debug", false, "gen", testPkg, ".", false, true},
 
-		"debug": {"gen " + testPkg + " -debug", false, "gen", testPkg, ".", true},
+		"openapi import":         {"openapi import openapi.yaml -o " + testOutput, false, "openapi", "openapi.yaml", testOutput, false, false},
+		"openapi missing import": {"openapi openapi.yaml", true, "", "", ".", false, false},
 	}
 
 	for k, c := range cases {
@@ -53,6 +62,7 @@ func TestCmdLine(t *testing.T) {
 			cmd = ""
 			path = ""
 			output = ""
+			check = false
 			debug = false
 		}
 
@@ -70,8 +80,156 @@ func TestCmdLine(t *testing.T) {
 		if output != c.ExpectedOutput {
 			t.Errorf("%s: Expected output to be %s but got %s", k, c.ExpectedOutput, output)
 		}
+		if check != c.ExpectedCheck {
+			t.Errorf("%s: Expected check to be %v but got %v", k, c.ExpectedCheck, check)
+		}
 		if debug != c.ExpectedDebug {
 			t.Errorf("%s: Expected debug to be %v but got %v", k, c.ExpectedDebug, debug)
 		}
 	}
 }
+
+func TestDiffCmdLine(t *testing.T) {
+	var (
+		usageCalled      bool
+		oldPath, newPath string
+		asJSON           bool
+	)
+
+	usage = func() { usageCalled = true }
+	gen = func(string, string, string, bool, bool) error { return nil }
+	compare = func(o, n string, j, _ bool) (bool, error) { oldPath, newPath, asJSON = o, n, j; return false, nil }
+	defer func() {
+		usage = help
+		gen = generate
+		compare = compareDesigns
+	}()
+
+	cases := map[string]struct {
+		CmdLine         string
+		ExpectedUsage   bool
+		ExpectedOldPath string
+		ExpectedNewPath string
+		ExpectedJSON    bool
+	}{
+		"diff":         {"diff /old /new", false, "/old", "/new", false},
+		"diff json":    {"diff /old /new -json", false, "/old", "/new", true},
+		"diff missing": {"diff /old", true, "", "", false},
+	}
+
+	for k, c := range cases {
+		os.Args = append([]string{"goa"}, strings.Split(c.CmdLine, " ")...)
+		usageCalled = false
+		oldPath, newPath, asJSON = "", "", false
+
+		main()
+
+		if usageCalled != c.ExpectedUsage {
+			t.Errorf("%s: Expected usage to be %v but got %v", k, c.ExpectedUsage, usageCalled)
+		}
+		if oldPath != c.ExpectedOldPath {
+			t.Errorf("%s: Expected old path to be %s but got %s", k, c.ExpectedOldPath, oldPath)
+		}
+		if newPath != c.ExpectedNewPath {
+			t.Errorf("%s: Expected new path to be %s but got %s", k, c.ExpectedNewPath, newPath)
+		}
+		if asJSON != c.ExpectedJSON {
+			t.Errorf("%s: Expected json to be %v but got %v", k, c.ExpectedJSON, asJSON)
+		}
+	}
+}
+
+func TestMockCmdLine(t *testing.T) {
+	var (
+		usageCalled bool
+		cmd, path   string
+		genOutput   string
+		output      string
+		args        []string
+	)
+
+	usage = func() { usageCalled = true }
+	gen = func(c, p, o string, _, _ bool) error { cmd, path, genOutput = c, p, o; return nil }
+	serve = func(o string, a []string) error {
+		output, args = o, a
+		if _, err := os.Stat(o); err != nil {
+			t.Errorf("Expected mock server directory to exist: %v", err)
+		}
+		return nil
+	}
+	defer func() {
+		usage = help
+		gen = generate
+		serve = runMockServer
+	}()
+
+	out := t.TempDir()
+	cases := map[string]struct {
+		CmdLine        string
+		ExpectedPath   string
+		ExpectedOutput string
+		ExpectedArgs   []string
+	}{
+		"mock output": {"mock /test -o " + out, "/test", out, nil},
+		"mock args":   {"mock /test -o " + out + " -- -http-addr :9090", "/test", out, []string{"-http-addr", ":9090"}},
+	}
+
+	for k, c := range cases {
+		os.Args = append([]string{"goa"}, strings.Split(c.CmdLine, " ")...)
+		usageCalled = false
+		cmd, path, genOutput, output, args = "", "", "", "", nil
+
+		main()
+
+		if usageCalled {
+			t.Errorf("%s: Expected usage not to be called", k)
+		}
+		if cmd != "mock" {
+			t.Errorf("%s: Expected command to be mock but got %s", k, cmd)
+		}
+		if path != c.ExpectedPath {
+			t.Errorf("%s: Expected path to be %s but got %s", k, c.ExpectedPath, path)
+		}
+		if filepath.Dir(output) != c.ExpectedOutput {
+			t.Errorf("%s: Expected mock server directory to be in %s but got %s", k, c.ExpectedOutput, output)
+		}
+		if genOutput != output {
+			t.Errorf("%s: Expected code to be generated in %s but got %s", k, output, genOutput)
+		}
+		if _, err := os.Stat(output); !os.IsNotExist(err) {
+			t.Errorf("%s: Expected mock server directory %s to be deleted", k, output)
+		}
+		if strings.Join(args, " ") != strings.Join(c.ExpectedArgs, " ") {
+			t.Errorf("%s: Expected args to be %v but got %v", k, c.ExpectedArgs, args)
+		}
+	}
+}
+
+func TestValidateFlags(t *testing.T) {
+	cases := map[string]struct {
+		Cmd           string
+		Check, AsJSON bool
+		ExpectedError string
+	}{
+		"gen":           {"gen", false, false, ""},
+		"gen check":     {"gen", true, false, ""},
+		"diff json":     {"diff", false, true, ""},
+		"example check": {"example", true, false, "the -check flag is only supported by the gen command"},
+		"gen json":      {"gen", false, true, "the -json flag is only supported by the diff command"},
+		"example json":  {"example", false, true, "the -json flag is only supported by the diff command"},
+		"mock json":     {"mock", false, true, "the -json flag is only supported by the diff command"},
+	}
+
+	for k, c := range cases {
+		err := validateFlags(c.Cmd, c.Check, c.AsJSON)
+		if c.ExpectedError == "" {
+			if err != nil {
+				t.Errorf("%s: Expected no error but got %v", k, err)
+			}
+			continue
+		}
+		if err == nil || err.Error() != c.ExpectedError {
+			t.Errorf("%s: Expected error %q but got %v", k, c.ExpectedError, err)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+)
+
+// mock generates the mock server together with the service and transport code
+// it uses in a temporary directory created under the output directory, then
+// builds and runs the mock server with the given command line arguments. The
+// code generated by gen in the output directory is left untouched. The
+// temporary directory is deleted once the mock server exits unless debug is
+// true.
+func mock(path, output string, args []string, debug bool) error {
+	if err := os.MkdirAll(output, 0750); err != nil {
+		return err
+	}
+	dir, err := os.MkdirTemp(output, "goamock")
+	if err != nil {
+		return err
+	}
+	if !debug {
+		defer os.RemoveAll(dir)
+	}
+	if err := gen("mock", path, dir, false, debug); err != nil {
+		return err
+	}
+	return serve(dir, args)
+}
+
+// runMockServer builds and runs the mock server generated in the output
+// directory with the given command line arguments. It returns once the mock
+// server exits.
+func runMockServer(output string, args []string) error {
+	gobin, err := exec.LookPath("go")
+	if err != nil {
+		return err
+	}
+	// Interrupts stop the mock server, wait for it to exit so that the
+	// generated code can be deleted.
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt)
+	defer signal.Stop(sig)
+
+	pkg := "." + string(filepath.Separator) + filepath.Join(codegen.Gendir, "mockserver")
+	cmd := exec.Command(gobin, append([]string{"run", pkg}, args...)...)
+	cmd.Dir = output
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		select {
+		case <-sig:
+			// The mock server was interrupted.
+			return nil
+		default:
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"goa.design/goa/v3/http/codegen/openapi/importer"
+)
+
+// importOpenAPI generates a design package in the "design" directory under
+// output from the OpenAPI 3 document at path. It prints the constructs that
+// could not be mapped to the Goa DSL to stderr and returns the paths of the
+// generated files.
+func importOpenAPI(path, output string) ([]string, error) {
+	doc, err := importer.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	design, err := importer.Import(doc, "design")
+	if err != nil {
+		return nil, err
+	}
+	dir := filepath.Join(output, "design")
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, err
+	}
+	file := filepath.Join(dir, "design.go")
+	if _, err := os.Stat(file); err == nil {
+		return nil, fmt.Errorf("%s already exists", file)
+	}
+	if err := os.WriteFile(file, design.Source, 0644); err != nil {
+		return nil, err
+	}
+	for _, w := range design.Warnings {
+		fmt.Fprintln(os.Stderr, "warning: "+w)
+	}
+	return []string{file}, nil
+}
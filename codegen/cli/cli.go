@@ -56,6 +56,11 @@ type (
 		Conversion string
 		// Example is a valid command invocation, starting with the command name.
 		Example string
+		// AllEndpoint is the qualified name of the function that wraps the
+		// endpoint of a paginated method into an endpoint that retrieves
+		// all the pages, e.g. "storage.NewListAllEndpoint". It is empty if
+		// the method is not paginated.
+		AllEndpoint string
 	}
 
 	// FlagData contains the data needed to render a command-line flag.
@@ -217,6 +222,9 @@ func BuildSubcommandData(svcName string, m *service.MethodData, buildFunction *B
 		BuildFunction: buildFunction,
 		Conversion:    conversion,
 	}
+	if m.Pagination != nil {
+		sub.AllEndpoint = service.Services.Get(svcName).PkgName + "." + m.Pagination.AllEndpoint
+	}
 	generateExample(sub, svcName)
 
 	return sub
@@ -397,6 +405,29 @@ func FieldLoadCode(f *FlagData, argName, argTypeName, validate string, defaultVa
 	return fmt.Sprintf("%s%s%s", startIf, code, endIf), declErr
 }
 
+// EnumFieldLoadCode returns the code used in the build payload function that
+// initializes a payload object field whose type uses the "type:enum" meta.
+// The code calls the parse function generated for the type given its
+// qualified name and type reference, it requires an "err" variable.
+func EnumFieldLoadCode(f *FlagData, argName, parseFunc, typeRef string, defaultValue any, payload expr.DataType, payloadRef string) string {
+	nilVal := "nil"
+	var zero string
+	if expr.IsPrimitive(payload) {
+		zero = fmt.Sprintf("var zero %s\n", payloadRef)
+		nilVal = "zero"
+	}
+	check := fmt.Sprintf("if err != nil {\n%sreturn %s, err\n}", zero, nilVal)
+	if f.Required || defaultValue != nil {
+		code := fmt.Sprintf("%s, err = %s(%s)\n%s", argName, parseFunc, f.FullName, check)
+		if f.Required {
+			return code
+		}
+		return fmt.Sprintf("if %s != \"\" {\n%s\n}", f.FullName, code)
+	}
+	return fmt.Sprintf("if %s != \"\" {\nvar val %s\nval, err = %s(%s)\n%s = &val\n%s\n}",
+		f.FullName, typeRef, parseFunc, f.FullName, argName, check)
+}
+
 // flagType calculates the type of a flag
 func flagType(tname string) string {
 	switch tname {
@@ -404,6 +435,10 @@ func flagType(tname string) string {
 		return strings.ToUpper(tname)
 	case bytesN:
 		return "STRING"
+	case decimalN, decimalN + "String":
+		return "DECIMAL"
+	case bigIntN, bigIntN + "String":
+		return "BIGINT"
 	default: // Any, Array, Map, Object, User
 		return "JSON"
 	}
@@ -463,6 +498,8 @@ var (
 	float64N = codegen.GoNativeTypeName(expr.Float64)
 	stringN  = codegen.GoNativeTypeName(expr.String)
 	bytesN   = codegen.GoNativeTypeName(expr.Bytes)
+	decimalN = codegen.GoNativeTypeName(expr.Decimal)
+	bigIntN  = codegen.GoNativeTypeName(expr.BigInt)
 )
 
 // conversionCode produces the code that converts the string contained in the
@@ -524,6 +561,12 @@ func conversionCode(from, to, typeName string, pointer bool) (string, bool, bool
 		parse = fmt.Sprintf("%s %s= []byte(%s)", target, decl, from)
 		declErr = false
 		checkErr = false
+	case decimalN, decimalN + "String":
+		parse = fmt.Sprintf("var v goa.Decimal\nv, err = goa.ParseDecimal(%s)", from)
+		cast = fmt.Sprintf("%s %s= %s(v)", target, decl, typeName)
+	case bigIntN, bigIntN + "String":
+		parse = fmt.Sprintf("var v goa.BigInt\nv, err = goa.ParseBigInt(%s)", from)
+		cast = fmt.Sprintf("%s %s= %s(v)", target, decl, typeName)
 	default:
 		parse = fmt.Sprintf("err = json.Unmarshal([]byte(%s), &%s)", from, target)
 	}
@@ -614,6 +657,9 @@ const parseFlagsT = `var (
 		{{- range .Flags }}
 		{{ .FullName }}Flag = {{ $sub.FullName }}Flags.String("{{ .Name }}", "{{ if .Default }}{{ .Default }}{{ else if .Required }}REQUIRED{{ end }}", {{ printf "%q" .Description }})
 		{{- end }}
+		{{- if .AllEndpoint }}
+		{{ .FullName }}AllFlag = {{ .FullName }}Flags.Bool("all", false, "Retrieve the items of all the pages")
+		{{- end }}
 		{{ end }}
 		{{- end }}
 	)
@@ -700,12 +746,15 @@ Additional help:
 
 {{- range .Subcommands }}
 func {{ .FullName }}Usage() {
-	fmt.Fprintf(os.Stderr, ` + "`" + `%[1]s [flags] {{ $.Name }} {{ .Name }}{{range .Flags }} -{{ .Name }} {{ .Type }}{{ end }}
+	fmt.Fprintf(os.Stderr, ` + "`" + `%[1]s [flags] {{ $.Name }} {{ .Name }}{{range .Flags }} -{{ .Name }} {{ .Type }}{{ end }}{{ if .AllEndpoint }} [-all]{{ end }}
 
 {{ printDescription .Description}}
 	{{- range .Flags }}
     -{{ .Name }} {{ .Type }}: {{ .Description }}
 	{{- end }}
+	{{- if .AllEndpoint }}
+    -all: Retrieve the items of all the pages
+	{{- end }}
 
 Example:
     %[1]s {{ .Example }}
@@ -0,0 +1,545 @@
+package diff
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Severity classifies the impact of a change on existing clients.
+type Severity string
+
+const (
+	// SeverityBreaking identifies changes that break existing clients.
+	SeverityBreaking Severity = "breaking"
+	// SeverityWarning identifies changes that may break clients that rely
+	// on implementation details, for example clients that do not handle
+	// new enum values in results.
+	SeverityWarning Severity = "warning"
+	// SeverityInfo identifies backwards compatible changes.
+	SeverityInfo Severity = "info"
+)
+
+type (
+	// Change describes a difference between two design snapshots.
+	Change struct {
+		// Severity is the impact of the change.
+		Severity Severity `json:"severity"`
+		// Service is the name of the service affected by the change.
+		Service string `json:"service"`
+		// Method is the name of the method affected by the change if any.
+		Method string `json:"method,omitempty"`
+		// Path is the location of the change in the method, for example
+		// "payload.address.city" or "http".
+		Path string `json:"path,omitempty"`
+		// Message describes the change.
+		Message string `json:"message"`
+	}
+
+	// comparer holds the state used to compare two snapshots.
+	comparer struct {
+		old, new *Snapshot
+		// service and method identify the method being compared.
+		service, method string
+		// seen records the pairs of user types already compared to
+		// avoid infinite recursion.
+		seen map[[2]string]bool
+		// changes lists the changes found so far.
+		changes []*Change
+	}
+
+	// direction indicates whether an attribute is sent by clients or by
+	// servers.
+	direction int
+)
+
+const (
+	// request identifies attributes sent by clients.
+	request direction = iota + 1
+	// response identifies attributes sent by servers.
+	response
+)
+
+// Compare returns the changes made to the design described by old that
+// resulted in the design described by new. The changes are listed in order
+// of definition of the services and methods.
+func Compare(old, new *Snapshot) []*Change {
+	c := &comparer{old: old, new: new}
+	for _, osvc := range old.Services {
+		nsvc := findService(new, osvc.Name)
+		c.service, c.method = osvc.Name, ""
+		if nsvc == nil {
+			c.add(SeverityBreaking, "", "service removed")
+			continue
+		}
+		for _, om := range osvc.Methods {
+			nm := findMethod(nsvc, om.Name)
+			c.method = om.Name
+			if nm == nil {
+				c.add(SeverityBreaking, "", "method removed")
+				continue
+			}
+			c.compareMethod(om, nm)
+		}
+		for _, nm := range nsvc.Methods {
+			if findMethod(osvc, nm.Name) == nil {
+				c.method = nm.Name
+				c.add(SeverityInfo, "", "method added")
+			}
+		}
+	}
+	for _, nsvc := range new.Services {
+		if findService(old, nsvc.Name) == nil {
+			c.service, c.method = nsvc.Name, ""
+			c.add(SeverityInfo, "", "service added")
+		}
+	}
+	return c.changes
+}
+
+// Breaking returns true if any of the given changes is breaking.
+func Breaking(changes []*Change) bool {
+	for _, ch := range changes {
+		if ch.Severity == SeverityBreaking {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns a human readable description of the change.
+func (ch *Change) String() string {
+	loc := ch.Service
+	if ch.Method != "" {
+		loc += "." + ch.Method
+	}
+	if ch.Path != "" {
+		loc += " " + ch.Path
+	}
+	return fmt.Sprintf("%s: %s: %s", ch.Severity, loc, ch.Message)
+}
+
+// compareMethod records the changes made to a method.
+func (c *comparer) compareMethod(om, nm *Method) {
+	if om.Stream != nm.Stream {
+		c.add(SeverityBreaking, "", "streaming changed from %s to %s", streamKind(om.Stream), streamKind(nm.Stream))
+	}
+	c.seen = make(map[[2]string]bool)
+	c.compareAttribute("payload", om.Payload, nm.Payload, request)
+	c.compareAttribute("streaming_payload", om.StreamingPayload, nm.StreamingPayload, request)
+	c.seen = make(map[[2]string]bool)
+	c.compareAttribute("result", om.Result, nm.Result, response)
+	c.compareHTTP(om.HTTP, nm.HTTP)
+	c.compareGRPC(om.GRPC, nm.GRPC)
+}
+
+// compareHTTP records the changes made to the HTTP mapping of a method.
+func (c *comparer) compareHTTP(o, n *HTTPEndpoint) {
+	switch {
+	case o == nil && n == nil:
+		return
+	case o == nil:
+		c.add(SeverityInfo, "http", "HTTP endpoint added")
+		return
+	case n == nil:
+		c.add(SeverityBreaking, "http", "HTTP endpoint removed")
+		return
+	}
+	for _, r := range o.Routes {
+		if !contains(n.Routes, r) {
+			c.add(SeverityBreaking, "http", "route %q removed", r)
+		}
+	}
+	for _, r := range n.Routes {
+		if !contains(o.Routes, r) {
+			c.add(SeverityInfo, "http", "route %q added", r)
+		}
+	}
+	if !equalInts(o.Statuses, n.Statuses) {
+		c.add(SeverityBreaking, "http", "success status changed from %s to %s", joinInts(o.Statuses), joinInts(n.Statuses))
+	}
+	c.compareErrors("http", o.Errors, n.Errors)
+}
+
+// compareGRPC records the changes made to the gRPC mapping of a method.
+func (c *comparer) compareGRPC(o, n *GRPCEndpoint) {
+	switch {
+	case o == nil && n == nil:
+		return
+	case o == nil:
+		c.add(SeverityInfo, "grpc", "gRPC endpoint added")
+		return
+	case n == nil:
+		c.add(SeverityBreaking, "grpc", "gRPC endpoint removed")
+		return
+	}
+	c.compareErrors("grpc", o.Errors, n.Errors)
+}
+
+// compareErrors records the changes made to the status codes of the method
+// errors.
+func (c *comparer) compareErrors(path string, o, n map[string]int) {
+	for _, name := range sortedKeys(o) {
+		code, ok := n[name]
+		switch {
+		case !ok:
+			c.add(SeverityInfo, path, "error %q removed", name)
+		case code != o[name]:
+			c.add(SeverityBreaking, path, "error %q status changed from %d to %d", name, o[name], code)
+		}
+	}
+	for _, name := range sortedKeys(n) {
+		if _, ok := o[name]; !ok {
+			c.add(SeverityWarning, path, "error %q added with status %d", name, n[name])
+		}
+	}
+}
+
+// compareAttribute records the changes made to an attribute. dir indicates
+// whether the attribute is part of a request or of a response.
+func (c *comparer) compareAttribute(path string, o, n *Attribute, dir direction) {
+	switch {
+	case o == nil && n == nil:
+		return
+	case o == nil:
+		sev := SeverityInfo
+		if dir == request {
+			sev = SeverityBreaking
+		}
+		c.add(sev, "", "%s added", path)
+		return
+	case n == nil:
+		sev := SeverityWarning
+		if dir == response {
+			sev = SeverityBreaking
+		}
+		c.add(sev, "", "%s removed", path)
+		return
+	}
+	c.compareValidation(path, o.Validation, n.Validation, dir)
+	c.compareType(path, o, n, dir)
+}
+
+// compareType records the changes made to the type of an attribute.
+func (c *comparer) compareType(path string, o, n *Attribute, dir direction) {
+	if o.UserType || n.UserType {
+		if o.UserType && n.UserType {
+			key := [2]string{o.Type, n.Type}
+			if c.seen[key] {
+				return
+			}
+			c.seen[key] = true
+		}
+		if o.UserType {
+			o = c.old.Types[o.Type]
+		}
+		if n.UserType {
+			n = c.new.Types[n.Type]
+		}
+		c.compareAttribute(path, o, n, dir)
+		return
+	}
+	if o.Type != n.Type {
+		c.add(SeverityBreaking, path, "type changed from %s to %s", o.Type, n.Type)
+		return
+	}
+	switch o.Type {
+	case "array":
+		c.compareAttribute(path+"[]", o.Elem, n.Elem, dir)
+	case "map":
+		c.compareAttribute(path+"{key}", o.Key, n.Key, dir)
+		c.compareAttribute(path+"{}", o.Elem, n.Elem, dir)
+	case "object":
+		c.compareFields(path, o.Fields, n.Fields, dir)
+	case "union":
+		c.compareUnion(path, o.Fields, n.Fields, dir)
+	}
+}
+
+// compareFields records the changes made to the attributes of an object.
+func (c *comparer) compareFields(path string, o, n []*Field, dir direction) {
+	for _, of := range o {
+		fp := path + "." + of.Name
+		nf := findField(n, of.Name)
+		if nf == nil {
+			sev := SeverityWarning
+			if dir == response {
+				sev = SeverityBreaking
+			}
+			c.add(sev, fp, "attribute removed")
+			continue
+		}
+		switch {
+		case dir == request && !of.Required && nf.Required:
+			c.add(SeverityBreaking, fp, "attribute is now required")
+		case dir == response && of.Required && !nf.Required:
+			c.add(SeverityBreaking, fp, "attribute is no longer required")
+		}
+		if of.Tag != "" && nf.Tag != "" && of.Tag != nf.Tag {
+			c.add(SeverityBreaking, fp, "gRPC field number changed from %s to %s", of.Tag, nf.Tag)
+		}
+		c.compareAttribute(fp, of.Attribute, nf.Attribute, dir)
+	}
+	for _, nf := range n {
+		if findField(o, nf.Name) != nil {
+			continue
+		}
+		fp := path + "." + nf.Name
+		if dir == request && nf.Required {
+			c.add(SeverityBreaking, fp, "required attribute added")
+			continue
+		}
+		c.add(SeverityInfo, fp, "attribute added")
+	}
+}
+
+// compareUnion records the changes made to the types of a union.
+func (c *comparer) compareUnion(path string, o, n []*Field, dir direction) {
+	for _, of := range o {
+		nf := findField(n, of.Name)
+		if nf == nil {
+			sev := SeverityInfo
+			if dir == request {
+				sev = SeverityBreaking
+			}
+			c.add(sev, path, "union type %q removed", of.Name)
+			continue
+		}
+		c.compareAttribute(path+"("+of.Name+")", of.Attribute, nf.Attribute, dir)
+	}
+	for _, nf := range n {
+		if findField(o, nf.Name) == nil {
+			sev := SeverityInfo
+			if dir == response {
+				sev = SeverityWarning
+			}
+			c.add(sev, path, "union type %q added", nf.Name)
+		}
+	}
+}
+
+// compareValidation records the changes made to the validations of an
+// attribute. Narrowed validations break clients that send the attribute
+// while widened validations may break clients that receive it.
+func (c *comparer) compareValidation(path string, o, n *Validation, dir direction) {
+	narrowed, widened := compareValidations(o, n)
+	for _, msg := range narrowed {
+		sev := SeverityInfo
+		if dir == request {
+			sev = SeverityBreaking
+		}
+		c.add(sev, path, "%s", msg)
+	}
+	for _, msg := range widened {
+		sev := SeverityInfo
+		if dir == response {
+			sev = SeverityWarning
+		}
+		c.add(sev, path, "%s", msg)
+	}
+}
+
+// add records a change for the method being compared.
+func (c *comparer) add(sev Severity, path, format string, args ...any) {
+	c.changes = append(c.changes, &Change{
+		Severity: sev,
+		Service:  c.service,
+		Method:   c.method,
+		Path:     path,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// compareValidations returns the descriptions of the changes that narrow the
+// set of valid values and of the changes that widen it.
+func compareValidations(o, n *Validation) (narrowed, widened []string) {
+	if o == nil {
+		o = &Validation{}
+	}
+	if n == nil {
+		n = &Validation{}
+	}
+	lower := func(name string, ov, nv *float64) {
+		switch {
+		case ov == nil && nv == nil:
+		case ov == nil:
+			narrowed = append(narrowed, fmt.Sprintf("%s %s added", name, formatFloat(*nv)))
+		case nv == nil:
+			widened = append(widened, fmt.Sprintf("%s %s removed", name, formatFloat(*ov)))
+		case *nv > *ov:
+			narrowed = append(narrowed, fmt.Sprintf("%s raised from %s to %s", name, formatFloat(*ov), formatFloat(*nv)))
+		case *nv < *ov:
+			widened = append(widened, fmt.Sprintf("%s lowered from %s to %s", name, formatFloat(*ov), formatFloat(*nv)))
+		}
+	}
+	upper := func(name string, ov, nv *float64) {
+		switch {
+		case ov == nil && nv == nil:
+		case ov == nil:
+			narrowed = append(narrowed, fmt.Sprintf("%s %s added", name, formatFloat(*nv)))
+		case nv == nil:
+			widened = append(widened, fmt.Sprintf("%s %s removed", name, formatFloat(*ov)))
+		case *nv < *ov:
+			narrowed = append(narrowed, fmt.Sprintf("%s lowered from %s to %s", name, formatFloat(*ov), formatFloat(*nv)))
+		case *nv > *ov:
+			widened = append(widened, fmt.Sprintf("%s raised from %s to %s", name, formatFloat(*ov), formatFloat(*nv)))
+		}
+	}
+	lower("minimum", o.Minimum, n.Minimum)
+	lower("exclusive minimum", o.ExclusiveMinimum, n.ExclusiveMinimum)
+	upper("maximum", o.Maximum, n.Maximum)
+	upper("exclusive maximum", o.ExclusiveMaximum, n.ExclusiveMaximum)
+	lower("minimum length", intToFloat(o.MinLength), intToFloat(n.MinLength))
+	upper("maximum length", intToFloat(o.MaxLength), intToFloat(n.MaxLength))
+
+	switch {
+	case len(o.Enum) == 0 && len(n.Enum) > 0:
+		narrowed = append(narrowed, fmt.Sprintf("enum %s added", formatValues(n.Enum)))
+	case len(o.Enum) > 0 && len(n.Enum) == 0:
+		widened = append(widened, fmt.Sprintf("enum %s removed", formatValues(o.Enum)))
+	case len(o.Enum) > 0:
+		if removed := missing(o.Enum, n.Enum); len(removed) > 0 {
+			narrowed = append(narrowed, fmt.Sprintf("enum values %s removed", formatValues(removed)))
+		}
+		if added := missing(n.Enum, o.Enum); len(added) > 0 {
+			widened = append(widened, fmt.Sprintf("enum values %s added", formatValues(added)))
+		}
+	}
+
+	// Changes to patterns and formats cannot be analyzed and are reported
+	// as both narrowing and widening.
+	changed := func(name, ov, nv string) {
+		switch {
+		case ov == nv:
+		case ov == "":
+			narrowed = append(narrowed, fmt.Sprintf("%s %q added", name, nv))
+		case nv == "":
+			widened = append(widened, fmt.Sprintf("%s %q removed", name, ov))
+		default:
+			msg := fmt.Sprintf("%s changed from %q to %q", name, ov, nv)
+			narrowed = append(narrowed, msg)
+			widened = append(widened, msg)
+		}
+	}
+	changed("pattern", o.Pattern, n.Pattern)
+	changed("format", o.Format, n.Format)
+	return
+}
+
+func findService(s *Snapshot, name string) *Service {
+	for _, svc := range s.Services {
+		if svc.Name == name {
+			return svc
+		}
+	}
+	return nil
+}
+
+func findMethod(svc *Service, name string) *Method {
+	for _, m := range svc.Methods {
+		if m.Name == name {
+			return m
+		}
+	}
+	return nil
+}
+
+func findField(fields []*Field, name string) *Field {
+	for _, f := range fields {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// missing returns the values of vals that are not in others.
+func missing(vals, others []any) []any {
+	var res []any
+	for _, v := range vals {
+		found := false
+		for _, o := range others {
+			if fmt.Sprint(v) == fmt.Sprint(o) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+func streamKind(s string) string {
+	if s == "" {
+		return "none"
+	}
+	return s
+}
+
+func contains(vals []string, v string) bool {
+	for _, val := range vals {
+		if val == v {
+			return true
+		}
+	}
+	return false
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func joinInts(vals []int) string {
+	if len(vals) == 0 {
+		return "none"
+	}
+	elems := make([]string, len(vals))
+	for i, v := range vals {
+		elems[i] = strconv.Itoa(v)
+	}
+	return strings.Join(elems, ", ")
+}
+
+func intToFloat(i *int) *float64 {
+	if i == nil {
+		return nil
+	}
+	f := float64(*i)
+	return &f
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func formatValues(vals []any) string {
+	elems := make([]string, len(vals))
+	for i, v := range vals {
+		if s, ok := v.(string); ok {
+			elems[i] = strconv.Quote(s)
+			continue
+		}
+		elems[i] = fmt.Sprint(v)
+	}
+	return strings.Join(elems, ", ")
+}
+
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,101 @@
+package diff
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/diff/testdata"
+)
+
+func TestCompare(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Old      func()
+		New      func()
+		Expected []string
+		Breaking bool
+	}{
+		{"removed-method", testdata.RemovedMethodOldDSL, testdata.RemovedMethodNewDSL, []string{
+			"breaking: Service.Removed: method removed",
+		}, true},
+		{"required-attribute", testdata.RequiredAttributeOldDSL, testdata.RequiredAttributeNewDSL, []string{
+			"breaking: Service.Method payload.name: attribute is now required",
+			"breaking: Service.Method payload.email: required attribute added",
+		}, true},
+		{"narrowed-validations", testdata.NarrowedValidationsOldDSL, testdata.NarrowedValidationsNewDSL, []string{
+			"breaking: Service.Method payload.age: minimum raised from 1 to 5",
+			`breaking: Service.Method payload.kind: enum values "fish" removed`,
+			`breaking: Service.Method payload.name: pattern "^[a-z]+$" added`,
+		}, true},
+		{"result", testdata.ResultOldDSL, testdata.ResultNewDSL, []string{
+			"breaking: Service.Method result.id: attribute removed",
+			"breaking: Service.Method result.name: attribute is no longer required",
+			`warning: Service.Method result.status: enum values "deleted" added`,
+			"info: Service.Method result.tags: attribute added",
+		}, true},
+		{"http", testdata.HTTPOldDSL, testdata.HTTPNewDSL, []string{
+			`breaking: Service.Method http: route "GET /items" removed`,
+			`info: Service.Method http: route "GET /v2/items" added`,
+			"breaking: Service.Method http: success status changed from 200 to 201",
+			`breaking: Service.Method http: error "not_found" status changed from 404 to 410`,
+		}, true},
+		{"grpc", testdata.GRPCOldDSL, testdata.GRPCNewDSL, []string{
+			"breaking: Service.Method payload.a: gRPC field number changed from 1 to 2",
+			"breaking: Service.Method payload.b: gRPC field number changed from 2 to 3",
+		}, true},
+		{"compatible", testdata.CompatibleOldDSL, testdata.CompatibleNewDSL, []string{
+			"info: Service.Method payload.name: maximum length raised from 10 to 20",
+			"info: Service.Method payload.nickname: attribute added",
+			`info: Service.Method http: route "POST /names" added`,
+			"info: Service.Added: method added",
+			"info: Other: service added",
+		}, false},
+		{"recursive", testdata.RecursiveOldDSL, testdata.RecursiveNewDSL, []string{
+			"breaking: Service.Method result.value: type changed from int to string",
+		}, true},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			old := snapshot(t, c.Old)
+			new := snapshot(t, c.New)
+			changes := Compare(old, new)
+			actual := make([]string, len(changes))
+			for i, ch := range changes {
+				actual[i] = ch.String()
+			}
+			assert.Equal(t, c.Expected, actual)
+			assert.Equal(t, c.Breaking, Breaking(changes))
+			assert.Empty(t, Compare(new, new))
+		})
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	old := snapshot(t, testdata.RemovedMethodOldDSL)
+	new := snapshot(t, testdata.RemovedMethodNewDSL)
+	var buf bytes.Buffer
+	require.NoError(t, WriteJSON(&buf, Compare(old, new)))
+	assert.JSONEq(t, `{
+		"breaking": true,
+		"changes": [{"severity": "breaking", "service": "Service", "method": "Removed", "message": "method removed"}]
+	}`, buf.String())
+}
+
+// snapshot runs the given DSL and returns the snapshot of the resulting design
+// as read back from its JSON representation.
+func snapshot(t *testing.T, dsl func()) *Snapshot {
+	t.Helper()
+	root := codegen.RunDSL(t, dsl)
+	files := Files(root)
+	require.Len(t, files, 1)
+	var buf bytes.Buffer
+	require.NoError(t, files[0].SectionTemplates[0].Write(&buf))
+	var s Snapshot
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &s))
+	return &s
+}
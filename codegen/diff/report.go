@@ -0,0 +1,44 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Report is the JSON representation of the result of a comparison.
+type Report struct {
+	// Breaking is true if any of the changes is breaking.
+	Breaking bool `json:"breaking"`
+	// Changes lists the changes.
+	Changes []*Change `json:"changes"`
+}
+
+// WriteText writes a human readable description of the changes to w, one
+// change per line followed by a summary.
+func WriteText(w io.Writer, changes []*Change) error {
+	counts := make(map[Severity]int)
+	for _, ch := range changes {
+		counts[ch.Severity]++
+		if _, err := fmt.Fprintln(w, ch.String()); err != nil {
+			return err
+		}
+	}
+	if len(changes) == 0 {
+		_, err := fmt.Fprintln(w, "no changes")
+		return err
+	}
+	_, err := fmt.Fprintf(w, "%d breaking, %d warning, %d info\n",
+		counts[SeverityBreaking], counts[SeverityWarning], counts[SeverityInfo])
+	return err
+}
+
+// WriteJSON writes the JSON representation of the changes to w.
+func WriteJSON(w io.Writer, changes []*Change) error {
+	if changes == nil {
+		changes = []*Change{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(&Report{Breaking: Breaking(changes), Changes: changes})
+}
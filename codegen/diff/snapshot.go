@@ -0,0 +1,338 @@
+/*
+Package diff compares two versions of a design and reports the changes that
+break existing clients.
+
+The comparison works on snapshots of the evaluated designs. A snapshot records
+the services, methods, payload and result attributes together with their
+validations and gRPC field numbers as well as the HTTP routes and status codes.
+Snapshots are serialized to JSON so that designs evaluated by different
+processes can be compared.
+*/
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"text/template"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+// SnapshotFile is the name of the file produced by the "snapshot" generator
+// command relative to the output directory.
+const SnapshotFile = "snapshot.json"
+
+type (
+	// Snapshot is a serializable description of the parts of an evaluated
+	// design that make up the contract between servers and clients.
+	Snapshot struct {
+		// API is the name of the API.
+		API string `json:"api"`
+		// Services lists the design services in order of definition.
+		Services []*Service `json:"services,omitempty"`
+		// Types indexes the definitions of the user types by name.
+		Types map[string]*Attribute `json:"types,omitempty"`
+	}
+
+	// Service describes a design service.
+	Service struct {
+		// Name is the name of the service.
+		Name string `json:"name"`
+		// Methods lists the service methods in order of definition.
+		Methods []*Method `json:"methods,omitempty"`
+	}
+
+	// Method describes a service method and its transport mappings.
+	Method struct {
+		// Name is the name of the method.
+		Name string `json:"name"`
+		// Stream is the kind of stream used by the method if any, one of
+		// "client", "server" or "bidirectional".
+		Stream string `json:"stream,omitempty"`
+		// Payload describes the method payload, nil if the method has no
+		// payload.
+		Payload *Attribute `json:"payload,omitempty"`
+		// StreamingPayload describes the payload of the messages streamed
+		// by the client if any.
+		StreamingPayload *Attribute `json:"streaming_payload,omitempty"`
+		// Result describes the method result, nil if the method has no
+		// result.
+		Result *Attribute `json:"result,omitempty"`
+		// HTTP describes the method HTTP endpoint if any.
+		HTTP *HTTPEndpoint `json:"http,omitempty"`
+		// GRPC describes the method gRPC endpoint if any.
+		GRPC *GRPCEndpoint `json:"grpc,omitempty"`
+	}
+
+	// Attribute describes the type and validations of a payload or result
+	// attribute.
+	Attribute struct {
+		// Type is the name of the attribute type: the name of a primitive
+		// type, "array", "map", "object", "union" or the name of a user
+		// type.
+		Type string `json:"type"`
+		// UserType is true if Type is the name of a user type whose
+		// definition is recorded in the snapshot types.
+		UserType bool `json:"user_type,omitempty"`
+		// Key describes the map keys if the attribute is a map.
+		Key *Attribute `json:"key,omitempty"`
+		// Elem describes the array or map elements if the attribute is an
+		// array or a map.
+		Elem *Attribute `json:"elem,omitempty"`
+		// Fields lists the object attributes or the union types.
+		Fields []*Field `json:"fields,omitempty"`
+		// Validation lists the attribute validations if any.
+		Validation *Validation `json:"validation,omitempty"`
+	}
+
+	// Field describes an object attribute or a union type.
+	Field struct {
+		// Name is the name of the attribute.
+		Name string `json:"name"`
+		// Required is true if the attribute is required.
+		Required bool `json:"required,omitempty"`
+		// Tag is the gRPC field number given by the "rpc:tag" meta if any.
+		Tag string `json:"tag,omitempty"`
+		// Attribute describes the attribute type.
+		Attribute *Attribute `json:"attribute"`
+	}
+
+	// Validation lists the validations that constrain the attribute values.
+	Validation struct {
+		Enum             []any    `json:"enum,omitempty"`
+		Format           string   `json:"format,omitempty"`
+		Pattern          string   `json:"pattern,omitempty"`
+		Minimum          *float64 `json:"minimum,omitempty"`
+		ExclusiveMinimum *float64 `json:"exclusive_minimum,omitempty"`
+		Maximum          *float64 `json:"maximum,omitempty"`
+		ExclusiveMaximum *float64 `json:"exclusive_maximum,omitempty"`
+		MinLength        *int     `json:"min_length,omitempty"`
+		MaxLength        *int     `json:"max_length,omitempty"`
+	}
+
+	// HTTPEndpoint describes the HTTP mapping of a method.
+	HTTPEndpoint struct {
+		// Routes lists the endpoint routes as "VERB /full/path".
+		Routes []string `json:"routes"`
+		// Statuses lists the status codes of the success responses.
+		Statuses []int `json:"statuses,omitempty"`
+		// Errors maps the method error names to the HTTP status codes.
+		Errors map[string]int `json:"errors,omitempty"`
+	}
+
+	// GRPCEndpoint describes the gRPC mapping of a method.
+	GRPCEndpoint struct {
+		// Errors maps the method error names to the gRPC status codes.
+		Errors map[string]int `json:"errors,omitempty"`
+	}
+)
+
+// Take computes the snapshot of the given evaluated design root.
+func Take(root *expr.RootExpr) *Snapshot {
+	s := &Snapshot{Types: make(map[string]*Attribute)}
+	if root.API != nil {
+		s.API = root.API.Name
+	}
+	for _, svc := range root.Services {
+		ss := &Service{Name: svc.Name}
+		for _, m := range svc.Methods {
+			ss.Methods = append(ss.Methods, s.method(root, m))
+		}
+		s.Services = append(s.Services, ss)
+	}
+	return s
+}
+
+// Load reads the snapshot stored in the JSON file at the given path.
+func Load(path string) (*Snapshot, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var s Snapshot
+	if err := json.Unmarshal(b, &s); err != nil {
+		return nil, fmt.Errorf("invalid design snapshot %q: %w", path, err)
+	}
+	return &s, nil
+}
+
+// Files returns the file that holds the JSON representation of the snapshot
+// of the given design root.
+func Files(root *expr.RootExpr) []*codegen.File {
+	section := &codegen.SectionTemplate{
+		Name:    "snapshot",
+		FuncMap: template.FuncMap{"toJSON": toJSON},
+		Source:  "{{ toJSON . }}\n",
+		Data:    Take(root),
+	}
+	return []*codegen.File{{
+		Path:             SnapshotFile,
+		SectionTemplates: []*codegen.SectionTemplate{section},
+	}}
+}
+
+// method returns the snapshot of the given method.
+func (s *Snapshot) method(root *expr.RootExpr, m *expr.MethodExpr) *Method {
+	sm := &Method{
+		Name:             m.Name,
+		Payload:          s.attribute(m.Payload),
+		StreamingPayload: s.attribute(m.StreamingPayload),
+		Result:           s.attribute(m.Result),
+	}
+	switch m.Stream {
+	case expr.ClientStreamKind:
+		sm.Stream = "client"
+	case expr.ServerStreamKind:
+		sm.Stream = "server"
+	case expr.BidirectionalStreamKind:
+		sm.Stream = "bidirectional"
+	}
+	if root.API == nil {
+		return sm
+	}
+	if hs := root.API.HTTP.Service(m.Service.Name); hs != nil {
+		if e := hs.Endpoint(m.Name); e != nil {
+			sm.HTTP = httpEndpoint(e)
+		}
+	}
+	if gs := root.API.GRPC.Service(m.Service.Name); gs != nil {
+		if e := gs.Endpoint(m.Name); e != nil {
+			sm.GRPC = grpcEndpoint(e)
+		}
+	}
+	return sm
+}
+
+// attribute returns the snapshot of the given attribute, nil if the attribute
+// is empty.
+func (s *Snapshot) attribute(att *expr.AttributeExpr) *Attribute {
+	if att == nil || att.Type == nil || att.Type == expr.Empty {
+		return nil
+	}
+	sa := &Attribute{Validation: validation(att.Validation)}
+	if ut, ok := att.Type.(expr.UserType); ok {
+		sa.Type = ut.Name()
+		sa.UserType = true
+		if _, ok := s.Types[ut.Name()]; !ok {
+			// Record a placeholder first so that recursive types terminate.
+			s.Types[ut.Name()] = nil
+			s.Types[ut.Name()] = s.typeDef(ut.Attribute())
+		}
+		return sa
+	}
+	s.fill(sa, att)
+	return sa
+}
+
+// typeDef returns the snapshot of the attribute that defines a user type.
+func (s *Snapshot) typeDef(att *expr.AttributeExpr) *Attribute {
+	if ut, ok := att.Type.(expr.UserType); ok {
+		// Aliases of user types are recorded as references.
+		return s.attribute(&expr.AttributeExpr{Type: ut, Validation: att.Validation})
+	}
+	sa := &Attribute{Validation: validation(att.Validation)}
+	s.fill(sa, att)
+	return sa
+}
+
+// fill initializes the type information of sa from the type of att which
+// must not be a user type.
+func (s *Snapshot) fill(sa *Attribute, att *expr.AttributeExpr) {
+	switch t := att.Type.(type) {
+	case *expr.Array:
+		sa.Type = "array"
+		sa.Elem = s.attribute(t.ElemType)
+	case *expr.Map:
+		sa.Type = "map"
+		sa.Key = s.attribute(t.KeyType)
+		sa.Elem = s.attribute(t.ElemType)
+	case *expr.Object:
+		sa.Type = "object"
+		for _, nat := range *t {
+			f := &Field{
+				Name:      nat.Name,
+				Required:  att.IsRequired(nat.Name),
+				Attribute: s.attribute(nat.Attribute),
+			}
+			f.Tag, _ = nat.Attribute.FieldTag()
+			sa.Fields = append(sa.Fields, f)
+		}
+	case *expr.Union:
+		sa.Type = "union"
+		for _, nat := range t.Values {
+			sa.Fields = append(sa.Fields, &Field{Name: nat.Name, Attribute: s.attribute(nat.Attribute)})
+		}
+	default:
+		sa.Type = t.Name()
+	}
+}
+
+// validation returns the snapshot of the given validation, nil if there are
+// no validations other than required attributes.
+func validation(v *expr.ValidationExpr) *Validation {
+	if v == nil {
+		return nil
+	}
+	if len(v.Values) == 0 && v.Format == "" && v.Pattern == "" &&
+		v.Minimum == nil && v.ExclusiveMinimum == nil &&
+		v.Maximum == nil && v.ExclusiveMaximum == nil &&
+		v.MinLength == nil && v.MaxLength == nil {
+		return nil
+	}
+	return &Validation{
+		Enum:             v.Values,
+		Format:           string(v.Format),
+		Pattern:          v.Pattern,
+		Minimum:          v.Minimum,
+		ExclusiveMinimum: v.ExclusiveMinimum,
+		Maximum:          v.Maximum,
+		ExclusiveMaximum: v.ExclusiveMaximum,
+		MinLength:        v.MinLength,
+		MaxLength:        v.MaxLength,
+	}
+}
+
+// httpEndpoint returns the snapshot of the given HTTP endpoint.
+func httpEndpoint(e *expr.HTTPEndpointExpr) *HTTPEndpoint {
+	he := &HTTPEndpoint{}
+	for _, r := range e.Routes {
+		for _, p := range r.FullPaths() {
+			he.Routes = append(he.Routes, r.Method+" "+p)
+		}
+	}
+	for _, r := range e.Responses {
+		he.Statuses = append(he.Statuses, r.StatusCode)
+	}
+	sort.Ints(he.Statuses)
+	for _, er := range e.HTTPErrors {
+		if he.Errors == nil {
+			he.Errors = make(map[string]int)
+		}
+		he.Errors[er.Name] = er.Response.StatusCode
+	}
+	return he
+}
+
+// grpcEndpoint returns the snapshot of the given gRPC endpoint.
+func grpcEndpoint(e *expr.GRPCEndpointExpr) *GRPCEndpoint {
+	ge := &GRPCEndpoint{}
+	for _, er := range e.GRPCErrors {
+		if ge.Errors == nil {
+			ge.Errors = make(map[string]int)
+		}
+		ge.Errors[er.Name] = er.Response.StatusCode
+	}
+	return ge
+}
+
+// toJSON returns the indented JSON representation of v.
+func toJSON(v any) string {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		panic("diff: " + err.Error()) // bug
+	}
+	return string(b)
+}
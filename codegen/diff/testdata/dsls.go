@@ -0,0 +1,235 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var RemovedMethodOldDSL = func() {
+	Service("Service", func() {
+		Method("Kept", func() {
+			HTTP(func() { GET("/kept") })
+		})
+		Method("Removed", func() {
+			HTTP(func() { GET("/removed") })
+		})
+	})
+}
+
+var RemovedMethodNewDSL = func() {
+	Service("Service", func() {
+		Method("Kept", func() {
+			HTTP(func() { GET("/kept") })
+		})
+	})
+}
+
+var RequiredAttributeOldDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("name", String)
+				Attribute("age", Int)
+			})
+			HTTP(func() { POST("/") })
+		})
+	})
+}
+
+var RequiredAttributeNewDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("name", String)
+				Attribute("age", Int)
+				Attribute("email", String)
+				Required("name", "email")
+			})
+			HTTP(func() { POST("/") })
+		})
+	})
+}
+
+var NarrowedValidationsOldDSL = func() {
+	var Pet = Type("Pet", func() {
+		Attribute("age", Int, func() {
+			Minimum(1)
+		})
+		Attribute("kind", String, func() {
+			Enum("cat", "dog", "fish")
+		})
+		Attribute("name", String)
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(Pet)
+			HTTP(func() { POST("/") })
+		})
+	})
+}
+
+var NarrowedValidationsNewDSL = func() {
+	var Pet = Type("Pet", func() {
+		Attribute("age", Int, func() {
+			Minimum(5)
+		})
+		Attribute("kind", String, func() {
+			Enum("cat", "dog")
+		})
+		Attribute("name", String, func() {
+			Pattern("^[a-z]+$")
+		})
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(Pet)
+			HTTP(func() { POST("/") })
+		})
+	})
+}
+
+var ResultOldDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Result(func() {
+				Attribute("id", String)
+				Attribute("name", String)
+				Attribute("status", String, func() {
+					Enum("active", "inactive")
+				})
+				Required("id", "name")
+			})
+			HTTP(func() { GET("/") })
+		})
+	})
+}
+
+var ResultNewDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Result(func() {
+				Attribute("name", String)
+				Attribute("status", String, func() {
+					Enum("active", "inactive", "deleted")
+				})
+				Attribute("tags", ArrayOf(String))
+			})
+			HTTP(func() { GET("/") })
+		})
+	})
+}
+
+var HTTPOldDSL = func() {
+	Service("Service", func() {
+		Error("not_found")
+		Method("Method", func() {
+			Result(String)
+			HTTP(func() {
+				GET("/items")
+				Response(StatusOK)
+				Response("not_found", StatusNotFound)
+			})
+		})
+	})
+}
+
+var HTTPNewDSL = func() {
+	Service("Service", func() {
+		Error("not_found")
+		Method("Method", func() {
+			Result(String)
+			HTTP(func() {
+				GET("/v2/items")
+				Response(StatusCreated)
+				Response("not_found", StatusGone)
+			})
+		})
+	})
+}
+
+var GRPCOldDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Field(1, "a", String)
+				Field(2, "b", String)
+			})
+			GRPC(func() {})
+		})
+	})
+}
+
+var GRPCNewDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Field(2, "a", String)
+				Field(3, "b", String)
+			})
+			GRPC(func() {})
+		})
+	})
+}
+
+var CompatibleOldDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("name", String, func() {
+					MaxLength(10)
+				})
+			})
+			HTTP(func() { POST("/") })
+		})
+	})
+}
+
+var CompatibleNewDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("name", String, func() {
+					MaxLength(20)
+				})
+				Attribute("nickname", String)
+			})
+			HTTP(func() {
+				POST("/")
+				POST("/names")
+			})
+		})
+		Method("Added", func() {
+			HTTP(func() { GET("/") })
+		})
+	})
+	Service("Other", func() {
+		Method("Method", func() {
+			HTTP(func() { GET("/other") })
+		})
+	})
+}
+
+var RecursiveOldDSL = func() {
+	var Node = Type("Node", func() {
+		Attribute("value", Int)
+		Attribute("children", ArrayOf("Node"))
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Result(Node)
+			HTTP(func() { GET("/") })
+		})
+	})
+}
+
+var RecursiveNewDSL = func() {
+	var Node = Type("Node", func() {
+		Attribute("value", String)
+		Attribute("children", ArrayOf("Node"))
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Result(Node)
+			HTTP(func() { GET("/") })
+		})
+	})
+}
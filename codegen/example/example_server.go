@@ -105,6 +105,7 @@ func exampleSvrMain(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *c
 			Name:   "server-main-endpoints",
 			Source: readTemplate("server_endpoints"),
 			Data: map[string]any{
+				"APIPkg":   apiPkg,
 				"Services": svcData,
 			},
 			FuncMap: map[string]any{
@@ -11,7 +11,7 @@
 	{
 	{{- range .Services }}
 		{{- if .Methods }}
-			{{ .VarName }}Endpoints = {{ .PkgName }}.NewEndpoints({{ .VarName }}Svc)
+			{{ .VarName }}Endpoints = {{ .PkgName }}.NewEndpoints({{ .VarName }}Svc{{ if .ServerInterceptors }}, {{ $.APIPkg }}.New{{ .StructName }}ServerInterceptors(){{ end }})
 			{{ .VarName }}Endpoints.Use(debug.LogPayloads())
 			{{ .VarName }}Endpoints.Use(log.Endpoint)
 		{{- end }}
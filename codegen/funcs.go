@@ -28,6 +28,10 @@ type (
 		FieldPointer bool
 		// FieldType is the type of the field in the struct.
 		FieldType expr.DataType
+		// FieldTime is the attribute of the field in the struct if the field
+		// is a Go time value initialized from a string argument, nil
+		// otherwise.
+		FieldTime *expr.AttributeExpr
 	}
 )
 
@@ -274,6 +278,12 @@ func InitStructFields(args []*InitArgData, targetVar, sourcePkg, targetPkg strin
 		switch {
 		case arg.FieldName == "" && arg.FieldType == nil:
 		// do nothing
+		case arg.FieldTime != nil:
+			target := targetVar
+			if arg.FieldName != "" {
+				target = fmt.Sprintf("%s.%s", targetVar, arg.FieldName)
+			}
+			code += transformTime(&expr.AttributeExpr{Type: arg.Type}, arg.FieldTime, arg.Name, target, arg.FieldName == "", arg.Pointer, arg.FieldPointer)
 		case expr.Equal(unalias(arg.Type), arg.FieldType):
 			// arg type and struct field type are the same. No need to call transform
 			// to initialize the field
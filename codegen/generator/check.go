@@ -0,0 +1,252 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+
+	"goa.design/goa/v3/codegen"
+)
+
+// diffContext is the number of unchanged lines shown around the changes of a
+// unified diff hunk.
+const diffContext = 3
+
+// Check runs the code generation algorithms and compares the generated files
+// with the files in dir without modifying them. The files are rendered in a
+// temporary directory so that the files produced by the finalizers (e.g. the
+// protocol buffer Go code) are compared as well. Check returns the unified diff
+// between the files in dir and the generated files. The diff lists the files
+// whose content changed, the generated files missing from dir and the extra
+// files found in the subdirectories of the gen directory that are not
+// generated anymore. The diff is empty if the code in dir is up to date.
+func Check(dir, cmd string) (string, error) {
+	gendir := filepath.Join(dir, codegen.Gendir)
+	_, err := os.Stat(gendir)
+	existed := err == nil
+	genfiles, err := generateFiles(dir, cmd)
+	if !existed {
+		// Remove the directory created to compute the gen package import
+		// path.
+		os.Remove(gendir)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	tmp, err := os.MkdirTemp("", "goa-check")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tmp)
+	skipped := make(map[string]bool)
+	for _, f := range genfiles {
+		if f.SkipExist {
+			if _, err := os.Stat(filepath.Join(dir, f.Path)); err == nil {
+				skipped[filepath.Clean(f.Path)] = true
+				continue
+			}
+		}
+		if _, err := f.Render(tmp); err != nil {
+			return "", err
+		}
+	}
+	return diffDirs(dir, tmp, skipped)
+}
+
+// diffDirs returns the unified diff between the files in dir and the files
+// generated in gendir. Only the files in the subdirectories of the gen
+// directory of dir may be reported as extra files as the other files are not
+// deleted by the code generation. The paths in skipped are ignored.
+func diffDirs(dir, gendir string, skipped map[string]bool) (string, error) {
+	paths := make(map[string]bool)
+	err := filepath.WalkDir(gendir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(gendir, path)
+		if err != nil {
+			return err
+		}
+		paths[rel] = true
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	root := filepath.Join(dir, codegen.Gendir)
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if os.IsNotExist(err) && path == root {
+			return filepath.SkipDir
+		}
+		if err != nil || d.IsDir() || filepath.Dir(path) == root {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if _, ok := paths[rel]; !ok {
+			paths[rel] = false
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	sorted := make([]string, 0, len(paths))
+	for p := range paths {
+		if !skipped[p] {
+			sorted = append(sorted, p)
+		}
+	}
+	sort.Strings(sorted)
+	var buf strings.Builder
+	for _, p := range sorted {
+		var (
+			name     = filepath.ToSlash(p)
+			from, to = "a/" + name, "b/" + name
+			gen      []byte
+		)
+		old, err := os.ReadFile(filepath.Join(dir, p))
+		if os.IsNotExist(err) {
+			from = "/dev/null"
+		} else if err != nil {
+			return "", err
+		}
+		if paths[p] {
+			if gen, err = os.ReadFile(filepath.Join(gendir, p)); err != nil {
+				return "", err
+			}
+		} else {
+			to = "/dev/null"
+		}
+		if from != "/dev/null" && to != "/dev/null" && bytes.Equal(old, gen) {
+			continue
+		}
+		buf.WriteString(unifiedDiff(from, to, string(old), string(gen)))
+	}
+	return buf.String(), nil
+}
+
+// unifiedDiff returns the unified diff between the content a of the file from
+// and the content b of the file to.
+func unifiedDiff(from, to, a, b string) string {
+	type line struct {
+		op   byte
+		text string
+	}
+	var lines []line
+	{
+		// Encode each distinct line as a rune so that the diff is computed
+		// line by line.
+		var (
+			runes = make(map[string]rune)
+			texts = make(map[rune]string)
+		)
+		encode := func(s string) []rune {
+			var rs []rune
+			for _, l := range strings.SplitAfter(s, "\n") {
+				if l == "" {
+					continue
+				}
+				r, ok := runes[l]
+				if !ok {
+					r = rune(len(runes))
+					if r >= 0xD800 {
+						// Skip the surrogate range that is not valid in
+						// strings.
+						r += 0x800
+					}
+					runes[l], texts[r] = r, l
+				}
+				rs = append(rs, r)
+			}
+			return rs
+		}
+		ra, rb := encode(a), encode(b)
+		for _, d := range diffmatchpatch.New().DiffMainRunes(ra, rb, false) {
+			op := byte(' ')
+			switch d.Type {
+			case diffmatchpatch.DiffDelete:
+				op = '-'
+			case diffmatchpatch.DiffInsert:
+				op = '+'
+			}
+			for _, r := range d.Text {
+				lines = append(lines, line{op, texts[r]})
+			}
+		}
+	}
+
+	// Compute the line numbers in a and b of each line of the diff.
+	var (
+		na = make([]int, len(lines)+1)
+		nb = make([]int, len(lines)+1)
+	)
+	for i, l := range lines {
+		na[i+1], nb[i+1] = na[i], nb[i]
+		if l.op != '+' {
+			na[i+1]++
+		}
+		if l.op != '-' {
+			nb[i+1]++
+		}
+	}
+
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "--- %s\n+++ %s\n", from, to)
+	for i := 0; i < len(lines); {
+		for i < len(lines) && lines[i].op == ' ' {
+			i++
+		}
+		if i == len(lines) {
+			break
+		}
+		start, end := max(i-diffContext, 0), i
+		for end < len(lines) {
+			if lines[end].op != ' ' {
+				end++
+				continue
+			}
+			j := end
+			for j < len(lines) && lines[j].op == ' ' {
+				j++
+			}
+			if j == len(lines) || j-end > 2*diffContext {
+				break
+			}
+			end = j
+		}
+		end = min(end+diffContext, len(lines))
+		fmt.Fprintf(&buf, "@@ -%s +%s @@\n", hunkRange(na[start], na[end]), hunkRange(nb[start], nb[end]))
+		for _, l := range lines[start:end] {
+			buf.WriteByte(l.op)
+			buf.WriteString(l.text)
+			if !strings.HasSuffix(l.text, "\n") {
+				buf.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+		i = end
+	}
+	return buf.String()
+}
+
+// hunkRange returns the range of a unified diff hunk header for the lines
+// following line start up to line end.
+func hunkRange(start, end int) string {
+	if end-start == 1 {
+		return fmt.Sprint(start + 1)
+	}
+	if end == start {
+		return fmt.Sprintf("%d,0", start)
+	}
+	return fmt.Sprintf("%d,%d", start+1, end-start)
+}
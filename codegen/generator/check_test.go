@@ -0,0 +1,62 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	cases := []struct {
+		Name     string
+		A, B     string
+		Expected string
+	}{
+		{"changed", "a\nb\nc\n", "a\nB\nc\n", "--- from\n+++ to\n@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n"},
+		{"created", "", "a\nb\n", "--- from\n+++ to\n@@ -0,0 +1,2 @@\n+a\n+b\n"},
+		{"deleted", "a\n", "", "--- from\n+++ to\n@@ -1 +0,0 @@\n-a\n"},
+		{"no newline", "a\nb", "a\nc", "--- from\n+++ to\n@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+c\n\\ No newline at end of file\n"},
+		{"hunks", "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n", "0\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n13\n",
+			"--- from\n+++ to\n@@ -1,4 +1,4 @@\n-1\n+0\n 2\n 3\n 4\n@@ -9,4 +9,4 @@\n 9\n 10\n 11\n-12\n+13\n"},
+		{"merged hunks", "1\n2\n3\n4\n5\n6\n7\n8\n", "0\n2\n3\n4\n5\n6\n7\n9\n",
+			"--- from\n+++ to\n@@ -1,8 +1,8 @@\n-1\n+0\n 2\n 3\n 4\n 5\n 6\n 7\n-8\n+9\n"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			assert.Equal(t, c.Expected, unifiedDiff("from", "to", c.A, c.B))
+		})
+	}
+}
+
+func TestDiffDirs(t *testing.T) {
+	var (
+		dir    = t.TempDir()
+		gendir = t.TempDir()
+	)
+	write := func(root, path, content string) {
+		path = filepath.Join(root, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0750))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	}
+	write(dir, "gen/svc/same.go", "same\n")
+	write(gendir, "gen/svc/same.go", "same\n")
+	write(dir, "gen/svc/changed.go", "old\n")
+	write(gendir, "gen/svc/changed.go", "new\n")
+	write(gendir, "gen/svc/missing.go", "missing\n")
+	write(dir, "gen/svc/extra.go", "extra\n")
+	write(dir, "gen/skipped/user.go", "user\n")
+	write(dir, "gen/doc.go", "package gen\n")
+
+	diff, err := diffDirs(dir, gendir, map[string]bool{filepath.Join("gen", "skipped", "user.go"): true})
+	require.NoError(t, err)
+	assert.Equal(t, "--- a/gen/svc/changed.go\n+++ b/gen/svc/changed.go\n@@ -1 +1 @@\n-old\n+new\n"+
+		"--- a/gen/svc/extra.go\n+++ /dev/null\n@@ -1 +0,0 @@\n-extra\n"+
+		"--- /dev/null\n+++ b/gen/svc/missing.go\n@@ -0,0 +1 @@\n+missing\n", diff)
+
+	diff, err = diffDirs(t.TempDir(), t.TempDir(), nil)
+	require.NoError(t, err)
+	assert.Empty(t, diff)
+}
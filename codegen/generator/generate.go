@@ -11,7 +11,50 @@ import (
 )
 
 // Generate runs the code generation algorithms.
-func Generate(dir, cmd string) (outputs []string, err1 error) {
+func Generate(dir, cmd string) ([]string, error) {
+	genfiles, err := generateFiles(dir, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	// 1. Write the files.
+	written := make(map[string]struct{})
+	for _, f := range genfiles {
+		filename, err := f.Render(dir)
+		if err != nil {
+			return nil, err
+		}
+		if filename != "" {
+			written[filename] = struct{}{}
+		}
+	}
+
+	// 2. Compute all output filenames.
+	var outputs []string
+	{
+		outputs = make([]string, len(written))
+		cwd, err := os.Getwd()
+		if err != nil {
+			cwd = "."
+		}
+		i := 0
+		for o := range written {
+			rel, err := filepath.Rel(cwd, o)
+			if err != nil {
+				rel = o
+			}
+			outputs[i] = rel
+			i++
+		}
+	}
+	sort.Strings(outputs)
+
+	return outputs, nil
+}
+
+// generateFiles runs the code generators and plugins for the given command
+// and returns the files they produce without rendering them.
+func generateFiles(dir, cmd string) (genfiles []*codegen.File, err1 error) {
 	// 1. Compute design roots.
 	var roots []eval.Root
 	{
@@ -41,7 +84,7 @@ func Generate(dir, cmd string) (outputs []string, err1 error) {
 		}
 		defer func() {
 			if err := os.Remove(dummy.Name()); err != nil {
-				outputs = nil
+				genfiles = nil
 				err1 = err
 			}
 		}()
@@ -69,14 +112,19 @@ func Generate(dir, cmd string) (outputs []string, err1 error) {
 		genfuncs = gs
 	}
 
-	// 4. Run the code pre generation plugins.
-	err := codegen.RunPluginsPrepare(cmd, genpkg, roots)
-	if err != nil {
-		return nil, err
+	// 4. Run the code pre generation plugins. The mock command generates the
+	// same code as the gen command and thus runs the gen plugins as well.
+	cmds := []string{cmd}
+	if cmd == "mock" {
+		cmds = []string{"gen", "mock"}
+	}
+	for _, c := range cmds {
+		if err := codegen.RunPluginsPrepare(c, genpkg, roots); err != nil {
+			return nil, err
+		}
 	}
 
 	// 5. Generate initial set of files produced by goa code generators.
-	var genfiles []*codegen.File
 	for _, gen := range genfuncs {
 		fs, err := gen(genpkg, roots)
 		if err != nil {
@@ -86,41 +134,13 @@ func Generate(dir, cmd string) (outputs []string, err1 error) {
 	}
 
 	// 6. Run the code generation plugins.
-	genfiles, err = codegen.RunPlugins(cmd, genpkg, roots, genfiles)
-	if err != nil {
-		return nil, err
-	}
-
-	// 7. Write the files.
-	written := make(map[string]struct{})
-	for _, f := range genfiles {
-		filename, err := f.Render(dir)
+	for _, c := range cmds {
+		fs, err := codegen.RunPlugins(c, genpkg, roots, genfiles)
 		if err != nil {
 			return nil, err
 		}
-		if filename != "" {
-			written[filename] = struct{}{}
-		}
+		genfiles = fs
 	}
 
-	// 8. Compute all output filenames.
-	{
-		outputs = make([]string, len(written))
-		cwd, err := os.Getwd()
-		if err != nil {
-			cwd = "."
-		}
-		i := 0
-		for o := range written {
-			rel, err := filepath.Rel(cwd, o)
-			if err != nil {
-				rel = o
-			}
-			outputs[i] = rel
-			i++
-		}
-	}
-	sort.Strings(outputs)
-
-	return outputs, nil
+	return genfiles, nil
 }
@@ -24,6 +24,10 @@ func generators(cmd string) ([]Genfunc, error) {
 		return []Genfunc{Service, Transport, OpenAPI}, nil
 	case "example":
 		return []Genfunc{Example}, nil
+	case "mock":
+		return []Genfunc{Service, Transport, Mock}, nil
+	case "snapshot":
+		return []Genfunc{Snapshot}, nil
 	default:
 		return nil, fmt.Errorf("unknown command %q", cmd)
 	}
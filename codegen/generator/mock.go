@@ -0,0 +1,40 @@
+package generator
+
+import (
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/service"
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+	grpccodegen "goa.design/goa/v3/grpc/codegen"
+	httpcodegen "goa.design/goa/v3/http/codegen"
+)
+
+// Mock iterates through the roots and returns the files that implement the
+// mock server. The mock server serves the service mocks using the generated
+// HTTP and gRPC transports.
+func Mock(genpkg string, roots []eval.Root) ([]*codegen.File, error) {
+	var files []*codegen.File
+	for _, root := range roots {
+		r, ok := root.(*expr.RootExpr)
+		if !ok {
+			continue // could be a plugin root expression
+		}
+		fs := service.MockServerFiles(genpkg, r)
+		if len(fs) == 0 {
+			continue
+		}
+		if f := httpcodegen.MockServerFile(genpkg, r); f != nil {
+			fs = append(fs, f)
+		}
+		if f := grpccodegen.MockServerFile(genpkg, r); f != nil {
+			fs = append(fs, f)
+		}
+		for _, f := range fs {
+			for _, s := range r.Services {
+				service.AddServiceDataMetaTypeImports(f.SectionTemplates[0], s)
+			}
+		}
+		files = append(files, fs...)
+	}
+	return files, nil
+}
@@ -24,9 +24,13 @@ func Service(genpkg string, roots []eval.Root) ([]*codegen.File, error) {
 			files = append(files, service.Files(genpkg, s, userTypePkgs)...)
 			files = append(files, service.EndpointFile(genpkg, s))
 			files = append(files, service.ClientFile(genpkg, s))
+			files = append(files, service.MockFile(genpkg, s))
 			if f := service.ViewsFile(genpkg, s); f != nil {
 				files = append(files, f)
 			}
+			if f := service.InterceptorsFile(genpkg, s); f != nil {
+				files = append(files, f)
+			}
 			for _, f := range files {
 				if len(f.SectionTemplates) > 0 {
 					service.AddServiceDataMetaTypeImports(f.SectionTemplates[0], s)
@@ -0,0 +1,19 @@
+package generator
+
+import (
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/diff"
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Snapshot iterates through the roots and returns the file that holds the
+// design snapshot used to detect breaking changes between design versions.
+func Snapshot(_ string, roots []eval.Root) ([]*codegen.File, error) {
+	for _, root := range roots {
+		if r, ok := root.(*expr.RootExpr); ok {
+			return diff.Files(r), nil
+		}
+	}
+	return nil, nil
+}
@@ -7,6 +7,7 @@ import (
 	"goa.design/goa/v3/expr"
 	grpccodegen "goa.design/goa/v3/grpc/codegen"
 	httpcodegen "goa.design/goa/v3/http/codegen"
+	jsonrpccodegen "goa.design/goa/v3/jsonrpc/codegen"
 )
 
 // Transport iterates through the roots and returns the files needed to render
@@ -26,6 +27,8 @@ func Transport(genpkg string, roots []eval.Root) ([]*codegen.File, error) {
 		files = append(files, httpcodegen.ClientTypeFiles(genpkg, r)...)
 		files = append(files, httpcodegen.PathFiles(r)...)
 		files = append(files, httpcodegen.ClientCLIFiles(genpkg, r)...)
+		files = append(files, httpcodegen.ContractTestFiles(genpkg, r)...)
+		files = append(files, httpcodegen.FuzzTestFiles(genpkg, r)...)
 
 		// GRPC
 		files = append(files, grpccodegen.ProtoFiles(genpkg, r)...)
@@ -34,6 +37,15 @@ func Transport(genpkg string, roots []eval.Root) ([]*codegen.File, error) {
 		files = append(files, grpccodegen.ServerTypeFiles(genpkg, r)...)
 		files = append(files, grpccodegen.ClientTypeFiles(genpkg, r)...)
 		files = append(files, grpccodegen.ClientCLIFiles(genpkg, r)...)
+		files = append(files, grpccodegen.ContractTestFiles(genpkg, r)...)
+		files = append(files, grpccodegen.FuzzTestFiles(genpkg, r)...)
+
+		// JSON-RPC
+		files = append(files, jsonrpccodegen.ServerFiles(genpkg, r)...)
+		files = append(files, jsonrpccodegen.ClientFiles(genpkg, r)...)
+		files = append(files, jsonrpccodegen.ServerTypeFiles(genpkg, r)...)
+		files = append(files, jsonrpccodegen.ClientTypeFiles(genpkg, r)...)
+		files = append(files, jsonrpccodegen.ClientCLIFiles(genpkg, r)...)
 
 		for _, f := range files {
 			if len(f.SectionTemplates) > 0 {
@@ -9,7 +9,7 @@ import (
 	"goa.design/goa/v3/expr"
 )
 
-var transformGoArrayT, transformGoMapT, transformGoUnionT, transformGoUnionToObjectT, transformGoObjectToUnionT *template.Template
+var transformGoArrayT, transformGoMapT, transformGoUnionT, transformGoUnionToObjectT, transformGoObjectToUnionT, transformGoDiscriminatedUnionToObjectT, transformGoObjectToDiscriminatedUnionT *template.Template
 
 // NOTE: can't initialize inline because https://github.com/golang/go/issues/1817
 func init() {
@@ -19,6 +19,8 @@ func init() {
 	transformGoUnionT = template.Must(template.New("transformGoUnion").Funcs(fm).Parse(transformGoUnionTmpl))
 	transformGoUnionToObjectT = template.Must(template.New("transformGoUnionToObject").Funcs(fm).Parse(transformGoUnionToObjectTmpl))
 	transformGoObjectToUnionT = template.Must(template.New("transformGoObjectToUnion").Funcs(fm).Parse(transformGoObjectToUnionTmpl))
+	transformGoDiscriminatedUnionToObjectT = template.Must(template.New("transformGoDiscriminatedUnionToObject").Funcs(fm).Parse(transformGoDiscriminatedUnionToObjectTmpl))
+	transformGoObjectToDiscriminatedUnionT = template.Must(template.New("transformGoObjectToDiscriminatedUnion").Funcs(fm).Parse(transformGoObjectToDiscriminatedUnionTmpl))
 }
 
 // GoTransform produces Go code that initializes the data structure defined
@@ -38,7 +40,9 @@ func init() {
 // "Type" which is of type string and contains the value type name (union types
 // are otherwise implemented as a struct containing a single field: the current
 // value - however having the kind explicitly stored is required to serialize to
-// JSON for example).
+// JSON for example). Unions that define a discriminator are mapped from and to
+// objects whose first attribute is the discriminator and whose other attributes
+// are the attributes of the union values.
 //
 // source and target are the attributes used in the transformation
 //
@@ -101,6 +105,9 @@ func transformPrimitive(source, target *expr.AttributeExpr, sourceVar, targetVar
 	if err := IsCompatible(source.Type, target.Type, sourceVar, targetVar); err != nil {
 		return "", err
 	}
+	if source.IsTime() != target.IsTime() {
+		return transformTime(source, target, sourceVar, targetVar, newVar, false, false), nil
+	}
 	assign := "="
 	if newVar {
 		assign = ":="
@@ -145,9 +152,13 @@ func transformObject(source, target *expr.AttributeExpr, sourceVar, targetVar st
 				_, isSrcUT = srcc.Type.(expr.UserType)
 				_, isTgtUT = tgtc.Type.(expr.UserType)
 			)
+			if srcc.IsTime() != tgtc.IsTime() {
+				postInitCode += transformTime(srcc, tgtc, srcField, targetVar+"."+tgtField, false, srcPtr, tgtPtr)
+				return
+			}
 			{
 				switch {
-				case isSrcUT || isTgtUT:
+				case (isSrcUT || isTgtUT) && !sameTypeRef(srcc, tgtc, ta):
 					deref := ""
 					if srcPtr {
 						deref = "*"
@@ -269,7 +280,11 @@ func transformObject(source, target *expr.AttributeExpr, sourceVar, targetVar st
 				// source attribute is a primitive pointer or not a primitive
 				code += fmt.Sprintf("if %s == nil {\n\t", srcVar)
 				if ta.TargetCtx.IsPrimitivePointer(n, tgtMatt.AttributeExpr) && expr.IsPrimitive(tgtc.Type) {
-					code += fmt.Sprintf("var tmp %s = %#v\n\t%s = &tmp\n", GoNativeTypeName(tgtc.Type), tdef, tgtVar)
+					typeName, _ := GetMetaType(tgtc)
+					if typeName == "" {
+						typeName = GoNativeTypeName(tgtc.Type)
+					}
+					code += fmt.Sprintf("var tmp %s = %#v\n\t%s = &tmp\n", typeName, tdef, tgtVar)
 				} else {
 					code += fmt.Sprintf("%s = %#v\n", tgtVar, tdef)
 				}
@@ -306,6 +321,13 @@ func transformObject(source, target *expr.AttributeExpr, sourceVar, targetVar st
 	return buffer.String(), nil
 }
 
+// sameTypeRef returns true if the Go types of the source and target
+// attributes are identical, for example when both refer to the type generated
+// for a user type that uses the "type:enum" meta.
+func sameTypeRef(source, target *expr.AttributeExpr, ta *TransformAttrs) bool {
+	return ta.SourceCtx.Scope.Ref(source, ta.SourceCtx.Pkg(source)) == ta.TargetCtx.Scope.Ref(target, ta.TargetCtx.Pkg(target))
+}
+
 // typeStringIsNilable takes a go type as a string and checks for a '[]' or
 // 'map[' prefix to see if it's a nilable primitive type.
 func typeStringIsNilable(typeName string) bool {
@@ -419,6 +441,9 @@ func transformUnion(source, target *expr.AttributeExpr, sourceVar, targetVar str
 }
 
 func transformUnionToObject(source, target *expr.AttributeExpr, sourceVar, targetVar string, newVar bool, ta *TransformAttrs) (string, error) {
+	if expr.AsUnion(source.Type).Discriminator != "" {
+		return transformDiscriminatedUnionToObject(source, target, sourceVar, targetVar, newVar, ta)
+	}
 	obj := expr.AsObject(target.Type)
 	if (*obj)[0].Attribute.Type != expr.String {
 		return "", fmt.Errorf("union to object transform requires first field to be string")
@@ -450,6 +475,9 @@ func transformUnionToObject(source, target *expr.AttributeExpr, sourceVar, targe
 }
 
 func transformObjectToUnion(source, target *expr.AttributeExpr, sourceVar, targetVar string, newVar bool, ta *TransformAttrs) (string, error) {
+	if expr.AsUnion(target.Type).Discriminator != "" {
+		return transformObjectToDiscriminatedUnion(source, target, sourceVar, targetVar, newVar, ta)
+	}
 	obj := expr.AsObject(source.Type)
 	if (*obj)[0].Attribute.Type != expr.String {
 		return "", fmt.Errorf("union to object transform requires first field to be string")
@@ -487,6 +515,80 @@ func transformObjectToUnion(source, target *expr.AttributeExpr, sourceVar, targe
 	return buf.String(), nil
 }
 
+// transformDiscriminatedUnionToObject generates Go code to transform a source
+// discriminated union to a target object whose first attribute is the
+// discriminator. The attributes of the union value are copied to the matching
+// target attributes.
+func transformDiscriminatedUnionToObject(source, target *expr.AttributeExpr, sourceVar, targetVar string, newVar bool, ta *TransformAttrs) (string, error) {
+	srcUnion := expr.AsUnion(source.Type)
+	disc := expr.AsObject(target.Type).Attribute(srcUnion.Discriminator)
+	if disc == nil || disc.Type != expr.String {
+		return "", fmt.Errorf("discriminated union to object transform requires %q string field", srcUnion.Discriminator)
+	}
+	sourceTypeRefs := make([]string, len(srcUnion.Values))
+	sourceTypeNames := make([]string, len(srcUnion.Values))
+	for i, st := range srcUnion.Values {
+		sourceTypeRefs[i] = ta.SourceCtx.Scope.Ref(st.Attribute, ta.SourceCtx.Pkg(st.Attribute))
+		sourceTypeNames[i] = st.Name
+	}
+	data := map[string]any{
+		"NewVar":          newVar,
+		"TargetVar":       targetVar,
+		"TypeRef":         ta.TargetCtx.Scope.Ref(target, ta.TargetCtx.Pkg(target)),
+		"Target":          target,
+		"SourceVar":       sourceVar,
+		"SourceTypes":     srcUnion.Values,
+		"SourceTypeRefs":  sourceTypeRefs,
+		"SourceTypeNames": sourceTypeNames,
+		"Field":           GoifyAtt(disc, srcUnion.Discriminator, true),
+		"Pointer":         ta.TargetCtx.IsPrimitivePointer(srcUnion.Discriminator, target),
+		"TransformAttrs":  ta,
+	}
+	isInterface := ta.TargetCtx.IsInterface
+	ta.TargetCtx.IsInterface = false
+	defer func() { ta.TargetCtx.IsInterface = isInterface }()
+	var buf bytes.Buffer
+	if err := transformGoDiscriminatedUnionToObjectT.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// transformObjectToDiscriminatedUnion generates Go code to transform a source
+// object whose first attribute is the discriminator to a target discriminated
+// union. The value is initialized from the matching source attributes.
+func transformObjectToDiscriminatedUnion(source, target *expr.AttributeExpr, sourceVar, targetVar string, newVar bool, ta *TransformAttrs) (string, error) {
+	tgtUnion := expr.AsUnion(target.Type)
+	disc := expr.AsObject(source.Type).Attribute(tgtUnion.Discriminator)
+	if disc == nil || disc.Type != expr.String {
+		return "", fmt.Errorf("object to discriminated union transform requires %q string field", tgtUnion.Discriminator)
+	}
+	unionTypes := make([]string, len(tgtUnion.Values))
+	for i, tt := range tgtUnion.Values {
+		unionTypes[i] = tt.Name
+	}
+	data := map[string]any{
+		"NewVar":         newVar,
+		"TargetVar":      targetVar,
+		"TypeRef":        ta.TargetCtx.Scope.Ref(target, ta.TargetCtx.Pkg(target)),
+		"Source":         source,
+		"SourceVar":      sourceVar,
+		"UnionTypes":     unionTypes,
+		"TargetTypes":    tgtUnion.Values,
+		"Field":          GoifyAtt(disc, tgtUnion.Discriminator, true),
+		"Pointer":        ta.SourceCtx.IsPrimitivePointer(tgtUnion.Discriminator, source),
+		"TransformAttrs": ta,
+	}
+	isInterface := ta.TargetCtx.IsInterface
+	ta.TargetCtx.IsInterface = false
+	defer func() { ta.TargetCtx.IsInterface = isInterface }()
+	var buf bytes.Buffer
+	if err := transformGoObjectToDiscriminatedUnionT.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
 // transformAttributeHelpers returns the Go transform functions and their definitions
 // that may be used in code produced by Transform. It returns an error if source and
 // target are incompatible (different types, fields of different type etc).
@@ -518,7 +620,7 @@ func transformAttributeHelpers(source, target *expr.AttributeExpr, ta *Transform
 	case expr.IsUnion(source.Type):
 		tt := expr.AsUnion(target.Type)
 		if tt == nil {
-			return
+			return discriminatedUnionHelpers(source, target, ta, seen)
 		}
 		for i, st := range expr.AsUnion(source.Type).Values {
 			if other, err = collectHelpers(st.Attribute, tt.Values[i].Attribute, true, ta, seen); err == nil {
@@ -527,7 +629,7 @@ func transformAttributeHelpers(source, target *expr.AttributeExpr, ta *Transform
 		}
 	case expr.IsObject(source.Type):
 		if expr.IsUnion(target.Type) {
-			return
+			return discriminatedUnionHelpers(source, target, ta, seen)
 		}
 		walkMatches(source, target, func(srcMatt, _ *expr.MappedAttributeExpr, srcc, tgtc *expr.AttributeExpr, n string) {
 			if err != nil {
@@ -574,7 +676,7 @@ func collectHelpers(source, target *expr.AttributeExpr, req bool, ta *TransformA
 	case expr.IsUnion(source.Type):
 		tt := expr.AsUnion(target.Type)
 		if tt == nil {
-			return
+			return discriminatedUnionHelpers(source, target, ta, seen)
 		}
 		for i, st := range expr.AsUnion(source.Type).Values {
 			if other, err = collectHelpers(st.Attribute, tt.Values[i].Attribute, req, ta, seen); err == nil {
@@ -583,7 +685,7 @@ func collectHelpers(source, target *expr.AttributeExpr, req bool, ta *TransformA
 		}
 	case expr.IsObject(source.Type):
 		if expr.IsUnion(target.Type) {
-			return
+			return discriminatedUnionHelpers(source, target, ta, seen)
 		}
 		walkMatches(source, target, func(srcMatt, _ *expr.MappedAttributeExpr, srcc, tgtc *expr.AttributeExpr, n string) {
 			if err != nil {
@@ -597,6 +699,38 @@ func collectHelpers(source, target *expr.AttributeExpr, req bool, ta *TransformA
 	return
 }
 
+// discriminatedUnionHelpers returns the transform helper functions used by the
+// code that transforms a discriminated union from or to an object. It returns
+// nil if the union is not discriminated as the union values are then encoded
+// to JSON directly.
+func discriminatedUnionHelpers(source, target *expr.AttributeExpr, ta *TransformAttrs, seen map[string]*TransformFunctionData) (helpers []*TransformFunctionData, err error) {
+	if u := expr.AsUnion(source.Type); u != nil {
+		if u.Discriminator == "" {
+			return nil, nil
+		}
+		for _, st := range u.Values {
+			other, err := transformAttributeHelpers(st.Attribute, target, ta, seen)
+			if err != nil {
+				return nil, err
+			}
+			helpers = append(helpers, other...)
+		}
+		return helpers, nil
+	}
+	u := expr.AsUnion(target.Type)
+	if u.Discriminator == "" {
+		return nil, nil
+	}
+	for _, tt := range u.Values {
+		other, err := transformAttributeHelpers(source, tt.Attribute, ta, seen)
+		if err != nil {
+			return nil, err
+		}
+		helpers = append(helpers, other...)
+	}
+	return helpers, nil
+}
+
 // generateHelper generates the code that transform instances of source into
 // target. Both source and targe must be user types or generateHelper panics.
 // generateHelper returns nil if a helper has already been generated for the
@@ -717,6 +851,29 @@ switch {{ .SourceVar }}.(type) {
 	Type: name,
 	Value: string(js),
 }
+`
+
+	transformGoDiscriminatedUnionToObjectTmpl = `{{ if .NewVar }}var {{ .TargetVar }} {{ .TypeRef }}
+{{ end }}switch actual := {{ .SourceVar }}.(type) {
+	{{- range $i, $ref := .SourceTypeRefs }}
+	case {{ $ref }}:
+		{{- transformAttribute (index $.SourceTypes $i).Attribute $.Target "actual" "obj" true $.TransformAttrs -}}
+		{{ if $.Pointer }}disc := {{ printf "%q" (index $.SourceTypeNames $i) }}
+		obj.{{ $.Field }} = &disc
+		{{ else }}obj.{{ $.Field }} = {{ printf "%q" (index $.SourceTypeNames $i) }}
+		{{ end }}{{ $.TargetVar }} = obj
+	{{- end }}
+}
+`
+
+	transformGoObjectToDiscriminatedUnionTmpl = `{{ if .NewVar }}var {{ .TargetVar }} {{ .TypeRef }}
+{{ end }}switch {{ if .Pointer }}*{{ end }}{{ .SourceVar }}.{{ .Field }} {
+	{{- range $i, $name := .UnionTypes }}
+	case {{ printf "%q" $name }}:
+		{{- transformAttribute $.Source (index $.TargetTypes $i).Attribute $.SourceVar "val" true $.TransformAttrs -}}
+		{{ $.TargetVar }} = val
+	{{- end }}
+}
 `
 
 	transformGoObjectToUnionTmpl = `{{ if .NewVar }}var {{ .TargetVar }} {{ .TypeRef }}
@@ -18,7 +18,9 @@ func TestGoTransformUnion(t *testing.T) {
 		unionStringInt  = root.UserType("Container").Attribute().Find("UnionStringInt").Find("UnionStringInt")
 		unionStringInt2 = root.UserType("Container").Attribute().Find("UnionStringInt2").Find("UnionStringInt2")
 		unionSomeType   = root.UserType("Container").Attribute().Find("UnionSomeType").Find("UnionSomeType")
+		unionDisc       = root.UserType("Container").Attribute().Find("UnionDiscriminated").Find("UnionDiscriminated")
 		userType        = &expr.AttributeExpr{Type: root.UserType("UnionUserType")}
+		discUserType    = &expr.AttributeExpr{Type: root.UserType("DiscriminatedUserType")}
 		defaultCtx      = NewAttributeContext(false, false, true, "", scope)
 	)
 	tc := []struct {
@@ -37,6 +39,9 @@ func TestGoTransformUnion(t *testing.T) {
 		{"User Type to UnionString", userType, unionString, userTypeToUnionStringCode},
 		{"User Type to UnionStringInt", userType, unionStringInt, userTypeToUnionStringIntCode},
 		{"User Type to UnionSomeType", userType, unionSomeType, userTypeToUnionSomeTypeCode},
+
+		{"UnionDiscriminated to User Type", unionDisc, discUserType, unionDiscriminatedToUserTypeCode},
+		{"User Type to UnionDiscriminated", discUserType, unionDisc, userTypeToUnionDiscriminatedCode},
 	}
 	for _, c := range tc {
 		t.Run(c.Name, func(t *testing.T) {
@@ -193,3 +198,40 @@ const userTypeToUnionSomeTypeCode = `func transform() {
 	}
 }
 `
+
+const unionDiscriminatedToUserTypeCode = `func transform() {
+	var target *DiscriminatedUserType
+	switch actual := source.(type) {
+	case *SomeType:
+		obj := &DiscriminatedUserType{
+			SomeField: actual.SomeField,
+		}
+		obj.Kind = "some"
+		target = obj
+	case *SomeOtherType:
+		obj := &DiscriminatedUserType{
+			OtherField: &actual.OtherField,
+		}
+		obj.Kind = "other"
+		target = obj
+	}
+}
+`
+
+const userTypeToUnionDiscriminatedCode = `func transform() {
+	var target *UnionDiscriminated
+	switch source.Kind {
+	case "some":
+		val := &SomeType{
+			SomeField: source.SomeField,
+		}
+		target = val
+	case "other":
+		val := &SomeOtherType{}
+		if source.OtherField != nil {
+			val.OtherField = *source.OtherField
+		}
+		target = val
+	}
+}
+`
@@ -100,7 +100,9 @@ func (loc *Location) PackageName() string {
 }
 
 // GetMetaType retrieves the type and package defined by the struct:field:type
-// metadata if any.
+// metadata if any. It returns time.Time or time.Duration for attributes mapped
+// to Go time types, see expr.AttributeExpr.IsTime, and the goa decimal types
+// for Decimal and BigInt attributes.
 func GetMetaType(att *expr.AttributeExpr) (typeName string, importS *ImportSpec) {
 	if att == nil {
 		return
@@ -115,10 +117,38 @@ func GetMetaType(att *expr.AttributeExpr) (typeName string, importS *ImportSpec)
 		if len(args) > 2 {
 			importS.Name = args[2]
 		}
+		return
+	}
+	if att.IsNullable() {
+		typeName = "goa.Nullable[" + decimalTypeName(att) + "]"
+		importS = GoaImport("")
+		return
+	}
+	if att.IsTime() {
+		typeName = "time.Time"
+		if att.Validation.Format == expr.FormatDuration {
+			typeName = "time.Duration"
+		}
+		importS = &ImportSpec{Path: "time"}
+		return
+	}
+	if att.Type == expr.Decimal || att.Type == expr.BigInt {
+		typeName = decimalTypeName(att)
+		importS = GoaImport("")
 	}
 	return
 }
 
+// decimalTypeName returns the name of the Go type used to represent the values
+// of the given primitive attribute, taking into account the encoding of Decimal
+// and BigInt values.
+func decimalTypeName(att *expr.AttributeExpr) string {
+	if att.IsStringEncoded() {
+		return GoNativeTypeName(att.Type) + "String"
+	}
+	return GoNativeTypeName(att.Type)
+}
+
 // GetMetaTypeImports parses the attribute for all user defined imports
 func GetMetaTypeImports(att *expr.AttributeExpr) []*ImportSpec {
 	return safelyGetMetaTypeImports(att, nil)
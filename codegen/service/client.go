@@ -45,6 +45,13 @@ func ClientFile(_ string, service *expr.ServiceExpr) *codegen.File {
 				Source: readTemplate("service_client_method"),
 				Data:   m,
 			})
+			if m.Pagination != nil {
+				sections = append(sections, &codegen.SectionTemplate{
+					Name:   "client-method-all",
+					Source: readTemplate("service_client_method_all"),
+					Data:   m,
+				})
+			}
 		}
 	}
 
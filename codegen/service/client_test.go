@@ -32,6 +32,8 @@ func TestClient(t *testing.T) {
 		{"client-streaming-payload-no-result", testdata.StreamingPayloadNoResultMethodDSL, testdata.StreamingPayloadNoResultMethodClient},
 		{"client-bidirectional-streaming", testdata.BidirectionalStreamingMethodDSL, testdata.BidirectionalStreamingMethodClient},
 		{"client-bidirectional-streaming-no-payload", testdata.BidirectionalStreamingNoPayloadMethodDSL, testdata.BidirectionalStreamingNoPayloadMethodClient},
+		{"client-interceptor", testdata.ClientInterceptorDSL, testdata.ClientInterceptorClient},
+		{"client-paginated", testdata.PaginatedEndpointDSL, testdata.PaginatedMethodsClient},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -30,6 +30,15 @@ type (
 		// Schemes contains the security schemes types used by the
 		// all the endpoints.
 		Schemes SchemesData
+		// ServerInterceptors lists the server interceptors applied to
+		// the endpoints.
+		ServerInterceptors []*InterceptorData
+		// ClientInterceptors lists the client interceptors applied to
+		// the endpoints.
+		ClientInterceptors []*InterceptorData
+		// HasLimits is true if any of the endpoints is rate or
+		// concurrency limited.
+		HasLimits bool
 	}
 
 	// EndpointMethodData describes a single endpoint method.
@@ -74,6 +83,9 @@ func EndpointFile(genpkg string, service *expr.ServiceExpr) *codegen.File {
 			codegen.GoaImport("security"),
 			{Path: genpkg + "/" + svcName + "/" + "views", Name: svc.ViewsPkg},
 		}
+		if hasRateLimit(svc.Methods) {
+			imports = append(imports, &codegen.ImportSpec{Path: "time"})
+		}
 		imports = append(imports, svc.UserTypeImports...)
 		header := codegen.Header(service.Name+" endpoints", svc.PkgName, imports)
 		def := &codegen.SectionTemplate{
@@ -123,6 +135,16 @@ func EndpointFile(genpkg string, service *expr.ServiceExpr) *codegen.File {
 				FuncMap: map[string]any{"payloadVar": payloadVar},
 			})
 		}
+		for _, m := range data.Methods {
+			if m.RateLimit != nil && m.RateLimit.KeyFunc != "" {
+				sections = append(sections, &codegen.SectionTemplate{
+					Name:    "endpoint-rate-limit-key",
+					Source:  readTemplate("service_endpoint_rate_limit_key"),
+					Data:    m,
+					FuncMap: map[string]any{"payloadVar": payloadVar},
+				})
+			}
+		}
 	}
 
 	return &codegen.File{Path: path, SectionTemplates: sections}
@@ -132,7 +154,11 @@ func endpointData(service *expr.ServiceExpr) *EndpointsData {
 	svc := Services.Get(service.Name)
 	methods := make([]*EndpointMethodData, len(svc.Methods))
 	names := make([]string, len(svc.Methods))
+	var hasLimits bool
 	for i, m := range svc.Methods {
+		if m.RateLimit != nil || m.MaxConcurrency > 0 {
+			hasLimits = true
+		}
 		methods[i] = &EndpointMethodData{
 			MethodData:     m,
 			ArgName:        codegen.Goify(m.VarName, false),
@@ -144,14 +170,17 @@ func endpointData(service *expr.ServiceExpr) *EndpointsData {
 	}
 	desc := fmt.Sprintf("%s wraps the %q service endpoints.", endpointsStructName, service.Name)
 	return &EndpointsData{
-		Name:           service.Name,
-		Description:    desc,
-		VarName:        endpointsStructName,
-		ClientVarName:  clientStructName,
-		ServiceVarName: serviceInterfaceName,
-		ClientInitArgs: strings.Join(names, ", "),
-		Methods:        methods,
-		Schemes:        svc.Schemes,
+		Name:               service.Name,
+		Description:        desc,
+		VarName:            endpointsStructName,
+		ClientVarName:      clientStructName,
+		ServiceVarName:     serviceInterfaceName,
+		ClientInitArgs:     strings.Join(names, ", "),
+		Methods:            methods,
+		Schemes:            svc.Schemes,
+		ServerInterceptors: svc.ServerInterceptors,
+		ClientInterceptors: svc.ClientInterceptors,
+		HasLimits:          hasLimits,
 	}
 }
 
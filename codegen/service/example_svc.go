@@ -72,6 +72,7 @@ func exampleServiceFile(genpkg string, _ *expr.RootExpr, svc *expr.ServiceExpr,
 		{Path: "strings"},
 		{Path: path.Join(genpkg, svcName), Name: data.PkgName},
 		{Path: "goa.design/clue/log"},
+		codegen.GoaImport(""),
 		{Path: "goa.design/goa/v3/security"},
 	}
 	sections := []*codegen.SectionTemplate{
@@ -93,6 +94,13 @@ func exampleServiceFile(genpkg string, _ *expr.RootExpr, svc *expr.ServiceExpr,
 			Data:   data,
 		})
 	}
+	if len(data.ServerInterceptors) > 0 {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "example-server-interceptors",
+			Source: readTemplate("example_server_interceptors"),
+			Data:   data,
+		})
+	}
 	for _, m := range svc.Methods {
 		sections = append(sections, basicEndpointSection(m, data))
 	}
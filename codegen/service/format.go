@@ -0,0 +1,78 @@
+package service
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+// FormatData contains the data needed to register the validator of a custom
+// format.
+type FormatData struct {
+	// Name is the name of the format.
+	Name string
+	// Validator is the reference to the Go function that validates the
+	// format values, e.g. "formats.ValidatePhone".
+	Validator string
+	// Import is the import specification of the package implementing the
+	// validator.
+	Import *codegen.ImportSpec
+}
+
+// buildFormatsData returns the data for the custom formats used by the
+// attributes of the service methods payloads, results and errors sorted by
+// name.
+func buildFormatsData(service *expr.ServiceExpr) []*FormatData {
+	if len(expr.Root.Formats) == 0 {
+		return nil
+	}
+	seen := make(map[expr.ValidationFormat]struct{})
+	var formats []*FormatData
+	collect := func(att *expr.AttributeExpr) {
+		if att == nil {
+			return
+		}
+		_ = codegen.Walk(att, func(a *expr.AttributeExpr) error {
+			if a.Validation == nil || a.Validation.Format == "" {
+				return nil
+			}
+			f := expr.Root.Format(a.Validation.Format)
+			if f == nil {
+				return nil
+			}
+			if _, ok := seen[f.Name]; ok {
+				return nil
+			}
+			seen[f.Name] = struct{}{}
+			alias := formatPackageAlias(f.Package)
+			formats = append(formats, &FormatData{
+				Name:      string(f.Name),
+				Validator: alias + "." + f.Validator,
+				Import:    &codegen.ImportSpec{Name: alias, Path: f.Package},
+			})
+			return nil
+		})
+	}
+	for _, m := range service.Methods {
+		collect(m.Payload)
+		collect(m.StreamingPayload)
+		collect(m.Result)
+		for _, er := range m.Errors {
+			collect(er.AttributeExpr)
+		}
+	}
+	for _, er := range service.Errors {
+		collect(er.AttributeExpr)
+	}
+	sort.Slice(formats, func(i, j int) bool { return formats[i].Name < formats[j].Name })
+	return formats
+}
+
+// formatPackageAlias returns the name used to import the package with the given
+// import path in the generated code.
+func formatPackageAlias(pkgPath string) string {
+	return strings.ToLower(codegen.Goify(path.Base(pkgPath), false))
+}
@@ -0,0 +1,469 @@
+package service
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// InterceptorData contains the data needed to render the types and
+	// functions used to implement an interceptor.
+	InterceptorData struct {
+		// Name is the interceptor name as defined in the design.
+		Name string
+		// Description is the interceptor description.
+		Description string
+		// VarName is the name of the interceptor interface method.
+		VarName string
+		// InfoName is the name of the struct that gives access to the
+		// request metadata, payload and result.
+		InfoName string
+		// PayloadAccessor is the name of the interface that gives typed
+		// access to the payload attributes if any.
+		PayloadAccessor string
+		// ResultAccessor is the name of the interface that gives typed
+		// access to the result attributes if any.
+		ResultAccessor string
+		// PayloadAttributes lists the payload attributes accessed by the
+		// interceptor.
+		PayloadAttributes []*AttributeAccessorData
+		// ResultAttributes lists the result attributes accessed by the
+		// interceptor.
+		ResultAttributes []*AttributeAccessorData
+		// Methods lists the method specific accessors.
+		Methods []*InterceptorMethodData
+	}
+
+	// InterceptorMethodData contains the data needed to render the
+	// implementation of the payload and result accessors for a given
+	// method.
+	InterceptorMethodData struct {
+		// MethodName is the method name as defined in the design.
+		MethodName string
+		// MethodVarName is the Go method name.
+		MethodVarName string
+		// ServerPayload is the expression used by the server to
+		// retrieve the payload from the endpoint request.
+		ServerPayload string
+		// ClientPayload is the expression used by the client to
+		// retrieve the payload from the endpoint request.
+		ClientPayload string
+		// Payload describes the payload accessor if any.
+		Payload *InterceptorAccessorData
+		// Result describes the result accessor if any.
+		Result *InterceptorAccessorData
+		// ViewedResult describes the accessor used by the server for
+		// viewed results if any.
+		ViewedResult *InterceptorAccessorData
+	}
+
+	// InterceptorAccessorData describes a struct implementing a payload or
+	// result accessor interface for a given method.
+	InterceptorAccessorData struct {
+		// Name is the name of the struct.
+		Name string
+		// Interface is the name of the implemented accessor interface.
+		Interface string
+		// Description is the struct description.
+		Description string
+		// Field is the name of the struct field holding the payload or
+		// result.
+		Field string
+		// FieldRef is the reference to the payload or result type.
+		FieldRef string
+		// Path is the expression used to access the payload or result
+		// attributes from the struct receiver.
+		Path string
+		// InitName is the name of the function that builds the accessor
+		// from a raw result if any.
+		InitName string
+		// Attributes lists the accessed attributes.
+		Attributes []*AttributeAccessorData
+	}
+
+	// AttributeAccessorData describes a payload or result attribute accessed
+	// by an interceptor.
+	AttributeAccessorData struct {
+		// Name is the name of the attribute accessor methods.
+		Name string
+		// FieldName is the name of the struct field holding the attribute.
+		FieldName string
+		// TypeRef is the reference to the attribute type.
+		TypeRef string
+		// Pointer is true if the struct field is a pointer to TypeRef.
+		Pointer bool
+		// Read is true if the interceptor reads the attribute.
+		Read bool
+		// Write is true if the interceptor writes the attribute.
+		Write bool
+	}
+
+	// InterceptorWrapperData contains the data needed to render the
+	// functions that wrap a method endpoint with its interceptors.
+	InterceptorWrapperData struct {
+		// Name is the name of the wrapper function.
+		Name string
+		// MethodName is the method name as defined in the design.
+		MethodName string
+		// ServiceName is the service name.
+		ServiceName string
+		// Side is either "server" or "client".
+		Side string
+		// Interface is the name of the interceptors interface.
+		Interface string
+		// Chain lists the names of the functions that apply each
+		// interceptor in order of application (reverse order of
+		// execution).
+		Chain []string
+		// Interceptors lists the interceptors in order of execution.
+		Interceptors []*InterceptorWrapperItem
+	}
+
+	// InterceptorWrapperItem describes the application of a single
+	// interceptor to a method endpoint.
+	InterceptorWrapperItem struct {
+		// Name is the name of the function that applies the interceptor.
+		Name string
+		// Interceptor is the interceptor data.
+		Interceptor *InterceptorData
+		// PayloadAccess is the name of the payload accessor struct if any.
+		PayloadAccess string
+		// Payload is the expression used to retrieve the payload from
+		// the endpoint request.
+		Payload string
+		// ResultInit is the name of the function that builds the result
+		// accessor if any.
+		ResultInit string
+	}
+)
+
+const (
+	// serverInterceptorsName is the name of the server interceptors
+	// interface.
+	serverInterceptorsName = "ServerInterceptors"
+
+	// clientInterceptorsName is the name of the client interceptors
+	// interface.
+	clientInterceptorsName = "ClientInterceptors"
+)
+
+// InterceptorsFile returns the file defining the interceptor interfaces,
+// the typed payload and result accessors and the functions that wrap the
+// service endpoints with the interceptors. It returns nil if the service does
+// not use interceptors.
+func InterceptorsFile(genpkg string, service *expr.ServiceExpr) *codegen.File {
+	svc := Services.Get(service.Name)
+	if len(svc.ServerInterceptors) == 0 && len(svc.ClientInterceptors) == 0 {
+		return nil
+	}
+	svcName := svc.PathName
+	path := filepath.Join(codegen.Gendir, svcName, "interceptors.go")
+	imports := []*codegen.ImportSpec{
+		{Path: "context"},
+		codegen.GoaImport(""),
+		{Path: genpkg + "/" + svcName + "/" + "views", Name: svc.ViewsPkg},
+	}
+	imports = append(imports, svc.UserTypeImports...)
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(service.Name+" interceptors", svc.PkgName, imports),
+		{
+			Name:   "interceptors-interfaces",
+			Source: readTemplate("interceptors"),
+			Data:   svc,
+		},
+	}
+	for _, i := range svc.interceptors() {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "interceptor-types",
+			Source: readTemplate("interceptor_types"),
+			Data:   i,
+		})
+		for _, m := range i.Methods {
+			for _, a := range []*InterceptorAccessorData{m.Payload, m.Result, m.ViewedResult} {
+				if a == nil {
+					continue
+				}
+				sections = append(sections, &codegen.SectionTemplate{
+					Name:   "interceptor-accessor",
+					Source: readTemplate("interceptor_accessor"),
+					Data:   a,
+				})
+			}
+		}
+	}
+	for _, w := range interceptorWrappers(svc, service, false) {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "server-interceptor-wrappers",
+			Source: readTemplate("interceptor_wrappers"),
+			Data:   w,
+		})
+	}
+	for _, w := range interceptorWrappers(svc, service, true) {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "client-interceptor-wrappers",
+			Source: readTemplate("interceptor_wrappers"),
+			Data:   w,
+		})
+	}
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// interceptors returns the server and client interceptors used by the service
+// without duplicates.
+func (d *Data) interceptors() []*InterceptorData {
+	var res []*InterceptorData
+	seen := make(map[string]struct{})
+	for _, i := range append(d.ServerInterceptors, d.ClientInterceptors...) {
+		if _, ok := seen[i.Name]; ok {
+			continue
+		}
+		seen[i.Name] = struct{}{}
+		res = append(res, i)
+	}
+	return res
+}
+
+// interceptorWrappers returns the data needed to render the functions that
+// wrap the service endpoints with the server or client interceptors.
+func interceptorWrappers(svc *Data, service *expr.ServiceExpr, client bool) []*InterceptorWrapperData {
+	var res []*InterceptorWrapperData
+	for _, m := range service.Methods {
+		is := m.ServerInterceptors
+		side, prefix, suffix, iface := "server", "", "Endpoint", serverInterceptorsName
+		if client {
+			is = m.ClientInterceptors
+			side, prefix, suffix, iface = "client", "Client", "ClientEndpoint", clientInterceptorsName
+		}
+		if len(is) == 0 {
+			continue
+		}
+		md := svc.Method(m.Name)
+		w := &InterceptorWrapperData{
+			Name:        "Wrap" + md.VarName + suffix,
+			MethodName:  m.Name,
+			ServiceName: svc.Name,
+			Side:        side,
+			Interface:   iface,
+		}
+		for _, i := range is {
+			id := svc.interceptor(i.Name)
+			imd := id.method(m.Name)
+			item := &InterceptorWrapperItem{
+				Name:        "wrap" + prefix + md.VarName + id.VarName,
+				Interceptor: id,
+			}
+			if imd.Payload != nil {
+				item.PayloadAccess = imd.Payload.Name
+				item.Payload = imd.ServerPayload
+				if client {
+					item.Payload = imd.ClientPayload
+				}
+			}
+			switch {
+			case imd.ViewedResult != nil && !client:
+				item.ResultInit = imd.ViewedResult.InitName
+			case imd.Result != nil:
+				item.ResultInit = imd.Result.InitName
+			}
+			w.Interceptors = append(w.Interceptors, item)
+		}
+		for idx := len(w.Interceptors) - 1; idx >= 0; idx-- {
+			w.Chain = append(w.Chain, w.Interceptors[idx].Name)
+		}
+		res = append(res, w)
+	}
+	return res
+}
+
+// interceptor returns the interceptor data with the given name.
+func (d *Data) interceptor(name string) *InterceptorData {
+	for _, i := range d.interceptors() {
+		if i.Name == name {
+			return i
+		}
+	}
+	return nil
+}
+
+// method returns the method specific accessors data for the method with the
+// given name.
+func (i *InterceptorData) method(name string) *InterceptorMethodData {
+	for _, m := range i.Methods {
+		if m.MethodName == name {
+			return m
+		}
+	}
+	return nil
+}
+
+// initInterceptors initializes the interceptors data of the service and of
+// its methods.
+func (d *Data) initInterceptors(service *expr.ServiceExpr) {
+	built := make(map[string]*InterceptorData)
+	get := func(i *expr.InterceptorExpr) *InterceptorData {
+		if id, ok := built[i.Name]; ok {
+			return id
+		}
+		id := buildInterceptorData(i, service, d)
+		built[i.Name] = id
+		return id
+	}
+	seenServer := make(map[string]struct{})
+	seenClient := make(map[string]struct{})
+	for _, m := range service.Methods {
+		md := d.Method(m.Name)
+		for _, i := range m.ServerInterceptors {
+			id := get(i)
+			md.ServerInterceptors = append(md.ServerInterceptors, id.VarName)
+			if _, ok := seenServer[i.Name]; !ok {
+				seenServer[i.Name] = struct{}{}
+				d.ServerInterceptors = append(d.ServerInterceptors, id)
+			}
+		}
+		for _, i := range m.ClientInterceptors {
+			id := get(i)
+			md.ClientInterceptors = append(md.ClientInterceptors, id.VarName)
+			if _, ok := seenClient[i.Name]; !ok {
+				seenClient[i.Name] = struct{}{}
+				d.ClientInterceptors = append(d.ClientInterceptors, id)
+			}
+		}
+	}
+}
+
+// buildInterceptorData builds the data needed to render the code of the
+// given interceptor for the given service.
+func buildInterceptorData(i *expr.InterceptorExpr, service *expr.ServiceExpr, svc *Data) *InterceptorData {
+	scope := svc.Scope
+	varName := codegen.Goify(i.Name, true)
+	desc := i.Description
+	if desc == "" {
+		desc = fmt.Sprintf("%s is the %q interceptor.", varName, i.Name)
+	}
+	id := &InterceptorData{
+		Name:        i.Name,
+		Description: desc,
+		VarName:     varName,
+		InfoName:    scope.Unique(varName + "Info"),
+	}
+	if i.HasPayloadAccess() {
+		id.PayloadAccessor = scope.Unique(varName + "Payload")
+	}
+	if i.HasResultAccess() {
+		id.ResultAccessor = scope.Unique(varName + "Result")
+	}
+	for _, m := range service.Methods {
+		if !contains(m.Interceptors(), i) {
+			continue
+		}
+		md := svc.Method(m.Name)
+		imd := &InterceptorMethodData{
+			MethodName:    m.Name,
+			MethodVarName: md.VarName,
+		}
+		lower := codegen.Goify(i.Name, false) + md.VarName
+		if id.PayloadAccessor != "" {
+			imd.ClientPayload = "req.(" + md.PayloadRef + ")"
+			imd.ServerPayload = imd.ClientPayload
+			if md.ServerStream != nil {
+				imd.ServerPayload = "req.(*" + md.ServerStream.EndpointStruct + ").Payload"
+			}
+			imd.Payload = &InterceptorAccessorData{
+				Name:        lower + "Payload",
+				Interface:   id.PayloadAccessor,
+				Description: fmt.Sprintf("%sPayload implements %s for the %q method payload.", lower, id.PayloadAccessor, m.Name),
+				Field:       "payload",
+				FieldRef:    md.PayloadRef,
+				Path:        "payload",
+				Attributes:  accessorsData(i.ReadPayload, i.WritePayload, m.Payload, scope, false),
+			}
+			if id.PayloadAttributes == nil {
+				id.PayloadAttributes = imd.Payload.Attributes
+			}
+		}
+		if id.ResultAccessor != "" && (md.ViewedResult == nil || contains(m.ClientInterceptors, i)) {
+			imd.Result = &InterceptorAccessorData{
+				Name:        lower + "Result",
+				Interface:   id.ResultAccessor,
+				Description: fmt.Sprintf("%sResult implements %s for the %q method result.", lower, id.ResultAccessor, m.Name),
+				Field:       "result",
+				FieldRef:    md.ResultRef,
+				Path:        "result",
+				InitName:    "new" + codegen.Goify(lower, true) + "Result",
+				Attributes:  accessorsData(i.ReadResult, i.WriteResult, m.Result, scope, false),
+			}
+		}
+		if id.ResultAccessor != "" && md.ViewedResult != nil && contains(m.ServerInterceptors, i) {
+			imd.ViewedResult = &InterceptorAccessorData{
+				Name:        lower + "ViewedResult",
+				Interface:   id.ResultAccessor,
+				Description: fmt.Sprintf("%sViewedResult implements %s for the %q method viewed result.", lower, id.ResultAccessor, m.Name),
+				Field:       "result",
+				FieldRef:    md.ViewedResult.FullRef,
+				Path:        "result.Projected",
+				InitName:    "new" + codegen.Goify(lower, true) + "ViewedResult",
+				Attributes:  accessorsData(i.ReadResult, i.WriteResult, m.Result, scope, true),
+			}
+		}
+		if id.ResultAttributes == nil && id.ResultAccessor != "" {
+			id.ResultAttributes = accessorsData(i.ReadResult, i.WriteResult, m.Result, scope, false)
+		}
+		id.Methods = append(id.Methods, imd)
+	}
+	return id
+}
+
+// accessorsData returns the data needed to render the accessors of the
+// attributes of parent listed in read and write. projected is true if the
+// accessors use the projected type of a viewed result which uses pointers for
+// all the primitive attributes.
+func accessorsData(read, write, parent *expr.AttributeExpr, scope *codegen.NameScope, projected bool) []*AttributeAccessorData {
+	var res []*AttributeAccessorData
+	index := make(map[string]*AttributeAccessorData)
+	add := func(att *expr.AttributeExpr, isWrite bool) {
+		if att == nil {
+			return
+		}
+		for _, nat := range *expr.AsObject(att.Type) {
+			if a, ok := index[nat.Name]; ok {
+				a.Read = a.Read || !isWrite
+				a.Write = a.Write || isWrite
+				continue
+			}
+			f := parent.Find(nat.Name)
+			if f == nil {
+				continue // validation reports the error
+			}
+			pointer := parent.IsPrimitivePointer(nat.Name, true)
+			if projected {
+				pointer = expr.IsPrimitive(f.Type)
+			}
+			a := &AttributeAccessorData{
+				Name:      codegen.Goify(nat.Name, true),
+				FieldName: codegen.GoifyAtt(f, nat.Name, true),
+				TypeRef:   scope.GoTypeRef(f),
+				Pointer:   pointer,
+				Read:      !isWrite,
+				Write:     isWrite,
+			}
+			index[nat.Name] = a
+			res = append(res, a)
+		}
+	}
+	add(read, false)
+	add(write, true)
+	return res
+}
+
+// contains returns true if the list of interceptors contains i.
+func contains(is []*expr.InterceptorExpr, i *expr.InterceptorExpr) bool {
+	for _, e := range is {
+		if e.Name == i.Name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"bytes"
+	"go/format"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/service/testdata"
+	"goa.design/goa/v3/expr"
+)
+
+func TestInterceptors(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+		Code string
+	}{
+		{"server-interceptor", testdata.ServerInterceptorDSL, testdata.ServerInterceptorCode},
+		{"payload-result-access", testdata.PayloadResultAccessDSL, testdata.PayloadResultAccessCode},
+		{"viewed-result-access", testdata.ViewedResultAccessDSL, testdata.ViewedResultAccessCode},
+		{"streaming-payload-access", testdata.StreamingPayloadAccessDSL, testdata.StreamingPayloadAccessCode},
+		{"client-interceptor", testdata.ClientInterceptorDSL, testdata.ClientInterceptorCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			codegen.RunDSL(t, c.DSL)
+			require.Len(t, expr.Root.Services, 1)
+			f := InterceptorsFile("goa.design/goa/example", expr.Root.Services[0])
+			require.NotNil(t, f)
+			buf := new(bytes.Buffer)
+			for _, s := range f.SectionTemplates[1:] {
+				require.NoError(t, s.Write(buf))
+			}
+			bs, err := format.Source(buf.Bytes())
+			require.NoError(t, err, buf.String())
+			code := strings.ReplaceAll(string(bs), "\r\n", "\n")
+			assert.Equal(t, c.Code, code)
+		})
+	}
+}
+
+func TestInterceptorsFileNil(t *testing.T) {
+	codegen.RunDSL(t, testdata.SingleEndpointDSL)
+	require.Len(t, expr.Root.Services, 1)
+	assert.Nil(t, InterceptorsFile("goa.design/goa/example", expr.Root.Services[0]))
+}
@@ -0,0 +1,630 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// MockData contains the data needed to render the mock of a service.
+	MockData struct {
+		// Name is the service name as defined in the design.
+		Name string
+		// PkgName is the name of the service package.
+		PkgName string
+		// Methods lists the mocked methods.
+		Methods []*MockMethodData
+		// Schemes lists the security schemes of the service.
+		Schemes SchemesData
+		// ServerInterceptors lists the server interceptors of the service.
+		ServerInterceptors []*InterceptorData
+	}
+
+	// MockMethodData contains the data needed to render the mock of a
+	// service method.
+	MockMethodData struct {
+		// Name is the method name as defined in the design.
+		Name string
+		// VarName is the Go method name.
+		VarName string
+		// FuncName is the name of the function type used to implement
+		// the method.
+		FuncName string
+		// FieldName is the name of the mock field that holds the method
+		// implementation.
+		FieldName string
+		// Params is the list of the method parameters.
+		Params string
+		// Args is the list of the method arguments.
+		Args string
+		// Results is the list of the method named results.
+		Results string
+		// PayloadVar is the name of the payload parameter if any.
+		PayloadVar string
+		// DefaultReturn is the list of the values returned when no
+		// function implements the method.
+		DefaultReturn string
+		// ResultRef is the reference to the result type if any.
+		ResultRef string
+		// PayloadValue is the code that initializes the payload with the
+		// design example if any. The code uses a ptr function to
+		// initialize pointers to primitive values.
+		PayloadValue string
+		// DefaultResult is the code that initializes the default result
+		// if any.
+		DefaultResult string
+		// ExampleError describes why the design examples of the payload
+		// or result do not validate if that is the case. The payload
+		// and default result are not initialized with such examples.
+		ExampleError string
+		// ClientEndpoint is the body of the client endpoint function
+		// that calls the mock.
+		ClientEndpoint string
+		// Errors lists the errors that the mock can return.
+		Errors []*MockErrorData
+	}
+
+	// MockErrorData contains the data needed to initialize an error
+	// returned by a mock method.
+	MockErrorData struct {
+		// Name is the error name as defined in the design.
+		Name string
+		// Value is the code that initializes the error.
+		Value string
+	}
+)
+
+// MockFile returns the file that implements the mock of the given service.
+// The mock implements the service interface with functions registered by the
+// tests or with default results built from the design examples.
+func MockFile(genpkg string, service *expr.ServiceExpr) *codegen.File {
+	svc := Services.Get(service.Name)
+	data := buildMockData(service, svc)
+	path := filepath.Join(codegen.Gendir, svc.PathName, "mock", "mock.go")
+	imports := []*codegen.ImportSpec{
+		{Path: "context"},
+		{Path: "fmt"},
+		{Path: "io"},
+		{Path: "strings"},
+		{Path: "sync"},
+		{Path: "time"},
+		codegen.GoaImport(""),
+		codegen.GoaImport("security"),
+		{Path: "errors"},
+		{Path: genpkg + "/" + svc.PathName, Name: svc.PkgName},
+	}
+	imports = append(imports, svc.UserTypeImports...)
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(service.Name+" mock", "mock", imports),
+		{
+			Name:    "mock-struct",
+			Source:  readTemplate("mock"),
+			Data:    data,
+			FuncMap: map[string]any{"hasErrors": hasMockErrors},
+		},
+	}
+	for _, m := range data.Methods {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "mock-method",
+			Source: readTemplate("mock_method"),
+			Data:   m,
+		})
+	}
+	sections = append(sections, &codegen.SectionTemplate{
+		Name:   "mock-client",
+		Source: readTemplate("mock_client"),
+		Data:   data,
+	})
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// MockMethods returns the data needed to call the mock of the given service,
+// it is used by the transport code generators to generate tests that use the
+// mock as the service implementation.
+func MockMethods(service *expr.ServiceExpr) []*MockMethodData {
+	return buildMockData(service, Services.Get(service.Name)).Methods
+}
+
+// HasContractTests returns true if the contract tests of the given service
+// must be generated. The tests are enabled with the "test:contract" meta set
+// on the API or on the service, the value "false" set on a service disables
+// them.
+func HasContractTests(root *expr.RootExpr, service *expr.ServiceExpr) bool {
+	return hasTestMeta(root, service, "test:contract")
+}
+
+// HasFuzzTests returns true if the fuzz tests of the given service must be
+// generated. The tests are enabled with the "test:fuzz" meta set on the API
+// or on the service, the value "false" set on a service disables them.
+func HasFuzzTests(root *expr.RootExpr, service *expr.ServiceExpr) bool {
+	return hasTestMeta(root, service, "test:fuzz")
+}
+
+// hasTestMeta returns true if the meta key is set on the service or on the
+// API with a value other than "false". The service meta overrides the API
+// meta.
+func hasTestMeta(root *expr.RootExpr, service *expr.ServiceExpr, key string) bool {
+	for _, m := range []expr.MetaExpr{service.Meta, root.API.Meta} {
+		if _, ok := m[key]; ok {
+			v, _ := m.Last(key)
+			return v != "false"
+		}
+	}
+	return false
+}
+
+// buildMockData builds the data needed to render the mock of the given
+// service.
+func buildMockData(service *expr.ServiceExpr, svc *Data) *MockData {
+	var (
+		methods = make([]*MockMethodData, len(svc.Methods))
+		rand    = expr.NewRandom(service.Name)
+	)
+	for i, m := range svc.Methods {
+		methods[i] = buildMockMethodData(service.Method(m.Name), m, svc, rand)
+	}
+	return &MockData{
+		Name:               svc.Name,
+		PkgName:            svc.PkgName,
+		Methods:            methods,
+		Schemes:            svc.Schemes,
+		ServerInterceptors: svc.ServerInterceptors,
+	}
+}
+
+// buildMockMethodData builds the data needed to render the mock of the given
+// method. rand is used to generate the values of the errors.
+func buildMockMethodData(m *expr.MethodExpr, md *MethodData, svc *Data, rand *expr.ExampleGenerator) *MockMethodData {
+	var (
+		pkg     = svc.PkgName
+		params  = []string{"ctx context.Context"}
+		args    = []string{"ctx"}
+		results []string
+		returns []string
+
+		payloadVar, payloadRef, payloadValue, resultRef, defaultResult string
+		exampleErrors                                                  []string
+	)
+	if md.Payload != "" {
+		payloadVar = "p"
+		payloadRef = svc.Scope.GoFullTypeRef(m.Payload, pkgOrDefault(m.Payload.Type, pkg))
+		ex := mockPayloadExample(m.Payload, md.PayloadEx)
+		if err := m.Payload.ValidateExample("payload", ex); err != nil {
+			exampleErrors = append(exampleErrors, err.Error())
+		} else {
+			payloadValue = mockValue(m.Payload, ex, svc.Scope, pkg)
+		}
+		params = append(params, "p "+payloadRef)
+		args = append(args, "p")
+	}
+	if md.Result != "" {
+		resultRef = svc.Scope.GoFullTypeRef(m.Result, pkgOrDefault(m.Result.Type, pkg))
+		if err := m.Result.ValidateExample("result", md.ResultEx); err != nil {
+			exampleErrors = append(exampleErrors, err.Error())
+		} else {
+			defaultResult = mockValue(m.Result, md.ResultEx, svc.Scope, pkg)
+		}
+	}
+	switch {
+	case md.ServerStream != nil:
+		params = append(params, "stream "+pkg+"."+md.ServerStream.Interface)
+		args = append(args, "stream")
+	default:
+		if md.SkipRequestBodyEncodeDecode {
+			params = append(params, "body io.ReadCloser")
+			args = append(args, "body")
+		}
+		if resultRef != "" {
+			results = append(results, "res "+resultRef)
+			returns = append(returns, "default"+md.VarName+"Result()")
+		}
+		if md.SkipResponseBodyEncodeDecode {
+			results = append(results, "resp io.ReadCloser")
+			returns = append(returns, `io.NopCloser(strings.NewReader(""))`)
+		}
+		if resultRef != "" && md.ViewedResult != nil && md.ViewedResult.ViewName == "" {
+			results = append(results, "view string")
+			returns = append(returns, `"default"`)
+		}
+	}
+	results = append(results, "err error")
+	returns = append(returns, "nil")
+	if md.ServerStream != nil {
+		// Streams are not mocked by default.
+		resultRef = ""
+	}
+	return &MockMethodData{
+		Name:           md.Name,
+		VarName:        md.VarName,
+		FuncName:       md.VarName + "Func",
+		FieldName:      codegen.Goify(md.VarName, false),
+		Params:         strings.Join(params, ", "),
+		Args:           strings.Join(args, ", "),
+		Results:        strings.Join(results, ", "),
+		PayloadVar:     payloadVar,
+		DefaultReturn:  strings.Join(returns, ", "),
+		ResultRef:      resultRef,
+		PayloadValue:   payloadValue,
+		DefaultResult:  defaultResult,
+		ExampleError:   strings.Join(exampleErrors, ", "),
+		ClientEndpoint: mockClientEndpoint(md, payloadRef, resultRef, pkg),
+		Errors:         mockErrors(m, svc, rand),
+	}
+}
+
+// hasMockErrors returns true if any of the given methods can return errors.
+func hasMockErrors(methods []*MockMethodData) bool {
+	for _, m := range methods {
+		if len(m.Errors) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// mockErrors returns the data needed to initialize the errors of the given
+// method. Errors whose type cannot be initialized are omitted.
+func mockErrors(m *expr.MethodExpr, svc *Data, rand *expr.ExampleGenerator) []*MockErrorData {
+	var errs []*MockErrorData
+	for _, er := range m.Errors {
+		var val string
+		switch {
+		case er.Type == expr.ErrorResult:
+			val = fmt.Sprintf("%s.Make%s(errors.New(%q))", svc.PkgName, codegen.Goify(er.Name, true), "mock "+er.Name+" error")
+		case expr.IsObject(er.Type):
+			ex := er.Example(rand)
+			if m, ok := ex.(map[string]any); ok {
+				for _, nat := range *expr.AsObject(er.Type) {
+					if _, ok := nat.Attribute.Meta["struct:error:name"]; ok {
+						m[nat.Name] = er.Name
+					}
+				}
+			}
+			val = mockValue(er.AttributeExpr, ex, svc.Scope, svc.PkgName)
+		}
+		if val == "" {
+			continue
+		}
+		errs = append(errs, &MockErrorData{Name: er.Name, Value: val})
+	}
+	return errs
+}
+
+// mockPayloadExample returns a copy of the payload example ex where the
+// security credentials do not contain spaces. The transports interpret the
+// first space of a credential as the separator between the scheme name and
+// the credential so that such examples would not make the round trip.
+func mockPayloadExample(payload *expr.AttributeExpr, ex any) any {
+	m, ok := ex.(map[string]any)
+	if !ok || !expr.IsObject(payload.Type) {
+		return ex
+	}
+	res := make(map[string]any, len(m))
+	for k, v := range m {
+		res[k] = v
+	}
+	for _, nat := range *expr.AsObject(payload.Type) {
+		for key := range nat.Attribute.Meta {
+			if !strings.HasPrefix(key, "security:") {
+				continue
+			}
+			if s, ok := res[nat.Name].(string); ok {
+				res[nat.Name] = strings.ReplaceAll(s, " ", "-")
+			}
+		}
+	}
+	return res
+}
+
+// mockClientEndpoint returns the body of the client endpoint function that
+// calls the mock implementation of the given method.
+func mockClientEndpoint(md *MethodData, payloadRef, resultRef, pkg string) string {
+	if md.ServerStream != nil {
+		return fmt.Sprintf("return nil, fmt.Errorf(\"mock client does not support streaming method %%q\", %q)", md.Name)
+	}
+	var (
+		code []string
+		args = []string{"ctx"}
+	)
+	switch {
+	case md.SkipRequestBodyEncodeDecode:
+		code = append(code, fmt.Sprintf("ep := req.(*%s.%s)", pkg, md.RequestStruct))
+		if payloadRef != "" {
+			args = append(args, "ep.Payload")
+		}
+		args = append(args, "ep.Body")
+	case payloadRef != "":
+		args = append(args, fmt.Sprintf("req.(%s)", payloadRef))
+	}
+	var lhs []string
+	if resultRef != "" {
+		lhs = append(lhs, "res")
+	}
+	if md.SkipResponseBodyEncodeDecode {
+		lhs = append(lhs, "body")
+	}
+	if resultRef != "" && md.ViewedResult != nil && md.ViewedResult.ViewName == "" {
+		lhs = append(lhs, "_")
+	}
+	call := fmt.Sprintf("m.%s(%s)", md.VarName, strings.Join(args, ", "))
+	switch {
+	case md.SkipResponseBodyEncodeDecode:
+		code = append(code,
+			fmt.Sprintf("%s, err := %s", strings.Join(lhs, ", "), call),
+			"if err != nil {\nreturn nil, err\n}",
+		)
+		if resultRef != "" {
+			code = append(code, fmt.Sprintf("return &%s.%s{Result: res, Body: body}, nil", pkg, md.ResponseStruct))
+		} else {
+			code = append(code, fmt.Sprintf("return &%s.%s{Body: body}, nil", pkg, md.ResponseStruct))
+		}
+	case resultRef != "":
+		code = append(code,
+			fmt.Sprintf("%s, err := %s", strings.Join(lhs, ", "), call),
+			"if err != nil {\nreturn nil, err\n}",
+			"return res, nil",
+		)
+	default:
+		code = append(code, "return nil, "+call)
+	}
+	return strings.Join(code, "\n")
+}
+
+// mockValue returns the Go code that initializes a value of the type of att
+// with the example value val. pkg is the name of the package that defines
+// the service types. mockValue returns the empty string if val is nil or if
+// the value cannot be initialized, the corresponding fields are then left
+// unset.
+func mockValue(att *expr.AttributeExpr, val any, scope *codegen.NameScope, pkg string) string {
+	if val == nil {
+		return ""
+	}
+	if t, _ := codegen.GetMetaType(att); t != "" {
+		return mockMetaValue(att, t, val)
+	}
+	ref := scope.GoFullTypeRef(att, pkgOrDefault(att.Type, pkg))
+	switch actual := att.Type.(type) {
+	case expr.UserType:
+		if actual == expr.Empty {
+			return ""
+		}
+		if expr.IsObject(actual) {
+			fields := mockFields(actual.Attribute(), val, scope, pkg)
+			if fields == "" {
+				return ""
+			}
+			return "&" + strings.TrimPrefix(ref, "*") + fields
+		}
+		if expr.IsPrimitive(actual) {
+			v := mockPrimitive(actual.Attribute().Type, mockInt32(actual.Attribute(), val))
+			if v == "" {
+				return ""
+			}
+			return fmt.Sprintf("%s(%s)", ref, v)
+		}
+		return mockComposite(ref, actual.Attribute(), val, scope, pkg)
+	case expr.Primitive:
+		return mockPrimitive(actual, mockInt32(att, val))
+	case *expr.Array, *expr.Map:
+		return mockComposite(ref, att, val, scope, pkg)
+	default:
+		// Inline objects and unions are not initialized.
+		return ""
+	}
+}
+
+// mockMetaValue returns the Go code that initializes a value of the type
+// typeName that the code generators use instead of the Go native type of att
+// (time, Decimal, BigInt and Nullable values) with the example value val. It
+// returns an empty string for custom types set with the "struct:field:type"
+// meta as there is no way to build their values.
+func mockMetaValue(att *expr.AttributeExpr, typeName string, val any) string {
+	if _, ok := att.Meta["struct:field:type"]; ok {
+		return ""
+	}
+	if att.IsNullable() {
+		if !expr.IsPrimitive(att.Type) {
+			return ""
+		}
+		t := strings.TrimSuffix(strings.TrimPrefix(typeName, "goa.Nullable["), "]")
+		v := mockPrimitive(att.Type, mockInt32(att, val))
+		if att.Type == expr.Decimal || att.Type == expr.BigInt {
+			v = fmt.Sprintf("%s(%q)", t, fmt.Sprint(val))
+		}
+		if v == "" {
+			return ""
+		}
+		return fmt.Sprintf("goa.NewNullable[%s](%s)", t, v)
+	}
+	switch typeName {
+	case "time.Time":
+		s, ok := val.(string)
+		if !ok {
+			return ""
+		}
+		layout := time.RFC3339Nano
+		if att.Validation.Format == expr.FormatDate {
+			layout = time.DateOnly
+		}
+		t, err := time.Parse(layout, s)
+		if err != nil {
+			return ""
+		}
+		t = t.UTC()
+		return fmt.Sprintf("time.Date(%d, %d, %d, %d, %d, %d, %d, time.UTC)",
+			t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond())
+	case "time.Duration":
+		s, ok := val.(string)
+		if !ok {
+			return ""
+		}
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return ""
+		}
+		return fmt.Sprintf("time.Duration(%d)", int64(d))
+	}
+	if att.Type == expr.Decimal || att.Type == expr.BigInt {
+		return fmt.Sprintf("%s(%q)", typeName, fmt.Sprint(val))
+	}
+	return ""
+}
+
+// hasMetaType returns true if the code generators use a type other than the Go
+// native type of the primitive attribute att.
+func hasMetaType(att *expr.AttributeExpr) bool {
+	t, _ := codegen.GetMetaType(att)
+	return t != ""
+}
+
+// mockFields returns the Go code that initializes the fields of a struct
+// generated for the object attribute att with the example value val. It
+// returns the empty string if a required field cannot be initialized as the
+// resulting value would not validate.
+func mockFields(att *expr.AttributeExpr, val any, scope *codegen.NameScope, pkg string) string {
+	m, ok := val.(map[string]any)
+	if !ok {
+		return "{}"
+	}
+	var fields []string
+	for _, nat := range *expr.AsObject(att.Type) {
+		v := mockValue(nat.Attribute, m[nat.Name], scope, pkg)
+		if v == "" {
+			if m[nat.Name] != nil && att.IsRequired(nat.Name) {
+				return ""
+			}
+			continue
+		}
+		if att.IsPrimitivePointer(nat.Name, true) {
+			if p, ok := nat.Attribute.Type.(expr.Primitive); ok && !hasMetaType(nat.Attribute) {
+				switch p.Kind() {
+				case expr.BooleanKind, expr.IntKind, expr.StringKind:
+				default:
+					v = fmt.Sprintf("%s(%s)", codegen.GoNativeTypeName(p), v)
+				}
+			}
+			v = "ptr(" + v + ")"
+		}
+		fields = append(fields, codegen.GoifyAtt(nat.Attribute, nat.Name, true)+": "+v)
+	}
+	if len(fields) == 0 {
+		return "{}"
+	}
+	return "{\n" + strings.Join(fields, ",\n") + ",\n}"
+}
+
+// mockComposite returns the Go code that initializes the slice or map of type
+// ref generated for the array or map attribute att with the example value
+// val.
+func mockComposite(ref string, att *expr.AttributeExpr, val any, scope *codegen.NameScope, pkg string) string {
+	var elems []string
+	switch actual := att.Type.(type) {
+	case *expr.Array:
+		v := reflect.ValueOf(val)
+		if v.Kind() != reflect.Slice {
+			return ""
+		}
+		for i := 0; i < v.Len(); i++ {
+			if e := mockValue(actual.ElemType, v.Index(i).Interface(), scope, pkg); e != "" {
+				elems = append(elems, e)
+			}
+		}
+	case *expr.Map:
+		v := reflect.ValueOf(val)
+		if v.Kind() != reflect.Map {
+			return ""
+		}
+		iter := v.MapRange()
+		for iter.Next() {
+			key := mockValue(actual.KeyType, iter.Key().Interface(), scope, pkg)
+			elem := mockValue(actual.ElemType, iter.Value().Interface(), scope, pkg)
+			if key != "" && elem != "" {
+				elems = append(elems, key+": "+elem)
+			}
+		}
+		sort.Strings(elems)
+	default:
+		return ""
+	}
+	if len(elems) == 0 {
+		return ref + "{}"
+	}
+	return ref + "{\n" + strings.Join(elems, ",\n") + ",\n}"
+}
+
+// mockInt32 returns the example value val of an Int or UInt attribute reduced
+// to 32 bits. Protobuf messages represent such attributes with 32-bit integers
+// so that larger values would not make the round trip through gRPC.
+func mockInt32(att *expr.AttributeExpr, val any) any {
+	var minimum *float64
+	if att.Validation != nil {
+		minimum = att.Validation.Minimum
+	}
+	switch v := val.(type) {
+	case int:
+		if v > math.MaxInt32 || v < math.MinInt32 {
+			v %= math.MaxInt32 + 1
+			if minimum != nil && float64(v) < *minimum {
+				v = int(*minimum)
+			}
+		}
+		return v
+	case uint:
+		if v > math.MaxUint32 {
+			v %= math.MaxUint32 + 1
+			if minimum != nil && float64(v) < *minimum {
+				v = uint(*minimum)
+			}
+		}
+		return v
+	}
+	return val
+}
+
+// mockPrimitive returns the Go literal for the example value val of the
+// primitive type dt, the empty string if there is none.
+func mockPrimitive(dt expr.DataType, val any) string {
+	switch dt.Kind() {
+	case expr.BooleanKind, expr.IntKind, expr.Int32Kind, expr.Int64Kind,
+		expr.UIntKind, expr.UInt32Kind, expr.UInt64Kind:
+		return fmt.Sprintf("%v", val)
+	case expr.Float32Kind, expr.Float64Kind:
+		switch f := val.(type) {
+		case float32:
+			return strconv.FormatFloat(float64(f), 'g', -1, 32)
+		case float64:
+			return strconv.FormatFloat(f, 'g', -1, 64)
+		}
+		return fmt.Sprintf("%v", val)
+	case expr.StringKind:
+		if s, ok := val.(string); ok {
+			return strconv.Quote(s)
+		}
+	case expr.BytesKind:
+		switch b := val.(type) {
+		case []byte:
+			return fmt.Sprintf("[]byte(%q)", b)
+		case string:
+			return fmt.Sprintf("[]byte(%q)", b)
+		}
+	}
+	return ""
+}
+
+// pkgOrDefault returns the name of the package that defines the user type dt
+// if it sets a custom location, pkg otherwise.
+func pkgOrDefault(dt expr.DataType, pkg string) string {
+	if loc := codegen.UserTypeLocation(dt); loc != nil {
+		return loc.PackageName()
+	}
+	return pkg
+}
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// MockServerData contains the data needed to render the main file of
+	// the mock server.
+	MockServerData struct {
+		// Services lists the mocked services.
+		Services []*MockServerServiceData
+		// HTTPServices lists the mocked services served over HTTP.
+		HTTPServices []*MockServerServiceData
+		// GRPCServices lists the mocked services served over gRPC.
+		GRPCServices []*MockServerServiceData
+	}
+
+	// MockServerServiceData contains the data needed to configure the mock
+	// of a service in the mock server.
+	MockServerServiceData struct {
+		// Name is the service name as defined in the design.
+		Name string
+		// VarName is the name of the variables holding the service mock
+		// and endpoints.
+		VarName string
+		// StructName is the service struct name.
+		StructName string
+		// PkgName is the name of the service package.
+		PkgName string
+		// MockPkg is the name of the service mock package.
+		MockPkg string
+		// ServerInterceptors is true if the service defines server
+		// interceptors.
+		ServerInterceptors bool
+		// Methods lists the methods whose responses may be overridden
+		// with scenarios.
+		Methods []*MockServerMethodData
+	}
+
+	// MockServerMethodData contains the data needed to override the
+	// responses of a method with scenarios.
+	MockServerMethodData struct {
+		*MockMethodData
+		// Assign is the code that initializes the results of the method
+		// from the result of the selected scenario if any.
+		Assign string
+	}
+)
+
+// MockServerFiles returns the files that implement the mock server: a main
+// package that serves the mocks of all the services over the transports
+// defined in the design. The mocks answer requests with the design examples
+// unless a scenario overrides the response. Clients may request a specific
+// error or scenario using the X-Goa-Mock-Error and X-Goa-Mock-Scenario HTTP
+// headers or gRPC metadata.
+func MockServerFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
+	data := buildMockServerData(root)
+	if len(data.Services) == 0 {
+		return nil
+	}
+	dir := filepath.Join(codegen.Gendir, "mockserver")
+	imports := []*codegen.ImportSpec{
+		{Path: "context"},
+		{Path: "flag"},
+		{Path: "fmt"},
+		{Path: "io"},
+		{Path: "log"},
+		{Path: "os"},
+		{Path: "os/signal"},
+		{Path: "strings"},
+		{Path: "sync"},
+		{Path: "syscall"},
+		codegen.GoaImport(""),
+	}
+	for _, svc := range data.Services {
+		sd := Services.Get(svc.Name)
+		imports = append(imports,
+			&codegen.ImportSpec{Path: path.Join(genpkg, sd.PathName), Name: svc.PkgName},
+			&codegen.ImportSpec{Path: path.Join(genpkg, sd.PathName, "mock"), Name: svc.MockPkg},
+		)
+		imports = append(imports, sd.UserTypeImports...)
+	}
+	sections := []*codegen.SectionTemplate{
+		codegen.Header("Mock server", "main", imports),
+		{
+			Name:   "mock-server-main",
+			Source: readTemplate("mock_server_main"),
+			Data:   data,
+		},
+	}
+	for _, svc := range data.Services {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "mock-server-configure",
+			Source: readTemplate("mock_server_configure"),
+			Data:   svc,
+		})
+	}
+	main := &codegen.File{Path: filepath.Join(dir, "main.go"), SectionTemplates: sections}
+	scenarios := &codegen.File{
+		Path: filepath.Join(dir, "scenarios.go"),
+		SectionTemplates: []*codegen.SectionTemplate{
+			codegen.Header("Mock server scenarios", "main", []*codegen.ImportSpec{
+				{Path: "context"},
+				{Path: "encoding/json"},
+				{Path: "fmt"},
+				{Path: "os"},
+				{Path: "reflect"},
+				{Path: "strings"},
+			}),
+			{
+				Name:   "mock-server-scenarios",
+				Source: readTemplate("mock_server_scenarios"),
+			},
+		},
+	}
+	return []*codegen.File{main, scenarios}
+}
+
+// buildMockServerData builds the data needed to render the main file of the
+// mock server. Only the services with methods that are exposed by a
+// transport are mocked.
+func buildMockServerData(root *expr.RootExpr) *MockServerData {
+	var (
+		data  = &MockServerData{}
+		scope = codegen.NewNameScope()
+	)
+	for _, s := range root.Services {
+		if len(s.Methods) == 0 {
+			continue
+		}
+		var (
+			hasHTTP = root.API.HTTP.Service(s.Name) != nil
+			hasGRPC = root.API.GRPC.Service(s.Name) != nil
+		)
+		if !hasHTTP && !hasGRPC {
+			continue
+		}
+		sd := Services.Get(s.Name)
+		md := buildMockData(s, sd)
+		svc := &MockServerServiceData{
+			Name:               sd.Name,
+			VarName:            sd.VarName,
+			StructName:         sd.StructName,
+			PkgName:            sd.PkgName,
+			MockPkg:            scope.Unique(sd.PkgName + "mock"),
+			ServerInterceptors: len(sd.ServerInterceptors) > 0,
+		}
+		for i, m := range md.Methods {
+			if sd.Methods[i].ServerStream != nil {
+				// Scenarios cannot override streamed results.
+				continue
+			}
+			svc.Methods = append(svc.Methods, &MockServerMethodData{
+				MockMethodData: m,
+				Assign:         mockServerAssign(m, sd.Methods[i]),
+			})
+		}
+		data.Services = append(data.Services, svc)
+		if hasHTTP {
+			data.HTTPServices = append(data.HTTPServices, svc)
+		}
+		if hasGRPC {
+			data.GRPCServices = append(data.GRPCServices, svc)
+		}
+	}
+	return data
+}
+
+// mockServerAssign returns the code that initializes the results of the
+// given method from the result r of the selected scenario, the empty string
+// if the method has no result.
+func mockServerAssign(m *MockMethodData, md *MethodData) string {
+	if m.ResultRef == "" {
+		return ""
+	}
+	code := []string{"res = r"}
+	if md.SkipResponseBodyEncodeDecode {
+		code = append(code, `resp = io.NopCloser(strings.NewReader(""))`)
+	}
+	if md.ViewedResult != nil && md.ViewedResult.ViewName == "" {
+		code = append(code, `view = "default"`)
+	}
+	return strings.Join(code, "\n")
+}
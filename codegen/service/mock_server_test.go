@@ -0,0 +1,36 @@
+package service
+
+import (
+	"bytes"
+	"go/format"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/service/testdata"
+	"goa.design/goa/v3/expr"
+)
+
+func TestMockServer(t *testing.T) {
+	codegen.RunDSL(t, testdata.MockServerDSL)
+	fs := MockServerFiles("test/gen", expr.Root)
+	require.Len(t, fs, 2)
+	assert.Equal(t, "gen/mockserver/main.go", fs[0].Path)
+	assert.Equal(t, "gen/mockserver/scenarios.go", fs[1].Path)
+	buf := new(bytes.Buffer)
+	for _, s := range fs[0].SectionTemplates[1:] {
+		require.NoError(t, s.Write(buf))
+	}
+	bs, err := format.Source(buf.Bytes())
+	require.NoError(t, err, buf.String())
+	code := strings.ReplaceAll(string(bs), "\r\n", "\n")
+	assert.Equal(t, testdata.MockServerCode, code)
+}
+
+func TestMockServerNoTransport(t *testing.T) {
+	codegen.RunDSL(t, testdata.MockDSL)
+	assert.Empty(t, MockServerFiles("test/gen", expr.Root))
+}
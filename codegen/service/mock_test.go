@@ -0,0 +1,80 @@
+package service
+
+import (
+	"bytes"
+	"go/format"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/service/testdata"
+	"goa.design/goa/v3/expr"
+)
+
+func TestMock(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+		Code string
+	}{
+		{"mock-no-payload", testdata.NoPayloadEndpointDSL, testdata.NoPayloadMethodMock},
+		{"mock-with-result", testdata.WithResultEndpointDSL, testdata.WithResultMethodMock},
+		{"mock", testdata.MockDSL, testdata.MockCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			codegen.RunDSL(t, c.DSL)
+			require.Len(t, expr.Root.Services, 1)
+			f := MockFile("test/gen", expr.Root.Services[0])
+			require.NotNil(t, f)
+			buf := new(bytes.Buffer)
+			for _, s := range f.SectionTemplates[1:] {
+				require.NoError(t, s.Write(buf))
+			}
+			bs, err := format.Source(buf.Bytes())
+			require.NoError(t, err, buf.String())
+			code := strings.ReplaceAll(string(bs), "\r\n", "\n")
+			assert.Equal(t, c.Code, code)
+		})
+	}
+}
+
+func TestMockRules(t *testing.T) {
+	codegen.RunDSL(t, testdata.MockRulesDSL)
+	require.Len(t, expr.Root.Services, 1)
+	svc := Services.Get("Contacts")
+	data := buildMockData(expr.Root.Services[0], svc)
+	require.Len(t, data.Methods, 1)
+	m := data.Methods[0]
+	fieldRx := regexp.MustCompile(`(?m)^\s*(\w+): (.*),$`)
+	for name, code := range map[string]string{"payload": m.PayloadValue, "result": m.DefaultResult} {
+		t.Run(name, func(t *testing.T) {
+			fields := make(map[string]string)
+			for _, match := range fieldRx.FindAllStringSubmatch(code, -1) {
+				fields[match[1]] = match[2]
+			}
+			_, email := fields["Email"]
+			_, sms := fields["SMS"]
+			assert.True(t, email != sms, "exactly one of email and sms must be set:\n%s", code)
+			assert.Regexp(t, `^contacts\.Color\("(red|green|blue)"\)$`, fields["Color"])
+			if start, ok := fields["Start"]; ok {
+				if end, ok := fields["End"]; ok {
+					assert.Greater(t, end, start)
+				}
+			}
+			if fields["Status"] == `ptr("rejected")` {
+				assert.Contains(t, fields, "Reason")
+			}
+			assert.Regexp(t, `^ptr\(time\.Date\(\d+, \d+, \d+, \d+, \d+, \d+, \d+, time\.UTC\)\)$`, fields["CreatedAt"])
+			assert.Regexp(t, `^ptr\(time\.Duration\(\d+\)\)$`, fields["TTL"])
+			assert.Regexp(t, `^ptr\(goa\.Decimal\("[0-9.]+"\)\)$`, fields["Price"])
+			assert.Regexp(t, `^ptr\(goa\.BigInt\("[0-9]+"\)\)$`, fields["Count"])
+			assert.Regexp(t, `^goa\.NewNullable\[string\]\(".*"\)$`, fields["Nick"])
+			assert.Equal(t, `ptr("+15551234567")`, fields["Phone"])
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+// PaginationData contains the data needed to render the client helpers of a
+// paginated method.
+type PaginationData struct {
+	// AllMethod is the name of the client method that iterates over the
+	// items of all the pages, e.g. "ListAll".
+	AllMethod string
+	// AllEndpoint is the name of the function that wraps the method
+	// client endpoint into an endpoint that retrieves all the pages, e.g.
+	// "NewListAllEndpoint".
+	AllEndpoint string
+	// PayloadName is the name of the payload type.
+	PayloadName string
+	// CursorField is the name of the payload field holding the cursor.
+	CursorField string
+	// CursorPointer is true if the cursor field is a pointer.
+	CursorPointer bool
+	// NextCursorField is the name of the result field holding the next
+	// cursor.
+	NextCursorField string
+	// NextCursorPointer is true if the next cursor field is a pointer.
+	NextCursorPointer bool
+	// ItemsField is the name of the result field holding the page items.
+	ItemsField string
+	// ItemRef is the reference to the page item type.
+	ItemRef string
+}
+
+// buildPaginationData builds the pagination data for the given method, nil if
+// the method is not paginated.
+func buildPaginationData(m *expr.MethodExpr, vname, payloadRef string, scope *codegen.NameScope) *PaginationData {
+	p := m.Pagination
+	if p == nil {
+		return nil
+	}
+	var (
+		cursor = m.Payload.Find(p.Cursor)
+		next   = m.Result.Find(p.NextCursor)
+		items  = m.Result.Find(p.Items)
+		elem   = expr.AsArray(items.Type).ElemType
+	)
+	return &PaginationData{
+		AllMethod:         vname + "All",
+		AllEndpoint:       "New" + vname + "AllEndpoint",
+		PayloadName:       strings.TrimPrefix(payloadRef, "*"),
+		CursorField:       codegen.GoifyAtt(cursor, p.Cursor, true),
+		CursorPointer:     m.Payload.IsPrimitivePointer(p.Cursor, true),
+		NextCursorField:   codegen.GoifyAtt(next, p.NextCursor, true),
+		NextCursorPointer: m.Result.IsPrimitivePointer(p.NextCursor, true),
+		ItemsField:        codegen.GoifyAtt(items, p.Items, true),
+		ItemRef:           scope.GoFullTypeRef(elem, codegen.UserTypeLocation(elem.Type).PackageName()),
+	}
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+// RateLimitData contains the data needed to render the rate limit applied to
+// a method endpoint.
+type RateLimitData struct {
+	// Limit is the maximum number of requests allowed per period.
+	Limit int
+	// Period is the Go expression of the period duration.
+	Period string
+	// Burst is the maximum number of requests handled at once.
+	Burst int
+	// KeyFunc is the name of the function that computes the token bucket
+	// key of a request if any.
+	KeyFunc string
+	// KeyField is the name of the payload field holding the key.
+	KeyField string
+	// KeyPointer is true if the key field is a pointer.
+	KeyPointer bool
+}
+
+// buildRateLimitData builds the rate limit data for the given method, nil if
+// the method is not rate limited.
+func buildRateLimitData(m *expr.MethodExpr, scope *codegen.NameScope) *RateLimitData {
+	r := m.RateLimit
+	if r == nil {
+		return nil
+	}
+	data := &RateLimitData{
+		Limit:  r.Limit,
+		Period: durationCode(r.Period),
+		Burst:  r.BurstSize(),
+	}
+	if r.Key != "" {
+		data.KeyFunc = scope.Unique(codegen.Goify(m.Name, false) + "RateLimitKey")
+		data.KeyField = codegen.Goify(r.Key, true)
+		data.KeyPointer = m.Payload.IsPrimitivePointer(r.Key, true)
+	}
+	return data
+}
+
+// hasRateLimit returns true if any of the given methods is rate limited.
+func hasRateLimit(methods []*MethodData) bool {
+	for _, m := range methods {
+		if m.RateLimit != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// durationCode returns the Go expression that evaluates to d.
+func durationCode(d time.Duration) string {
+	units := []struct {
+		d    time.Duration
+		name string
+	}{
+		{time.Hour, "time.Hour"},
+		{time.Minute, "time.Minute"},
+		{time.Second, "time.Second"},
+		{time.Millisecond, "time.Millisecond"},
+		{time.Microsecond, "time.Microsecond"},
+	}
+	for _, u := range units {
+		if d%u.d != 0 {
+			continue
+		}
+		if d == u.d {
+			return u.name
+		}
+		return fmt.Sprintf("%d * %s", d/u.d, u.name)
+	}
+	return fmt.Sprintf("time.Duration(%d)", d)
+}
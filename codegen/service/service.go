@@ -161,14 +161,26 @@ func Files(genpkg string, service *expr.ServiceExpr, userTypePkgs map[string][]s
 		})
 	}
 
+	if len(svc.Formats) > 0 {
+		svcSections = append(svcSections, &codegen.SectionTemplate{
+			Name:   "service-formats",
+			Source: readTemplate("service_formats"),
+			Data:   svc.Formats,
+		})
+	}
+
 	imports := []*codegen.ImportSpec{
 		codegen.SimpleImport("context"),
 		codegen.SimpleImport("io"),
+		codegen.SimpleImport("strconv"),
 		codegen.GoaImport(""),
 		codegen.GoaImport("security"),
 		codegen.NewImport(svc.ViewsPkg, genpkg+"/"+svcName+"/views"),
 	}
 	imports = append(imports, svc.UserTypeImports...)
+	for _, f := range svc.Formats {
+		imports = append(imports, f.Import)
+	}
 	header := codegen.Header(service.Name+" service", svc.PkgName, imports)
 	def := &codegen.SectionTemplate{
 		Name:    "service",
@@ -207,7 +219,10 @@ func Files(genpkg string, service *expr.ServiceExpr, userTypePkgs map[string][]s
 		if p == svcPath {
 			continue
 		}
-		var secs []*codegen.SectionTemplate
+		var (
+			secs    []*codegen.SectionTemplate
+			imports []*codegen.ImportSpec
+		)
 		ts := typesByPath[p]
 		sort.Strings(ts)
 		for _, name := range ts {
@@ -221,14 +236,18 @@ func Files(genpkg string, service *expr.ServiceExpr, userTypePkgs map[string][]s
 				continue
 			}
 			userTypePkgs[p] = append(userTypePkgs[p], name)
-			secs = append(secs, typeDefSections[p][name])
+			sec := typeDefSections[p][name]
+			if ut, ok := sec.Data.(*UserTypeData); ok && ut.Enum != nil && imports == nil {
+				imports = []*codegen.ImportSpec{codegen.SimpleImport("strconv"), codegen.GoaImport("")}
+			}
+			secs = append(secs, sec)
 		}
 		if len(secs) == 0 {
 			continue
 		}
 		fullRelPath := filepath.Join(codegen.Gendir, p)
 		dir, _ := filepath.Split(fullRelPath)
-		h := codegen.Header("User types", codegen.Goify(filepath.Base(dir), false), nil)
+		h := codegen.Header("User types", codegen.Goify(filepath.Base(dir), false), imports)
 		sections := append([]*codegen.SectionTemplate{h}, secs...)
 		files = append(files, &codegen.File{Path: fullRelPath, SectionTemplates: sections})
 	}
@@ -70,6 +70,12 @@ type (
 		Methods []*MethodData
 		// Schemes is the list of security schemes required by the service methods.
 		Schemes SchemesData
+		// ServerInterceptors lists the server interceptors applied to the
+		// service methods.
+		ServerInterceptors []*InterceptorData
+		// ClientInterceptors lists the client interceptors applied to the
+		// service methods.
+		ClientInterceptors []*InterceptorData
 		// Scope initialized with all the service types.
 		Scope *codegen.NameScope
 		// ViewScope initialized with all the viewed types.
@@ -80,6 +86,8 @@ type (
 		// ProtoImports lists the import specifications for the custom
 		// proto types used by the service.
 		ProtoImports []*codegen.ImportSpec
+		// Formats lists the custom formats used by the service types.
+		Formats []*FormatData
 
 		// userTypes lists the type definitions that the service depends on.
 		userTypes []*UserTypeData
@@ -214,6 +222,21 @@ type (
 		// result and response body reader when SkipResponseBodyEncodeDecode is
 		// used.
 		ResponseStruct string
+		// ServerInterceptors lists the names of the server interceptors
+		// applied to the method in order of execution.
+		ServerInterceptors []string
+		// ClientInterceptors lists the names of the client interceptors
+		// applied to the method in order of execution.
+		ClientInterceptors []string
+		// RateLimit describes the rate limit applied to the method
+		// endpoint if any.
+		RateLimit *RateLimitData
+		// MaxConcurrency is the maximum number of requests handled
+		// concurrently by the method endpoint, 0 means no limit.
+		MaxConcurrency int
+		// Pagination describes the cursor based pagination of the method
+		// results if any.
+		Pagination *PaginationData
 	}
 
 	// StreamData is the data used to generate client and server interfaces that
@@ -271,6 +294,9 @@ type (
 		Description string
 		// Def is the type definition Go code.
 		Def string
+		// TypeParams is the type parameter list of Go generic types, for
+		// example "[T any]".
+		TypeParams string
 		// Ref is the reference to the type.
 		Ref string
 		// Loc defines the file and Go package of the type if overridden
@@ -278,6 +304,34 @@ type (
 		Loc *codegen.Location
 		// Type is the underlying type.
 		Type expr.UserType
+		// Enum describes the constants and helpers generated for types
+		// that use the "type:enum" meta, nil otherwise.
+		Enum *EnumData
+	}
+
+	// EnumData describes the constants and helper functions generated for a
+	// user type that uses the "type:enum" meta.
+	EnumData struct {
+		// Values lists the enum values.
+		Values []*EnumValueData
+		// ParseName is the name of the function that parses strings into
+		// enum values.
+		ParseName string
+		// IsString is true if the underlying type is a string.
+		IsString bool
+		// Unsigned is true if the underlying type is an unsigned integer.
+		Unsigned bool
+		// BitSize is the bit size of the underlying integer type, zero if
+		// the type is int or uint.
+		BitSize int
+	}
+
+	// EnumValueData describes a single enum value.
+	EnumValueData struct {
+		// Name is the name of the constant.
+		Name string
+		// Value is the Go literal for the value.
+		Value string
 	}
 
 	// SchemeData describes a single security scheme.
@@ -712,6 +766,8 @@ func (d ServicesData) analyze(service *expr.ServiceExpr) *Data {
 		}
 	}
 
+	types = append(types, buildGenericTypes(service, types, errTypes, methods, scope)...)
+
 	var (
 		unionMethods []*UnionValueMethodData
 	)
@@ -778,7 +834,9 @@ func (d ServicesData) analyze(service *expr.ServiceExpr) *Data {
 		viewedUnionMethods: viewedUnionMeths,
 		viewedResultTypes:  viewedRTs,
 		unionValueMethods:  unionMethods,
+		Formats:            buildFormatsData(service),
 	}
+	data.initInterceptors(service)
 	d[service.Name] = data
 
 	return data
@@ -817,6 +875,7 @@ func collectTypes(at *expr.AttributeExpr, scope *codegen.NameScope, seen map[str
 			Ref:         scope.GoTypeRef(at),
 			Loc:         codegen.UserTypeLocation(dt),
 			Type:        dt,
+			Enum:        buildEnumData(dt, scope.GoTypeName(at)),
 		})
 		seen[dt.ID()] = struct{}{}
 		data = append(data, collect(dt.Attribute())...)
@@ -837,6 +896,201 @@ func collectTypes(at *expr.AttributeExpr, scope *codegen.NameScope, seen map[str
 	return
 }
 
+// buildEnumData returns the data needed to render the constants and helper
+// functions of the given user type if it uses the "type:enum" meta, nil
+// otherwise. name is the Go name of the type.
+func buildEnumData(ut expr.UserType, name string) *EnumData {
+	if !expr.IsTypedEnum(ut) {
+		return nil
+	}
+	att := ut.Attribute()
+	if att.Validation == nil {
+		return nil
+	}
+	data := &EnumData{ParseName: "Parse" + name}
+	switch att.Type {
+	case expr.String:
+		data.IsString = true
+	case expr.Int32:
+		data.BitSize = 32
+	case expr.Int64:
+		data.BitSize = 64
+	case expr.UInt:
+		data.Unsigned = true
+	case expr.UInt32:
+		data.Unsigned = true
+		data.BitSize = 32
+	case expr.UInt64:
+		data.Unsigned = true
+		data.BitSize = 64
+	}
+	seen := make(map[string]int)
+	for _, v := range att.Validation.Values {
+		suffix := codegen.Goify(fmt.Sprint(v), true)
+		if _, ok := v.(string); !ok && strings.HasPrefix(fmt.Sprint(v), "-") {
+			suffix = "Neg" + suffix
+		} else if ok && suffix == "" {
+			suffix = "Empty"
+		}
+		cname := name + suffix
+		if n := seen[cname]; n > 0 {
+			cname = fmt.Sprintf("%s%d", cname, n+1)
+		}
+		seen[name+suffix]++
+		data.Values = append(data.Values, &EnumValueData{Name: cname, Value: fmt.Sprintf("%#v", v)})
+	}
+	return data
+}
+
+// buildGenericTypes returns the data needed to render the Go generic types of
+// the type templates that use the "type:generic" meta and whose instances are
+// used by the service. It also changes the definitions of the instances into
+// aliases of the corresponding generic type instantiations. Templates whose
+// instances cannot all be rendered with the same generic type are left
+// untouched.
+func buildGenericTypes(service *expr.ServiceExpr, types, errTypes []*UserTypeData, methods []*MethodData, scope *codegen.NameScope) []*UserTypeData {
+	var (
+		templates []*expr.TypeTemplateExpr
+		instances = make(map[*expr.TypeTemplateExpr][]*expr.TypeInstanceExpr)
+		defs      = make(map[*expr.TypeInstanceExpr][]*string)
+		excluded  = make(map[*expr.TypeTemplateExpr]struct{})
+	)
+	record := func(dt expr.DataType, def *string) {
+		inst := expr.Root.TypeInstance(dt)
+		if inst == nil {
+			return
+		}
+		if _, ok := inst.Type.Meta["type:generic"]; !ok {
+			return
+		}
+		if _, ok := defs[inst]; !ok {
+			if _, ok := instances[inst.Template]; !ok {
+				templates = append(templates, inst.Template)
+			}
+			instances[inst.Template] = append(instances[inst.Template], inst)
+		}
+		defs[inst] = append(defs[inst], def)
+	}
+	for _, t := range types {
+		record(t.Type, &t.Def)
+	}
+	for i, m := range service.Methods {
+		record(m.Payload.Type, &methods[i].PayloadDef)
+		record(m.StreamingPayload.Type, &methods[i].StreamingPayloadDef)
+		record(m.Result.Type, &methods[i].ResultDef)
+	}
+	for _, et := range errTypes {
+		// Error types implement the error interface, methods cannot be
+		// defined on generic type instantiations.
+		if inst := expr.Root.TypeInstance(et.Type); inst != nil {
+			excluded[inst.Template] = struct{}{}
+		}
+	}
+
+	var data []*UserTypeData
+	for _, t := range templates {
+		if _, ok := excluded[t]; ok {
+			continue
+		}
+		insts := instances[t]
+		params := insts[0].Type.Meta["type:generic"]
+		if len(params) == 0 {
+			params = []string{"T"}
+			if t.Params > 1 {
+				params = make([]string, t.Params)
+				for i := range params {
+					params[i] = fmt.Sprintf("T%d", i+1)
+				}
+			}
+		}
+		if len(params) != t.Params {
+			continue
+		}
+		var def string
+		for _, inst := range insts {
+			d, ok := genericTypeDef(inst, params, scope)
+			if !ok || def != "" && d != def {
+				def = ""
+				break
+			}
+			def = d
+		}
+		if def == "" {
+			continue
+		}
+		name := scope.Unique(codegen.Goify(t.Name, true))
+		data = append(data, &UserTypeData{
+			Name:        t.Name,
+			VarName:     name,
+			Description: insts[0].Type.Description,
+			Def:         def,
+			TypeParams:  "[" + strings.Join(params, ", ") + " any]",
+			Type:        insts[0].Type,
+		})
+		for _, inst := range insts {
+			args := make([]string, len(inst.Args))
+			for i, arg := range inst.Args {
+				args[i] = scope.GoTypeName(&expr.AttributeExpr{Type: arg})
+			}
+			alias := "= " + name + "[" + strings.Join(args, ", ") + "]"
+			for _, d := range defs[inst] {
+				*d = alias
+			}
+		}
+	}
+	return data
+}
+
+// genericTypeDef returns the definition of the Go generic type of the
+// template of the given instance using the given type parameter names. It
+// returns false if the instance cannot be rendered as an instantiation of the
+// generic type, that is if it or any of its arguments is not a user type
+// defined in the service package.
+func genericTypeDef(inst *expr.TypeInstanceExpr, params []string, scope *codegen.NameScope) (string, bool) {
+	if codegen.UserTypeLocation(inst.Type) != nil {
+		return "", false
+	}
+	subs := make(map[string]expr.DataType, len(params))
+	for i, arg := range inst.Args {
+		ut, ok := arg.(expr.UserType)
+		if !ok || codegen.UserTypeLocation(ut) != nil {
+			return "", false
+		}
+		// The type parameter has the same underlying type as the
+		// argument so that it is rendered with the same indirections.
+		p := &expr.UserTypeExpr{TypeName: params[i], AttributeExpr: &expr.AttributeExpr{Type: ut.Attribute().Type}}
+		if scope.GoTypeName(&expr.AttributeExpr{Type: p}) != params[i] {
+			return "", false
+		}
+		subs[ut.ID()] = p
+	}
+	return scope.GoTypeDef(substituteTypeArgs(inst.Type.Attribute(), subs), false, true), true
+}
+
+// substituteTypeArgs returns a copy of att where the user types indexed by
+// ID in subs are replaced with the corresponding types. It does not recurse
+// into user types.
+func substituteTypeArgs(att *expr.AttributeExpr, subs map[string]expr.DataType) *expr.AttributeExpr {
+	dup := *att
+	switch dt := att.Type.(type) {
+	case expr.UserType:
+		if p, ok := subs[dt.ID()]; ok {
+			dup.Type = p
+		}
+	case *expr.Object:
+		obj := make(expr.Object, len(*dt))
+		for i, nat := range *dt {
+			obj[i] = &expr.NamedAttributeExpr{Name: nat.Name, Attribute: substituteTypeArgs(nat.Attribute, subs)}
+		}
+		dup.Type = &obj
+	case *expr.Array:
+		dup.Type = &expr.Array{ElemType: substituteTypeArgs(dt.ElemType, subs)}
+	case *expr.Map:
+		dup.Type = &expr.Map{KeyType: substituteTypeArgs(dt.KeyType, subs), ElemType: substituteTypeArgs(dt.ElemType, subs)}
+	}
+	return &dup
+}
+
 // collectUnionMethods traverses the attribute to gather all union value methods.
 func collectUnionMethods(att *expr.AttributeExpr, scope *codegen.NameScope, loc *codegen.Location, seen map[string]struct{}) (data []*UnionValueMethodData) {
 	if att == nil || att.Type == expr.Empty {
@@ -1000,6 +1254,9 @@ func buildMethodData(m *expr.MethodExpr, scope *codegen.NameScope) *MethodData {
 		SkipResponseBodyEncodeDecode: httpMet != nil && httpMet.SkipResponseBodyEncodeDecode,
 		RequestStruct:                vname + "RequestData",
 		ResponseStruct:               vname + "ResponseData",
+		RateLimit:                    buildRateLimitData(m, scope),
+		MaxConcurrency:               m.MaxConcurrency,
+		Pagination:                   buildPaginationData(m, vname, payloadRef, scope),
 	}
 	if m.IsStreaming() {
 		initStreamData(data, m, vname, rname, resultRef, scope)
@@ -43,6 +43,11 @@ func TestService(t *testing.T) {
 		{"service-custom-errors-custom-field", testdata.CustomErrorsCustomFieldDSL, testdata.CustomErrorsCustomField},
 		{"service-force-generate-type", testdata.ForceGenerateTypeDSL, testdata.ForceGenerateType},
 		{"service-force-generate-type-explicit", testdata.ForceGenerateTypeExplicitDSL, testdata.ForceGenerateTypeExplicit},
+		{"service-typed-enum", testdata.TypedEnumDSL, testdata.TypedEnum},
+		{"service-time", testdata.TimeDSL, testdata.Time},
+		{"service-nullable", testdata.NullableDSL, testdata.NullableCode},
+		{"service-custom-format", testdata.CustomFormatDSL, testdata.CustomFormatCode},
+		{"service-type-template", testdata.TypeTemplateDSL, testdata.TypeTemplateCode},
 		{"service-streaming-result", testdata.StreamingResultMethodDSL, testdata.StreamingResultMethod},
 		{"service-streaming-result-with-views", testdata.StreamingResultWithViewsMethodDSL, testdata.StreamingResultWithViewsMethod},
 		{"service-streaming-result-with-explicit-view", testdata.StreamingResultWithExplicitViewMethodDSL, testdata.StreamingResultWithExplicitViewMethod},
@@ -0,0 +1,16 @@
+
+{{ printf "%sServerInterceptors implements the server interceptors of the %s service." .VarName .Name | comment }}
+type {{ .VarName }}ServerInterceptors struct{}
+
+{{ printf "New%sServerInterceptors returns the %s service server interceptors." .StructName .Name | comment }}
+func New{{ .StructName }}ServerInterceptors() {{ .PkgName }}.ServerInterceptors {
+	return &{{ .VarName }}ServerInterceptors{}
+}
+{{- range .ServerInterceptors }}
+
+{{ comment .Description }}
+func (i *{{ $.VarName }}ServerInterceptors) {{ .VarName }}(ctx context.Context, info *{{ $.PkgName }}.{{ .InfoName }}, next goa.Endpoint) (any, error) {
+	log.Printf(ctx, "[{{ .Name }}] %s.%s", info.Service(), info.Method())
+	return next(ctx, info.RawPayload())
+}
+{{- end }}
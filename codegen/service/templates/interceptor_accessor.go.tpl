@@ -0,0 +1,45 @@
+{{ comment .Description }}
+type {{ .Name }} struct {
+	{{ .Field }} {{ .FieldRef }}
+}
+{{- if .InitName }}
+
+{{ printf "%s returns the %s built from the given raw value. The raw value is nil if the endpoint returned an error." .InitName .Interface | comment }}
+func {{ .InitName }}(res any) {{ .Interface }} {
+	{{ .Field }}, _ := res.({{ .FieldRef }})
+	return &{{ .Name }}{ {{- .Field }}: {{ .Field }}}
+}
+{{- end }}
+{{- $acc := . }}
+{{- $isNil := "" }}
+{{- if .InitName }}
+	{{- $isNil = printf "a.%s == nil" .Field }}
+	{{- if ne .Path .Field }}{{ $isNil = printf "%s || a.%s == nil" $isNil .Path }}{{ end }}
+{{- end }}
+{{- range .Attributes }}
+	{{- if .Read }}
+
+{{ printf "%s returns the value of the %q field." .Name .FieldName | comment }}
+func (a *{{ $acc.Name }}) {{ .Name }}() {{ .TypeRef }} {
+		{{- if or $isNil .Pointer }}
+	if {{ $isNil }}{{ if and $isNil .Pointer }} || {{ end }}{{ if .Pointer }}a.{{ $acc.Path }}.{{ .FieldName }} == nil{{ end }} {
+		var zero {{ .TypeRef }}
+		return zero
+	}
+		{{- end }}
+	return {{ if .Pointer }}*{{ end }}a.{{ $acc.Path }}.{{ .FieldName }}
+}
+	{{- end }}
+	{{- if .Write }}
+
+{{ printf "Set%s sets the value of the %q field." .Name .FieldName | comment }}
+func (a *{{ $acc.Name }}) Set{{ .Name }}(v {{ .TypeRef }}) {
+		{{- if $isNil }}
+	if {{ $isNil }} {
+		return
+	}
+		{{- end }}
+	a.{{ $acc.Path }}.{{ .FieldName }} = {{ if .Pointer }}&{{ end }}v
+}
+	{{- end }}
+{{- end }}
@@ -0,0 +1,69 @@
+{{ printf "%s provides metadata about the current interception by the %q interceptor." .InfoName .Name | comment }}
+type {{ .InfoName }} struct {
+	service    string
+	method     string
+	rawPayload any
+{{- if .PayloadAccessor }}
+	payload    {{ .PayloadAccessor }}
+{{- end }}
+{{- if .ResultAccessor }}
+	result     func(any) {{ .ResultAccessor }}
+{{- end }}
+}
+
+// Service returns the name of the service handling the request.
+func (info *{{ .InfoName }}) Service() string {
+	return info.service
+}
+
+// Method returns the name of the method handling the request.
+func (info *{{ .InfoName }}) Method() string {
+	return info.method
+}
+
+// RawPayload returns the raw payload of the request.
+func (info *{{ .InfoName }}) RawPayload() any {
+	return info.rawPayload
+}
+{{- if .PayloadAccessor }}
+
+// Payload returns a type-safe accessor for the method payload.
+func (info *{{ .InfoName }}) Payload() {{ .PayloadAccessor }} {
+	return info.payload
+}
+{{- end }}
+{{- if .ResultAccessor }}
+
+// Result returns a type-safe accessor for the method result.
+func (info *{{ .InfoName }}) Result(res any) {{ .ResultAccessor }} {
+	return info.result(res)
+}
+{{- end }}
+{{- if .PayloadAccessor }}
+
+{{ printf "%s provides type-safe access to the method payload." .PayloadAccessor | comment }}
+type {{ .PayloadAccessor }} interface {
+{{- range .PayloadAttributes }}
+	{{- if .Read }}
+	{{ .Name }}() {{ .TypeRef }}
+	{{- end }}
+	{{- if .Write }}
+	Set{{ .Name }}({{ .TypeRef }})
+	{{- end }}
+{{- end }}
+}
+{{- end }}
+{{- if .ResultAccessor }}
+
+{{ printf "%s provides type-safe access to the method result." .ResultAccessor | comment }}
+type {{ .ResultAccessor }} interface {
+{{- range .ResultAttributes }}
+	{{- if .Read }}
+	{{ .Name }}() {{ .TypeRef }}
+	{{- end }}
+	{{- if .Write }}
+	Set{{ .Name }}({{ .TypeRef }})
+	{{- end }}
+{{- end }}
+}
+{{- end }}
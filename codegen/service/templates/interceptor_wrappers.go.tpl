@@ -0,0 +1,27 @@
+{{ printf "%s wraps the %q endpoint with the %s-side interceptors defined in the design." .Name .MethodName .Side | comment }}
+func {{ .Name }}(endpoint goa.Endpoint, i {{ .Interface }}) goa.Endpoint {
+{{- range .Chain }}
+	endpoint = {{ . }}(endpoint, i)
+{{- end }}
+	return endpoint
+}
+{{- range .Interceptors }}
+
+{{ printf "%s applies the %q %s-side interceptor to the %q endpoint." .Name .Interceptor.Name $.Side $.MethodName | comment }}
+func {{ .Name }}(endpoint goa.Endpoint, i {{ $.Interface }}) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		info := &{{ .Interceptor.InfoName }}{
+			service:    {{ printf "%q" $.ServiceName }},
+			method:     {{ printf "%q" $.MethodName }},
+			rawPayload: req,
+	{{- if .PayloadAccess }}
+			payload:    &{{ .PayloadAccess }}{payload: {{ .Payload }}},
+	{{- end }}
+	{{- if .ResultInit }}
+			result:     {{ .ResultInit }},
+	{{- end }}
+		}
+		return i.{{ .Interceptor.VarName }}(ctx, info, endpoint)
+	}
+}
+{{- end }}
@@ -0,0 +1,25 @@
+{{- if .ServerInterceptors }}
+// ServerInterceptors defines the interface for all server-side interceptors.
+// Server interceptors execute after the request is decoded and before the
+// payload is sent to the service. The implementation is responsible for
+// calling next to complete the request.
+type ServerInterceptors interface {
+{{- range .ServerInterceptors }}
+	{{ comment .Description }}
+	{{ .VarName }}(ctx context.Context, info *{{ .InfoName }}, next goa.Endpoint) (any, error)
+{{- end }}
+}
+{{- end }}
+{{- if .ClientInterceptors }}
+
+// ClientInterceptors defines the interface for all client-side interceptors.
+// Client interceptors execute after the payload is encoded and before the
+// request is sent to the server. The implementation is responsible for calling
+// next to complete the request.
+type ClientInterceptors interface {
+{{- range .ClientInterceptors }}
+	{{ comment .Description }}
+	{{ .VarName }}(ctx context.Context, info *{{ .InfoName }}, next goa.Endpoint) (any, error)
+{{- end }}
+}
+{{- end }}
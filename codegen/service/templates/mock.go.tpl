@@ -0,0 +1,110 @@
+type (
+	{{ printf "Mock implements the %q service interface. The mock records the calls made to its methods and implements each method with the functions queued with the Expect methods, then with the function registered with the Set method if any and otherwise returns a default result built from the design examples." .Name | comment }}
+	Mock struct {
+{{- range .Methods }}
+		{{ .FieldName }}Funcs []{{ .FuncName }}
+		{{ .FieldName }}Func {{ .FuncName }}
+{{- end }}
+{{- range .Schemes }}
+		auth{{ .Type }} security.Auth{{ .Type }}Func
+{{- end }}
+		calls []*Call
+		mu sync.Mutex
+	}
+
+	// Call describes a call made to a mock method.
+	Call struct {
+		// Method is the name of the method as defined in the design.
+		Method string
+		// Payload is the method payload, nil if the method has no payload.
+		Payload any
+	}
+{{- range .Methods }}
+
+	{{ printf "%s implements the %q method." .FuncName .Name | comment }}
+	{{ .FuncName }} func({{ .Params }}) ({{ .Results }})
+{{- end }}
+)
+
+{{ printf "New returns a mock of the %q service that returns the default results." .Name | comment }}
+func New() *Mock {
+	return &Mock{}
+}
+
+// Calls returns the calls made to the mock in order.
+func (m *Mock) Calls() []*Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*Call(nil), m.calls...)
+}
+
+// HasMore returns true if some of the functions queued with the Expect
+// methods have not been called yet.
+func (m *Mock) HasMore() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return {{ range $i, $m := .Methods }}{{ if $i }} || {{ end }}len(m.{{ .FieldName }}Funcs) > 0{{ else }}false{{ end }}
+}
+{{- range .Schemes }}
+
+{{ printf "Set%sAuth sets the function that implements the %s security scheme, by default all requests are authorized." .Type .Type | comment }}
+func (m *Mock) Set{{ .Type }}Auth(f security.Auth{{ .Type }}Func) *Mock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.auth{{ .Type }} = f
+	return m
+}
+
+{{ printf "%sAuth implements the authorization logic for the %s security scheme." .Type .Type | comment }}
+func (m *Mock) {{ .Type }}Auth(ctx context.Context, {{ if eq .Type "Basic" }}user, pass{{ else if eq .Type "APIKey" }}key{{ else }}token{{ end }} string, schema *security.{{ .Type }}Scheme) (context.Context, error) {
+	m.mu.Lock()
+	f := m.auth{{ .Type }}
+	m.mu.Unlock()
+	if f == nil {
+		return ctx, nil
+	}
+	return f(ctx, {{ if eq .Type "Basic" }}user, pass{{ else if eq .Type "APIKey" }}key{{ else }}token{{ end }}, schema)
+}
+{{- end }}
+
+{{ printf "NewError returns the error named name of the %q service method, nil if the method does not define such an error. Mock functions may use NewError to return the errors defined in the design." .Name | comment }}
+func NewError(method, name string) error {
+{{- if hasErrors .Methods }}
+	switch method {
+	{{- range .Methods }}
+		{{- if .Errors }}
+	case {{ printf "%q" .Name }}:
+		switch name {
+			{{- range .Errors }}
+		case {{ printf "%q" .Name }}:
+			return {{ .Value }}
+			{{- end }}
+		}
+		{{- end }}
+	{{- end }}
+	}
+{{- end }}
+	return nil
+}
+{{- if .ServerInterceptors }}
+
+{{ printf "ServerInterceptors implements the server interceptors of the %q service by calling the next interceptor or endpoint." .Name | comment }}
+type ServerInterceptors struct{}
+	{{- range .ServerInterceptors }}
+
+{{ printf "%s calls the next interceptor or endpoint." .VarName | comment }}
+func (ServerInterceptors) {{ .VarName }}(ctx context.Context, info *{{ $.PkgName }}.{{ .InfoName }}, next goa.Endpoint) (any, error) {
+	return next(ctx, info.RawPayload())
+}
+	{{- end }}
+{{- end }}
+
+// record records a call made to the mock.
+func (m *Mock) record(method string, payload any) {
+	m.calls = append(m.calls, &Call{Method: method, Payload: payload})
+}
+
+// ptr returns a pointer to v.
+func ptr[T any](v T) *T {
+	return &v
+}
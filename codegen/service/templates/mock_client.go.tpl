@@ -0,0 +1,11 @@
+
+{{ printf "Client returns a %q service client whose endpoints call the mock methods. The client does not support streaming methods." .Name | comment }}
+func (m *Mock) Client() *{{ .PkgName }}.Client {
+	return &{{ .PkgName }}.Client{
+{{- range .Methods }}
+		{{ .VarName }}Endpoint: func(ctx context.Context, req any) (any, error) {
+			{{ .ClientEndpoint }}
+		},
+{{- end }}
+	}
+}
@@ -0,0 +1,43 @@
+
+{{ printf "Expect%s queues f so that it implements the next call to the %q method that is not implemented by a previously queued function." .VarName .Name | comment }}
+func (m *Mock) Expect{{ .VarName }}(f {{ .FuncName }}) *Mock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.{{ .FieldName }}Funcs = append(m.{{ .FieldName }}Funcs, f)
+	return m
+}
+
+{{ printf "Set%s sets the function that implements the %q method once the queued functions have been called." .VarName .Name | comment }}
+func (m *Mock) Set{{ .VarName }}(f {{ .FuncName }}) *Mock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.{{ .FieldName }}Func = f
+	return m
+}
+
+{{ printf "%s implements the %q method." .VarName .Name | comment }}
+func (m *Mock) {{ .VarName }}({{ .Params }}) ({{ .Results }}) {
+	m.mu.Lock()
+	m.record({{ printf "%q" .Name }}, {{ if .PayloadVar }}{{ .PayloadVar }}{{ else }}nil{{ end }})
+	f := m.{{ .FieldName }}Func
+	if len(m.{{ .FieldName }}Funcs) > 0 {
+		f = m.{{ .FieldName }}Funcs[0]
+		m.{{ .FieldName }}Funcs = m.{{ .FieldName }}Funcs[1:]
+	}
+	m.mu.Unlock()
+	if f == nil {
+		return {{ .DefaultReturn }}
+	}
+	return f({{ .Args }})
+}
+{{- if .ResultRef }}
+
+{{ printf "default%sResult returns the default result of the %q method." .VarName .Name | comment }}
+func default{{ .VarName }}Result() (res {{ .ResultRef }}) {
+	{{- if .DefaultResult }}
+	return {{ .DefaultResult }}
+	{{- else }}
+	return
+	{{- end }}
+}
+{{- end }}
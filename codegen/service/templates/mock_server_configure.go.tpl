@@ -0,0 +1,47 @@
+{{ printf "configure%s configures the %q service mock so that the scenarios override the default responses." .StructName .Name | comment }}
+func configure{{ .StructName }}(m *{{ .MockPkg }}.Mock, scenarios []*scenario) error {
+	for _, s := range scenarios {
+		if s.Service != {{ printf "%q" .Name }} {
+			continue
+		}
+{{- if .Methods }}
+		switch s.Method {
+		case {{ range $i, $m := .Methods }}{{ if $i }}, {{ end }}{{ printf "%q" .Name }}{{ end }}:
+		default:
+			return fmt.Errorf("scenario %q: service %q has no method %q that supports scenarios", s.Name, s.Service, s.Method)
+		}
+		if s.Error != "" && {{ .MockPkg }}.NewError(s.Method, s.Error) == nil {
+			return fmt.Errorf("scenario %q: method %q of service %q does not define error %q", s.Name, s.Method, s.Service, s.Error)
+		}
+{{- else }}
+		return fmt.Errorf("scenario %q: service %q has no method that supports scenarios", s.Name, s.Service)
+{{- end }}
+	}
+{{- if .Methods }}
+	def := {{ .MockPkg }}.New()
+{{- end }}
+{{- range .Methods }}
+	{{- if .ResultRef }}
+	{{ .FieldName }}Results, err := scenarioResults[{{ .ResultRef }}](scenarios, {{ printf "%q" $.Name }}, {{ printf "%q" .Name }})
+	if err != nil {
+		return err
+	}
+	{{- end }}
+	m.Set{{ .VarName }}(func({{ .Params }}) ({{ .Results }}) {
+		if s := selectScenario(ctx, scenarios, {{ printf "%q" $.Name }}, {{ printf "%q" .Name }}); s != nil {
+			if s.Error != "" {
+				err = {{ $.MockPkg }}.NewError({{ printf "%q" .Name }}, s.Error)
+				return
+			}
+		{{- if .Assign }}
+			if r, ok := {{ .FieldName }}Results[s.Name]; ok {
+				{{ .Assign }}
+				return
+			}
+		{{- end }}
+		}
+		return def.{{ .VarName }}({{ .Args }})
+	})
+{{- end }}
+	return nil
+}
@@ -0,0 +1,119 @@
+func main() {
+	var (
+{{- if .HTTPServices }}
+		httpAddrF = flag.String("http-addr", ":8080", "HTTP listen `address`")
+{{- end }}
+{{- if .GRPCServices }}
+		grpcAddrF = flag.String("grpc-addr", ":8081", "gRPC listen `address`")
+{{- end }}
+		scenariosF = flag.String("scenarios", "", "JSON `file` listing the scenarios that override the default responses")
+	)
+	flag.Parse()
+
+	var scenarios []*scenario
+	if *scenariosF != "" {
+		var err error
+		if scenarios, err = loadScenarios(*scenariosF); err != nil {
+			log.Fatalf("failed to load scenarios: %v", err)
+		}
+		for _, s := range scenarios {
+			switch s.Service {
+			case {{ range $i, $s := .Services }}{{ if $i }}, {{ end }}{{ printf "%q" .Name }}{{ end }}:
+			default:
+				log.Fatalf("invalid scenarios: scenario %q: unknown service %q", s.Name, s.Service)
+			}
+		}
+	}
+
+	// Initialize the service mocks and wrap them with endpoints that return
+	// the errors requested by the clients.
+	var (
+{{- range .Services }}
+		{{ .VarName }}Endpoints *{{ .PkgName }}.Endpoints
+{{- end }}
+	)
+	{
+{{- range .Services }}
+		{{ .VarName }}Mock := {{ .MockPkg }}.New()
+		if err := configure{{ .StructName }}({{ .VarName }}Mock, scenarios); err != nil {
+			log.Fatalf("invalid scenarios: %v", err)
+		}
+		{{ .VarName }}Endpoints = {{ .PkgName }}.NewEndpoints({{ .VarName }}Mock{{ if .ServerInterceptors }}, {{ .MockPkg }}.ServerInterceptors{}{{ end }})
+		{{ .VarName }}Endpoints.Use(mockErrors({{ .MockPkg }}.NewError))
+{{- end }}
+	}
+
+	// Create channel used by both the signal handler and server goroutines
+	// to notify the main goroutine when to stop the servers.
+	errc := make(chan error)
+
+	// Setup interrupt handler so that SIGINT and SIGTERM signals cause the
+	// servers to stop gracefully.
+	go func() {
+		c := make(chan os.Signal, 1)
+		signal.Notify(c, syscall.SIGINT, syscall.SIGTERM)
+		errc <- fmt.Errorf("%s", <-c)
+	}()
+
+	var wg sync.WaitGroup
+	ctx, cancel := context.WithCancel(context.Background())
+{{- if .HTTPServices }}
+	handleHTTPServer(ctx, *httpAddrF{{ range .HTTPServices }}, {{ .VarName }}Endpoints{{ end }}, &wg, errc)
+{{- end }}
+{{- if .GRPCServices }}
+	handleGRPCServer(ctx, *grpcAddrF{{ range .GRPCServices }}, {{ .VarName }}Endpoints{{ end }}, &wg, errc)
+{{- end }}
+
+	// Wait for signal.
+	log.Printf("exiting (%v)", <-errc)
+
+	// Send cancellation signal to the goroutines.
+	cancel()
+
+	wg.Wait()
+	log.Printf("exited")
+}
+
+// mockKey is the type of the context keys used to record the error and the
+// scenario requested by the client.
+type mockKey int
+
+const (
+	// errorKey is the context key used to record the name of the error
+	// requested by the client.
+	errorKey mockKey = iota + 1
+	// scenarioKey is the context key used to record the name of the
+	// scenario requested by the client.
+	scenarioKey
+)
+
+// withMock returns a copy of ctx that records the names of the error and of
+// the scenario requested by the client if any.
+func withMock(ctx context.Context, errName, scenarioName string) context.Context {
+	if errName != "" {
+		ctx = context.WithValue(ctx, errorKey, errName)
+	}
+	if scenarioName != "" {
+		ctx = context.WithValue(ctx, scenarioKey, scenarioName)
+	}
+	return ctx
+}
+
+// mockErrors returns an endpoint middleware that returns the error requested
+// by the client instead of calling the endpoint. newError initializes the
+// errors given the names of the method and of the error.
+func mockErrors(newError func(method, name string) error) func(goa.Endpoint) goa.Endpoint {
+	return func(e goa.Endpoint) goa.Endpoint {
+		return func(ctx context.Context, req any) (any, error) {
+			name, _ := ctx.Value(errorKey).(string)
+			if name == "" {
+				return e(ctx, req)
+			}
+			method, _ := ctx.Value(goa.MethodKey).(string)
+			if err := newError(method, name); err != nil {
+				return nil, err
+			}
+			return nil, goa.PermanentError("unknown_error", "method %q does not define error %q", method, name)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+// scenario describes a response returned by the mock server. The scenarios
+// are read from a JSON file that lists objects with the following fields:
+//
+//	service: name of the service as defined in the design
+//	method:  name of the method as defined in the design
+//	name:    name of the scenario, used to select it with the
+//	         X-Goa-Mock-Scenario header or x-goa-mock-scenario metadata
+//	result:  result value returned by the method
+//	error:   name of the error returned by the method
+//
+// The first scenario of a method is used unless the client selects another
+// one. Scenarios that define neither a result nor an error use the default
+// response. The keys of the result objects are matched against the attribute
+// names ignoring case, "_" and "-".
+type scenario struct {
+	Service string          `json:"service"`
+	Method  string          `json:"method"`
+	Name    string          `json:"name"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   string          `json:"error,omitempty"`
+}
+
+// loadScenarios reads the scenarios listed in the JSON file at path.
+func loadScenarios(path string) ([]*scenario, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var scenarios []*scenario
+	if err := json.Unmarshal(b, &scenarios); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return scenarios, nil
+}
+
+// selectScenario returns the scenario of the given method selected by the
+// client, the first scenario of the method if the client does not select one
+// and nil if there is none.
+func selectScenario(ctx context.Context, scenarios []*scenario, svc, method string) *scenario {
+	name, _ := ctx.Value(scenarioKey).(string)
+	for _, s := range scenarios {
+		if s.Service == svc && s.Method == method && (name == "" || s.Name == name) {
+			return s
+		}
+	}
+	return nil
+}
+
+// scenarioResults decodes the results of the scenarios of the given method
+// indexed by scenario name. Scenarios that do not define a result are
+// omitted.
+func scenarioResults[T any](scenarios []*scenario, svc, method string) (map[string]T, error) {
+	res := make(map[string]T)
+	for _, s := range scenarios {
+		if s.Service != svc || s.Method != method || s.Error != "" || len(s.Result) == 0 {
+			continue
+		}
+		var raw any
+		if err := json.Unmarshal(s.Result, &raw); err != nil {
+			return nil, fmt.Errorf("scenario %q: %w", s.Name, err)
+		}
+		var v T
+		if err := decodeValue(reflect.ValueOf(&v).Elem(), raw); err != nil {
+			return nil, fmt.Errorf("scenario %q: result%w", s.Name, err)
+		}
+		res[s.Name] = v
+	}
+	return res, nil
+}
+
+// decodeValue sets dst with the JSON value v.
+func decodeValue(dst reflect.Value, v any) error {
+	if v == nil {
+		return nil
+	}
+	if u, ok := dst.Addr().Interface().(json.Unmarshaler); ok {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		return u.UnmarshalJSON(b)
+	}
+	switch dst.Kind() {
+	case reflect.Pointer:
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		return decodeValue(dst.Elem(), v)
+	case reflect.Struct:
+		m, ok := v.(map[string]any)
+		if !ok {
+			return fmt.Errorf(": expected an object, got %v", v)
+		}
+		fields := make(map[string]reflect.Value, dst.NumField())
+		for i := 0; i < dst.NumField(); i++ {
+			if f := dst.Type().Field(i); f.IsExported() {
+				fields[normalizeKey(f.Name)] = dst.Field(i)
+			}
+		}
+		for k, e := range m {
+			f, ok := fields[normalizeKey(k)]
+			if !ok {
+				return fmt.Errorf(": unknown attribute %q", k)
+			}
+			if err := decodeValue(f, e); err != nil {
+				return fmt.Errorf(".%s%w", k, err)
+			}
+		}
+		return nil
+	case reflect.Slice:
+		if s, ok := v.(string); ok && dst.Type().Elem().Kind() == reflect.Uint8 {
+			dst.SetBytes([]byte(s))
+			return nil
+		}
+		a, ok := v.([]any)
+		if !ok {
+			return fmt.Errorf(": expected an array, got %v", v)
+		}
+		s := reflect.MakeSlice(dst.Type(), len(a), len(a))
+		for i, e := range a {
+			if err := decodeValue(s.Index(i), e); err != nil {
+				return fmt.Errorf("[%d]%w", i, err)
+			}
+		}
+		dst.Set(s)
+		return nil
+	case reflect.Map:
+		m, ok := v.(map[string]any)
+		if !ok {
+			return fmt.Errorf(": expected an object, got %v", v)
+		}
+		res := reflect.MakeMapWithSize(dst.Type(), len(m))
+		for k, e := range m {
+			key := reflect.New(dst.Type().Key()).Elem()
+			if key.Kind() == reflect.String {
+				key.SetString(k)
+			} else if err := json.Unmarshal([]byte(k), key.Addr().Interface()); err != nil {
+				return fmt.Errorf("[%s]: %w", k, err)
+			}
+			elem := reflect.New(dst.Type().Elem()).Elem()
+			if err := decodeValue(elem, e); err != nil {
+				return fmt.Errorf("[%s]%w", k, err)
+			}
+			res.SetMapIndex(key, elem)
+		}
+		dst.Set(res)
+		return nil
+	case reflect.Interface:
+		val := reflect.ValueOf(v)
+		if !val.Type().AssignableTo(dst.Type()) {
+			return fmt.Errorf(": cannot decode %v into %s", v, dst.Type())
+		}
+		dst.Set(val)
+		return nil
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(b, dst.Addr().Interface()); err != nil {
+			return fmt.Errorf(": %w", err)
+		}
+		return nil
+	}
+}
+
+// normalizeKey returns the key used to match the JSON object keys with the
+// struct field names.
+func normalizeKey(k string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(k))
+}
@@ -1,8 +1,12 @@
 {{ printf "New%s initializes a %q service client given the endpoints." .ClientVarName .Name | comment }}
-func New{{ .ClientVarName }}({{ .ClientInitArgs }} goa.Endpoint) *{{ .ClientVarName }} {
+func New{{ .ClientVarName }}({{ .ClientInitArgs }} goa.Endpoint{{ if .ClientInterceptors }}, ci ClientInterceptors{{ end }}) *{{ .ClientVarName }} {
 	return &{{ .ClientVarName }}{
 {{- range .Methods }}
+		{{- if .ClientInterceptors }}
+		{{ .VarName }}Endpoint: Wrap{{ .VarName }}ClientEndpoint({{ .ArgName }}, ci),
+		{{- else }}
 		{{ .VarName }}Endpoint: {{ .ArgName }},
+		{{- end }}
 {{- end }}
 	}
 }
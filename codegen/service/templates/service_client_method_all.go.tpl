@@ -0,0 +1,56 @@
+
+{{ printf "%s calls the %q endpoint of the %q service repeatedly to iterate over the items of all the pages starting with the page identified by the payload cursor. Iteration stops at the first error. The returned function can be used in a range loop with Go 1.23 or later, earlier versions may call it with a yield function directly." .Pagination.AllMethod .Name .ServiceName | comment }}
+func (c *{{ .ClientVarName }}) {{ .Pagination.AllMethod }}(ctx context.Context, p {{ .PayloadRef }}) func(yield func({{ .Pagination.ItemRef }}, error) bool) {
+	return func(yield func({{ .Pagination.ItemRef }}, error) bool) {
+		page := {{ .Pagination.PayloadName }}{}
+		if p != nil {
+			page = *p
+		}
+		for {
+			res, err := c.{{ .VarName }}(ctx, &page)
+			if err != nil {
+				var zero {{ .Pagination.ItemRef }}
+				yield(zero, err)
+				return
+			}
+			for _, item := range res.{{ .Pagination.ItemsField }} {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			{{- if .Pagination.NextCursorPointer }}
+			if res.{{ .Pagination.NextCursorField }} == nil || *res.{{ .Pagination.NextCursorField }} == "" {
+				return
+			}
+			{{- else }}
+			if res.{{ .Pagination.NextCursorField }} == "" {
+				return
+			}
+			{{- end }}
+			page.{{ .Pagination.CursorField }} = {{ if not .Pagination.NextCursorPointer }}{{ if .Pagination.CursorPointer }}&{{ end }}{{ else if not .Pagination.CursorPointer }}*{{ end }}res.{{ .Pagination.NextCursorField }}
+		}
+	}
+}
+
+{{ printf "%s returns an endpoint that calls the %q endpoint for each page and returns the items of all the pages." .Pagination.AllEndpoint .Name | comment }}
+func {{ .Pagination.AllEndpoint }}(endpoint goa.Endpoint) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		c := &{{ .ClientVarName }}{ {{ .VarName }}Endpoint: endpoint }
+		var (
+			items []{{ .Pagination.ItemRef }}
+			err   error
+		)
+		c.{{ .Pagination.AllMethod }}(ctx, req.({{ .PayloadRef }}))(func(item {{ .Pagination.ItemRef }}, e error) bool {
+			if e != nil {
+				err = e
+				return false
+			}
+			items = append(items, item)
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+		return items, nil
+	}
+}
@@ -0,0 +1,21 @@
+
+
+{{ printf "%s returns the key of the token bucket used to rate limit the requests made to the %q endpoint." .RateLimit.KeyFunc .Name | comment }}
+func {{ .RateLimit.KeyFunc }}(_ context.Context, req any) string {
+{{- if .ServerStream }}
+	ep := req.(*{{ .ServerStream.EndpointStruct }})
+{{- else if .SkipRequestBodyEncodeDecode }}
+	ep := req.(*{{ .RequestStruct }})
+{{- else }}
+	p := req.({{ .PayloadRef }})
+{{- end }}
+{{- $payload := payloadVar . }}
+{{- if .RateLimit.KeyPointer }}
+	if {{ $payload }}.{{ .RateLimit.KeyField }} == nil {
+		return ""
+	}
+	return *{{ $payload }}.{{ .RateLimit.KeyField }}
+{{- else }}
+	return {{ $payload }}.{{ .RateLimit.KeyField }}
+{{- end }}
+}
@@ -1,14 +1,34 @@
 
 
 {{ printf "New%s wraps the methods of the %q service with endpoints." .VarName .Name | comment }}
-func New{{ .VarName }}(s {{ .ServiceVarName }}) *{{ .VarName }} {
+func New{{ .VarName }}(s {{ .ServiceVarName }}{{ if .ServerInterceptors }}, si ServerInterceptors{{ end }}) *{{ .VarName }} {
 {{- if .Schemes }}
 	// Casting service to Auther interface
 	a := s.(Auther)
 {{- end }}
+{{- if or .ServerInterceptors .HasLimits }}
+	endpoints := &{{ .VarName }}{
+{{- range .Methods }}
+		{{ .VarName }}: New{{ .VarName }}Endpoint(s{{ range .Schemes }}, a.{{ .Type }}Auth{{ end }}),
+{{- end }}
+	}
+{{- range .Methods }}
+	{{- if .ServerInterceptors }}
+	endpoints.{{ .VarName }} = Wrap{{ .VarName }}Endpoint(endpoints.{{ .VarName }}, si)
+	{{- end }}
+	{{- if .MaxConcurrency }}
+	endpoints.{{ .VarName }} = goa.MaxConcurrency({{ .MaxConcurrency }})(endpoints.{{ .VarName }})
+	{{- end }}
+	{{- if .RateLimit }}
+	endpoints.{{ .VarName }} = goa.RateLimit({{ .RateLimit.Limit }}, {{ .RateLimit.Period }}, {{ .RateLimit.Burst }}, {{ if .RateLimit.KeyFunc }}{{ .RateLimit.KeyFunc }}{{ else }}nil{{ end }})(endpoints.{{ .VarName }})
+	{{- end }}
+{{- end }}
+	return endpoints
+{{- else }}
 	return &{{ .VarName }}{
 {{- range .Methods }}
 		{{ .VarName }}: New{{ .VarName }}Endpoint(s{{ range .Schemes }}, a.{{ .Type }}Auth{{ end }}),
 {{- end }}
 	}
-}
\ No newline at end of file
+{{- end }}
+}
@@ -0,0 +1,6 @@
+{{ comment "init registers the validators of the custom formats used by the service with the goa package." }}
+func init() {
+{{- range . }}
+	goa.RegisterFormat({{ printf "%q" .Name }}, {{ .Validator }})
+{{- end }}
+}
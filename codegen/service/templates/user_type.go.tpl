@@ -1,2 +1,49 @@
 {{ comment .Description }}
-type {{ .VarName }} {{ .Def }}
+type {{ .VarName }}{{ .TypeParams }} {{ .Def }}
+{{- with .Enum }}
+
+// Enum values of {{ $.VarName }}.
+const (
+{{- range .Values }}
+	{{ .Name }} {{ $.VarName }} = {{ .Value }}
+{{- end }}
+)
+
+// String returns the string representation of the {{ $.VarName }} value.
+func (v {{ $.VarName }}) String() string {
+{{- if .IsString }}
+	return string(v)
+{{- else if .Unsigned }}
+	return strconv.FormatUint(uint64(v), 10)
+{{- else }}
+	return strconv.FormatInt(int64(v), 10)
+{{- end }}
+}
+
+// Valid returns true if the {{ $.VarName }} value is one of the enum values.
+func (v {{ $.VarName }}) Valid() bool {
+	switch v {
+	case {{ range $i, $v := .Values }}{{ if $i }}, {{ end }}{{ .Name }}{{ end }}:
+		return true
+	}
+	return false
+}
+
+// {{ .ParseName }} returns the {{ $.VarName }} value represented by s, it returns
+// an error if s is not one of the enum values.
+func {{ .ParseName }}(s string) ({{ $.VarName }}, error) {
+{{- if .IsString }}
+	v := {{ $.VarName }}(s)
+{{- else }}
+	i, err := strconv.Parse{{ if .Unsigned }}Uint{{ else }}Int{{ end }}(s, 10, {{ .BitSize }})
+	if err != nil {
+		return 0, goa.InvalidFieldTypeError({{ printf "%q" $.Name }}, s, "integer")
+	}
+	v := {{ $.VarName }}(i)
+{{- end }}
+	if !v.Valid() {
+		return v, goa.InvalidEnumValueError({{ printf "%q" $.Name }}, s, []any{ {{- range $i, $v := .Values }}{{ if $i }}, {{ end }}{{ .Value }}{{ end }} })
+	}
+	return v, nil
+}
+{{- end }}
@@ -283,3 +283,168 @@ func (c *Client) BidirectionalStreamingNoPayloadMethod(ctx context.Context) (res
 	return ires.(BidirectionalStreamingNoPayloadMethodClientStream), nil
 }
 `
+
+const ClientInterceptorClient = `// Client is the "ClientInterceptor" service client.
+type Client struct {
+	MethodEndpoint goa.Endpoint
+}
+
+// NewClient initializes a "ClientInterceptor" service client given the
+// endpoints.
+func NewClient(method goa.Endpoint, ci ClientInterceptors) *Client {
+	return &Client{
+		MethodEndpoint: WrapMethodClientEndpoint(method, ci),
+	}
+}
+
+// Method calls the "Method" endpoint of the "ClientInterceptor" service.
+func (c *Client) Method(ctx context.Context, p *MethodPayload) (err error) {
+	_, err = c.MethodEndpoint(ctx, p)
+	return
+}
+`
+
+const PaginatedMethodsClient = `// Client is the "Paginated" service client.
+type Client struct {
+	ListEndpoint  goa.Endpoint
+	NamesEndpoint goa.Endpoint
+}
+
+// NewClient initializes a "Paginated" service client given the endpoints.
+func NewClient(list, names goa.Endpoint) *Client {
+	return &Client{
+		ListEndpoint:  list,
+		NamesEndpoint: names,
+	}
+}
+
+// List calls the "List" endpoint of the "Paginated" service.
+func (c *Client) List(ctx context.Context, p *ListPayload) (res *ListResult, err error) {
+	var ires any
+	ires, err = c.ListEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*ListResult), nil
+}
+
+// ListAll calls the "List" endpoint of the "Paginated" service repeatedly to
+// iterate over the items of all the pages starting with the page identified by
+// the payload cursor. Iteration stops at the first error. The returned
+// function can be used in a range loop with Go 1.23 or later, earlier versions
+// may call it with a yield function directly.
+func (c *Client) ListAll(ctx context.Context, p *ListPayload) func(yield func(*Item, error) bool) {
+	return func(yield func(*Item, error) bool) {
+		page := ListPayload{}
+		if p != nil {
+			page = *p
+		}
+		for {
+			res, err := c.List(ctx, &page)
+			if err != nil {
+				var zero *Item
+				yield(zero, err)
+				return
+			}
+			for _, item := range res.Items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if res.NextCursor == nil || *res.NextCursor == "" {
+				return
+			}
+			page.Cursor = res.NextCursor
+		}
+	}
+}
+
+// NewListAllEndpoint returns an endpoint that calls the "List" endpoint for
+// each page and returns the items of all the pages.
+func NewListAllEndpoint(endpoint goa.Endpoint) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		c := &Client{ListEndpoint: endpoint}
+		var (
+			items []*Item
+			err   error
+		)
+		c.ListAll(ctx, req.(*ListPayload))(func(item *Item, e error) bool {
+			if e != nil {
+				err = e
+				return false
+			}
+			items = append(items, item)
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+		return items, nil
+	}
+}
+
+// Names calls the "Names" endpoint of the "Paginated" service.
+func (c *Client) Names(ctx context.Context, p *NamesPayload) (res *NamesResult, err error) {
+	var ires any
+	ires, err = c.NamesEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*NamesResult), nil
+}
+
+// NamesAll calls the "Names" endpoint of the "Paginated" service repeatedly to
+// iterate over the items of all the pages starting with the page identified by
+// the payload cursor. Iteration stops at the first error. The returned
+// function can be used in a range loop with Go 1.23 or later, earlier versions
+// may call it with a yield function directly.
+func (c *Client) NamesAll(ctx context.Context, p *NamesPayload) func(yield func(string, error) bool) {
+	return func(yield func(string, error) bool) {
+		page := NamesPayload{}
+		if p != nil {
+			page = *p
+		}
+		for {
+			res, err := c.Names(ctx, &page)
+			if err != nil {
+				var zero string
+				yield(zero, err)
+				return
+			}
+			for _, item := range res.Names {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if res.NextPage == "" {
+				return
+			}
+			page.Page = res.NextPage
+		}
+	}
+}
+
+// NewNamesAllEndpoint returns an endpoint that calls the "Names" endpoint for
+// each page and returns the items of all the pages.
+func NewNamesAllEndpoint(endpoint goa.Endpoint) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		c := &Client{NamesEndpoint: endpoint}
+		var (
+			items []string
+			err   error
+		)
+		c.NamesAll(ctx, req.(*NamesPayload))(func(item string, e error) bool {
+			if e != nil {
+				err = e
+				return false
+			}
+			items = append(items, item)
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+		return items, nil
+	}
+}
+`
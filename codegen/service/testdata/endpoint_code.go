@@ -513,3 +513,87 @@ func NewBidirectionalStreamingNoPayloadMethodEndpoint(s Service) goa.Endpoint {
 	}
 }
 `
+
+const ServerInterceptorEndpoint = `// Endpoints wraps the "ServerInterceptor" service endpoints.
+type Endpoints struct {
+	Method goa.Endpoint
+}
+
+// NewEndpoints wraps the methods of the "ServerInterceptor" service with
+// endpoints.
+func NewEndpoints(s Service, si ServerInterceptors) *Endpoints {
+	endpoints := &Endpoints{
+		Method: NewMethodEndpoint(s),
+	}
+	endpoints.Method = WrapMethodEndpoint(endpoints.Method, si)
+	return endpoints
+}
+
+// Use applies the given middleware to all the "ServerInterceptor" service
+// endpoints.
+func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
+	e.Method = m(e.Method)
+}
+
+// NewMethodEndpoint returns an endpoint function that calls the method
+// "Method" of service "ServerInterceptor".
+func NewMethodEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(string)
+		return nil, s.Method(ctx, p)
+	}
+}
+`
+
+const RateLimitEndpoint = `// Endpoints wraps the "RateLimit" service endpoints.
+type Endpoints struct {
+	Keyed     goa.Endpoint
+	Inherited goa.Endpoint
+}
+
+// NewEndpoints wraps the methods of the "RateLimit" service with endpoints.
+func NewEndpoints(s Service) *Endpoints {
+	endpoints := &Endpoints{
+		Keyed:     NewKeyedEndpoint(s),
+		Inherited: NewInheritedEndpoint(s),
+	}
+	endpoints.Keyed = goa.MaxConcurrency(5)(endpoints.Keyed)
+	endpoints.Keyed = goa.RateLimit(10, 1500*time.Millisecond, 20, keyedRateLimitKey)(endpoints.Keyed)
+	endpoints.Inherited = goa.RateLimit(100, time.Minute, 100, nil)(endpoints.Inherited)
+	return endpoints
+}
+
+// Use applies the given middleware to all the "RateLimit" service endpoints.
+func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
+	e.Keyed = m(e.Keyed)
+	e.Inherited = m(e.Inherited)
+}
+
+// NewKeyedEndpoint returns an endpoint function that calls the method "Keyed"
+// of service "RateLimit".
+func NewKeyedEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*KeyedPayload)
+		return nil, s.Keyed(ctx, p)
+	}
+}
+
+// NewInheritedEndpoint returns an endpoint function that calls the method
+// "Inherited" of service "RateLimit".
+func NewInheritedEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*InheritedPayload)
+		return nil, s.Inherited(ctx, p)
+	}
+}
+
+// keyedRateLimitKey returns the key of the token bucket used to rate limit the
+// requests made to the "Keyed" endpoint.
+func keyedRateLimitKey(_ context.Context, req any) string {
+	p := req.(*KeyedPayload)
+	if p.Key == nil {
+		return ""
+	}
+	return *p.Key
+}
+`
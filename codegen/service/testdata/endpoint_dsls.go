@@ -1,6 +1,8 @@
 package testdata
 
 import (
+	"time"
+
 	. "goa.design/goa/v3/dsl"
 )
 
@@ -181,3 +183,61 @@ var BidirectionalStreamingEndpointDSL = func() {
 		})
 	})
 }
+
+var RateLimitEndpointDSL = func() {
+	Service("RateLimit", func() {
+		RateLimit(100, time.Minute)
+		Method("Keyed", func() {
+			Payload(func() {
+				Attribute("key", String)
+				Attribute("id", Int)
+			})
+			RateLimit(10, 1500*time.Millisecond, func() {
+				Burst(20)
+				RateLimitKey("key")
+			})
+			MaxConcurrency(5)
+		})
+		Method("Inherited", func() {
+			Payload(func() {
+				Attribute("key", String)
+				Required("key")
+			})
+		})
+	})
+}
+
+var PaginatedEndpointDSL = func() {
+	var Item = Type("Item", func() {
+		Attribute("id", String)
+	})
+	Service("Paginated", func() {
+		Method("List", func() {
+			Payload(func() {
+				Attribute("cursor", String)
+				Attribute("limit", Int)
+			})
+			Result(func() {
+				Attribute("items", ArrayOf(Item))
+				Attribute("next_cursor", String)
+			})
+			Paginated()
+		})
+		Method("Names", func() {
+			Payload(func() {
+				Attribute("page", String)
+				Required("page")
+			})
+			Result(func() {
+				Attribute("names", ArrayOf(String))
+				Attribute("next_page", String)
+				Required("names", "next_page")
+			})
+			Paginated(func() {
+				Cursor("page")
+				NextCursor("next_page")
+				PageItems("names")
+			})
+		})
+	})
+}
@@ -0,0 +1,456 @@
+package testdata
+
+const ServerInterceptorCode = `
+// ServerInterceptors defines the interface for all server-side interceptors.
+// Server interceptors execute after the request is decoded and before the
+// payload is sent to the service. The implementation is responsible for
+// calling next to complete the request.
+type ServerInterceptors interface {
+	// Logger logs the requests
+	Logger(ctx context.Context, info *LoggerInfo, next goa.Endpoint) (any, error)
+}
+
+// LoggerInfo provides metadata about the current interception by the "Logger"
+// interceptor.
+type LoggerInfo struct {
+	service    string
+	method     string
+	rawPayload any
+}
+
+// Service returns the name of the service handling the request.
+func (info *LoggerInfo) Service() string {
+	return info.service
+}
+
+// Method returns the name of the method handling the request.
+func (info *LoggerInfo) Method() string {
+	return info.method
+}
+
+// RawPayload returns the raw payload of the request.
+func (info *LoggerInfo) RawPayload() any {
+	return info.rawPayload
+}
+
+// WrapMethodEndpoint wraps the "Method" endpoint with the server-side
+// interceptors defined in the design.
+func WrapMethodEndpoint(endpoint goa.Endpoint, i ServerInterceptors) goa.Endpoint {
+	endpoint = wrapMethodLogger(endpoint, i)
+	return endpoint
+}
+
+// wrapMethodLogger applies the "Logger" server-side interceptor to the
+// "Method" endpoint.
+func wrapMethodLogger(endpoint goa.Endpoint, i ServerInterceptors) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		info := &LoggerInfo{
+			service:    "ServerInterceptor",
+			method:     "Method",
+			rawPayload: req,
+		}
+		return i.Logger(ctx, info, endpoint)
+	}
+}
+`
+
+const PayloadResultAccessCode = `
+// ServerInterceptors defines the interface for all server-side interceptors.
+// Server interceptors execute after the request is decoded and before the
+// payload is sent to the service. The implementation is responsible for
+// calling next to complete the request.
+type ServerInterceptors interface {
+	// Cache is the "Cache" interceptor.
+	Cache(ctx context.Context, info *CacheInfo, next goa.Endpoint) (any, error)
+}
+
+// CacheInfo provides metadata about the current interception by the "Cache"
+// interceptor.
+type CacheInfo struct {
+	service    string
+	method     string
+	rawPayload any
+	payload    CachePayload
+	result     func(any) CacheResult2
+}
+
+// Service returns the name of the service handling the request.
+func (info *CacheInfo) Service() string {
+	return info.service
+}
+
+// Method returns the name of the method handling the request.
+func (info *CacheInfo) Method() string {
+	return info.method
+}
+
+// RawPayload returns the raw payload of the request.
+func (info *CacheInfo) RawPayload() any {
+	return info.rawPayload
+}
+
+// Payload returns a type-safe accessor for the method payload.
+func (info *CacheInfo) Payload() CachePayload {
+	return info.payload
+}
+
+// Result returns a type-safe accessor for the method result.
+func (info *CacheInfo) Result(res any) CacheResult2 {
+	return info.result(res)
+}
+
+// CachePayload provides type-safe access to the method payload.
+type CachePayload interface {
+	ID() string
+}
+
+// CacheResult2 provides type-safe access to the method result.
+type CacheResult2 interface {
+	Value() int
+	SetCached(bool)
+}
+
+// cacheMethodPayload implements CachePayload for the "Method" method payload.
+type cacheMethodPayload struct {
+	payload *MethodPayload
+}
+
+// ID returns the value of the "ID" field.
+func (a *cacheMethodPayload) ID() string {
+	return a.payload.ID
+}
+
+// cacheMethodResult implements CacheResult2 for the "Method" method result.
+type cacheMethodResult struct {
+	result *CacheResult
+}
+
+// newCacheMethodResult returns the CacheResult2 built from the given raw
+// value. The raw value is nil if the endpoint returned an error.
+func newCacheMethodResult(res any) CacheResult2 {
+	result, _ := res.(*CacheResult)
+	return &cacheMethodResult{result: result}
+}
+
+// Value returns the value of the "Value" field.
+func (a *cacheMethodResult) Value() int {
+	if a.result == nil {
+		var zero int
+		return zero
+	}
+	return a.result.Value
+}
+
+// SetCached sets the value of the "Cached" field.
+func (a *cacheMethodResult) SetCached(v bool) {
+	if a.result == nil {
+		return
+	}
+	a.result.Cached = &v
+}
+
+// WrapMethodEndpoint wraps the "Method" endpoint with the server-side
+// interceptors defined in the design.
+func WrapMethodEndpoint(endpoint goa.Endpoint, i ServerInterceptors) goa.Endpoint {
+	endpoint = wrapMethodCache(endpoint, i)
+	return endpoint
+}
+
+// wrapMethodCache applies the "Cache" server-side interceptor to the "Method"
+// endpoint.
+func wrapMethodCache(endpoint goa.Endpoint, i ServerInterceptors) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		info := &CacheInfo{
+			service:    "PayloadResultAccess",
+			method:     "Method",
+			rawPayload: req,
+			payload:    &cacheMethodPayload{payload: req.(*MethodPayload)},
+			result:     newCacheMethodResult,
+		}
+		return i.Cache(ctx, info, endpoint)
+	}
+}
+`
+
+const ViewedResultAccessCode = `
+// ServerInterceptors defines the interface for all server-side interceptors.
+// Server interceptors execute after the request is decoded and before the
+// payload is sent to the service. The implementation is responsible for
+// calling next to complete the request.
+type ServerInterceptors interface {
+	// Stamp is the "Stamp" interceptor.
+	Stamp(ctx context.Context, info *StampInfo, next goa.Endpoint) (any, error)
+}
+
+// StampInfo provides metadata about the current interception by the "Stamp"
+// interceptor.
+type StampInfo struct {
+	service    string
+	method     string
+	rawPayload any
+	result     func(any) StampResult
+}
+
+// Service returns the name of the service handling the request.
+func (info *StampInfo) Service() string {
+	return info.service
+}
+
+// Method returns the name of the method handling the request.
+func (info *StampInfo) Method() string {
+	return info.method
+}
+
+// RawPayload returns the raw payload of the request.
+func (info *StampInfo) RawPayload() any {
+	return info.rawPayload
+}
+
+// Result returns a type-safe accessor for the method result.
+func (info *StampInfo) Result(res any) StampResult {
+	return info.result(res)
+}
+
+// StampResult provides type-safe access to the method result.
+type StampResult interface {
+	SetStamp(string)
+}
+
+// stampMethodViewedResult implements StampResult for the "Method" method
+// viewed result.
+type stampMethodViewedResult struct {
+	result *viewedresultaccessviews.Viewed
+}
+
+// newStampMethodViewedResult returns the StampResult built from the given raw
+// value. The raw value is nil if the endpoint returned an error.
+func newStampMethodViewedResult(res any) StampResult {
+	result, _ := res.(*viewedresultaccessviews.Viewed)
+	return &stampMethodViewedResult{result: result}
+}
+
+// SetStamp sets the value of the "Stamp" field.
+func (a *stampMethodViewedResult) SetStamp(v string) {
+	if a.result == nil || a.result.Projected == nil {
+		return
+	}
+	a.result.Projected.Stamp = &v
+}
+
+// WrapMethodEndpoint wraps the "Method" endpoint with the server-side
+// interceptors defined in the design.
+func WrapMethodEndpoint(endpoint goa.Endpoint, i ServerInterceptors) goa.Endpoint {
+	endpoint = wrapMethodStamp(endpoint, i)
+	return endpoint
+}
+
+// wrapMethodStamp applies the "Stamp" server-side interceptor to the "Method"
+// endpoint.
+func wrapMethodStamp(endpoint goa.Endpoint, i ServerInterceptors) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		info := &StampInfo{
+			service:    "ViewedResultAccess",
+			method:     "Method",
+			rawPayload: req,
+			result:     newStampMethodViewedResult,
+		}
+		return i.Stamp(ctx, info, endpoint)
+	}
+}
+`
+
+const StreamingPayloadAccessCode = `
+// ServerInterceptors defines the interface for all server-side interceptors.
+// Server interceptors execute after the request is decoded and before the
+// payload is sent to the service. The implementation is responsible for
+// calling next to complete the request.
+type ServerInterceptors interface {
+	// Tenant is the "Tenant" interceptor.
+	Tenant(ctx context.Context, info *TenantInfo, next goa.Endpoint) (any, error)
+}
+
+// TenantInfo provides metadata about the current interception by the "Tenant"
+// interceptor.
+type TenantInfo struct {
+	service    string
+	method     string
+	rawPayload any
+	payload    TenantPayload
+}
+
+// Service returns the name of the service handling the request.
+func (info *TenantInfo) Service() string {
+	return info.service
+}
+
+// Method returns the name of the method handling the request.
+func (info *TenantInfo) Method() string {
+	return info.method
+}
+
+// RawPayload returns the raw payload of the request.
+func (info *TenantInfo) RawPayload() any {
+	return info.rawPayload
+}
+
+// Payload returns a type-safe accessor for the method payload.
+func (info *TenantInfo) Payload() TenantPayload {
+	return info.payload
+}
+
+// TenantPayload provides type-safe access to the method payload.
+type TenantPayload interface {
+	SetTenant(string)
+}
+
+// tenantMethodPayload implements TenantPayload for the "Method" method payload.
+type tenantMethodPayload struct {
+	payload *MethodPayload
+}
+
+// SetTenant sets the value of the "Tenant" field.
+func (a *tenantMethodPayload) SetTenant(v string) {
+	a.payload.Tenant = &v
+}
+
+// WrapMethodEndpoint wraps the "Method" endpoint with the server-side
+// interceptors defined in the design.
+func WrapMethodEndpoint(endpoint goa.Endpoint, i ServerInterceptors) goa.Endpoint {
+	endpoint = wrapMethodTenant(endpoint, i)
+	return endpoint
+}
+
+// wrapMethodTenant applies the "Tenant" server-side interceptor to the
+// "Method" endpoint.
+func wrapMethodTenant(endpoint goa.Endpoint, i ServerInterceptors) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		info := &TenantInfo{
+			service:    "StreamingPayloadAccess",
+			method:     "Method",
+			rawPayload: req,
+			payload:    &tenantMethodPayload{payload: req.(*MethodEndpointInput).Payload},
+		}
+		return i.Tenant(ctx, info, endpoint)
+	}
+}
+`
+
+const ClientInterceptorCode = `
+
+// ClientInterceptors defines the interface for all client-side interceptors.
+// Client interceptors execute after the payload is encoded and before the
+// request is sent to the server. The implementation is responsible for calling
+// next to complete the request.
+type ClientInterceptors interface {
+	// Trace is the "Trace" interceptor.
+	Trace(ctx context.Context, info *TraceInfo, next goa.Endpoint) (any, error)
+	// Retry is the "Retry" interceptor.
+	Retry(ctx context.Context, info *RetryInfo, next goa.Endpoint) (any, error)
+}
+
+// TraceInfo provides metadata about the current interception by the "Trace"
+// interceptor.
+type TraceInfo struct {
+	service    string
+	method     string
+	rawPayload any
+}
+
+// Service returns the name of the service handling the request.
+func (info *TraceInfo) Service() string {
+	return info.service
+}
+
+// Method returns the name of the method handling the request.
+func (info *TraceInfo) Method() string {
+	return info.method
+}
+
+// RawPayload returns the raw payload of the request.
+func (info *TraceInfo) RawPayload() any {
+	return info.rawPayload
+}
+
+// RetryInfo provides metadata about the current interception by the "Retry"
+// interceptor.
+type RetryInfo struct {
+	service    string
+	method     string
+	rawPayload any
+	payload    RetryPayload
+}
+
+// Service returns the name of the service handling the request.
+func (info *RetryInfo) Service() string {
+	return info.service
+}
+
+// Method returns the name of the method handling the request.
+func (info *RetryInfo) Method() string {
+	return info.method
+}
+
+// RawPayload returns the raw payload of the request.
+func (info *RetryInfo) RawPayload() any {
+	return info.rawPayload
+}
+
+// Payload returns a type-safe accessor for the method payload.
+func (info *RetryInfo) Payload() RetryPayload {
+	return info.payload
+}
+
+// RetryPayload provides type-safe access to the method payload.
+type RetryPayload interface {
+	ID() int
+}
+
+// retryMethodPayload implements RetryPayload for the "Method" method payload.
+type retryMethodPayload struct {
+	payload *MethodPayload
+}
+
+// ID returns the value of the "ID" field.
+func (a *retryMethodPayload) ID() int {
+	if a.payload.ID == nil {
+		var zero int
+		return zero
+	}
+	return *a.payload.ID
+}
+
+// WrapMethodClientEndpoint wraps the "Method" endpoint with the client-side
+// interceptors defined in the design.
+func WrapMethodClientEndpoint(endpoint goa.Endpoint, i ClientInterceptors) goa.Endpoint {
+	endpoint = wrapClientMethodRetry(endpoint, i)
+	endpoint = wrapClientMethodTrace(endpoint, i)
+	return endpoint
+}
+
+// wrapClientMethodTrace applies the "Trace" client-side interceptor to the
+// "Method" endpoint.
+func wrapClientMethodTrace(endpoint goa.Endpoint, i ClientInterceptors) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		info := &TraceInfo{
+			service:    "ClientInterceptor",
+			method:     "Method",
+			rawPayload: req,
+		}
+		return i.Trace(ctx, info, endpoint)
+	}
+}
+
+// wrapClientMethodRetry applies the "Retry" client-side interceptor to the
+// "Method" endpoint.
+func wrapClientMethodRetry(endpoint goa.Endpoint, i ClientInterceptors) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		info := &RetryInfo{
+			service:    "ClientInterceptor",
+			method:     "Method",
+			rawPayload: req,
+			payload:    &retryMethodPayload{payload: req.(*MethodPayload)},
+		}
+		return i.Retry(ctx, info, endpoint)
+	}
+}
+`
@@ -0,0 +1,98 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var ServerInterceptorDSL = func() {
+	var Logger = Interceptor("Logger", func() {
+		Description("Logger logs the requests")
+	})
+	Service("ServerInterceptor", func() {
+		ServerInterceptor(Logger)
+		Method("Method", func() {
+			Payload(String)
+		})
+	})
+}
+
+var PayloadResultAccessDSL = func() {
+	var Cache = Interceptor("Cache", func() {
+		ReadPayload(func() {
+			Attribute("id")
+		})
+		ReadResult(func() {
+			Attribute("value")
+		})
+		WriteResult(func() {
+			Attribute("cached")
+		})
+	})
+	var CacheResult = Type("CacheResult", func() {
+		Attribute("value", Int)
+		Attribute("cached", Boolean)
+		Required("value")
+	})
+	Service("PayloadResultAccess", func() {
+		Method("Method", func() {
+			ServerInterceptor(Cache)
+			Payload(func() {
+				Attribute("id", String)
+				Required("id")
+			})
+			Result(CacheResult)
+		})
+	})
+}
+
+var ViewedResultAccessDSL = func() {
+	var Stamp = Interceptor("Stamp", func() {
+		WriteResult(func() {
+			Attribute("stamp")
+		})
+	})
+	var ViewedResult = ResultType("application/vnd.viewed", func() {
+		Attribute("stamp", String)
+	})
+	Service("ViewedResultAccess", func() {
+		Method("Method", func() {
+			ServerInterceptor(Stamp)
+			Result(ViewedResult)
+		})
+	})
+}
+
+var StreamingPayloadAccessDSL = func() {
+	var Tenant = Interceptor("Tenant", func() {
+		WritePayload(func() {
+			Attribute("tenant")
+		})
+	})
+	Service("StreamingPayloadAccess", func() {
+		Method("Method", func() {
+			ServerInterceptor(Tenant)
+			Payload(func() {
+				Attribute("tenant", String)
+			})
+			StreamingResult(String)
+		})
+	})
+}
+
+var ClientInterceptorDSL = func() {
+	var Retry = Interceptor("Retry", func() {
+		ReadPayload(func() {
+			Attribute("id")
+		})
+	})
+	var Trace = Interceptor("Trace")
+	Service("ClientInterceptor", func() {
+		ClientInterceptor(Trace)
+		Method("Method", func() {
+			ClientInterceptor(Retry)
+			Payload(func() {
+				Attribute("id", Int)
+			})
+		})
+	})
+}
@@ -0,0 +1,649 @@
+package testdata
+
+const NoPayloadMethodMock = `type (
+	// Mock implements the "NoPayload" service interface. The mock records the
+	// calls made to its methods and implements each method with the functions
+	// queued with the Expect methods, then with the function registered with the
+	// Set method if any and otherwise returns a default result built from the
+	// design examples.
+	Mock struct {
+		noPayloadFuncs []NoPayloadFunc
+		noPayloadFunc  NoPayloadFunc
+		calls          []*Call
+		mu             sync.Mutex
+	}
+
+	// Call describes a call made to a mock method.
+	Call struct {
+		// Method is the name of the method as defined in the design.
+		Method string
+		// Payload is the method payload, nil if the method has no payload.
+		Payload any
+	}
+
+	// NoPayloadFunc implements the "NoPayload" method.
+	NoPayloadFunc func(ctx context.Context) (err error)
+)
+
+// New returns a mock of the "NoPayload" service that returns the default
+// results.
+func New() *Mock {
+	return &Mock{}
+}
+
+// Calls returns the calls made to the mock in order.
+func (m *Mock) Calls() []*Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*Call(nil), m.calls...)
+}
+
+// HasMore returns true if some of the functions queued with the Expect
+// methods have not been called yet.
+func (m *Mock) HasMore() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.noPayloadFuncs) > 0
+}
+
+// NewError returns the error named name of the "NoPayload" service method, nil
+// if the method does not define such an error. Mock functions may use NewError
+// to return the errors defined in the design.
+func NewError(method, name string) error {
+	return nil
+}
+
+// record records a call made to the mock.
+func (m *Mock) record(method string, payload any) {
+	m.calls = append(m.calls, &Call{Method: method, Payload: payload})
+}
+
+// ptr returns a pointer to v.
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// ExpectNoPayload queues f so that it implements the next call to the
+// "NoPayload" method that is not implemented by a previously queued function.
+func (m *Mock) ExpectNoPayload(f NoPayloadFunc) *Mock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.noPayloadFuncs = append(m.noPayloadFuncs, f)
+	return m
+}
+
+// SetNoPayload sets the function that implements the "NoPayload" method once
+// the queued functions have been called.
+func (m *Mock) SetNoPayload(f NoPayloadFunc) *Mock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.noPayloadFunc = f
+	return m
+}
+
+// NoPayload implements the "NoPayload" method.
+func (m *Mock) NoPayload(ctx context.Context) (err error) {
+	m.mu.Lock()
+	m.record("NoPayload", nil)
+	f := m.noPayloadFunc
+	if len(m.noPayloadFuncs) > 0 {
+		f = m.noPayloadFuncs[0]
+		m.noPayloadFuncs = m.noPayloadFuncs[1:]
+	}
+	m.mu.Unlock()
+	if f == nil {
+		return nil
+	}
+	return f(ctx)
+}
+
+// Client returns a "NoPayload" service client whose endpoints call the mock
+// methods. The client does not support streaming methods.
+func (m *Mock) Client() *nopayload.Client {
+	return &nopayload.Client{
+		NoPayloadEndpoint: func(ctx context.Context, req any) (any, error) {
+			return nil, m.NoPayload(ctx)
+		},
+	}
+}
+`
+
+const WithResultMethodMock = `type (
+	// Mock implements the "WithResult" service interface. The mock records the
+	// calls made to its methods and implements each method with the functions
+	// queued with the Expect methods, then with the function registered with the
+	// Set method if any and otherwise returns a default result built from the
+	// design examples.
+	Mock struct {
+		aFuncs []AFunc
+		aFunc  AFunc
+		calls  []*Call
+		mu     sync.Mutex
+	}
+
+	// Call describes a call made to a mock method.
+	Call struct {
+		// Method is the name of the method as defined in the design.
+		Method string
+		// Payload is the method payload, nil if the method has no payload.
+		Payload any
+	}
+
+	// AFunc implements the "A" method.
+	AFunc func(ctx context.Context) (res *withresult.Rtype, err error)
+)
+
+// New returns a mock of the "WithResult" service that returns the default
+// results.
+func New() *Mock {
+	return &Mock{}
+}
+
+// Calls returns the calls made to the mock in order.
+func (m *Mock) Calls() []*Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*Call(nil), m.calls...)
+}
+
+// HasMore returns true if some of the functions queued with the Expect
+// methods have not been called yet.
+func (m *Mock) HasMore() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.aFuncs) > 0
+}
+
+// NewError returns the error named name of the "WithResult" service method,
+// nil if the method does not define such an error. Mock functions may use
+// NewError to return the errors defined in the design.
+func NewError(method, name string) error {
+	return nil
+}
+
+// record records a call made to the mock.
+func (m *Mock) record(method string, payload any) {
+	m.calls = append(m.calls, &Call{Method: method, Payload: payload})
+}
+
+// ptr returns a pointer to v.
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// ExpectA queues f so that it implements the next call to the "A" method that
+// is not implemented by a previously queued function.
+func (m *Mock) ExpectA(f AFunc) *Mock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.aFuncs = append(m.aFuncs, f)
+	return m
+}
+
+// SetA sets the function that implements the "A" method once the queued
+// functions have been called.
+func (m *Mock) SetA(f AFunc) *Mock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.aFunc = f
+	return m
+}
+
+// A implements the "A" method.
+func (m *Mock) A(ctx context.Context) (res *withresult.Rtype, err error) {
+	m.mu.Lock()
+	m.record("A", nil)
+	f := m.aFunc
+	if len(m.aFuncs) > 0 {
+		f = m.aFuncs[0]
+		m.aFuncs = m.aFuncs[1:]
+	}
+	m.mu.Unlock()
+	if f == nil {
+		return defaultAResult(), nil
+	}
+	return f(ctx)
+}
+
+// defaultAResult returns the default result of the "A" method.
+func defaultAResult() (res *withresult.Rtype) {
+	return &withresult.Rtype{
+		A: ptr("Quia molestias."),
+		B: ptr("Doloribus qui quia."),
+	}
+}
+
+// Client returns a "WithResult" service client whose endpoints call the mock
+// methods. The client does not support streaming methods.
+func (m *Mock) Client() *withresult.Client {
+	return &withresult.Client{
+		AEndpoint: func(ctx context.Context, req any) (any, error) {
+			res, err := m.A(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return res, nil
+		},
+	}
+}
+`
+
+const MockCode = `type (
+	// Mock implements the "Catalog" service interface. The mock records the calls
+	// made to its methods and implements each method with the functions queued
+	// with the Expect methods, then with the function registered with the Set
+	// method if any and otherwise returns a default result built from the design
+	// examples.
+	Mock struct {
+		getFuncs   []GetFunc
+		getFunc    GetFunc
+		pingFuncs  []PingFunc
+		pingFunc   PingFunc
+		watchFuncs []WatchFunc
+		watchFunc  WatchFunc
+		authJWT    security.AuthJWTFunc
+		calls      []*Call
+		mu         sync.Mutex
+	}
+
+	// Call describes a call made to a mock method.
+	Call struct {
+		// Method is the name of the method as defined in the design.
+		Method string
+		// Payload is the method payload, nil if the method has no payload.
+		Payload any
+	}
+
+	// GetFunc implements the "Get" method.
+	GetFunc func(ctx context.Context, p *catalog.GetPayload) (res *catalog.Item, err error)
+
+	// PingFunc implements the "Ping" method.
+	PingFunc func(ctx context.Context) (err error)
+
+	// WatchFunc implements the "Watch" method.
+	WatchFunc func(ctx context.Context, p catalog.ID, stream catalog.WatchServerStream) (err error)
+)
+
+// New returns a mock of the "Catalog" service that returns the default results.
+func New() *Mock {
+	return &Mock{}
+}
+
+// Calls returns the calls made to the mock in order.
+func (m *Mock) Calls() []*Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*Call(nil), m.calls...)
+}
+
+// HasMore returns true if some of the functions queued with the Expect
+// methods have not been called yet.
+func (m *Mock) HasMore() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.getFuncs) > 0 || len(m.pingFuncs) > 0 || len(m.watchFuncs) > 0
+}
+
+// SetJWTAuth sets the function that implements the JWT security scheme, by
+// default all requests are authorized.
+func (m *Mock) SetJWTAuth(f security.AuthJWTFunc) *Mock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.authJWT = f
+	return m
+}
+
+// JWTAuth implements the authorization logic for the JWT security scheme.
+func (m *Mock) JWTAuth(ctx context.Context, token string, schema *security.JWTScheme) (context.Context, error) {
+	m.mu.Lock()
+	f := m.authJWT
+	m.mu.Unlock()
+	if f == nil {
+		return ctx, nil
+	}
+	return f(ctx, token, schema)
+}
+
+// NewError returns the error named name of the "Catalog" service method, nil
+// if the method does not define such an error. Mock functions may use NewError
+// to return the errors defined in the design.
+func NewError(method, name string) error {
+	switch method {
+	case "Get":
+		switch name {
+		case "not_found":
+			return catalog.MakeNotFound(errors.New("mock not_found error"))
+		case "unavailable":
+			return &catalog.Unavailable{
+				Name:  "unavailable",
+				Retry: 1075804400,
+			}
+		}
+	case "Ping":
+		switch name {
+		case "unavailable":
+			return &catalog.Unavailable{
+				Name:  "unavailable",
+				Retry: 1075804400,
+			}
+		}
+	case "Watch":
+		switch name {
+		case "unavailable":
+			return &catalog.Unavailable{
+				Name:  "unavailable",
+				Retry: 1075804400,
+			}
+		}
+	}
+	return nil
+}
+
+// ServerInterceptors implements the server interceptors of the "Catalog"
+// service by calling the next interceptor or endpoint.
+type ServerInterceptors struct{}
+
+// Logger calls the next interceptor or endpoint.
+func (ServerInterceptors) Logger(ctx context.Context, info *catalog.LoggerInfo, next goa.Endpoint) (any, error) {
+	return next(ctx, info.RawPayload())
+}
+
+// record records a call made to the mock.
+func (m *Mock) record(method string, payload any) {
+	m.calls = append(m.calls, &Call{Method: method, Payload: payload})
+}
+
+// ptr returns a pointer to v.
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// ExpectGet queues f so that it implements the next call to the "Get" method
+// that is not implemented by a previously queued function.
+func (m *Mock) ExpectGet(f GetFunc) *Mock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.getFuncs = append(m.getFuncs, f)
+	return m
+}
+
+// SetGet sets the function that implements the "Get" method once the queued
+// functions have been called.
+func (m *Mock) SetGet(f GetFunc) *Mock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.getFunc = f
+	return m
+}
+
+// Get implements the "Get" method.
+func (m *Mock) Get(ctx context.Context, p *catalog.GetPayload) (res *catalog.Item, err error) {
+	m.mu.Lock()
+	m.record("Get", p)
+	f := m.getFunc
+	if len(m.getFuncs) > 0 {
+		f = m.getFuncs[0]
+		m.getFuncs = m.getFuncs[1:]
+	}
+	m.mu.Unlock()
+	if f == nil {
+		return defaultGetResult(), nil
+	}
+	return f(ctx, p)
+}
+
+// defaultGetResult returns the default result of the "Get" method.
+func defaultGetResult() (res *catalog.Item) {
+	return &catalog.Item{
+		ID:    catalog.ID("Doloribus qui quia."),
+		Count: ptr(int32(2145665882)),
+		Tags: []string{
+			"Et quae sunt itaque.",
+			"Optio quia ullam aut.",
+			"Iste perspiciatis.",
+			"Harum et.",
+		},
+	}
+}
+
+// ExpectPing queues f so that it implements the next call to the "Ping" method
+// that is not implemented by a previously queued function.
+func (m *Mock) ExpectPing(f PingFunc) *Mock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pingFuncs = append(m.pingFuncs, f)
+	return m
+}
+
+// SetPing sets the function that implements the "Ping" method once the queued
+// functions have been called.
+func (m *Mock) SetPing(f PingFunc) *Mock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pingFunc = f
+	return m
+}
+
+// Ping implements the "Ping" method.
+func (m *Mock) Ping(ctx context.Context) (err error) {
+	m.mu.Lock()
+	m.record("Ping", nil)
+	f := m.pingFunc
+	if len(m.pingFuncs) > 0 {
+		f = m.pingFuncs[0]
+		m.pingFuncs = m.pingFuncs[1:]
+	}
+	m.mu.Unlock()
+	if f == nil {
+		return nil
+	}
+	return f(ctx)
+}
+
+// ExpectWatch queues f so that it implements the next call to the "Watch"
+// method that is not implemented by a previously queued function.
+func (m *Mock) ExpectWatch(f WatchFunc) *Mock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.watchFuncs = append(m.watchFuncs, f)
+	return m
+}
+
+// SetWatch sets the function that implements the "Watch" method once the
+// queued functions have been called.
+func (m *Mock) SetWatch(f WatchFunc) *Mock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.watchFunc = f
+	return m
+}
+
+// Watch implements the "Watch" method.
+func (m *Mock) Watch(ctx context.Context, p catalog.ID, stream catalog.WatchServerStream) (err error) {
+	m.mu.Lock()
+	m.record("Watch", p)
+	f := m.watchFunc
+	if len(m.watchFuncs) > 0 {
+		f = m.watchFuncs[0]
+		m.watchFuncs = m.watchFuncs[1:]
+	}
+	m.mu.Unlock()
+	if f == nil {
+		return nil
+	}
+	return f(ctx, p, stream)
+}
+
+// Client returns a "Catalog" service client whose endpoints call the mock
+// methods. The client does not support streaming methods.
+func (m *Mock) Client() *catalog.Client {
+	return &catalog.Client{
+		GetEndpoint: func(ctx context.Context, req any) (any, error) {
+			res, err := m.Get(ctx, req.(*catalog.GetPayload))
+			if err != nil {
+				return nil, err
+			}
+			return res, nil
+		},
+		PingEndpoint: func(ctx context.Context, req any) (any, error) {
+			return nil, m.Ping(ctx)
+		},
+		WatchEndpoint: func(ctx context.Context, req any) (any, error) {
+			return nil, fmt.Errorf("mock client does not support streaming method %q", "Watch")
+		},
+	}
+}
+`
+
+const MockServerCode = `func main() {
+	var (
+		httpAddrF  = flag.String("http-addr", ":8080", "HTTP listen ` + "`" + `address` + "`" + `")
+		scenariosF = flag.String("scenarios", "", "JSON ` + "`" + `file` + "`" + ` listing the scenarios that override the default responses")
+	)
+	flag.Parse()
+
+	var scenarios []*scenario
+	if *scenariosF != "" {
+		var err error
+		if scenarios, err = loadScenarios(*scenariosF); err != nil {
+			log.Fatalf("failed to load scenarios: %v", err)
+		}
+		for _, s := range scenarios {
+			switch s.Service {
+			case "Store":
+			default:
+				log.Fatalf("invalid scenarios: scenario %q: unknown service %q", s.Name, s.Service)
+			}
+		}
+	}
+
+	// Initialize the service mocks and wrap them with endpoints that return
+	// the errors requested by the clients.
+	var (
+		storeEndpoints *store.Endpoints
+	)
+	{
+		storeMock := storemock.New()
+		if err := configureStore(storeMock, scenarios); err != nil {
+			log.Fatalf("invalid scenarios: %v", err)
+		}
+		storeEndpoints = store.NewEndpoints(storeMock)
+		storeEndpoints.Use(mockErrors(storemock.NewError))
+	}
+
+	// Create channel used by both the signal handler and server goroutines
+	// to notify the main goroutine when to stop the servers.
+	errc := make(chan error)
+
+	// Setup interrupt handler so that SIGINT and SIGTERM signals cause the
+	// servers to stop gracefully.
+	go func() {
+		c := make(chan os.Signal, 1)
+		signal.Notify(c, syscall.SIGINT, syscall.SIGTERM)
+		errc <- fmt.Errorf("%s", <-c)
+	}()
+
+	var wg sync.WaitGroup
+	ctx, cancel := context.WithCancel(context.Background())
+	handleHTTPServer(ctx, *httpAddrF, storeEndpoints, &wg, errc)
+
+	// Wait for signal.
+	log.Printf("exiting (%v)", <-errc)
+
+	// Send cancellation signal to the goroutines.
+	cancel()
+
+	wg.Wait()
+	log.Printf("exited")
+}
+
+// mockKey is the type of the context keys used to record the error and the
+// scenario requested by the client.
+type mockKey int
+
+const (
+	// errorKey is the context key used to record the name of the error
+	// requested by the client.
+	errorKey mockKey = iota + 1
+	// scenarioKey is the context key used to record the name of the
+	// scenario requested by the client.
+	scenarioKey
+)
+
+// withMock returns a copy of ctx that records the names of the error and of
+// the scenario requested by the client if any.
+func withMock(ctx context.Context, errName, scenarioName string) context.Context {
+	if errName != "" {
+		ctx = context.WithValue(ctx, errorKey, errName)
+	}
+	if scenarioName != "" {
+		ctx = context.WithValue(ctx, scenarioKey, scenarioName)
+	}
+	return ctx
+}
+
+// mockErrors returns an endpoint middleware that returns the error requested
+// by the client instead of calling the endpoint. newError initializes the
+// errors given the names of the method and of the error.
+func mockErrors(newError func(method, name string) error) func(goa.Endpoint) goa.Endpoint {
+	return func(e goa.Endpoint) goa.Endpoint {
+		return func(ctx context.Context, req any) (any, error) {
+			name, _ := ctx.Value(errorKey).(string)
+			if name == "" {
+				return e(ctx, req)
+			}
+			method, _ := ctx.Value(goa.MethodKey).(string)
+			if err := newError(method, name); err != nil {
+				return nil, err
+			}
+			return nil, goa.PermanentError("unknown_error", "method %q does not define error %q", method, name)
+		}
+	}
+}
+
+// configureStore configures the "Store" service mock so that the scenarios
+// override the default responses.
+func configureStore(m *storemock.Mock, scenarios []*scenario) error {
+	for _, s := range scenarios {
+		if s.Service != "Store" {
+			continue
+		}
+		switch s.Method {
+		case "Get", "Ping":
+		default:
+			return fmt.Errorf("scenario %q: service %q has no method %q that supports scenarios", s.Name, s.Service, s.Method)
+		}
+		if s.Error != "" && storemock.NewError(s.Method, s.Error) == nil {
+			return fmt.Errorf("scenario %q: method %q of service %q does not define error %q", s.Name, s.Method, s.Service, s.Error)
+		}
+	}
+	def := storemock.New()
+	getResults, err := scenarioResults[*store.Item](scenarios, "Store", "Get")
+	if err != nil {
+		return err
+	}
+	m.SetGet(func(ctx context.Context, p string) (res *store.Item, err error) {
+		if s := selectScenario(ctx, scenarios, "Store", "Get"); s != nil {
+			if s.Error != "" {
+				err = storemock.NewError("Get", s.Error)
+				return
+			}
+			if r, ok := getResults[s.Name]; ok {
+				res = r
+				return
+			}
+		}
+		return def.Get(ctx, p)
+	})
+	m.SetPing(func(ctx context.Context) (err error) {
+		if s := selectScenario(ctx, scenarios, "Store", "Ping"); s != nil {
+			if s.Error != "" {
+				err = storemock.NewError("Ping", s.Error)
+				return
+			}
+		}
+		return def.Ping(ctx)
+	})
+	return nil
+}
+`
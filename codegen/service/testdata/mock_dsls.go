@@ -0,0 +1,114 @@
+package testdata
+
+import . "goa.design/goa/v3/dsl"
+
+var MockDSL = func() {
+	var ID = Type("ID", String)
+	var Item = Type("Item", func() {
+		Attribute("id", ID)
+		Attribute("count", Int32)
+		Attribute("tags", ArrayOf(String))
+		Required("id")
+	})
+	var Unavailable = Type("Unavailable", func() {
+		ErrorName("name", String)
+		Attribute("retry", Int)
+		Required("name", "retry")
+	})
+	var JWTAuth = JWTSecurity("jwt")
+	var Logger = Interceptor("Logger")
+	Service("Catalog", func() {
+		ServerInterceptor(Logger)
+		Error("unavailable", Unavailable)
+		Method("Get", func() {
+			Security(JWTAuth)
+			Payload(func() {
+				Token("token", String)
+				Attribute("id", ID)
+			})
+			Result(Item)
+			Error("not_found")
+		})
+		Method("Ping", func() {})
+		Method("Watch", func() {
+			Payload(ID)
+			StreamingResult(Item)
+		})
+	})
+}
+
+var MockServerDSL = func() {
+	var Item = Type("Item", func() {
+		Attribute("id", String)
+		Required("id")
+	})
+	Service("Store", func() {
+		Error("unavailable")
+		Method("Get", func() {
+			Payload(String)
+			Result(Item)
+			HTTP(func() {
+				GET("/{id}")
+				Response("unavailable", StatusServiceUnavailable)
+			})
+		})
+		Method("Ping", func() {
+			HTTP(func() {
+				GET("/ping")
+			})
+		})
+	})
+}
+
+var MockRulesDSL = func() {
+	var Phone = CustomFormat("phone", func() {
+		Validator("example.com/formats", "ValidatePhone")
+		Example("+15551234567")
+	})
+	var Color = Type("Color", String, func() {
+		Enum("red", "green", "blue")
+	})
+	var Contact = Type("Contact", func() {
+		Attribute("email", String, func() {
+			Format(FormatEmail)
+		})
+		Attribute("sms", String)
+		Attribute("color", Color)
+		Attribute("start", String, func() {
+			Format(FormatDate)
+		})
+		Attribute("end", String, func() {
+			Format(FormatDate)
+		})
+		Attribute("status", String, func() {
+			Enum("ok", "rejected")
+		})
+		Attribute("reason", String)
+		Attribute("created_at", String, func() {
+			Format(FormatDateTime)
+			Meta("struct:field:time")
+		})
+		Attribute("ttl", String, func() {
+			Format(FormatDuration)
+			Meta("struct:field:time")
+		})
+		Attribute("price", Decimal)
+		Attribute("count", BigInt)
+		Attribute("nick", String, func() {
+			Nullable()
+		})
+		Attribute("phone", String, func() {
+			Format(Phone)
+		})
+		Required("color")
+		ExactlyOneOf("email", "sms")
+		After("end", "start")
+		RequiredIf("status", "rejected", "reason")
+	})
+	Service("Contacts", func() {
+		Method("Create", func() {
+			Payload(Contact)
+			Result(Contact)
+		})
+	})
+}
@@ -3197,3 +3197,262 @@ type Foo struct {
 	IntField *int
 }
 `
+
+const Time = `
+// Service is the Time service interface.
+type Service interface {
+	// A implements A.
+	A(context.Context, *APayload) (err error)
+}
+
+// APIName is the name of the API as defined in the design.
+const APIName = "test api"
+
+// APIVersion is the version of the API as defined in the design.
+const APIVersion = "0.0.1"
+
+// ServiceName is the name of the service as defined in the design. This is the
+// same value that is set in the endpoint request contexts under the ServiceKey
+// key.
+const ServiceName = "Time"
+
+// MethodNames lists the service method names as defined in the design. These
+// are the same values that are set in the endpoint request contexts under the
+// MethodKey key.
+var MethodNames = [1]string{"A"}
+
+// APayload is the payload type of the Time service A method.
+type APayload struct {
+	CreatedAt time.Time
+	Day       *time.Time
+	Timeout   *time.Duration
+	Raw       *string
+}
+`
+
+const TypedEnum = `
+// Service is the TypedEnum service interface.
+type Service interface {
+	// A implements A.
+	A(context.Context, *APayload) (err error)
+}
+
+// APIName is the name of the API as defined in the design.
+const APIName = "test api"
+
+// APIVersion is the version of the API as defined in the design.
+const APIVersion = "0.0.1"
+
+// ServiceName is the name of the service as defined in the design. This is the
+// same value that is set in the endpoint request contexts under the ServiceKey
+// key.
+const ServiceName = "TypedEnum"
+
+// MethodNames lists the service method names as defined in the design. These
+// are the same values that are set in the endpoint request contexts under the
+// MethodKey key.
+var MethodNames = [1]string{"A"}
+
+// APayload is the payload type of the TypedEnum service A method.
+type APayload struct {
+	Color    Color
+	Priority *Priority
+}
+
+type Color string
+
+// Enum values of Color.
+const (
+	ColorRed       Color = "red"
+	ColorDarkGreen Color = "dark-green"
+)
+
+// String returns the string representation of the Color value.
+func (v Color) String() string {
+	return string(v)
+}
+
+// Valid returns true if the Color value is one of the enum values.
+func (v Color) Valid() bool {
+	switch v {
+	case ColorRed, ColorDarkGreen:
+		return true
+	}
+	return false
+}
+
+// ParseColor returns the Color value represented by s, it returns
+// an error if s is not one of the enum values.
+func ParseColor(s string) (Color, error) {
+	v := Color(s)
+	if !v.Valid() {
+		return v, goa.InvalidEnumValueError("Color", s, []any{"red", "dark-green"})
+	}
+	return v, nil
+}
+
+type Priority int32
+
+// Enum values of Priority.
+const (
+	Priority1 Priority = 1
+	Priority2 Priority = 2
+)
+
+// String returns the string representation of the Priority value.
+func (v Priority) String() string {
+	return strconv.FormatInt(int64(v), 10)
+}
+
+// Valid returns true if the Priority value is one of the enum values.
+func (v Priority) Valid() bool {
+	switch v {
+	case Priority1, Priority2:
+		return true
+	}
+	return false
+}
+
+// ParsePriority returns the Priority value represented by s, it returns
+// an error if s is not one of the enum values.
+func ParsePriority(s string) (Priority, error) {
+	i, err := strconv.ParseInt(s, 10, 32)
+	if err != nil {
+		return 0, goa.InvalidFieldTypeError("Priority", s, "integer")
+	}
+	v := Priority(i)
+	if !v.Valid() {
+		return v, goa.InvalidEnumValueError("Priority", s, []any{1, 2})
+	}
+	return v, nil
+}
+`
+
+const NullableCode = `
+// Service is the Nullable service interface.
+type Service interface {
+	// A implements A.
+	A(context.Context, *APayload) (err error)
+}
+
+// APIName is the name of the API as defined in the design.
+const APIName = "test api"
+
+// APIVersion is the version of the API as defined in the design.
+const APIVersion = "0.0.1"
+
+// ServiceName is the name of the service as defined in the design. This is the
+// same value that is set in the endpoint request contexts under the ServiceKey
+// key.
+const ServiceName = "Nullable"
+
+// MethodNames lists the service method names as defined in the design. These
+// are the same values that are set in the endpoint request contexts under the
+// MethodKey key.
+var MethodNames = [1]string{"A"}
+
+// APayload is the payload type of the Nullable service A method.
+type APayload struct {
+	ID       int
+	Nickname goa.Nullable[string]
+	Age      goa.Nullable[int]
+	Name     *string
+}
+`
+
+const CustomFormatCode = `
+// Service is the CustomFormat service interface.
+type Service interface {
+	// A implements A.
+	A(context.Context, *APayload) (err error)
+}
+
+// APIName is the name of the API as defined in the design.
+const APIName = "test api"
+
+// APIVersion is the version of the API as defined in the design.
+const APIVersion = "0.0.1"
+
+// ServiceName is the name of the service as defined in the design. This is the
+// same value that is set in the endpoint request contexts under the ServiceKey
+// key.
+const ServiceName = "CustomFormat"
+
+// MethodNames lists the service method names as defined in the design. These
+// are the same values that are set in the endpoint request contexts under the
+// MethodKey key.
+var MethodNames = [1]string{"A"}
+
+// APayload is the payload type of the CustomFormat service A method.
+type APayload struct {
+	Phone   *string
+	Items   []string
+	Version *string
+}
+
+// init registers the validators of the custom formats used by the service with
+// the goa package.
+func init() {
+	goa.RegisterFormat("phone", formats.ValidatePhone)
+	goa.RegisterFormat("sku", skuformats.Validate)
+}
+`
+
+const TypeTemplateCode = `
+// Service is the TypeTemplate service interface.
+type Service interface {
+	// A implements A.
+	A(context.Context, *PairOfStringAndUser) (res *PageOfUser, err error)
+	// B implements B.
+	B(context.Context) (res *BResult, err error)
+}
+
+// APIName is the name of the API as defined in the design.
+const APIName = "test api"
+
+// APIVersion is the version of the API as defined in the design.
+const APIVersion = "0.0.1"
+
+// ServiceName is the name of the service as defined in the design. This is the
+// same value that is set in the endpoint request contexts under the ServiceKey
+// key.
+const ServiceName = "TypeTemplate"
+
+// MethodNames lists the service method names as defined in the design. These
+// are the same values that are set in the endpoint request contexts under the
+// MethodKey key.
+var MethodNames = [2]string{"A", "B"}
+
+// BResult is the result type of the TypeTemplate service B method.
+type BResult struct {
+	Teams *PageOfTeam
+	Users *PageOfUser
+}
+
+// Page of items
+type Page[T any] struct {
+	Items      []*T
+	First      *T
+	NextCursor *string
+}
+
+// Page of items
+type PageOfTeam = Page[Team]
+
+// PageOfUser is the result type of the TypeTemplate service A method.
+type PageOfUser = Page[User]
+
+// PairOfStringAndUser is the payload type of the TypeTemplate service A method.
+type PairOfStringAndUser struct {
+	Key   string
+	Value *User
+}
+
+type Team struct {
+	Members *int
+}
+
+type User struct {
+	Name *string
+}
+`
@@ -2,6 +2,7 @@ package testdata
 
 import (
 	. "goa.design/goa/v3/dsl"
+	"goa.design/goa/v3/expr"
 )
 
 var SingleMethodDSL = func() {
@@ -967,3 +968,127 @@ var PkgPathPayloadAttributeDSL = func() {
 		})
 	})
 }
+
+var TypedEnumDSL = func() {
+	var Color = Type("Color", String, func() {
+		Enum("red", "dark-green")
+		Meta("type:enum")
+	})
+	var Priority = Type("Priority", Int32, func() {
+		Enum(1, 2)
+		Meta("type:enum")
+	})
+	Service("TypedEnum", func() {
+		Method("A", func() {
+			Payload(func() {
+				Attribute("color", Color)
+				Attribute("priority", Priority)
+				Required("color")
+			})
+		})
+	})
+}
+
+var TimeDSL = func() {
+	Service("Time", func() {
+		Method("A", func() {
+			Payload(func() {
+				Attribute("created_at", String, func() {
+					Format(FormatDateTime)
+					Meta("struct:field:time")
+				})
+				Attribute("day", String, func() {
+					Format(FormatDate)
+					Meta("struct:field:time")
+				})
+				Attribute("timeout", String, func() {
+					Format(FormatDuration)
+					Meta("struct:field:time")
+				})
+				Attribute("raw", String, func() {
+					Format(FormatDateTime)
+				})
+				Required("created_at")
+			})
+		})
+	})
+}
+
+var NullableDSL = func() {
+	Service("Nullable", func() {
+		Method("A", func() {
+			Payload(func() {
+				Attribute("id", Int)
+				Attribute("nickname", String, func() {
+					Nullable()
+				})
+				Attribute("age", Int, func() {
+					Nullable()
+				})
+				Attribute("name", String)
+				Required("id")
+			})
+		})
+	})
+}
+
+var CustomFormatDSL = func() {
+	var Phone = CustomFormat("phone", func() {
+		Validator("example.com/formats", "ValidatePhone")
+		Example("+15551234567")
+	})
+	var SKU = CustomFormat("sku", func() {
+		Validator("example.com/catalog/sku-formats", "Validate")
+	})
+	Service("CustomFormat", func() {
+		Method("A", func() {
+			Payload(func() {
+				Attribute("phone", String, func() {
+					Format(Phone)
+				})
+				Attribute("items", ArrayOf(String, func() {
+					Format(SKU)
+				}))
+				Attribute("version", String, func() {
+					Format(FormatSemver)
+				})
+			})
+		})
+	})
+}
+
+var page = TypeTemplate("Page", func(T expr.DataType) {
+	Description("Page of items")
+	Meta("type:generic")
+	Attribute("items", ArrayOf(T))
+	Attribute("first", T)
+	Attribute("next_cursor", String)
+	Required("items")
+})
+
+var pair = TypeTemplate("Pair", func(K, V expr.DataType) {
+	Attribute("key", K)
+	Attribute("value", V)
+	Required("key")
+})
+
+var TypeTemplateDSL = func() {
+	var User = Type("User", func() {
+		Attribute("name", String)
+	})
+	var Team = Type("Team", func() {
+		Attribute("members", Int)
+	})
+	Service("TypeTemplate", func() {
+		Method("A", func() {
+			Payload(pair(String, User))
+			Result(page(User))
+		})
+		Method("B", func() {
+			Result(func() {
+				Attribute("teams", page(Team))
+				Attribute("users", page(User))
+			})
+		})
+	})
+}
@@ -37,6 +37,17 @@ var TestUnionDSL = func() {
 				Attribute("SomeType", SomeType)
 			})
 		})
+		SomeOtherType = Type("SomeOtherType", func() {
+			Attribute("otherField", Int)
+			Required("otherField")
+		})
+		UnionDiscriminated = Type("UnionDiscriminated", func() {
+			OneOf("UnionDiscriminated", func() {
+				Discriminator("kind")
+				Attribute("some", SomeType)
+				Attribute("other", SomeOtherType)
+			})
+		})
 
 		_ = Type("Container", func() {
 			Attribute("UnionString", UnionString)
@@ -44,6 +55,7 @@ var TestUnionDSL = func() {
 			Attribute("UnionStringInt", UnionStringInt)
 			Attribute("UnionStringInt2", UnionStringInt2)
 			Attribute("UnionSomeType", UnionSomeType)
+			Attribute("UnionDiscriminated", UnionDiscriminated)
 		})
 
 		_ = Type("UnionUserType", func() {
@@ -51,5 +63,12 @@ var TestUnionDSL = func() {
 			Attribute("Value", String)
 			Required("Type", "Value")
 		})
+
+		_ = Type("DiscriminatedUserType", func() {
+			Attribute("kind", String)
+			Attribute("someField", String)
+			Attribute("otherField", Int)
+			Required("kind")
+		})
 	)
 }
@@ -583,5 +583,128 @@ const (
 		}
 	}
 }
+`
+
+	RulesRequiredValidationCode = `func Validate() (err error) {
+	err = goa.MergeErrors(err, goa.ValidateExactlyOneOf("target", []string{"email", "phone"}, target.Email != nil, target.Phone != nil))
+	if target.End != nil && target.Start != nil {
+		err = goa.MergeErrors(err, goa.ValidateAfter("target.end", *target.End, "target.start", *target.Start, goa.FormatDateTime))
+	}
+	if target.Max != nil {
+		if *target.Max <= target.Min {
+			err = goa.MergeErrors(err, goa.InvalidOrderError("target.max", *target.Max, "target.min", target.Min))
+		}
+	}
+	if target.Status == "rejected" {
+		if target.Reason == nil {
+			err = goa.MergeErrors(err, goa.MissingFieldError("reason", "target"))
+		}
+	}
+	if target.Start != nil {
+		err = goa.MergeErrors(err, goa.ValidateFormat("target.start", *target.Start, goa.FormatDateTime))
+	}
+	if target.End != nil {
+		err = goa.MergeErrors(err, goa.ValidateFormat("target.end", *target.End, goa.FormatDateTime))
+	}
+}
+`
+
+	RulesPointerValidationCode = `func Validate() (err error) {
+	if target.Status == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("status", "target"))
+	}
+	if target.Min == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("min", "target"))
+	}
+	err = goa.MergeErrors(err, goa.ValidateExactlyOneOf("target", []string{"email", "phone"}, target.Email != nil, target.Phone != nil))
+	if target.End != nil && target.Start != nil {
+		err = goa.MergeErrors(err, goa.ValidateAfter("target.end", *target.End, "target.start", *target.Start, goa.FormatDateTime))
+	}
+	if target.Max != nil && target.Min != nil {
+		if *target.Max <= *target.Min {
+			err = goa.MergeErrors(err, goa.InvalidOrderError("target.max", *target.Max, "target.min", *target.Min))
+		}
+	}
+	if target.Status != nil && *target.Status == "rejected" {
+		if target.Reason == nil {
+			err = goa.MergeErrors(err, goa.MissingFieldError("reason", "target"))
+		}
+	}
+	if target.Start != nil {
+		err = goa.MergeErrors(err, goa.ValidateFormat("target.start", *target.Start, goa.FormatDateTime))
+	}
+	if target.End != nil {
+		err = goa.MergeErrors(err, goa.ValidateFormat("target.end", *target.End, goa.FormatDateTime))
+	}
+}
+`
+
+	CustomFormatsValidationCode = `func Validate() (err error) {
+	if target.Phone != nil {
+		err = goa.MergeErrors(err, goa.ValidateFormat("target.phone", *target.Phone, goa.Format("phone")))
+	}
+	err = goa.MergeErrors(err, goa.ValidateFormat("target.currency", target.Currency, goa.FormatCurrency))
+}
+`
+
+	DecimalRequiredValidationCode = `func Validate() (err error) {
+	err = goa.MergeErrors(err, goa.ValidateDecimalMinimum("target.price", string(target.Price), "0.01", false))
+	err = goa.MergeErrors(err, goa.ValidateDecimalMaximum("target.price", string(target.Price), "1000", true))
+	if target.Amount != nil {
+		err = goa.MergeErrors(err, goa.ValidateDecimalMinimum("target.amount", string(*target.Amount), "0", true))
+	}
+	if target.Count != nil {
+		err = goa.MergeErrors(err, goa.ValidateDecimalMaximum("target.count", string(*target.Count), "100", false))
+	}
+}
+`
+
+	DecimalPointerValidationCode = `func Validate() (err error) {
+	if target.Price == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("price", "target"))
+	}
+	if target.Price != nil {
+		err = goa.MergeErrors(err, goa.ValidateDecimalMinimum("target.price", string(*target.Price), "0.01", false))
+	}
+	if target.Price != nil {
+		err = goa.MergeErrors(err, goa.ValidateDecimalMaximum("target.price", string(*target.Price), "1000", true))
+	}
+	if target.Amount != nil {
+		err = goa.MergeErrors(err, goa.ValidateDecimalMinimum("target.amount", string(*target.Amount), "0", true))
+	}
+	if target.Count != nil {
+		err = goa.MergeErrors(err, goa.ValidateDecimalMaximum("target.count", string(*target.Count), "100", false))
+	}
+}
+`
+
+	DiscriminatedUnionValidationCode = `func Validate() (err error) {
+	if target.Kind == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("kind", "target"))
+	}
+	if target.Kind != nil {
+		switch *target.Kind {
+		case "bank":
+			if target.Iban == nil {
+				err = goa.MergeErrors(err, goa.MissingFieldError("iban", "target"))
+			}
+		case "card":
+			if target.Number == nil {
+				err = goa.MergeErrors(err, goa.MissingFieldError("number", "target"))
+			}
+			err = goa.MergeErrors(err, goa.ValidateExactlyOneOf("target", []string{"cvv", "token"}, target.Cvv != nil, target.Token != nil))
+		}
+	}
+	if target.Kind != nil {
+		if !(*target.Kind == "bank" || *target.Kind == "card") {
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError("target.kind", *target.Kind, []any{"bank", "card"}))
+		}
+	}
+	if target.Iban != nil {
+		if utf8.RuneCountInString(*target.Iban) < 15 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError("target.iban", *target.Iban, utf8.RuneCountInString(*target.Iban), 15, true))
+		}
+	}
+}
 `
 )
@@ -153,5 +153,77 @@ var ValidationTypesDSL = func() {
 				Attribute("integer", IntegerT)
 			})
 		})
+
+		_ = Type("Rules", func() {
+			Attribute("email", String)
+			Attribute("phone", String)
+			Attribute("status", String)
+			Attribute("reason", String)
+			Attribute("start", String, func() {
+				Format(FormatDateTime)
+			})
+			Attribute("end", String, func() {
+				Format(FormatDateTime)
+			})
+			Attribute("min", Int)
+			Attribute("max", Int)
+			Required("status", "min")
+			ExactlyOneOf("email", "phone")
+			After("end", "start")
+			After("max", "min")
+			RequiredIf("status", "rejected", "reason")
+		})
+
+		Phone = CustomFormat("phone", func() {
+			Validator("example.com/formats", "ValidatePhone")
+		})
+
+		_ = Type("Formats", func() {
+			Attribute("phone", String, func() {
+				Format(Phone)
+			})
+			Attribute("currency", String, func() {
+				Format(FormatCurrency)
+			})
+			Required("currency")
+		})
+
+		_ = Type("Decimal", func() {
+			Attribute("price", Decimal, func() {
+				Minimum(0.01)
+				ExclusiveMaximum(1000)
+			})
+			Attribute("amount", Decimal, func() {
+				ExclusiveMinimum(0)
+				Meta("struct:field:encoding", "string")
+			})
+			Attribute("count", BigInt, func() {
+				Maximum(100)
+			})
+			Required("price")
+		})
+
+		BankT = Type("Bank", func() {
+			Attribute("iban", String, func() {
+				MinLength(15)
+			})
+			Required("iban")
+		})
+
+		CardT = Type("Card", func() {
+			Attribute("number", String)
+			Attribute("cvv", String)
+			Attribute("token", String)
+			Required("number")
+			ExactlyOneOf("cvv", "token")
+		})
+
+		_ = Type("Payment", func() {
+			OneOf("Payment", func() {
+				Discriminator("kind")
+				Attribute("bank", BankT)
+				Attribute("card", CardT)
+			})
+		})
 	)
 }
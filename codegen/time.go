@@ -0,0 +1,68 @@
+package codegen
+
+import (
+	"fmt"
+
+	"goa.design/goa/v3/expr"
+)
+
+// TimeParseCode returns the Go expression that parses the string held by
+// sourceVar into the time.Time or time.Duration value of the given attribute
+// using the attribute format. The expression returns the value and an error.
+func TimeParseCode(att *expr.AttributeExpr, sourceVar string) string {
+	switch att.Validation.Format {
+	case expr.FormatDuration:
+		return fmt.Sprintf("time.ParseDuration(%s)", sourceVar)
+	case expr.FormatDate:
+		return fmt.Sprintf("time.Parse(time.DateOnly, %s)", sourceVar)
+	default:
+		return fmt.Sprintf("time.Parse(time.RFC3339, %s)", sourceVar)
+	}
+}
+
+// TimeFormatCode returns the Go expression that formats the time.Time or
+// time.Duration value held by sourceVar into a string using the format of the
+// given attribute.
+func TimeFormatCode(att *expr.AttributeExpr, sourceVar string) string {
+	switch att.Validation.Format {
+	case expr.FormatDuration:
+		return fmt.Sprintf("%s.String()", sourceVar)
+	case expr.FormatDate:
+		return fmt.Sprintf("%s.Format(time.DateOnly)", sourceVar)
+	default:
+		return fmt.Sprintf("%s.Format(time.RFC3339)", sourceVar)
+	}
+}
+
+// transformTime returns the code that initializes targetVar from sourceVar
+// when exactly one of the source and target attributes is mapped to a Go
+// time type. Strings are parsed ignoring errors as they must have been
+// validated prior.
+func transformTime(source, target *expr.AttributeExpr, sourceVar, targetVar string, newVar, srcPtr, tgtPtr bool) string {
+	src := sourceVar
+	if srcPtr && !source.IsTime() {
+		src = "*" + sourceVar
+	}
+	assign := "="
+	if newVar {
+		assign = ":="
+	}
+	var code string
+	switch {
+	case source.IsTime() && tgtPtr:
+		code = fmt.Sprintf("tmp := %s\n%s %s &tmp\n", TimeFormatCode(target, src), targetVar, assign)
+	case source.IsTime():
+		code = fmt.Sprintf("%s %s %s\n", targetVar, assign, TimeFormatCode(target, src))
+	case tgtPtr:
+		code = fmt.Sprintf("tmp, _ := %s\n%s %s &tmp\n", TimeParseCode(target, src), targetVar, assign)
+	default:
+		code = fmt.Sprintf("%s, _ %s %s\n", targetVar, assign, TimeParseCode(target, src))
+	}
+	if srcPtr {
+		return fmt.Sprintf("if %s != nil {\n%s}\n", sourceVar, code)
+	}
+	if tgtPtr {
+		return fmt.Sprintf("{\n%s}\n", code)
+	}
+	return code
+}
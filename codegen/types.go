@@ -36,6 +36,10 @@ func GoNativeTypeName(t expr.DataType) string {
 		return "[]byte"
 	case expr.AnyKind:
 		return "any"
+	case expr.DecimalKind:
+		return "goa.Decimal"
+	case expr.BigIntKind:
+		return "goa.BigInt"
 	default:
 		panic(fmt.Sprintf("cannot compute native Go type for %T", t)) // bug
 	}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"text/template"
 
@@ -11,17 +12,22 @@ import (
 )
 
 var (
-	enumValT       *template.Template
-	formatValT     *template.Template
-	patternValT    *template.Template
-	exclMinMaxValT *template.Template
-	minMaxValT     *template.Template
-	lengthValT     *template.Template
-	requiredValT   *template.Template
-	arrayValT      *template.Template
-	mapValT        *template.Template
-	unionValT      *template.Template
-	userValT       *template.Template
+	enumValT         *template.Template
+	formatValT       *template.Template
+	patternValT      *template.Template
+	exclMinMaxValT   *template.Template
+	minMaxValT       *template.Template
+	lengthValT       *template.Template
+	requiredValT     *template.Template
+	arrayValT        *template.Template
+	mapValT          *template.Template
+	unionValT        *template.Template
+	userValT         *template.Template
+	exactlyOneOfT    *template.Template
+	decimalRangeValT *template.Template
+	afterValT        *template.Template
+	requiredIfValT   *template.Template
+	variantValT      *template.Template
 )
 
 func init() {
@@ -36,12 +42,17 @@ func init() {
 	patternValT = template.Must(template.New("pattern").Funcs(fm).Parse(patternValTmpl))
 	exclMinMaxValT = template.Must(template.New("exclMinMax").Funcs(fm).Parse(exclMinMaxValTmpl))
 	minMaxValT = template.Must(template.New("minMax").Funcs(fm).Parse(minMaxValTmpl))
+	decimalRangeValT = template.Must(template.New("decimalRange").Funcs(fm).Parse(decimalRangeValTmpl))
 	lengthValT = template.Must(template.New("length").Funcs(fm).Parse(lengthValTmpl))
 	requiredValT = template.Must(template.New("req").Funcs(fm).Parse(requiredValTmpl))
 	arrayValT = template.Must(template.New("array").Funcs(fm).Parse(arrayValTmpl))
 	mapValT = template.Must(template.New("map").Funcs(fm).Parse(mapValTmpl))
 	unionValT = template.Must(template.New("union").Funcs(fm).Parse(unionValTmpl))
 	userValT = template.Must(template.New("user").Funcs(fm).Parse(userValTmpl))
+	exactlyOneOfT = template.Must(template.New("exactlyOneOf").Funcs(fm).Parse(exactlyOneOfValTmpl))
+	afterValT = template.Must(template.New("after").Funcs(fm).Parse(afterValTmpl))
+	requiredIfValT = template.Must(template.New("requiredIf").Funcs(fm).Parse(requiredIfValTmpl))
+	variantValT = template.Must(template.New("variant").Funcs(fm).Parse(variantValTmpl))
 }
 
 // AttributeValidationCode produces Go code that runs the validations defined
@@ -202,6 +213,9 @@ func recurseValidationCode(att *expr.AttributeExpr, put expr.UserType, attCtx *A
 }
 
 func validateAttribute(ctx *AttributeContext, att *expr.AttributeExpr, put expr.UserType, target, context string, req, view bool) string {
+	if att.IsNullable() {
+		return validateNullable(ctx, att, put, target, context, view)
+	}
 	ut, isUT := att.Type.(expr.UserType)
 	if !isUT {
 		code := recurseValidationCode(att, put, ctx, req, false, view, target, context, nil).String()
@@ -235,6 +249,23 @@ func validateAttribute(ctx *AttributeContext, att *expr.AttributeExpr, put expr.
 	return fmt.Sprintf("if %s != nil {\n\t%s\n}", target, buf.String())
 }
 
+// validateNullable produces Go code that runs the validations defined in the
+// given nullable attribute against the value it holds if any.
+func validateNullable(ctx *AttributeContext, att *expr.AttributeExpr, put expr.UserType, target, context string, view bool) string {
+	vctx := ctx.Dup()
+	vctx.Pointer = false
+	code := recurseValidationCode(att, put, vctx, true, false, view, target+".Value", context, nil).String()
+	if code == "" {
+		return ""
+	}
+	cond := fmt.Sprintf("if %s.IsValue() {\n", target)
+	if _, ok := att.Meta["struct:field:proto"]; ok {
+		// Protocol buffer wrapper messages are nil when absent or null.
+		cond = fmt.Sprintf("if %s != nil {\n", target)
+	}
+	return fmt.Sprintf("%s%s\n}", cond, code)
+}
+
 // validationCode produces Go code that runs the validations defined in the
 // given attribute definition if any against the content of the variable named
 // target. The generated code assumes that there is a pre-existing "err"
@@ -274,12 +305,15 @@ func validationCode(att *expr.AttributeExpr, attCtx *AttributeContext, req, alia
 		kind            = att.Type.Kind()
 		isNativePointer = kind == expr.BytesKind || kind == expr.AnyKind
 		isPointer       = attCtx.Pointer || (!req && (att.DefaultValue == nil || !attCtx.UseDefault))
+		isDecimal       = kind == expr.DecimalKind || kind == expr.BigIntKind
 		tval            = target
 	)
 	if isPointer && expr.IsPrimitive(att.Type) && !isNativePointer {
 		tval = "*" + tval
 	}
-	if alias {
+	if isDecimal {
+		tval = fmt.Sprintf("string(%s)", tval)
+	} else if alias {
 		tval = fmt.Sprintf("%s(%s)", att.Type.Name(), tval)
 	}
 	data := map[string]any{
@@ -307,7 +341,7 @@ func validationCode(att *expr.AttributeExpr, attCtx *AttributeContext, req, alia
 			res = append(res, val)
 		}
 	}
-	if format := validation.Format; format != "" {
+	if format := validation.Format; format != "" && !att.IsTime() {
 		data["format"] = string(format)
 		if val := runTemplate(formatValT, data); val != "" {
 			res = append(res, val)
@@ -319,28 +353,51 @@ func validationCode(att *expr.AttributeExpr, attCtx *AttributeContext, req, alia
 			res = append(res, val)
 		}
 	}
-	if exclMin := validation.ExclusiveMinimum; exclMin != nil {
+	if isDecimal {
+		// Decimal values are compared using arbitrary-precision arithmetic.
+		limits := []struct {
+			val              *float64
+			isMin, exclusive bool
+		}{
+			{validation.ExclusiveMinimum, true, true},
+			{validation.Minimum, true, false},
+			{validation.ExclusiveMaximum, false, true},
+			{validation.Maximum, false, false},
+		}
+		for _, l := range limits {
+			if l.val == nil {
+				continue
+			}
+			data["limit"] = strconv.FormatFloat(*l.val, 'f', -1, 64)
+			data["isMin"] = l.isMin
+			data["exclusive"] = l.exclusive
+			if val := runTemplate(decimalRangeValT, data); val != "" {
+				res = append(res, val)
+			}
+		}
+	}
+	if exclMin := validation.ExclusiveMinimum; exclMin != nil && !isDecimal {
 		data["exclMin"] = *exclMin
 		data["isExclMin"] = true
 		if val := runTemplate(exclMinMaxValT, data); val != "" {
 			res = append(res, val)
 		}
 	}
-	if min := validation.Minimum; min != nil {
+	if min := validation.Minimum; min != nil && !isDecimal {
 		data["min"] = *min
 		data["isMin"] = true
 		if val := runTemplate(minMaxValT, data); val != "" {
 			res = append(res, val)
 		}
 	}
-	if exclMax := validation.ExclusiveMaximum; exclMax != nil {
+	if exclMax := validation.ExclusiveMaximum; exclMax != nil && !isDecimal {
 		data["exclMax"] = *exclMax
 		data["isExclMax"] = true
 		if val := runTemplate(exclMinMaxValT, data); val != "" {
 			res = append(res, val)
 		}
 	}
-	if max := validation.Maximum; max != nil {
+	if max := validation.Maximum; max != nil && !isDecimal {
 		data["max"] = *max
 		data["isMin"] = false
 		if val := runTemplate(minMaxValT, data); val != "" {
@@ -371,9 +428,190 @@ func validationCode(att *expr.AttributeExpr, attCtx *AttributeContext, req, alia
 		data["reqAtt"] = reqAtt
 		res = append(res, runTemplate(requiredValT, data))
 	}
+	if obj != nil && validation.HasRules() {
+		res = append(res, rulesValidationCode(att, validation, attCtx, target, context)...)
+	}
 	return strings.Join(res, "\n")
 }
 
+// ruleField describes a field of an object referenced by a cross-field
+// validation rule.
+type ruleField struct {
+	// Name is the field name used in error messages.
+	Name string
+	// Ref is the reference to the field.
+	Ref string
+	// IsSet is the expression that tests whether the field is set, empty
+	// if the field is always set.
+	IsSet string
+	// Val is the expression that evaluates to the field value.
+	Val string
+}
+
+// variantCase describes the validations that apply to an object when its
+// discriminator has the value Value, see expr.VariantRuleExpr.
+type variantCase struct {
+	// Value is the discriminator value.
+	Value string
+	// Required lists the fields required by the variant.
+	Required []*ruleField
+	// Rules lists the code that runs the cross-field rules of the variant.
+	Rules []string
+}
+
+// rulesValidationCode produces the Go code that runs the cross-field
+// validation rules defined on the object attribute att against the value held
+// by the variable named target.
+func rulesValidationCode(att *expr.AttributeExpr, validation *expr.ValidationExpr, attCtx *AttributeContext, target, context string) []string {
+	field := func(name string) *ruleField {
+		fatt := expr.AsObject(att.Type).Attribute(name)
+		if fatt == nil {
+			return nil
+		}
+		f := &ruleField{Name: name, Ref: target + "." + attCtx.Scope.Field(fatt, name, true)}
+		f.Val = f.Ref
+		_, proto := fatt.Meta["struct:field:proto"]
+		kind := fatt.Type.Kind()
+		switch {
+		case !expr.IsPrimitive(fatt.Type) || kind == expr.BytesKind || kind == expr.AnyKind:
+			f.IsSet = f.Ref + " != nil"
+		case fatt.IsTime() && proto:
+			// Protocol buffer well-known types are always pointers.
+			f.IsSet = f.Ref + " != nil"
+			if fatt.Validation.Format == expr.FormatDuration {
+				f.Val = f.Ref + ".AsDuration()"
+			} else {
+				f.Val = f.Ref + ".AsTime()"
+			}
+		case attCtx.Pointer || (!att.IsRequired(name) && (fatt.DefaultValue == nil || !attCtx.UseDefault)):
+			f.IsSet = f.Ref + " != nil"
+			f.Val = "*" + f.Ref
+		}
+		return f
+	}
+	runTemplate := func(tmpl *template.Template, data any) string {
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			panic(err) // bug
+		}
+		return buf.String()
+	}
+	var res []string
+	for _, names := range validation.ExactlyOneOf {
+		var set []string
+		for _, n := range names {
+			f := field(n)
+			if f == nil {
+				set = nil
+				break
+			}
+			if f.IsSet == "" {
+				set = append(set, "true")
+				continue
+			}
+			set = append(set, f.IsSet)
+		}
+		if set == nil {
+			continue
+		}
+		res = append(res, runTemplate(exactlyOneOfT, map[string]any{
+			"context": context,
+			"names":   fmt.Sprintf("%#v", names),
+			"set":     set,
+		}))
+	}
+	for _, r := range validation.After {
+		f, o := field(r.Attribute), field(r.Other)
+		if f == nil || o == nil {
+			continue
+		}
+		var conds []string
+		if f.IsSet != "" {
+			conds = append(conds, f.IsSet)
+		}
+		if o.IsSet != "" {
+			conds = append(conds, o.IsSet)
+		}
+		fatt := expr.AsObject(att.Type).Attribute(r.Attribute)
+		var format string
+		if fatt.Type == expr.String && !fatt.IsTime() {
+			format = constant(string(fatt.Validation.Format))
+		}
+		recv := f.Val
+		if strings.HasPrefix(recv, "*") {
+			// Methods of time.Time can be called on pointers.
+			recv = f.Ref
+		}
+		res = append(res, runTemplate(afterValT, map[string]any{
+			"context": context,
+			"recv":    recv,
+			"field":   f,
+			"other":   o,
+			"conds":   strings.Join(conds, " && "),
+			"time":    fatt.IsTime() && fatt.Validation.Format != expr.FormatDuration,
+			"format":  format,
+		}))
+	}
+	for _, r := range validation.RequiredIf {
+		f := field(r.Attribute)
+		if f == nil {
+			continue
+		}
+		var reqs []*ruleField
+		for _, n := range r.Required {
+			if rf := field(n); rf != nil && rf.IsSet != "" {
+				reqs = append(reqs, rf)
+			}
+		}
+		if len(reqs) == 0 {
+			continue
+		}
+		cond := fmt.Sprintf("%s == %#v", f.Val, r.Value)
+		if f.IsSet != "" {
+			cond = f.IsSet + " && " + cond
+		}
+		res = append(res, runTemplate(requiredIfValT, map[string]any{
+			"context":  context,
+			"cond":     cond,
+			"required": reqs,
+		}))
+	}
+	var (
+		discs []string
+		cases = make(map[string][]*variantCase)
+	)
+	for _, r := range validation.Variants {
+		c := &variantCase{Value: r.Value}
+		for _, n := range r.Validation.Required {
+			if rf := field(n); rf != nil && rf.IsSet != "" {
+				c.Required = append(c.Required, rf)
+			}
+		}
+		if r.Validation.HasRules() {
+			c.Rules = rulesValidationCode(att, r.Validation, attCtx, target, context)
+		}
+		if len(c.Required) == 0 && len(c.Rules) == 0 {
+			continue
+		}
+		if _, ok := cases[r.Attribute]; !ok {
+			discs = append(discs, r.Attribute)
+		}
+		cases[r.Attribute] = append(cases[r.Attribute], c)
+	}
+	for _, d := range discs {
+		f := field(d)
+		if f == nil {
+			continue
+		}
+		res = append(res, runTemplate(variantValT, map[string]any{
+			"context": context,
+			"field":   f,
+			"cases":   cases[d],
+		}))
+	}
+	return res
+}
+
 // hasValidations returns true if a UserType contains validations.
 func hasValidations(attCtx *AttributeContext, ut expr.UserType) bool {
 	// We need to check empirically whether there are validations to be
@@ -480,7 +718,8 @@ func oneof(target string, vals []any) string {
 	return strings.Join(elems, " || ")
 }
 
-// constant returns the Go constant name of the format with the given value.
+// constant returns the Go constant name of the format with the given value or
+// a conversion of the name to goa.Format for custom formats.
 func constant(formatName string) string {
 	switch formatName {
 	case "date":
@@ -511,6 +750,21 @@ func constant(formatName string) string {
 		return "goa.FormatJSON"
 	case "rfc1123":
 		return "goa.FormatRFC1123"
+	case "duration":
+		return "goa.FormatDuration"
+	case "e164":
+		return "goa.FormatE164"
+	case "ulid":
+		return "goa.FormatULID"
+	case "semver":
+		return "goa.FormatSemver"
+	case "iso4217":
+		return "goa.FormatCurrency"
+	case "base64":
+		return "goa.FormatBase64"
+	}
+	if expr.Root.Format(expr.ValidationFormat(formatName)) != nil {
+		return fmt.Sprintf("goa.Format(%q)", formatName)
 	}
 	panic("unknown format") // bug
 }
@@ -577,6 +831,13 @@ if !({{ oneof .targetVal .values }}) {
 {{ end -}}
 }`
 
+	decimalRangeValTmpl = `{{ if .isPointer -}}if {{ .target }} != nil {
+{{ end -}}
+        err = goa.MergeErrors(err, goa.ValidateDecimal{{ if .isMin }}Minimum{{ else }}Maximum{{ end }}({{ printf "%q" .context }}, {{ .targetVal }}, {{ printf "%q" .limit }}, {{ .exclusive }}))
+{{- if .isPointer }}
+}
+{{- end }}`
+
 	lengthValTmpl = `{{ $target := or (and (or (or .array .map) .nonzero) .target) .targetVal -}}
 {{ if and .isPointer .string -}}
 if {{ .target }} != nil {
@@ -585,6 +846,48 @@ if {{ if .string }}utf8.RuneCountInString({{ $target }}){{ else }}len({{ $target
         err = goa.MergeErrors(err, goa.InvalidLengthError({{ printf "%q" .context }}, {{ $target }}, {{ if .string }}utf8.RuneCountInString({{ $target }}){{ else }}len({{ $target }}){{ end }}, {{ if .isMinLength }}{{ .minLength }}, true{{ else }}{{ .maxLength }}, false{{ end }}))
 }{{- if and .isPointer .string }}
 }
+{{- end }}`
+
+	exactlyOneOfValTmpl = `err = goa.MergeErrors(err, goa.ValidateExactlyOneOf({{ printf "%q" .context }}, {{ .names }}{{ range .set }}, {{ . }}{{ end }}))`
+
+	afterValTmpl = `{{ if .conds }}if {{ .conds }} {
+{{ end -}}
+{{- if .format -}}
+        err = goa.MergeErrors(err, goa.ValidateAfter({{ printf "%q" (printf "%s.%s" .context .field.Name) }}, {{ .field.Val }}, {{ printf "%q" (printf "%s.%s" .context .other.Name) }}, {{ .other.Val }}, {{ .format }}))
+{{- else -}}
+        if {{ if .time }}!{{ .recv }}.After({{ .other.Val }}){{ else }}{{ .field.Val }} <= {{ .other.Val }}{{ end }} {
+        err = goa.MergeErrors(err, goa.InvalidOrderError({{ printf "%q" (printf "%s.%s" .context .field.Name) }}, {{ .field.Val }}, {{ printf "%q" (printf "%s.%s" .context .other.Name) }}, {{ .other.Val }}))
+}
+{{- end }}
+{{- if .conds }}
+}
+{{- end }}`
+
+	requiredIfValTmpl = `if {{ .cond }} {
+{{- range .required }}
+        if {{ .Ref }} == nil {
+        err = goa.MergeErrors(err, goa.MissingFieldError({{ printf "%q" .Name }}, {{ printf "%q" $.context }}))
+}
+{{- end }}
+}`
+
+	variantValTmpl = `{{ if .field.IsSet }}if {{ .field.IsSet }} {
+{{ end -}}
+switch {{ .field.Val }} {
+{{- range .cases }}
+case {{ printf "%q" .Value }}:
+{{- range .Required }}
+        if {{ .Ref }} == nil {
+        err = goa.MergeErrors(err, goa.MissingFieldError({{ printf "%q" .Name }}, {{ printf "%q" $.context }}))
+}
+{{- end }}
+{{- range .Rules }}
+{{ . }}
+{{- end }}
+{{- end }}
+}
+{{- if .field.IsSet }}
+}
 {{- end }}`
 
 	requiredValTmpl = `if {{ $.target }}.{{ .attCtx.Scope.Field $.reqAtt .req true }} == nil {
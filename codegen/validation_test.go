@@ -25,6 +25,10 @@ func TestRecursiveValidationCode(t *testing.T) {
 		rtcolT   = root.UserType("Collection")
 		colT     = root.UserType("TypeWithCollection")
 		deepT    = root.UserType("Deep")
+		rulesT   = root.UserType("Rules")
+		formatsT = root.UserType("Formats")
+		decimalT = root.UserType("Decimal")
+		paymentT = root.UserType("Payment")
 	)
 	cases := []struct {
 		Name       string
@@ -60,6 +64,11 @@ func TestRecursiveValidationCode(t *testing.T) {
 		{"collection-pointer", rtcolT, false, true, false, testdata.ResultCollectionPointerValidationCode},
 		{"type-with-collection-pointer", colT, false, true, false, testdata.TypeWithCollectionPointerValidationCode},
 		{"type-with-embedded-type", deepT, false, true, false, testdata.TypeWithEmbeddedTypeValidationCode},
+		{"rules-required", rulesT, true, false, false, testdata.RulesRequiredValidationCode},
+		{"rules-pointer", rulesT, false, true, false, testdata.RulesPointerValidationCode},
+		{"custom-formats", formatsT, false, false, false, testdata.CustomFormatsValidationCode},
+		{"decimal-required", decimalT, true, false, false, testdata.DecimalRequiredValidationCode},
+		{"decimal-pointer", decimalT, false, true, false, testdata.DecimalPointerValidationCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -80,4 +89,14 @@ func TestRecursiveValidationCode(t *testing.T) {
 			t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, Diff(t, code, testdata.UnionWithViewValidationCode))
 		}
 	})
+	// Special case of discriminated unions serialized to objects
+	t.Run("discriminated-union", func(t *testing.T) {
+		ctx := NewAttributeContext(true, false, false, "", scope)
+		att := expr.DiscriminatedUnionToObject(paymentT.Attribute().Find("Payment"))
+		code := ValidationCode(att, nil, ctx, true, false, false, "target")
+		code = FormatTestCode(t, "package foo\nfunc Validate() (err error){\n"+code+"}")
+		if code != testdata.DiscriminatedUnionValidationCode {
+			t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, Diff(t, code, testdata.DiscriminatedUnionValidationCode))
+		}
+	})
 }
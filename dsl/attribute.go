@@ -237,6 +237,80 @@ func OneOf(name string, args ...any) {
 	Attribute(name, &expr.Union{TypeName: name}, desc, fn)
 }
 
+// Discriminator sets the name of the property that identifies the type of the
+// union value in HTTP requests and responses. By default unions are serialized
+// as objects with a "Type" field holding the value type name and a "Value"
+// field holding the JSON encoded value. Discriminated unions are serialized as
+// the value object with the discriminator property added, for example:
+//
+//	{"kind": "card", "number": "4111111111111111"}
+//
+// The values of discriminated unions must be objects. The discriminator value
+// is the name of the union attribute. The OpenAPI v3 specification describes
+// discriminated unions using "oneOf" and "discriminator".
+//
+// Discriminator must appear in a OneOf expression.
+//
+// Discriminator takes a single argument: the name of the discriminator
+// property.
+//
+// Example:
+//
+//	var Payment = Type("Payment", func() {
+//	    OneOf("method", func() {
+//	        Discriminator("kind")
+//	        Attribute("card", Card)
+//	        Attribute("bank", BankAccount)
+//	    })
+//	})
+func Discriminator(name string) {
+	a, ok := eval.Current().(*expr.AttributeExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	u, ok := a.Type.(*expr.Union)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if name == "" {
+		eval.InvalidArgError("discriminator property name", name)
+		return
+	}
+	u.Discriminator = name
+}
+
+// Nullable makes it possible to distinguish an attribute explicitly set to null
+// from an attribute that is absent. The code generators produce a
+// goa.Nullable field for the attribute in both the service and transport types
+// so that partial updates (e.g. PATCH requests) can tell whether a field must
+// be cleared or left untouched.
+//
+// Nullable must appear in an Attribute or Field DSL. The attribute must be of a
+// primitive type other than Any, must not be required and must not define a
+// default value. Nullable attributes cannot be mapped to HTTP parameters,
+// headers or cookies. gRPC messages use the protocol buffer wrapper types to
+// represent nullable fields, explicit nulls are transmitted as unset fields.
+//
+// Nullable takes no argument.
+//
+// Example:
+//
+//	var UpdateUser = Type("UpdateUser", func() {
+//	    Attribute("nickname", String, func() {
+//	        Nullable()
+//	    })
+//	})
+func Nullable() {
+	a, ok := eval.Current().(*expr.AttributeExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	a.AddMeta("goa:nullable")
+}
+
 // Default sets the default value for an attribute.
 //
 // Default must appear in an Attribute DSL.
@@ -253,7 +327,7 @@ func Default(def any) {
 			def, expr.QualifiedTypeName(a.Type))
 		return
 	}
-	a.SetDefault(def)
+	a.SetDefault(decimalValue(a.Type, def))
 }
 
 // Example provides an example value for a type, a parameter, a header or any
@@ -268,8 +342,9 @@ func Default(def any) {
 // example is generated unless the "openapi:example" meta is set to "false".
 // See Meta.
 //
-// Example must appear in a Attributes, Attribute, Params, Param, Headers or
-// Header DSL.
+// Example must appear in a Attributes, Attribute, Params, Param, Headers,
+// Header or CustomFormat DSL. Examples of custom formats must be strings, they
+// are used to generate the random examples of the attributes using the format.
 //
 // Example takes one or two arguments: an optional summary and the example value
 // or defining DSL.
@@ -319,6 +394,15 @@ func Example(args ...any) {
 		}
 		arg = args[1]
 	}
+	if f, ok := eval.Current().(*expr.FormatExpr); ok {
+		v, ok := arg.(string)
+		if !ok {
+			eval.InvalidArgError("example value (string)", arg)
+			return
+		}
+		f.Examples = append(f.Examples, v)
+		return
+	}
 	a, ok := eval.Current().(*expr.AttributeExpr)
 	if !ok {
 		eval.IncompatibleDSL()
@@ -404,3 +488,16 @@ func parseAttributeArgs(baseAttr *expr.AttributeExpr, args ...any) (expr.DataTyp
 
 	return dataType, description, fn
 }
+
+// decimalValue returns the text of the number v if t is Decimal or BigInt so
+// that the values of such attributes are always represented with strings, v
+// otherwise.
+func decimalValue(t expr.DataType, v any) any {
+	if t == nil || t.Kind() != expr.DecimalKind && t.Kind() != expr.BigIntKind {
+		return v
+	}
+	if _, ok := v.(string); ok {
+		return v
+	}
+	return fmt.Sprint(v)
+}
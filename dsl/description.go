@@ -8,7 +8,8 @@ import (
 // Description sets the expression description.
 //
 // Description may appear in API, Docs, Type or Attribute.
-// Description may also appear in Response and Files.
+// Description may also appear in Response, Files, Interceptor, CustomFormat and
+// Scenario.
 //
 // Description accepts one arguments: the description string.
 //
@@ -39,12 +40,18 @@ func Description(d string) {
 		e.Description = d
 	case *expr.SchemeExpr:
 		e.Description = d
+	case *expr.InterceptorExpr:
+		e.Description = d
 	case *expr.HTTPResponseExpr:
 		e.Description = d
 	case *expr.HTTPFileServerExpr:
 		e.Description = d
 	case *expr.GRPCResponseExpr:
 		e.Description = d
+	case *expr.FormatExpr:
+		e.Description = d
+	case *expr.ScenarioExpr:
+		e.Description = d
 	default:
 		eval.IncompatibleDSL()
 	}
@@ -60,7 +60,9 @@ const (
 //
 // Error must appear in the Service (to define error responses that apply to all
 // the service methods) or Method expressions. Error may also appear under the API
-// expression to create reusable error definitions.
+// expression to create reusable error definitions. Finally Error may appear in
+// a Scenario expression in which case it takes the name of the error expected
+// by the client and optionally the error value, see Scenario.
 //
 // See Attribute for details on the Error arguments.
 //
@@ -84,6 +86,17 @@ const (
 //	    })
 //	})
 func Error(name string, args ...any) {
+	if s, ok := eval.Current().(*expr.ScenarioExpr); ok {
+		if len(args) > 1 {
+			eval.TooManyArgError()
+			return
+		}
+		s.Error = name
+		if len(args) > 0 {
+			s.ErrorValue = scenarioValue(args[0])
+		}
+		return
+	}
 	if len(args) == 0 {
 		args = []any{expr.ErrorResult}
 	}
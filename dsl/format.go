@@ -0,0 +1,91 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// CustomFormat defines a custom string format that may be used with Format
+// like the built-in formats. The values of the format are validated by a user
+// provided Go function with the signature func(string) error, the generated
+// service packages register the function with the goa package so that the
+// generated validation code calls it. The name of the format is also used as
+// the format of the corresponding OpenAPI schemas.
+//
+// CustomFormat must appear at the top level of the design, it returns the
+// format so that it can be given to Format.
+//
+// CustomFormat takes two arguments: the name of the format and a DSL function
+// that must use Validator and may use Description and Example. The examples
+// are used to generate random examples of the attributes using the format.
+//
+// Example:
+//
+//	var PhoneNumber = CustomFormat("phone", func() {
+//	    Description("North American phone number")
+//	    Validator("example.com/formats", "ValidatePhone")
+//	    Example("+15551234567")
+//	})
+//
+//	var Contact = Type("Contact", func() {
+//	    Attribute("phone", String, func() {
+//	        Format(PhoneNumber)
+//	    })
+//	})
+func CustomFormat(name string, fn func()) expr.ValidationFormat {
+	if _, ok := eval.Current().(eval.TopExpr); !ok {
+		eval.IncompatibleDSL()
+		return ""
+	}
+	if name == "" {
+		eval.ReportError("format name cannot be empty")
+		return ""
+	}
+	f := expr.ValidationFormat(name)
+	if expr.Root.Format(f) != nil {
+		eval.ReportError("format %#v defined twice", name)
+		return f
+	}
+	if new(expr.AttributeExpr).IsSupportedValidationFormat(f) {
+		eval.ReportError("format %#v is a built-in format", name)
+		return f
+	}
+	e := &expr.FormatExpr{Name: f}
+	if !eval.Execute(fn, e) {
+		return f
+	}
+	if e.Validator == "" {
+		eval.ReportError("format %#v must define a validator using Validator", name)
+		return f
+	}
+	expr.Root.Formats = append(expr.Root.Formats, e)
+	return f
+}
+
+// Validator sets the Go function used to validate the values of a custom
+// format. The function must have the signature func(string) error and return
+// an error describing why the value does not match the format.
+//
+// Validator must appear in a CustomFormat expression.
+//
+// Validator takes two arguments: the import path of the package implementing
+// the function and the name of the function.
+//
+// Example:
+//
+//	var PhoneNumber = CustomFormat("phone", func() {
+//	    Validator("example.com/formats", "ValidatePhone")
+//	})
+func Validator(pkgPath, funcName string) {
+	f, ok := eval.Current().(*expr.FormatExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if pkgPath == "" || funcName == "" {
+		eval.ReportError("validator package path and function name cannot be empty")
+		return
+	}
+	f.Package = pkgPath
+	f.Validator = funcName
+}
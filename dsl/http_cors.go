@@ -0,0 +1,182 @@
+package dsl
+
+import (
+	"strings"
+
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Origin defines the CORS policy for a given origin. The origin can be a
+// string, a string containing a single "*" wildcard or a regular expression
+// delimited with forward slashes. "*" allows all origins. Origin may be
+// specified multiple times to define policies for different origins, the
+// first policy matching the request origin applies.
+//
+// The generated HTTP server mounts a handler for the OPTIONS method on every
+// path of the service endpoints to respond to preflight requests and sets the
+// CORS response headers on all the endpoint responses.
+//
+// Origin must appear in API, API HTTP or Service HTTP expressions. Policies
+// defined at the API level apply to all the services and are evaluated after
+// the service policies.
+//
+// Origin accepts an origin string as first argument and an optional DSL
+// function as second argument. The DSL function may use AllowMethods,
+// AllowHeaders, ExposeHeaders, MaxAge and AllowCredentials to describe the
+// policy.
+//
+// Example:
+//
+//	var _ = API("calc", func() {
+//	    Origin("https://goa.design")
+//	})
+//
+//	var _ = Service("calc", func() {
+//	    HTTP(func() {
+//	        Origin("/(api|swagger)[.]goa[.]design/", func() {
+//	            AllowMethods("GET", "POST")
+//	            AllowHeaders("X-Shared-Secret")
+//	            ExposeHeaders("X-Time")
+//	            MaxAge(600)
+//	            AllowCredentials()
+//	        })
+//	    })
+//	})
+func Origin(origin string, fn ...func()) {
+	if len(fn) > 1 {
+		eval.TooManyArgError()
+		return
+	}
+	o := &expr.HTTPOriginExpr{}
+	o.Origin, o.Regexp = expr.ParseOrigin(origin)
+	switch e := eval.Current().(type) {
+	case *expr.APIExpr:
+		o.Parent = e.HTTP
+		e.HTTP.CORSOrigins = append(e.HTTP.CORSOrigins, o)
+	case *expr.RootExpr:
+		o.Parent = e.API.HTTP
+		e.API.HTTP.CORSOrigins = append(e.API.HTTP.CORSOrigins, o)
+	case *expr.HTTPServiceExpr:
+		o.Parent = e
+		e.CORSOrigins = append(e.CORSOrigins, o)
+	default:
+		eval.IncompatibleDSL()
+		return
+	}
+	if len(fn) > 0 {
+		eval.Execute(fn[0], o)
+	}
+}
+
+// AllowMethods lists the HTTP methods allowed in cross-origin requests. The
+// methods are returned in the Access-Control-Allow-Methods header of the
+// preflight responses.
+//
+// AllowMethods must appear in an Origin expression.
+//
+// AllowMethods accepts one or more HTTP methods as argument.
+//
+// Example:
+//
+//	Origin("https://goa.design", func() {
+//	    AllowMethods("GET", "POST")
+//	})
+func AllowMethods(methods ...string) {
+	o, ok := eval.Current().(*expr.HTTPOriginExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	for _, m := range methods {
+		o.Methods = append(o.Methods, strings.ToUpper(m))
+	}
+}
+
+// AllowHeaders lists the request headers allowed in cross-origin requests.
+// The headers are returned in the Access-Control-Allow-Headers header of the
+// preflight responses.
+//
+// AllowHeaders must appear in an Origin expression.
+//
+// AllowHeaders accepts one or more header names as argument.
+//
+// Example:
+//
+//	Origin("https://goa.design", func() {
+//	    AllowHeaders("Authorization", "X-Shared-Secret")
+//	})
+func AllowHeaders(headers ...string) {
+	o, ok := eval.Current().(*expr.HTTPOriginExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	o.Headers = append(o.Headers, headers...)
+}
+
+// ExposeHeaders lists the response headers exposed to the client in
+// cross-origin requests. The headers are returned in the
+// Access-Control-Expose-Headers header of the responses.
+//
+// ExposeHeaders must appear in an Origin expression.
+//
+// ExposeHeaders accepts one or more header names as argument.
+//
+// Example:
+//
+//	Origin("https://goa.design", func() {
+//	    ExposeHeaders("X-Time")
+//	})
+func ExposeHeaders(headers ...string) {
+	o, ok := eval.Current().(*expr.HTTPOriginExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	o.Exposed = append(o.Exposed, headers...)
+}
+
+// MaxAge sets the number of seconds the client may cache the preflight
+// response. The value is returned in the Access-Control-Max-Age header of the
+// preflight responses.
+//
+// MaxAge must appear in an Origin expression.
+//
+// MaxAge accepts a number of seconds as argument.
+//
+// Example:
+//
+//	Origin("https://goa.design", func() {
+//	    MaxAge(600)
+//	})
+func MaxAge(seconds uint) {
+	o, ok := eval.Current().(*expr.HTTPOriginExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	o.MaxAge = seconds
+}
+
+// AllowCredentials indicates that the responses may be exposed to the client
+// when the request credentials mode is "include". It sets the
+// Access-Control-Allow-Credentials header to "true" in the responses.
+//
+// AllowCredentials must appear in an Origin expression.
+//
+// AllowCredentials takes no argument.
+//
+// Example:
+//
+//	Origin("https://goa.design", func() {
+//	    AllowCredentials()
+//	})
+func AllowCredentials() {
+	o, ok := eval.Current().(*expr.HTTPOriginExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	o.Credentials = true
+}
@@ -0,0 +1,136 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// ServerSentEvents indicates that the HTTP endpoint streams its results using
+// Server-Sent Events (https://html.spec.whatwg.org/multipage/server-sent-events.html)
+// instead of WebSockets. The generated server writes the results to a
+// "text/event-stream" response and the generated client reads them back.
+//
+// ServerSentEvents must appear in a HTTP endpoint expression. The method must
+// define a StreamingResult and no StreamingPayload.
+//
+// ServerSentEvents accepts an optional DSL function that maps result
+// attributes to the event fields using SSEEventData, SSEEventID, SSEEventType
+// and SSEEventRetry. It may also map a payload attribute to the Last-Event-ID
+// request header using SSERequestID. The entire result is sent JSON encoded in
+// the event "data" field by default.
+//
+// Example:
+//
+//	var Event = Type("Event", func() {
+//	    Attribute("id", String)
+//	    Attribute("type", String)
+//	    Attribute("message", String)
+//	    Required("message")
+//	})
+//
+//	var _ = Service("notifier", func() {
+//	    Method("subscribe", func() {
+//	        Payload(func() {
+//	            Attribute("start_id", String)
+//	        })
+//	        StreamingResult(Event)
+//	        HTTP(func() {
+//	            GET("/events")
+//	            ServerSentEvents(func() {
+//	                SSEEventData("message")
+//	                SSEEventID("id")
+//	                SSEEventType("type")
+//	                SSERequestID("start_id")
+//	            })
+//	        })
+//	    })
+//	})
+func ServerSentEvents(fn ...func()) {
+	if len(fn) > 1 {
+		eval.TooManyArgError()
+		return
+	}
+	e, ok := eval.Current().(*expr.HTTPEndpointExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	sse := &expr.HTTPSSEExpr{Endpoint: e}
+	if len(fn) > 0 {
+		eval.Execute(fn[0], sse)
+	}
+	e.SSE = sse
+}
+
+// SSEEventData sets the name of the result attribute used to build the event
+// "data" field. String attributes are written as is, other types are JSON
+// encoded.
+//
+// SSEEventData must appear in a ServerSentEvents expression.
+//
+// SSEEventData takes one argument: the name of the result attribute.
+func SSEEventData(name string) {
+	if sse, ok := eval.Current().(*expr.HTTPSSEExpr); ok {
+		sse.DataField = name
+		return
+	}
+	eval.IncompatibleDSL()
+}
+
+// SSEEventID sets the name of the result attribute used to build the event
+// "id" field. The attribute must be a String.
+//
+// SSEEventID must appear in a ServerSentEvents expression.
+//
+// SSEEventID takes one argument: the name of the result attribute.
+func SSEEventID(name string) {
+	if sse, ok := eval.Current().(*expr.HTTPSSEExpr); ok {
+		sse.IDField = name
+		return
+	}
+	eval.IncompatibleDSL()
+}
+
+// SSEEventType sets the name of the result attribute used to build the event
+// "event" field. The attribute must be a String.
+//
+// SSEEventType must appear in a ServerSentEvents expression.
+//
+// SSEEventType takes one argument: the name of the result attribute.
+func SSEEventType(name string) {
+	if sse, ok := eval.Current().(*expr.HTTPSSEExpr); ok {
+		sse.EventField = name
+		return
+	}
+	eval.IncompatibleDSL()
+}
+
+// SSEEventRetry sets the name of the result attribute used to build the event
+// "retry" field. The attribute must be an integer and holds the client
+// reconnection delay in milliseconds.
+//
+// SSEEventRetry must appear in a ServerSentEvents expression.
+//
+// SSEEventRetry takes one argument: the name of the result attribute.
+func SSEEventRetry(name string) {
+	if sse, ok := eval.Current().(*expr.HTTPSSEExpr); ok {
+		sse.RetryField = name
+		return
+	}
+	eval.IncompatibleDSL()
+}
+
+// SSERequestID sets the name of the payload attribute initialized from the
+// Last-Event-ID request header. Clients set the header to the ID of the last
+// event they received when resuming a stream. The attribute must be a String.
+//
+// SSERequestID must appear in a ServerSentEvents expression.
+//
+// SSERequestID takes one argument: the name of the payload attribute.
+func SSERequestID(name string) {
+	if sse, ok := eval.Current().(*expr.HTTPSSEExpr); ok {
+		sse.RequestIDField = name
+		return
+	}
+	eval.IncompatibleDSL()
+}
@@ -0,0 +1,256 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Interceptor defines an interceptor. Interceptors wrap the service method
+// endpoints and may be applied on the server side with ServerInterceptor or
+// on the client side with ClientInterceptor. The generated code defines one
+// interface per side that must be implemented by the user, the methods of the
+// interfaces are given typed access to the payload and result attributes
+// listed with ReadPayload, WritePayload, ReadResult and WriteResult.
+// Interceptors are applied regardless of the transport used to expose the
+// service.
+//
+// Interceptor is a top level DSL.
+//
+// Interceptor accepts two arguments: the name of the interceptor and an
+// optional DSL function.
+//
+// Example:
+//
+//	var Cache = Interceptor("Cache", func() {
+//	    Description("Server-side cache of the record lookups")
+//	    ReadPayload(func() {
+//	        Attribute("id")
+//	    })
+//	    WriteResult(func() {
+//	        Attribute("cached_at")
+//	    })
+//	})
+//
+//	var _ = Service("records", func() {
+//	    ServerInterceptor(Cache)
+//	    Method("get", func() {
+//	        Payload(func() {
+//	            Attribute("id", String)
+//	        })
+//	        Result(Record)
+//	    })
+//	})
+func Interceptor(name string, fn ...func()) *expr.InterceptorExpr {
+	if len(fn) > 1 {
+		eval.TooManyArgError()
+		return nil
+	}
+	if _, ok := eval.Current().(eval.TopExpr); !ok {
+		eval.IncompatibleDSL()
+		return nil
+	}
+	if name == "" {
+		eval.ReportError("interceptor name cannot be empty")
+		return nil
+	}
+	if expr.Root.Interceptor(name) != nil {
+		eval.ReportError("interceptor %#v defined twice", name)
+		return nil
+	}
+	i := &expr.InterceptorExpr{Name: name}
+	if len(fn) > 0 {
+		if !eval.Execute(fn[0], i) {
+			return nil
+		}
+	}
+	expr.Root.Interceptors = append(expr.Root.Interceptors, i)
+	return i
+}
+
+// ReadPayload lists the payload attributes read by the interceptor. The
+// attribute types are taken from the payloads of the methods the interceptor
+// is applied to.
+//
+// ReadPayload must appear in an Interceptor expression.
+//
+// ReadPayload takes a single argument: a DSL function listing the attributes
+// using Attribute.
+//
+// Example:
+//
+//	ReadPayload(func() {
+//	    Attribute("id")
+//	})
+func ReadPayload(fn func()) {
+	interceptorAttributes(fn, func(i *expr.InterceptorExpr, att *expr.AttributeExpr) {
+		i.ReadPayload = att
+	})
+}
+
+// WritePayload lists the payload attributes written by the interceptor. The
+// attribute types are taken from the payloads of the methods the interceptor
+// is applied to.
+//
+// WritePayload must appear in an Interceptor expression.
+//
+// WritePayload takes a single argument: a DSL function listing the attributes
+// using Attribute.
+//
+// Example:
+//
+//	WritePayload(func() {
+//	    Attribute("tenant")
+//	})
+func WritePayload(fn func()) {
+	interceptorAttributes(fn, func(i *expr.InterceptorExpr, att *expr.AttributeExpr) {
+		i.WritePayload = att
+	})
+}
+
+// ReadResult lists the result attributes read by the interceptor. The
+// attribute types are taken from the results of the methods the interceptor is
+// applied to.
+//
+// ReadResult must appear in an Interceptor expression.
+//
+// ReadResult takes a single argument: a DSL function listing the attributes
+// using Attribute.
+//
+// Example:
+//
+//	ReadResult(func() {
+//	    Attribute("status")
+//	})
+func ReadResult(fn func()) {
+	interceptorAttributes(fn, func(i *expr.InterceptorExpr, att *expr.AttributeExpr) {
+		i.ReadResult = att
+	})
+}
+
+// WriteResult lists the result attributes written by the interceptor. The
+// attribute types are taken from the results of the methods the interceptor is
+// applied to.
+//
+// WriteResult must appear in an Interceptor expression.
+//
+// WriteResult takes a single argument: a DSL function listing the attributes
+// using Attribute.
+//
+// Example:
+//
+//	WriteResult(func() {
+//	    Attribute("cached_at")
+//	})
+func WriteResult(fn func()) {
+	interceptorAttributes(fn, func(i *expr.InterceptorExpr, att *expr.AttributeExpr) {
+		i.WriteResult = att
+	})
+}
+
+// ServerInterceptor applies the given interceptors to the server side of the
+// service or method endpoints. Interceptors defined on the service apply to
+// all the service methods and run before the method interceptors. Interceptors
+// run in the order in which they are listed.
+//
+// ServerInterceptor must appear in a Service or Method expression.
+//
+// ServerInterceptor accepts one or more interceptor expressions or interceptor
+// names as argument.
+//
+// Example:
+//
+//	var _ = Service("records", func() {
+//	    ServerInterceptor(Cache, "Audit")
+//	})
+func ServerInterceptor(interceptors ...any) {
+	is := interceptorList(interceptors)
+	if is == nil {
+		return
+	}
+	switch e := eval.Current().(type) {
+	case *expr.ServiceExpr:
+		e.ServerInterceptors = append(e.ServerInterceptors, is...)
+	case *expr.MethodExpr:
+		e.ServerInterceptors = append(e.ServerInterceptors, is...)
+	default:
+		eval.IncompatibleDSL()
+	}
+}
+
+// ClientInterceptor applies the given interceptors to the client side of the
+// service or method endpoints. Interceptors defined on the service apply to
+// all the service methods and run before the method interceptors. Interceptors
+// run in the order in which they are listed.
+//
+// ClientInterceptor must appear in a Service or Method expression.
+//
+// ClientInterceptor accepts one or more interceptor expressions or interceptor
+// names as argument.
+//
+// Example:
+//
+//	var _ = Service("records", func() {
+//	    Method("get", func() {
+//	        ClientInterceptor(Retry)
+//	    })
+//	})
+func ClientInterceptor(interceptors ...any) {
+	is := interceptorList(interceptors)
+	if is == nil {
+		return
+	}
+	switch e := eval.Current().(type) {
+	case *expr.ServiceExpr:
+		e.ClientInterceptors = append(e.ClientInterceptors, is...)
+	case *expr.MethodExpr:
+		e.ClientInterceptors = append(e.ClientInterceptors, is...)
+	default:
+		eval.IncompatibleDSL()
+	}
+}
+
+// interceptorAttributes executes fn to build the list of attributes accessed
+// by the current interceptor and calls set to record it.
+func interceptorAttributes(fn func(), set func(*expr.InterceptorExpr, *expr.AttributeExpr)) {
+	i, ok := eval.Current().(*expr.InterceptorExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	att := &expr.AttributeExpr{Type: &expr.Object{}}
+	if !eval.Execute(fn, att) {
+		return
+	}
+	set(i, att)
+}
+
+// interceptorList returns the interceptor expressions corresponding to the
+// given interceptor expressions or names. It reports an error and returns nil
+// if any of the arguments is invalid.
+func interceptorList(args []any) []*expr.InterceptorExpr {
+	if len(args) == 0 {
+		eval.ReportError("missing interceptor")
+		return nil
+	}
+	is := make([]*expr.InterceptorExpr, len(args))
+	for idx, arg := range args {
+		switch val := arg.(type) {
+		case string:
+			is[idx] = expr.Root.Interceptor(val)
+			if is[idx] == nil {
+				eval.ReportError("interceptor %q not found", val)
+				return nil
+			}
+		case *expr.InterceptorExpr:
+			if val == nil {
+				eval.InvalidArgError("interceptor or interceptor name", val)
+				return nil
+			}
+			is[idx] = val
+		default:
+			eval.InvalidArgError("interceptor or interceptor name", val)
+			return nil
+		}
+	}
+	return is
+}
@@ -0,0 +1,67 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// JSONRPC defines the JSON-RPC 2.0 transport specific properties of an API, a
+// service or a single method. JSON-RPC services are served by a single HTTP
+// endpoint that accepts POST requests whose body contains a JSON-RPC request
+// object (or a batch of request objects). The server dispatches the requests
+// to the service methods using the request "method" member. The method
+// payload is read from the request "params" member and the result is written
+// to the response "result" member.
+//
+// JSONRPC must appear in an API, a Service or a Method expression. Only the
+// methods that define a JSONRPC expression are exposed via JSON-RPC.
+//
+// JSONRPC accepts an optional argument which is the defining DSL function.
+// Path may be used in the API and service expressions to set the path of the
+// HTTP endpoint serving the JSON-RPC requests. Error may be used in the
+// service and method expressions to define the errors returned by the method.
+// Errors are mapped to JSON-RPC error objects using the standard error codes
+// (see the goa.design/goa/v3/jsonrpc package).
+//
+// Example:
+//
+//	var _ = API("calc", func() {
+//	    JSONRPC(func() {
+//	        Path("/jsonrpc") // Path of the API JSON-RPC endpoints.
+//	    })
+//	})
+//
+//	var _ = Service("calculator", func() {
+//	    JSONRPC(func() {
+//	        Path("/calc") // Path of the service JSON-RPC endpoint.
+//	    })
+//
+//	    Method("add", func() {
+//	        Payload(Operands)
+//	        Result(Int)
+//	        JSONRPC(func() {}) // Expose "add" via JSON-RPC.
+//	    })
+//	})
+func JSONRPC(fns ...func()) {
+	if len(fns) > 1 {
+		eval.TooManyArgError()
+		return
+	}
+	fn := func() {}
+	if len(fns) == 1 {
+		fn = fns[0]
+	}
+	switch actual := eval.Current().(type) {
+	case *expr.APIExpr:
+		eval.Execute(fn, expr.Root.API.JSONRPC)
+	case *expr.ServiceExpr:
+		res := expr.Root.API.JSONRPC.ServiceFor(actual)
+		res.DSLFunc = fn
+	case *expr.MethodExpr:
+		res := expr.Root.API.JSONRPC.ServiceFor(actual.Service)
+		act := res.EndpointFor(actual.Name, actual)
+		act.DSLFunc = fn
+	default:
+		eval.IncompatibleDSL()
+	}
+}
@@ -30,6 +30,26 @@ import (
 //	    Meta("type:generate:force", "service1", "service2")
 //	})
 //
+// - "type:enum" generates a constant for each value of the enum defined on the
+// user type it is set on together with String and Valid methods and a Parse
+// function. The type must be a String or integer type. The generated HTTP body
+// types and CLI flag parsing use the type and the generated proto files map
+// String types to enum declarations.
+//
+//	var Color = Type("Color", String, func() {
+//	    Enum("red", "green", "blue")
+//	    Meta("type:enum")
+//	})
+//
+// - "type:generic" set in the DSL of a type template generates a single Go
+// generic type for all the template instances, see TypeTemplate. The values
+// are the names of the Go type parameters.
+//
+//	var Page = TypeTemplate("Page", func(T expr.DataType) {
+//	    Meta("type:generic", "Item")
+//	    Attribute("items", ArrayOf(T))
+//	})
+//
 // - "struct:error:name" DEPRECATED, use ErrorName instead.
 //
 // - "struct:pkg:path" overrides where the Go type generated for the enclosing
@@ -83,6 +103,37 @@ import (
 //	     })
 //	})
 //
+// - "struct:field:time" generates time.Time fields for String attributes that
+// use the FormatDateTime or FormatDate formats and time.Duration fields for
+// attributes that use the FormatDuration format. Applicable to API definitions
+// (applies to all attributes) or individual attributes, the value "false"
+// disables the mapping. The values are encoded as strings in HTTP requests and
+// responses and as google.protobuf.Timestamp and google.protobuf.Duration
+// messages in gRPC. Attributes with default values are not mapped.
+//
+//	var _ = API("MyAPI", func() {
+//	    Meta("struct:field:time")
+//	})
+//
+//	var MyType = Type("MyType", func() {
+//	    Attribute("created_at", String, func() {
+//	        Format(FormatDateTime)
+//	        Meta("struct:field:time")
+//	    })
+//	})
+//
+// - "struct:field:encoding" sets the JSON encoding of Decimal and BigInt
+// attributes, the value "string" generates goa.DecimalString and
+// goa.BigIntString fields that encode values as JSON strings instead of JSON
+// numbers. Applicable to API definitions (applies to all attributes) or
+// individual attributes.
+//
+//	var MyType = Type("MyType", func() {
+//	    Attribute("amount", Decimal, func() {
+//	        Meta("struct:field:encoding", "string")
+//	    })
+//	})
+//
 // - "struct:field:proto" overrides the generated protobuf field type. If the
 // type is defined in a separate proto file, the last three elements define the
 // proto file import path, Go type name and Go import path respectively.
@@ -136,6 +187,57 @@ import (
 //	    Meta("protoc:include", "/usr/local/include/google/protobuf")
 //	})
 //
+// - "protoc:compiler" specifies the compiler used to produce the .pb.go and
+// _grpc.pb.go files from the generated .proto files. The value "protoc" (the
+// default) runs the protoc executable with the protoc-gen-go and
+// protoc-gen-go-grpc plugins which must be installed. The value "builtin"
+// compiles the .proto files in-process with a pure Go compiler so that code
+// generation does not depend on any external tool. Applicable to API and
+// service definitions only. The service value overrides the API value.
+//
+//	var _ = API("myapi", func() {
+//	    Meta("protoc:compiler", "builtin")
+//	})
+//
+// - "http:compression" makes the generated HTTP servers compress the response
+// bodies and decompress the request bodies and the generated HTTP clients
+// decompress the response bodies using gzip or deflate, see goahttp.Compress
+// and goahttp.NewCompressionDoer. Applicable to API and service definitions
+// only, the value "false" set on a service disables compression for the
+// service.
+//
+//	var _ = API("myapi", func() {
+//	    Meta("http:compression")
+//	})
+//
+// - "test:contract" generates contract tests for the HTTP and gRPC transports
+// in gen/http/<service>/server/contract_test.go and
+// gen/grpc/<service>/server/contract_test.go. The tests serve the service mock
+// with the generated server, call each endpoint with the generated client
+// using the design examples and check the status codes, the headers and that
+// the payloads, results and errors make the round trip. Applicable to API and
+// service definitions only, the value "false" set on a service disables the
+// tests generated for the service.
+//
+//	var _ = API("myapi", func() {
+//	    Meta("test:contract")
+//	})
+//
+// - "test:fuzz" generates Go fuzz targets for the HTTP and gRPC request
+// decoders in gen/http/<service>/server/fuzz_test.go and
+// gen/grpc/<service>/server/fuzz_test.go. The targets are seeded with the
+// requests built from the design examples by the generated client and check
+// that the decoders do not panic and that the payloads they accept are accepted
+// again once encoded by the client. Design examples that do not satisfy the
+// payload validations are not used as seeds. Streaming endpoints and endpoints
+// without payload are not fuzzed. Applicable to API and service definitions only, the
+// value "false" set on a service disables the targets generated for the
+// service.
+//
+//	var _ = API("myapi", func() {
+//	    Meta("test:fuzz")
+//	})
+//
 // - "swagger:generate" DEPRECATED, use "openapi:generate" instead.
 //
 // - "openapi:generate" specifies whether OpenAPI specification should be
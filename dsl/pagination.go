@@ -0,0 +1,130 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Paginated declares that the method results are paginated using cursors. The
+// method payload holds the cursor of the requested page and the method result
+// holds the page items and the cursor of the next page, an empty or absent
+// next cursor indicates the last page. By default the cursor attribute is
+// named "cursor", the next cursor attribute "next_cursor" and the items
+// attribute "items".
+//
+// The generated service client exposes an additional method that iterates over
+// the items of all the pages (e.g. ListAll for a method named List) and the
+// generated command line tools accept an "--all" flag that retrieves all the
+// pages. HTTP endpoints of paginated methods must map the cursor attribute to
+// a query string parameter, the generated servers set the "Link" response
+// header to the URL of the next page.
+//
+// Paginated must appear in a Method expression.
+//
+// Paginated accepts an optional DSL function that may use Cursor, NextCursor
+// and PageItems to override the default attribute names.
+//
+// Example:
+//
+//	Method("list", func() {
+//	    Payload(func() {
+//	        Attribute("page", String, "Page cursor")
+//	        Attribute("limit", Int, "Maximum number of items per page")
+//	    })
+//	    Result(func() {
+//	        Attribute("items", ArrayOf(Bottle))
+//	        Attribute("next_cursor", String)
+//	    })
+//	    Paginated(func() {
+//	        Cursor("page")
+//	    })
+//	    HTTP(func() {
+//	        GET("/")
+//	        Param("page")
+//	        Param("limit")
+//	    })
+//	})
+func Paginated(fn ...func()) {
+	if len(fn) > 1 {
+		eval.TooManyArgError()
+		return
+	}
+	m, ok := eval.Current().(*expr.MethodExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	p := &expr.PaginationExpr{
+		Cursor:     expr.DefaultPaginationCursor,
+		NextCursor: expr.DefaultPaginationNextCursor,
+		Items:      expr.DefaultPaginationItems,
+		Method:     m,
+	}
+	m.Pagination = p
+	if len(fn) > 0 {
+		eval.Execute(fn[0], p)
+	}
+}
+
+// Cursor sets the name of the payload attribute holding the cursor of the
+// requested page. The attribute must be of type String.
+//
+// Cursor must appear in a Paginated expression.
+//
+// Cursor accepts a single argument: the name of the payload attribute.
+//
+// Example:
+//
+//	Paginated(func() {
+//	    Cursor("page")
+//	})
+func Cursor(attribute string) {
+	p, ok := eval.Current().(*expr.PaginationExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	p.Cursor = attribute
+}
+
+// NextCursor sets the name of the result attribute holding the cursor of the
+// next page. The attribute must be of type String.
+//
+// NextCursor must appear in a Paginated expression.
+//
+// NextCursor accepts a single argument: the name of the result attribute.
+//
+// Example:
+//
+//	Paginated(func() {
+//	    NextCursor("next_page")
+//	})
+func NextCursor(attribute string) {
+	p, ok := eval.Current().(*expr.PaginationExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	p.NextCursor = attribute
+}
+
+// PageItems sets the name of the result attribute holding the page items. The
+// attribute must be an array.
+//
+// PageItems must appear in a Paginated expression.
+//
+// PageItems accepts a single argument: the name of the result attribute.
+//
+// Example:
+//
+//	Paginated(func() {
+//	    PageItems("bottles")
+//	})
+func PageItems(attribute string) {
+	p, ok := eval.Current().(*expr.PaginationExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	p.Items = attribute
+}
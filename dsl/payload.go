@@ -8,7 +8,9 @@ import (
 // Payload defines the data type of a method input. Payload also makes the
 // input required.
 //
-// Payload must appear in a Method expression.
+// Payload must appear in a Method expression. Payload may also appear in a
+// Scenario expression in which case it takes a single argument: the payload
+// value sent by the client, see Scenario.
 //
 // Payload takes one to three arguments. The first argument is either a type or
 // a DSL function. If the first argument is a type then an optional description
@@ -69,6 +71,14 @@ import (
 //	    })
 //	})
 func Payload(val any, args ...any) {
+	if s, ok := eval.Current().(*expr.ScenarioExpr); ok {
+		if len(args) > 0 {
+			eval.TooManyArgError()
+			return
+		}
+		s.Payload = scenarioValue(val)
+		return
+	}
 	if len(args) > 2 {
 		eval.TooManyArgError()
 		return
@@ -0,0 +1,148 @@
+package dsl
+
+import (
+	"time"
+
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// RateLimit defines a token bucket rate limit enforced by the generated
+// endpoints. Requests rejected by the limit fail with a "rate_limited" error
+// that the HTTP transport maps to 429 Too Many Requests with a Retry-After
+// header and the gRPC transport maps to ResourceExhausted with the retry
+// delay in the status details. A rate limit defined on a service applies to
+// each of its methods that does not define its own.
+//
+// RateLimit must appear in a Service or Method expression.
+//
+// RateLimit accepts three arguments: the maximum number of requests allowed
+// per period, the period and an optional DSL function that may use Burst and
+// RateLimitKey.
+//
+// Example:
+//
+//	var _ = Service("calc", func() {
+//	    RateLimit(1000, time.Minute) // Applies to all methods
+//	    Method("add", func() {
+//	        Security(APIKeyAuth)
+//	        Payload(func() {
+//	            APIKey("api_key", "key", String)
+//	            Attribute("a", Int)
+//	            Attribute("b", Int)
+//	        })
+//	        RateLimit(10, time.Second, func() {
+//	            Burst(20)           // Allow bursts of up to 20 requests
+//	            RateLimitKey("key") // One bucket per API key
+//	        })
+//	    })
+//	})
+func RateLimit(limit int, period time.Duration, fn ...func()) {
+	if len(fn) > 1 {
+		eval.TooManyArgError()
+		return
+	}
+	if limit <= 0 {
+		eval.InvalidArgError("strictly positive number of requests", limit)
+		return
+	}
+	if period <= 0 {
+		eval.InvalidArgError("strictly positive period", period)
+		return
+	}
+	r := &expr.RateLimitExpr{Limit: limit, Period: period}
+	switch e := eval.Current().(type) {
+	case *expr.ServiceExpr:
+		r.Parent = e
+		e.RateLimit = r
+	case *expr.MethodExpr:
+		r.Parent = e
+		e.RateLimit = r
+	default:
+		eval.IncompatibleDSL()
+		return
+	}
+	if len(fn) > 0 {
+		eval.Execute(fn[0], r)
+	}
+}
+
+// Burst sets the maximum number of requests that may be handled at once by a
+// rate limit. The default is the number of requests allowed per period.
+//
+// Burst must appear in a RateLimit expression.
+//
+// Burst accepts a single argument: the size of the burst.
+//
+// Example:
+//
+//	RateLimit(10, time.Second, func() {
+//	    Burst(20)
+//	})
+func Burst(n int) {
+	r, ok := eval.Current().(*expr.RateLimitExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if n <= 0 {
+		eval.InvalidArgError("strictly positive burst", n)
+		return
+	}
+	r.Burst = n
+}
+
+// RateLimitKey sets the name of the payload attribute whose value identifies
+// the token bucket used to rate limit a request, for example the attribute
+// holding the API key of a security scheme. The attribute must be of type
+// String. All the requests share the same bucket when no key is given.
+//
+// RateLimitKey must appear in a RateLimit expression.
+//
+// RateLimitKey accepts a single argument: the name of the payload attribute.
+//
+// Example:
+//
+//	RateLimit(10, time.Second, func() {
+//	    RateLimitKey("key")
+//	})
+func RateLimitKey(attribute string) {
+	r, ok := eval.Current().(*expr.RateLimitExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	r.Key = attribute
+}
+
+// MaxConcurrency sets the maximum number of requests handled concurrently by
+// the generated endpoints. Requests received while the maximum is reached fail
+// with a "rate_limited" error, see RateLimit. A limit defined on a service
+// applies to each of its methods that does not define its own.
+//
+// MaxConcurrency must appear in a Service or Method expression.
+//
+// MaxConcurrency accepts a single argument: the maximum number of concurrent
+// requests.
+//
+// Example:
+//
+//	var _ = Service("reports", func() {
+//	    Method("generate", func() {
+//	        MaxConcurrency(5)
+//	    })
+//	})
+func MaxConcurrency(n int) {
+	if n <= 0 {
+		eval.InvalidArgError("strictly positive number of requests", n)
+		return
+	}
+	switch e := eval.Current().(type) {
+	case *expr.ServiceExpr:
+		e.MaxConcurrency = n
+	case *expr.MethodExpr:
+		e.MaxConcurrency = n
+	default:
+		eval.IncompatibleDSL()
+	}
+}
@@ -7,7 +7,9 @@ import (
 
 // Result defines the data type of a method output.
 //
-// Result must appear in a Method expression.
+// Result must appear in a Method expression. Result may also appear in a
+// Scenario expression in which case it takes a single argument: the result
+// value expected by the client, see Scenario.
 //
 // Result takes one to three arguments. The first argument is either a type or a
 // DSL function. If the first argument is a type then an optional description
@@ -71,6 +73,14 @@ import (
 //	    })
 //	})
 func Result(val any, args ...any) {
+	if s, ok := eval.Current().(*expr.ScenarioExpr); ok {
+		if len(args) > 0 {
+			eval.TooManyArgError()
+			return
+		}
+		s.Result = scenarioValue(val)
+		return
+	}
 	if len(args) > 2 {
 		eval.TooManyArgError()
 		return
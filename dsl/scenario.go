@@ -0,0 +1,94 @@
+package dsl
+
+import (
+	"reflect"
+
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Scenario describes an example exchange of a method: the payload sent by the
+// client and the result or the error it should expect in return. Where
+// Example attaches example values to individual attributes, Scenario
+// describes complete requests and responses. The generated OpenAPI v3
+// specifications list the scenarios in the examples of the operation
+// parameters, request body and responses and the generated command line tools
+// use the payload of the first scenario that defines one in their usage
+// examples.
+//
+// Scenario must appear in a Method expression.
+//
+// Scenario takes two arguments: the name of the scenario which must be unique
+// in the method and the DSL describing the exchange. The DSL may use
+// Description, Payload, Result and Error. Payload and Result accept the
+// payload and result values, Error accepts the name of the expected error and
+// optionally the error value. Values of transport specific elements such as
+// path parameters, headers or bodies are derived from the payload, result and
+// error values using the transport mappings of the method. The values must be
+// compatible with the method types, error values may omit required
+// attributes.
+//
+// Example:
+//
+//	Method("show", func() {
+//	    Payload(func() {
+//	        Attribute("id", Int)
+//	        Attribute("view", String)
+//	        Required("id")
+//	    })
+//	    Result(Bottle)
+//	    Error("not_found")
+//	    Scenario("existing bottle", func() {
+//	        Description("Retrieve an existing bottle")
+//	        Payload(Val{"id": 1, "view": "tiny"})
+//	        Result(Val{"id": 1, "name": "Chateau Montelena"})
+//	    })
+//	    Scenario("missing bottle", func() {
+//	        Payload(Val{"id": 42})
+//	        Error("not_found")
+//	    })
+//	    HTTP(func() {
+//	        GET("/{id}")
+//	        Header("view:X-View")
+//	        Response(StatusOK)
+//	        Response("not_found", StatusNotFound)
+//	    })
+//	})
+func Scenario(name string, fn func()) {
+	m, ok := eval.Current().(*expr.MethodExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	s := &expr.ScenarioExpr{Name: name, Method: m}
+	if eval.Execute(fn, s) {
+		m.Scenarios = append(m.Scenarios, s)
+	}
+}
+
+// scenarioValue converts the Val values and the slices contained in v into
+// map[string]any and []any values respectively.
+func scenarioValue(v any) any {
+	switch actual := v.(type) {
+	case nil, []byte:
+		return v
+	case Val:
+		return scenarioValue(map[string]any(actual))
+	case expr.Val:
+		return scenarioValue(map[string]any(actual))
+	case map[string]any:
+		res := make(map[string]any, len(actual))
+		for k, v := range actual {
+			res[k] = scenarioValue(v)
+		}
+		return res
+	}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+		res := make([]any, rv.Len())
+		for i := range res {
+			res[i] = scenarioValue(rv.Index(i).Interface())
+		}
+		return res
+	}
+	return v
+}
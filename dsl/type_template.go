@@ -0,0 +1,117 @@
+package dsl
+
+import (
+	"slices"
+
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// TypeTemplate defines a user type template parameterized by one to three
+// types. TypeTemplate returns a function that instantiates the template: the
+// instance is a user type whose name is built from the template name and the
+// names of the arguments, for example instantiating the template "Page" with
+// the type "User" produces the type "PageOfUser" and instantiating the
+// template "Result" with the types "User" and "Error" produces the type
+// "ResultOfUserAndError". Instantiating a template multiple times with the
+// same arguments returns the same user type.
+//
+// The template DSL is executed once per instance with the template arguments
+// and may use any of the functions allowed in Type.
+//
+// The generated code defines one struct per instance. Setting the meta
+// "type:generic" in the template DSL generates a single Go generic type in
+// the service package instead and makes the instances aliases of the
+// instantiations of the generic type. The meta values, if any, are the names
+// of the Go type parameters (T by default for templates with a single
+// parameter, T1, T2 and T3 otherwise). Go generic types are only generated if
+// all the arguments of all the instances of the template are user types
+// defined in the service package.
+//
+// TypeTemplate is a top level definition.
+//
+// TypeTemplate takes two arguments: the name of the template and the
+// template DSL. The DSL is a function that accepts one to three arguments of
+// type expr.DataType.
+//
+// Example:
+//
+//	var Page = TypeTemplate("Page", func(T expr.DataType) {
+//	    Meta("type:generic")
+//	    Attribute("items", ArrayOf(T), "Page items")
+//	    Attribute("next_cursor", String, "Cursor of the next page")
+//	    Required("items")
+//	})
+//
+//	var UserPage = Page(User)
+//
+//	Method("list", func() {
+//	    Result(Page(User)) // Same type as UserPage
+//	})
+func TypeTemplate(name string, fn any) func(args ...expr.DataType) expr.UserType {
+	if _, ok := eval.Current().(eval.TopExpr); !ok {
+		eval.IncompatibleDSL()
+		return nil
+	}
+	t := &expr.TypeTemplateExpr{Name: name}
+	switch f := fn.(type) {
+	case func(expr.DataType):
+		t.Params = 1
+		t.DSLFunc = func(args ...expr.DataType) { f(args[0]) }
+	case func(expr.DataType, expr.DataType):
+		t.Params = 2
+		t.DSLFunc = func(args ...expr.DataType) { f(args[0], args[1]) }
+	case func(expr.DataType, expr.DataType, expr.DataType):
+		t.Params = 3
+		t.DSLFunc = func(args ...expr.DataType) { f(args[0], args[1], args[2]) }
+	default:
+		eval.InvalidArgError("function with one to three expr.DataType arguments", fn)
+		return nil
+	}
+	return func(args ...expr.DataType) expr.UserType {
+		return instantiate(t, args)
+	}
+}
+
+// instantiate returns the instance of the template t for the given arguments,
+// creating it if needed.
+func instantiate(t *expr.TypeTemplateExpr, args []expr.DataType) expr.UserType {
+	if len(args) != t.Params {
+		eval.ReportError("type template %#v expects %d type argument(s), got %d", t.Name, t.Params, len(args))
+		return nil
+	}
+	for _, arg := range args {
+		if arg == nil {
+			eval.ReportError("type template %#v argument cannot be nil", t.Name)
+			return nil
+		}
+	}
+	if !slices.Contains(expr.Root.TypeTemplates, t) {
+		// The instances recorded so far belong to a previous design.
+		t.Instances = nil
+		expr.Root.TypeTemplates = append(expr.Root.TypeTemplates, t)
+	}
+	if inst := t.Instance(args); inst != nil {
+		return inst.Type
+	}
+	name := t.InstanceName(args)
+	if expr.Root.UserType(name) != nil {
+		eval.ReportError("type %#v defined twice", name)
+		return nil
+	}
+	ut := &expr.UserTypeExpr{
+		TypeName:      name,
+		AttributeExpr: &expr.AttributeExpr{Type: &expr.Object{}},
+	}
+	fn := func() { t.DSLFunc(args...) }
+	if _, ok := eval.Current().(eval.TopExpr); ok {
+		ut.DSLFunc = fn
+	} else {
+		// The user type DSLs have already been executed, run the
+		// instance DSL right away.
+		eval.Execute(fn, ut.AttributeExpr)
+	}
+	t.Instances = append(t.Instances, &expr.TypeInstanceExpr{Type: ut, Template: t, Args: args})
+	expr.Root.Types = append(expr.Root.Types, ut)
+	return ut
+}
@@ -38,6 +38,17 @@ const (
 
 	// Any is the type for an arbitrary JSON value (any in Go).
 	Any = expr.Any
+
+	// Decimal is the type for an arbitrary-precision decimal number. The
+	// generated code represents values with goa.Decimal which encodes them
+	// as JSON numbers without loss of precision. Set the meta
+	// "struct:field:encoding" to "string" to encode values as JSON strings
+	// instead.
+	Decimal = expr.Decimal
+
+	// BigInt is the type for an arbitrary-precision integer. The generated
+	// code represents values with goa.BigInt, see Decimal.
+	BigInt = expr.BigInt
 )
 
 // Empty represents empty values.
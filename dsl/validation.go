@@ -51,6 +51,25 @@ const (
 
 	// FormatRFC1123 describes RFC1123 date time values.
 	FormatRFC1123 = expr.FormatRFC1123
+
+	// FormatDuration describes duration values using the syntax of Go
+	// time.ParseDuration, for example "1h30m".
+	FormatDuration = expr.FormatDuration
+
+	// FormatE164 describes ITU-T E.164 international phone numbers.
+	FormatE164 = expr.FormatE164
+
+	// FormatULID describes ULID values.
+	FormatULID = expr.FormatULID
+
+	// FormatSemver describes semantic version 2.0.0 values.
+	FormatSemver = expr.FormatSemver
+
+	// FormatCurrency describes ISO 4217 alphabetic currency codes.
+	FormatCurrency = expr.FormatCurrency
+
+	// FormatBase64 describes RFC4648 standard base64 encoded values.
+	FormatBase64 = expr.FormatBase64
 )
 
 // Enum adds a "enum" validation to the attribute.
@@ -106,7 +125,7 @@ func Enum(vals ...any) {
 				case expr.ArrayVal:
 					a.Validation.Values[i] = actual.ToSlice()
 				default:
-					a.Validation.Values[i] = actual
+					a.Validation.Values[i] = decimalValue(a.Type, actual)
 				}
 			}
 		}
@@ -141,6 +160,20 @@ func Enum(vals ...any) {
 //
 // FormatRFC1123: RFC1123 date time
 //
+// FormatDuration: Go duration, for example "1h30m"
+//
+// FormatE164: ITU-T E.164 international phone number
+//
+// FormatULID: ULID
+//
+// FormatSemver: semantic version 2.0.0
+//
+// FormatCurrency: ISO 4217 alphabetic currency code
+//
+// FormatBase64: RFC4648 standard base64 encoded value
+//
+// Format also accepts the custom formats defined with CustomFormat.
+//
 // Example:
 //
 //	Attribute("created_at", String, func() {
@@ -202,7 +235,8 @@ func ExclusiveMinimum(val any) {
 			a.Type.Kind() != expr.IntKind && a.Type.Kind() != expr.UIntKind &&
 			a.Type.Kind() != expr.Int32Kind && a.Type.Kind() != expr.UInt32Kind &&
 			a.Type.Kind() != expr.Int64Kind && a.Type.Kind() != expr.UInt64Kind &&
-			a.Type.Kind() != expr.Float32Kind && a.Type.Kind() != expr.Float64Kind {
+			a.Type.Kind() != expr.Float32Kind && a.Type.Kind() != expr.Float64Kind &&
+			a.Type.Kind() != expr.DecimalKind && a.Type.Kind() != expr.BigIntKind {
 
 			incompatibleAttributeType("exclusiveMinimum", a.Type.Name(), "a number")
 		} else {
@@ -243,7 +277,8 @@ func Minimum(val any) {
 			a.Type.Kind() != expr.IntKind && a.Type.Kind() != expr.UIntKind &&
 			a.Type.Kind() != expr.Int32Kind && a.Type.Kind() != expr.UInt32Kind &&
 			a.Type.Kind() != expr.Int64Kind && a.Type.Kind() != expr.UInt64Kind &&
-			a.Type.Kind() != expr.Float32Kind && a.Type.Kind() != expr.Float64Kind {
+			a.Type.Kind() != expr.Float32Kind && a.Type.Kind() != expr.Float64Kind &&
+			a.Type.Kind() != expr.DecimalKind && a.Type.Kind() != expr.BigIntKind {
 
 			incompatibleAttributeType("minimum", a.Type.Name(), "a number")
 		} else {
@@ -284,7 +319,8 @@ func ExclusiveMaximum(val any) {
 			a.Type.Kind() != expr.IntKind && a.Type.Kind() != expr.UIntKind &&
 			a.Type.Kind() != expr.Int32Kind && a.Type.Kind() != expr.UInt32Kind &&
 			a.Type.Kind() != expr.Int64Kind && a.Type.Kind() != expr.UInt64Kind &&
-			a.Type.Kind() != expr.Float32Kind && a.Type.Kind() != expr.Float64Kind {
+			a.Type.Kind() != expr.Float32Kind && a.Type.Kind() != expr.Float64Kind &&
+			a.Type.Kind() != expr.DecimalKind && a.Type.Kind() != expr.BigIntKind {
 
 			incompatibleAttributeType("exclusiveMaximum", a.Type.Name(), "a number")
 		} else {
@@ -325,7 +361,8 @@ func Maximum(val any) {
 			a.Type.Kind() != expr.IntKind && a.Type.Kind() != expr.UIntKind &&
 			a.Type.Kind() != expr.Int32Kind && a.Type.Kind() != expr.UInt32Kind &&
 			a.Type.Kind() != expr.Int64Kind && a.Type.Kind() != expr.UInt64Kind &&
-			a.Type.Kind() != expr.Float32Kind && a.Type.Kind() != expr.Float64Kind {
+			a.Type.Kind() != expr.Float32Kind && a.Type.Kind() != expr.Float64Kind &&
+			a.Type.Kind() != expr.DecimalKind && a.Type.Kind() != expr.BigIntKind {
 
 			incompatibleAttributeType("maximum", a.Type.Name(), "an integer or a number")
 		} else {
@@ -458,6 +495,121 @@ func Required(names ...string) {
 	}
 }
 
+// ExactlyOneOf adds a validation rule to the object attribute that requires
+// exactly one of the given attributes to be set. The attributes cannot be
+// required nor define default values.
+//
+// ExactlyOneOf must appear in an attribute, type or result type expression
+// describing an object. It may appear multiple times to define multiple
+// groups of mutually exclusive attributes.
+//
+// ExactlyOneOf takes the names of two or more attributes as arguments.
+//
+// Example:
+//
+//	var _ = Type("Contact", func() {
+//	    Attribute("email", String)
+//	    Attribute("phone", String)
+//	    ExactlyOneOf("email", "phone")
+//	})
+func ExactlyOneOf(names ...string) {
+	for _, v := range objectValidations("exactly one of") {
+		v.AddExactlyOneOf(names...)
+	}
+}
+
+// After adds a validation rule to the object attribute that requires the value
+// of the attribute named name to be after (strictly greater than) the value of
+// the attribute named other. The rule only applies when both attributes are
+// set. Both attributes must be of the same type: a number type or String with
+// the date, date-time or duration format.
+//
+// After must appear in an attribute, type or result type expression describing
+// an object.
+//
+// After takes the names of the two attributes as arguments.
+//
+// Example:
+//
+//	var _ = Type("Period", func() {
+//	    Attribute("start", String, func() {
+//	        Format(FormatDateTime)
+//	    })
+//	    Attribute("end", String, func() {
+//	        Format(FormatDateTime)
+//	    })
+//	    After("end", "start")
+//	})
+func After(name, other string) {
+	for _, v := range objectValidations("after") {
+		v.AddAfter(&expr.AfterRuleExpr{Attribute: name, Other: other})
+	}
+}
+
+// RequiredIf adds a validation rule to the object attribute that makes the
+// attributes with the given names required when the value of the attribute
+// named name is equal to val. The attribute named name must be of type String,
+// a number type or Boolean.
+//
+// RequiredIf must appear in an attribute, type or result type expression
+// describing an object.
+//
+// RequiredIf takes the name of the attribute holding the condition value, the
+// value and the names of the attributes that become required as arguments.
+//
+// Example:
+//
+//	var _ = Type("Review", func() {
+//	    Attribute("status", String, func() {
+//	        Enum("approved", "rejected")
+//	    })
+//	    Attribute("reason", String)
+//	    Required("status")
+//	    RequiredIf("status", "rejected", "reason")
+//	})
+func RequiredIf(name string, val any, names ...string) {
+	if len(names) == 0 {
+		eval.ReportError("RequiredIf requires at least one attribute name")
+		return
+	}
+	for _, v := range objectValidations("required if") {
+		v.AddRequiredIf(&expr.RequiredIfRuleExpr{Attribute: name, Value: val, Required: names})
+	}
+}
+
+// objectValidations returns the validations of the object attribute defined by
+// the current DSL and of its user type if any. It reports an error and returns
+// nil if the current DSL does not define an object attribute.
+func objectValidations(validation string) []*expr.ValidationExpr {
+	var at *expr.AttributeExpr
+	switch def := eval.Current().(type) {
+	case *expr.AttributeExpr:
+		at = def
+	case *expr.ResultTypeExpr:
+		at = def.AttributeExpr
+	case *expr.MappedAttributeExpr:
+		at = def.AttributeExpr
+	default:
+		eval.IncompatibleDSL()
+		return nil
+	}
+	if at.Type != nil && !expr.IsObject(at.Type) {
+		incompatibleAttributeType(validation, at.Type.Name(), "an object")
+		return nil
+	}
+	if at.Validation == nil {
+		at.Validation = &expr.ValidationExpr{}
+	}
+	vals := []*expr.ValidationExpr{at.Validation}
+	if ut, ok := at.Type.(expr.UserType); ok {
+		if ut.Attribute().Validation == nil {
+			ut.Attribute().Validation = &expr.ValidationExpr{}
+		}
+		vals = append(vals, ut.Attribute().Validation)
+	}
+	return vals
+}
+
 // incompatibleAttributeType reports an error for validations defined on
 // incompatible attributes (e.g. max value on string).
 func incompatibleAttributeType(validation, actual, expected string) {
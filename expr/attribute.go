@@ -2,6 +2,7 @@ package expr
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"goa.design/goa/v3/eval"
@@ -97,6 +98,18 @@ type (
 		// described at
 		// http://json-schema.org/latest/json-schema-validation.html#anchor61.
 		Required []string
+		// ExactlyOneOf lists groups of attributes of objects where exactly
+		// one attribute of each group must be set.
+		ExactlyOneOf [][]string
+		// After lists the rules that require the value of an attribute
+		// of an object to be after the value of another attribute.
+		After []*AfterRuleExpr
+		// RequiredIf lists the rules that make attributes of an object
+		// required when another attribute has a given value.
+		RequiredIf []*RequiredIfRuleExpr
+		// Variants lists the validations that apply to an object when
+		// its discriminator attribute has a given value.
+		Variants []*VariantRuleExpr
 	}
 
 	// ValidationFormat is the type used to enumerate the possible string
@@ -150,6 +163,25 @@ const (
 
 	// FormatRFC1123 describes RFC1123 date time values.
 	FormatRFC1123 = "rfc1123"
+
+	// FormatDuration describes duration values using the syntax of Go
+	// time.ParseDuration, for example "1h30m".
+	FormatDuration = "duration"
+
+	// FormatE164 describes ITU-T E.164 international phone numbers.
+	FormatE164 = "e164"
+
+	// FormatULID describes ULID values.
+	FormatULID = "ulid"
+
+	// FormatSemver describes semantic version 2.0.0 values.
+	FormatSemver = "semver"
+
+	// FormatCurrency describes ISO 4217 alphabetic currency codes.
+	FormatCurrency = "iso4217"
+
+	// FormatBase64 describes RFC4648 standard base64 encoded values.
+	FormatBase64 = "base64"
 )
 
 const (
@@ -209,6 +241,7 @@ func (a *AttributeExpr) Validate(ctx string, parent eval.Expression) *eval.Valid
 		ctx += " - "
 	}
 	verr.Merge(a.validateEnumDefault(ctx, parent))
+	verr.Merge(a.validateNullable(ctx, parent))
 	if v := a.Validation; v != nil {
 		verr.Merge(v.Validate(ctx, parent))
 	}
@@ -218,6 +251,7 @@ func (a *AttributeExpr) Validate(ctx string, parent eval.Expression) *eval.Valid
 				verr.Add(parent, `%srequired field %q does not exist in type %s`, ctx, n, a.Type.Name())
 			}
 		}
+		verr.Merge(a.validateRules(ctx, parent))
 		var pkgPath string
 		if ut, ok := a.Type.(UserType); ok {
 			if meta, ok := ut.Attribute().Meta["struct:pkg:path"]; ok {
@@ -226,13 +260,22 @@ func (a *AttributeExpr) Validate(ctx string, parent eval.Expression) *eval.Valid
 		}
 		for _, nat := range *o {
 			verr.Merge(a.validatePkgPath(pkgPath, nat.Attribute.Type))
+			if nat.Attribute.IsNullable() && a.IsRequired(nat.Name) {
+				verr.Add(parent, "%snullable field %q cannot be required", ctx, nat.Name)
+			}
 			ctx = fmt.Sprintf("field %s", nat.Name)
 			verr.Merge(nat.Attribute.Validate(ctx, parent))
 		}
 	} else if ar := AsArray(a.Type); ar != nil {
 		elemType := ar.ElemType
+		if _, ok := elemType.Meta["goa:nullable"]; ok {
+			verr.Add(parent, "%sarray elements cannot be nullable", ctx)
+		}
 		verr.Merge(elemType.Validate(ctx, a))
 	} else if u := AsUnion(a.Type); u != nil {
+		if u.Discriminator != "" {
+			verr.Merge(u.validateDiscriminator(parent))
+		}
 		for _, ut := range u.Values {
 			verr.Merge(ut.Attribute.Validate(ctx, parent))
 			if IsArray(ut.Attribute.Type) {
@@ -265,6 +308,22 @@ func (a *AttributeExpr) Validate(ctx string, parent eval.Expression) *eval.Valid
 	return verr
 }
 
+// validateNullable makes sure that nullable attributes are primitives with no
+// default value.
+func (a *AttributeExpr) validateNullable(ctx string, parent eval.Expression) *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if _, ok := a.Meta["goa:nullable"]; !ok {
+		return verr
+	}
+	if !a.IsNullable() {
+		verr.Add(parent, "%snullable attribute must be of a primitive type other than Any, got %s", ctx, a.Type.Name())
+	}
+	if a.DefaultValue != nil {
+		verr.Add(parent, "%snullable attribute cannot have a default value", ctx)
+	}
+	return verr
+}
+
 func (a *AttributeExpr) validatePkgPath(pkgPath string, t DataType) *eval.ValidationErrors {
 	verr := new(eval.ValidationErrors)
 	if ar := AsArray(t); ar != nil {
@@ -458,12 +517,62 @@ func (a *AttributeExpr) IsPrimitivePointer(attName string, useDefault bool) bool
 		return false
 	}
 	if IsPrimitive(att.Type) {
-		return att.Type.Kind() != BytesKind && att.Type.Kind() != AnyKind &&
+		return att.Type.Kind() != BytesKind && att.Type.Kind() != AnyKind && !att.IsNullable() &&
 			!a.IsRequired(attName) && (!a.HasDefaultValue(attName) || !useDefault)
 	}
 	return false
 }
 
+// IsTime returns true if the attribute is a String with the date-time, date or
+// duration format that the code generators map to time.Time or time.Duration.
+// This is the case when the "struct:field:time" meta is set on the attribute
+// or on the API and the attribute does not set it to "false" nor define a
+// default value. Nullable attributes are never mapped to Go time types.
+func (a *AttributeExpr) IsTime() bool {
+	if a == nil || a.Type != String || a.Validation == nil || a.DefaultValue != nil || a.IsNullable() {
+		return false
+	}
+	switch a.Validation.Format {
+	case FormatDateTime, FormatDate, FormatDuration:
+	default:
+		return false
+	}
+	vals, ok := a.Meta["struct:field:time"]
+	if !ok && Root.API != nil {
+		vals, ok = Root.API.Meta["struct:field:time"]
+	}
+	return ok && (len(vals) == 0 || vals[len(vals)-1] != "false")
+}
+
+// IsStringEncoded returns true if the attribute is a Decimal or BigInt whose
+// values are encoded as JSON strings rather than JSON numbers. This is the
+// case when the "struct:field:encoding" meta is set to "string" on the
+// attribute or on the API.
+func (a *AttributeExpr) IsStringEncoded() bool {
+	if a == nil || a.Type != Decimal && a.Type != BigInt {
+		return false
+	}
+	vals, ok := a.Meta["struct:field:encoding"]
+	if !ok && Root.API != nil {
+		vals, ok = Root.API.Meta["struct:field:encoding"]
+	}
+	return ok && len(vals) > 0 && vals[len(vals)-1] == "string"
+}
+
+// IsNullable returns true if the attribute is a primitive that uses the
+// Nullable DSL. The code generators map such attributes to goa.Nullable
+// fields that distinguish explicit nulls from absent values.
+func (a *AttributeExpr) IsNullable() bool {
+	if a == nil {
+		return false
+	}
+	if _, ok := a.Meta["goa:nullable"]; !ok {
+		return false
+	}
+	_, ok := a.Type.(Primitive)
+	return ok && a.Type != Any
+}
+
 // HasTag returns true if the attribute is an object that has an attribute with
 // the given tag.
 func (a *AttributeExpr) HasTag(tag string) bool {
@@ -843,6 +952,7 @@ func (v *ValidationExpr) Merge(other *ValidationExpr) {
 		v.MaxLength = other.MaxLength
 	}
 	v.AddRequired(other.Required...)
+	v.mergeRules(other)
 }
 
 // AddRequired merges the required fields into v.
@@ -888,7 +998,7 @@ func (v *ValidationExpr) HasRequiredOnly() bool {
 		(v.MaxLength != nil) {
 		return false
 	}
-	return true
+	return !v.HasRules()
 }
 
 // Dup makes a shallow dup of the validation.
@@ -909,6 +1019,10 @@ func (v *ValidationExpr) Dup() *ValidationExpr {
 		MinLength:        v.MinLength,
 		MaxLength:        v.MaxLength,
 		Required:         req,
+		ExactlyOneOf:     slices.Clone(v.ExactlyOneOf),
+		After:            slices.Clone(v.After),
+		RequiredIf:       slices.Clone(v.RequiredIf),
+		Variants:         slices.Clone(v.Variants),
 	}
 }
 
@@ -948,9 +1062,23 @@ func (v *ValidationExpr) Debug(title, prefix, indent string) {
 	if len(v.Required) > 0 {
 		fmt.Printf("%s%s- required: %v\n", prefix, indent, v.Required)
 	}
+	for _, names := range v.ExactlyOneOf {
+		fmt.Printf("%s%s- exactlyOneOf: %v\n", prefix, indent, names)
+	}
+	for _, r := range v.After {
+		fmt.Printf("%s%s- after: %s > %s\n", prefix, indent, r.Attribute, r.Other)
+	}
+	for _, r := range v.RequiredIf {
+		fmt.Printf("%s%s- requiredIf: %s == %v => %v\n", prefix, indent, r.Attribute, r.Value, r.Required)
+	}
+	for _, r := range v.Variants {
+		fmt.Printf("%s%s- variant: %s == %q => %v\n", prefix, indent, r.Attribute, r.Value, r.Validation.Required)
+	}
 }
 
-// IsSupportedValidationFormat checks if the validation format is supported by goa.
+// IsSupportedValidationFormat checks if the validation format is supported by
+// goa, either as a built-in format or as a custom format defined with the
+// CustomFormat DSL.
 func (*AttributeExpr) IsSupportedValidationFormat(vf ValidationFormat) bool {
 	switch vf {
 	case FormatDate:
@@ -981,8 +1109,20 @@ func (*AttributeExpr) IsSupportedValidationFormat(vf ValidationFormat) bool {
 		return true
 	case FormatRFC1123:
 		return true
+	case FormatDuration:
+		return true
+	case FormatE164:
+		return true
+	case FormatULID:
+		return true
+	case FormatSemver:
+		return true
+	case FormatCurrency:
+		return true
+	case FormatBase64:
+		return true
 	}
-	return false
+	return Root.Format(vf) != nil
 }
 
 // walkAttribute iterates over the given attribute, its bases and references
@@ -946,6 +946,95 @@ func TestAttributeExprHasDefaultValue(t *testing.T) {
 	}
 }
 
+func TestAttributeExprIsTime(t *testing.T) {
+	format := func(f ValidationFormat) *ValidationExpr { return &ValidationExpr{Format: f} }
+	enabled := MetaExpr{"struct:field:time": nil}
+	disabled := MetaExpr{"struct:field:time": []string{"false"}}
+	cases := map[string]struct {
+		att      *AttributeExpr
+		apiMeta  MetaExpr
+		expected bool
+	}{
+		"date-time": {
+			att:      &AttributeExpr{Type: String, Validation: format(FormatDateTime), Meta: enabled},
+			expected: true,
+		},
+		"date": {
+			att:      &AttributeExpr{Type: String, Validation: format(FormatDate), Meta: enabled},
+			expected: true,
+		},
+		"duration": {
+			att:      &AttributeExpr{Type: String, Validation: format(FormatDuration), Meta: enabled},
+			expected: true,
+		},
+		"api": {
+			att:      &AttributeExpr{Type: String, Validation: format(FormatDateTime)},
+			apiMeta:  enabled,
+			expected: true,
+		},
+		"api disabled": {
+			att:      &AttributeExpr{Type: String, Validation: format(FormatDateTime), Meta: disabled},
+			apiMeta:  enabled,
+			expected: false,
+		},
+		"no meta": {
+			att:      &AttributeExpr{Type: String, Validation: format(FormatDateTime)},
+			expected: false,
+		},
+		"other format": {
+			att:      &AttributeExpr{Type: String, Validation: format(FormatEmail), Meta: enabled},
+			expected: false,
+		},
+		"default value": {
+			att:      &AttributeExpr{Type: String, Validation: format(FormatDate), Meta: enabled, DefaultValue: "2020-01-01"},
+			expected: false,
+		},
+		"not string": {
+			att:      &AttributeExpr{Type: Bytes, Validation: format(FormatDateTime), Meta: enabled},
+			expected: false,
+		},
+		"nil": {
+			expected: false,
+		},
+	}
+
+	api := Root.API
+	defer func() { Root.API = api }()
+	for k, tc := range cases {
+		Root.API = &APIExpr{Meta: tc.apiMeta}
+		if actual := tc.att.IsTime(); tc.expected != actual {
+			t.Errorf("%s: got %#v, expected %#v", k, actual, tc.expected)
+		}
+	}
+}
+
+func TestAttributeExprIsStringEncoded(t *testing.T) {
+	str := MetaExpr{"struct:field:encoding": []string{"string"}}
+	num := MetaExpr{"struct:field:encoding": []string{"number"}}
+	cases := map[string]struct {
+		att      *AttributeExpr
+		apiMeta  MetaExpr
+		expected bool
+	}{
+		"decimal":        {att: &AttributeExpr{Type: Decimal, Meta: str}, expected: true},
+		"bigint":         {att: &AttributeExpr{Type: BigInt, Meta: str}, expected: true},
+		"api":            {att: &AttributeExpr{Type: Decimal}, apiMeta: str, expected: true},
+		"api overridden": {att: &AttributeExpr{Type: Decimal, Meta: num}, apiMeta: str, expected: false},
+		"no meta":        {att: &AttributeExpr{Type: Decimal}, expected: false},
+		"not decimal":    {att: &AttributeExpr{Type: Float64, Meta: str}, expected: false},
+		"nil":            {expected: false},
+	}
+
+	api := Root.API
+	defer func() { Root.API = api }()
+	for k, tc := range cases {
+		Root.API = &APIExpr{Meta: tc.apiMeta}
+		if actual := tc.att.IsStringEncoded(); tc.expected != actual {
+			t.Errorf("%s: got %#v, expected %#v", k, actual, tc.expected)
+		}
+	}
+}
+
 func TestValidationExprHasRequiredOnly(t *testing.T) {
 	var (
 		values           = []any{"foo"}
@@ -0,0 +1,114 @@
+package expr_test
+
+import (
+	"testing"
+
+	. "goa.design/goa/v3/dsl"
+	"goa.design/goa/v3/expr"
+)
+
+func TestTypedEnumValidation(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"string", stringTypedEnumDSL, ""},
+		{"integer", intTypedEnumDSL, ""},
+		{"not primitive", objectTypedEnumDSL, `attribute: type "Color" uses the "type:enum" meta but is not a String or integer type`},
+		{"float", floatTypedEnumDSL, `attribute: type "Ratio" uses the "type:enum" meta but is not a String or integer type`},
+		{"no enum", noEnumTypedEnumDSL, `attribute: type "Color" uses the "type:enum" meta but does not define an enum`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				expr.RunDSL(t, c.DSL)
+			} else {
+				err := expr.RunInvalidDSL(t, c.DSL)
+				if err.Error() != c.Error {
+					t.Errorf("got error %q, expected %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestIsTypedEnum(t *testing.T) {
+	expr.RunDSL(t, stringTypedEnumDSL)
+	if !expr.IsTypedEnum(expr.Root.UserType("Color")) {
+		t.Errorf("expected Color to be a typed enum")
+	}
+	if expr.IsTypedEnum(expr.Root.UserType("Shade")) {
+		t.Errorf("expected Shade not to be a typed enum")
+	}
+	if expr.IsTypedEnum(expr.String) {
+		t.Errorf("expected String not to be a typed enum")
+	}
+}
+
+var stringTypedEnumDSL = func() {
+	var Color = Type("Color", String, func() {
+		Enum("red", "green")
+		Meta("type:enum")
+	})
+	var Shade = Type("Shade", String, func() {
+		Enum("light", "dark")
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("color", Color)
+				Attribute("shade", Shade)
+			})
+		})
+	})
+}
+
+var intTypedEnumDSL = func() {
+	var Priority = Type("Priority", Int32, func() {
+		Enum(1, 2, 3)
+		Meta("type:enum")
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(Priority)
+		})
+	})
+}
+
+var objectTypedEnumDSL = func() {
+	var Color = Type("Color", func() {
+		Attribute("name", String, func() {
+			Enum("red", "green")
+		})
+		Meta("type:enum")
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(Color)
+		})
+	})
+}
+
+var floatTypedEnumDSL = func() {
+	var Ratio = Type("Ratio", Float64, func() {
+		Enum(0.5, 1.0)
+		Meta("type:enum")
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(Ratio)
+		})
+	})
+}
+
+var noEnumTypedEnumDSL = func() {
+	var Color = Type("Color", String, func() {
+		Meta("type:enum")
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(Color)
+		})
+	})
+}
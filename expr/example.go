@@ -1,12 +1,18 @@
 package expr
 
 import (
+	"encoding/base64"
 	"fmt"
 	"math"
+	"reflect"
 	"regexp"
 	"regexp/syntax"
+	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
+
+	goa "goa.design/goa/v3/pkg"
 )
 
 const (
@@ -18,6 +24,10 @@ const (
 // isn't such a value then Example computes a random value for the attribute
 // using the given random value producer.
 func (a *AttributeExpr) Example(r *ExampleGenerator) any {
+	return decimalExample(a, a.example(r))
+}
+
+func (a *AttributeExpr) example(r *ExampleGenerator) any {
 	if ex := a.ExtractUserExamples(); len(ex) > 0 {
 		// Return the last item in the slice so that examples can be overridden
 		// in the DSL. Overridden examples are always appended to the UserExamples
@@ -83,7 +93,7 @@ func (a *AttributeExpr) Example(r *ExampleGenerator) any {
 			}
 		}
 		if example == nil {
-			example = a.Type.Example(r)
+			example = ruleExample(a, a.Type.Example(r), r)
 		}
 		return example
 	}
@@ -198,6 +208,29 @@ func byFormat(a *AttributeExpr, r *ExampleGenerator) any {
 		return nil
 	}
 	format := a.Validation.Format
+	if f := Root.Format(format); f != nil {
+		if len(f.Examples) == 0 {
+			return r.Name()
+		}
+		return f.Examples[r.Int()%len(f.Examples)]
+	}
+	switch format {
+	case FormatE164:
+		return fmt.Sprintf("+1%010d", r.Int()%10000000000)
+	case FormatULID:
+		res, err := syntax.Parse(`[0-7][0-9A-HJKMNP-TV-Z]{25}`, 0)
+		if err != nil {
+			return "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+		}
+		return patgen(res, r)
+	case FormatSemver:
+		return fmt.Sprintf("%d.%d.%d", r.Int()%10, r.Int()%20, r.Int()%100)
+	case FormatCurrency:
+		currencies := []string{"USD", "EUR", "GBP", "JPY", "CHF", "CAD", "AUD"}
+		return currencies[r.Int()%len(currencies)]
+	case FormatBase64:
+		return base64.StdEncoding.EncodeToString([]byte(r.Characters(8)))
+	}
 	if res, ok := map[ValidationFormat]any{
 		FormatEmail:    r.Email(),
 		FormatHostname: r.Hostname(),
@@ -214,11 +247,12 @@ func byFormat(a *AttributeExpr, r *ExampleGenerator) any {
 			}
 			return patgen(res, r)
 		}(),
-		FormatCIDR:    "192.168.100.14/24",
-		FormatRegexp:  r.Characters(3) + ".*",
-		FormatRFC1123: time.Unix(int64(r.Int())%1454957045, 0).UTC().Format(time.RFC1123), // to obtain a "fixed" rand
-		FormatUUID:    r.UUID(),
-		FormatJSON:    `{"name":"example","email":"mail@example.com"}`,
+		FormatCIDR:     "192.168.100.14/24",
+		FormatRegexp:   r.Characters(3) + ".*",
+		FormatRFC1123:  time.Unix(int64(r.Int())%1454957045, 0).UTC().Format(time.RFC1123), // to obtain a "fixed" rand
+		FormatUUID:     r.UUID(),
+		FormatJSON:     `{"name":"example","email":"mail@example.com"}`,
+		FormatDuration: "1h30m0s",
 	}[format]; ok {
 		return res
 	}
@@ -336,6 +370,10 @@ func byMinMax(a *AttributeExpr, r *ExampleGenerator) any {
 			return r.UInt64() + uint64(min)
 		case Float32Kind:
 			return float32(sign) * (r.Float32() + float32(min))
+		case DecimalKind:
+			return strconv.FormatFloat(float64(sign)*(r.Float64()+min), 'f', -1, 64)
+		case BigIntKind:
+			return strconv.FormatInt(int64(sign)*(r.Int64()+int64(min)), 10)
 		default:
 			return float64(sign) * (r.Float64() + min)
 		}
@@ -357,6 +395,10 @@ func byMinMax(a *AttributeExpr, r *ExampleGenerator) any {
 			return r.UInt64()%uint64(delta) + uint64(min)
 		case Float32Kind:
 			return r.Float32()*float32(delta) + float32(min)
+		case DecimalKind:
+			return strconv.FormatFloat(r.Float64()*delta+min, 'f', -1, 64)
+		case BigIntKind:
+			return strconv.FormatInt(r.Int64()%int64(delta)+int64(min), 10)
 		default:
 			return r.Float64()*delta + min
 		}
@@ -376,6 +418,10 @@ func byMinMax(a *AttributeExpr, r *ExampleGenerator) any {
 		return uint64(min)
 	case Float32Kind:
 		return float32(min)
+	case DecimalKind:
+		return strconv.FormatFloat(min, 'f', -1, 64)
+	case BigIntKind:
+		return strconv.FormatInt(int64(min), 10)
 	default:
 		return min
 	}
@@ -430,3 +476,330 @@ func checkMinMaxValue(a *AttributeExpr, example any) bool {
 	}
 	return true
 }
+
+// decimalExample returns the example value v of a Decimal or BigInt attribute
+// as a goa.Decimal or goa.BigInt if the attribute values are encoded as JSON
+// numbers and as a string otherwise so that examples match the encoding.
+func decimalExample(a *AttributeExpr, v any) any {
+	if v == nil || a.Type != Decimal && a.Type != BigInt {
+		return v
+	}
+	s, ok := v.(string)
+	if !ok {
+		s = fmt.Sprint(v)
+	}
+	switch {
+	case a.IsStringEncoded():
+		return s
+	case a.Type == Decimal:
+		return goa.Decimal(s)
+	default:
+		return goa.BigInt(s)
+	}
+}
+
+// ruleExample modifies the example value ex of the object attribute a so that
+// it satisfies the cross-field validation rules of a: exactly one attribute of
+// each ExactlyOneOf rule is set, the attributes required by the RequiredIf
+// rules are set and the values of the After rule attributes are ordered.
+func ruleExample(a *AttributeExpr, ex any, r *ExampleGenerator) any {
+	m, ok := ex.(map[string]any)
+	if !ok || a.Validation == nil || !a.Validation.HasRules() || r.Randomizer == nil {
+		return ex
+	}
+	set := func(n string) bool {
+		if att := a.Find(n); att != nil {
+			if v := att.Example(r); v != nil {
+				m[n] = v
+				return true
+			}
+		}
+		return false
+	}
+	for _, rule := range a.Validation.RequiredIf {
+		if v, ok := m[rule.Attribute]; !ok || fmt.Sprint(v) != fmt.Sprint(rule.Value) {
+			continue
+		}
+		for _, n := range rule.Required {
+			if _, ok := m[n]; !ok {
+				set(n)
+			}
+		}
+	}
+	for _, names := range a.Validation.ExactlyOneOf {
+		var found bool
+		for _, n := range names {
+			if _, ok := m[n]; ok {
+				if found {
+					delete(m, n)
+				}
+				found = true
+			}
+		}
+		for _, n := range names {
+			if found {
+				break
+			}
+			found = set(n)
+		}
+	}
+	for _, rule := range a.Validation.After {
+		v, o := m[rule.Attribute], m[rule.Other]
+		if v == nil || o == nil {
+			continue
+		}
+		f := formatOf(a.Find(rule.Attribute))
+		if after, ok := isAfter(v, o, f); !ok || after {
+			continue
+		}
+		// Generate new values until they differ if they are equal and
+		// swap them if they are in the wrong order.
+		for i := 0; i < maxAttempts; i++ {
+			if after, _ := isAfter(m[rule.Other], m[rule.Attribute], f); after {
+				m[rule.Attribute], m[rule.Other] = m[rule.Other], m[rule.Attribute]
+				break
+			}
+			n := rule.Attribute
+			if i%2 == 1 {
+				n = rule.Other
+			}
+			if !set(n) {
+				break
+			}
+			if after, _ := isAfter(m[rule.Attribute], m[rule.Other], f); after {
+				break
+			}
+		}
+	}
+	return m
+}
+
+// isAfter returns true if the example value v is after the example value o as
+// defined by the After validation rule. ok is false if the values cannot be
+// compared.
+func isAfter(v, o any, f ValidationFormat) (after, ok bool) {
+	if s, isString := v.(string); isString {
+		so, isString := o.(string)
+		if !isString {
+			return false, false
+		}
+		if f == FormatDuration {
+			dv, errv := time.ParseDuration(s)
+			do, erro := time.ParseDuration(so)
+			return dv > do, errv == nil && erro == nil
+		}
+		layout := time.RFC3339
+		if f == FormatDate {
+			layout = time.DateOnly
+		}
+		tv, errv := time.Parse(layout, s)
+		to, erro := time.Parse(layout, so)
+		return tv.After(to), errv == nil && erro == nil
+	}
+	fv, okv := toFloat(v)
+	fo, oko := toFloat(o)
+	return fv > fo, okv && oko
+}
+
+// toFloat converts the numeric example value v to a float64.
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+// ValidateExample returns an error if the example value val does not satisfy
+// the validations of the attribute, of its user type if any and of its child
+// attributes including the cross-field validation rules. The code generators
+// use it to make sure that the values they initialize with examples are
+// accepted by the generated validation code. name is the name of the value
+// used in error messages.
+func (a *AttributeExpr) ValidateExample(name string, val any) error {
+	if val == nil {
+		return nil
+	}
+	if err := a.validateExampleValue(name, val); err != nil {
+		return err
+	}
+	switch actual := a.Type.(type) {
+	case UserType:
+		return actual.Attribute().ValidateExample(name, val)
+	case *Object:
+		m, ok := val.(map[string]any)
+		if !ok {
+			return nil
+		}
+		for _, nat := range *actual {
+			if err := nat.Attribute.ValidateExample(name+"."+nat.Name, m[nat.Name]); err != nil {
+				return err
+			}
+		}
+	case *Array:
+		v := reflect.ValueOf(val)
+		if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+			return nil
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := actual.ElemType.ValidateExample(fmt.Sprintf("%s[%d]", name, i), v.Index(i).Interface()); err != nil {
+				return err
+			}
+		}
+	case *Map:
+		v := reflect.ValueOf(val)
+		if v.Kind() != reflect.Map {
+			return nil
+		}
+		iter := v.MapRange()
+		for iter.Next() {
+			k := iter.Key().Interface()
+			if err := actual.KeyType.ValidateExample(fmt.Sprintf("%s key %v", name, k), k); err != nil {
+				return err
+			}
+			if err := actual.ElemType.ValidateExample(fmt.Sprintf("%s[%v]", name, k), iter.Value().Interface()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateExampleValue returns an error if the example value val does not
+// satisfy the validations defined directly on the attribute.
+func (a *AttributeExpr) validateExampleValue(ctx string, val any) error {
+	v := a.Validation
+	if v == nil {
+		return nil
+	}
+	if len(v.Values) > 0 {
+		found := false
+		for _, e := range v.Values {
+			if fmt.Sprint(e) == fmt.Sprint(val) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return goa.InvalidEnumValueError(ctx, val, v.Values)
+		}
+	}
+	if s, ok := val.(string); ok && a.Type.Kind() == StringKind {
+		// Custom formats are validated by user provided functions that
+		// are not available when generating code.
+		if v.Format != "" && Root.Format(v.Format) == nil {
+			if err := goa.ValidateFormat(ctx, s, goa.Format(v.Format)); err != nil {
+				return err
+			}
+		}
+		if v.Pattern != "" {
+			if err := goa.ValidatePattern(ctx, s, v.Pattern); err != nil {
+				return err
+			}
+		}
+	}
+	if f, ok := exampleFloat(a, val); ok {
+		switch {
+		case v.Minimum != nil && f < *v.Minimum:
+			return goa.InvalidRangeError(ctx, val, *v.Minimum, true)
+		case v.ExclusiveMinimum != nil && f <= *v.ExclusiveMinimum:
+			return goa.InvalidRangeError(ctx, val, *v.ExclusiveMinimum, true)
+		case v.Maximum != nil && f > *v.Maximum:
+			return goa.InvalidRangeError(ctx, val, *v.Maximum, false)
+		case v.ExclusiveMaximum != nil && f >= *v.ExclusiveMaximum:
+			return goa.InvalidRangeError(ctx, val, *v.ExclusiveMaximum, false)
+		}
+	}
+	if n, ok := exampleLength(val); ok {
+		switch {
+		case v.MinLength != nil && n < *v.MinLength:
+			return goa.InvalidLengthError(ctx, val, n, *v.MinLength, true)
+		case v.MaxLength != nil && n > *v.MaxLength:
+			return goa.InvalidLengthError(ctx, val, n, *v.MaxLength, false)
+		}
+	}
+	m, ok := val.(map[string]any)
+	if !ok || !IsObject(a.Type) {
+		return nil
+	}
+	obj := AsObject(a.Type)
+	for _, n := range v.Required {
+		if _, ok := m[n]; !ok && obj.Attribute(n) != nil && a.GetDefault(n) == nil {
+			return goa.MissingFieldError(n, ctx)
+		}
+	}
+	for _, names := range v.ExactlyOneOf {
+		set := make([]bool, len(names))
+		for i, n := range names {
+			_, set[i] = m[n]
+		}
+		if err := goa.ValidateExactlyOneOf(ctx, names, set...); err != nil {
+			return err
+		}
+	}
+	for _, r := range v.After {
+		val, ok := m[r.Attribute]
+		other, ok2 := m[r.Other]
+		if !ok || !ok2 {
+			continue
+		}
+		var f ValidationFormat
+		if att := obj.Attribute(r.Attribute); att != nil {
+			f = formatOf(att)
+		}
+		if after, ok := isAfter(val, other, f); ok && !after {
+			return goa.InvalidOrderError(ctx+"."+r.Attribute, val, r.Other, other)
+		}
+	}
+	for _, r := range v.RequiredIf {
+		if fmt.Sprint(m[r.Attribute]) != fmt.Sprint(r.Value) {
+			continue
+		}
+		for _, n := range r.Required {
+			if _, ok := m[n]; !ok {
+				return goa.MissingFieldError(n, ctx)
+			}
+		}
+	}
+	return nil
+}
+
+// exampleFloat returns the numeric example value val of the attribute a as a
+// float64.
+func exampleFloat(a *AttributeExpr, val any) (float64, bool) {
+	if f, ok := toFloat(val); ok {
+		return f, true
+	}
+	if a.Type.Kind() != DecimalKind && a.Type.Kind() != BigIntKind {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(fmt.Sprint(val), 64)
+	return f, err == nil
+}
+
+// exampleLength returns the length of the string, array or map example value
+// val.
+func exampleLength(val any) (int, bool) {
+	if s, ok := val.(string); ok {
+		return utf8.RuneCountInString(s), true
+	}
+	switch v := reflect.ValueOf(val); v.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return v.Len(), true
+	}
+	return 0, false
+}
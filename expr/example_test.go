@@ -9,6 +9,7 @@ import (
 
 	"goa.design/goa/v3/expr"
 	"goa.design/goa/v3/expr/testdata"
+	goa "goa.design/goa/v3/pkg"
 )
 
 func TestByPattern(t *testing.T) {
@@ -49,6 +50,36 @@ func TestByFormatUUID(t *testing.T) {
 	}
 }
 
+func TestDecimalExample(t *testing.T) {
+	min := 10.0
+	cases := []struct {
+		Name     string
+		Att      *expr.AttributeExpr
+		Expected reflect.Type
+	}{
+		{"decimal", &expr.AttributeExpr{Type: expr.Decimal}, reflect.TypeOf(goa.Decimal(""))},
+		{"bigint", &expr.AttributeExpr{Type: expr.BigInt}, reflect.TypeOf(goa.BigInt(""))},
+		{"string", &expr.AttributeExpr{Type: expr.Decimal, Meta: expr.MetaExpr{"struct:field:encoding": []string{"string"}}}, reflect.TypeOf("")},
+		{"minimum", &expr.AttributeExpr{Type: expr.Decimal, Validation: &expr.ValidationExpr{Minimum: &min}}, reflect.TypeOf(goa.Decimal(""))},
+	}
+	r := expr.NewRandom("test")
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			example := c.Att.Example(r)
+			if actual := reflect.TypeOf(example); actual != c.Expected {
+				t.Fatalf("got example of type %s, expected %s", actual, c.Expected)
+			}
+			s := reflect.ValueOf(example).String()
+			if _, err := goa.ParseDecimal(s); err != nil {
+				t.Errorf("got invalid example %q: %s", s, err)
+			}
+			if c.Att.Validation != nil && goa.CompareDecimal(s, "10") < 0 {
+				t.Errorf("got example %s, expected a value greater than or equal to 10", s)
+			}
+		})
+	}
+}
+
 func TestExample(t *testing.T) {
 	cases := []struct {
 		Name     string
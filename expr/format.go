@@ -0,0 +1,32 @@
+package expr
+
+import "fmt"
+
+type (
+	// FormatExpr describes a custom string format. Custom formats are
+	// validated by a user provided Go function that the generated code
+	// registers with the goa package so that the generated validations may
+	// use the format like any built-in format.
+	FormatExpr struct {
+		// Name is the name of the format as used in Format validations
+		// and in the OpenAPI specifications.
+		Name ValidationFormat
+		// Description is the optional description of the format.
+		Description string
+		// Package is the import path of the Go package implementing the
+		// validator function.
+		Package string
+		// Validator is the name of the Go function that validates the
+		// format values. The function must have the signature
+		// func(string) error.
+		Validator string
+		// Examples lists example values used to generate random
+		// examples of attributes that use the format.
+		Examples []string
+	}
+)
+
+// EvalName returns the generic expression name used in error messages.
+func (f *FormatExpr) EvalName() string {
+	return fmt.Sprintf("format %#v", f.Name)
+}
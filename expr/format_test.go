@@ -0,0 +1,102 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+
+	. "goa.design/goa/v3/dsl"
+	"goa.design/goa/v3/expr"
+)
+
+func TestCustomFormat(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"valid", validCustomFormatDSL, ""},
+		{"no validator", noValidatorCustomFormatDSL, `format "phone" must define a validator using Validator`},
+		{"built-in", builtInCustomFormatDSL, `format "uuid" is a built-in format`},
+		{"duplicate", duplicateCustomFormatDSL, `format "phone" defined twice`},
+		{"unknown", unknownFormatDSL, `invalid validation format "phone"`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				expr.RunDSL(t, c.DSL)
+			} else {
+				err := expr.RunInvalidDSL(t, c.DSL)
+				if !strings.Contains(err.Error(), c.Error) {
+					t.Errorf("got error %q, expected it to contain %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestCustomFormatExample(t *testing.T) {
+	expr.RunDSL(t, validCustomFormatDSL)
+	f := expr.Root.Format("phone")
+	if f == nil {
+		t.Fatal("expected format phone to be defined")
+	}
+	if f.Package != "example.com/formats" || f.Validator != "ValidatePhone" {
+		t.Errorf("got validator %s.%s, expected example.com/formats.ValidatePhone", f.Package, f.Validator)
+	}
+	att := expr.Root.Services[0].Methods[0].Payload.Find("phone")
+	ex := att.Example(expr.NewRandom("test"))
+	if ex != "+15551234567" && ex != "+33123456789" {
+		t.Errorf("got example %v, expected one of the format examples", ex)
+	}
+}
+
+var validCustomFormatDSL = func() {
+	var Phone = CustomFormat("phone", func() {
+		Description("International phone number")
+		Validator("example.com/formats", "ValidatePhone")
+		Example("+15551234567")
+		Example("+33123456789")
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("phone", String, func() {
+					Format(Phone)
+				})
+			})
+		})
+	})
+}
+
+var noValidatorCustomFormatDSL = func() {
+	CustomFormat("phone", func() {
+		Example("+15551234567")
+	})
+}
+
+var builtInCustomFormatDSL = func() {
+	CustomFormat("uuid", func() {
+		Validator("example.com/formats", "ValidateUUID")
+	})
+}
+
+var duplicateCustomFormatDSL = func() {
+	CustomFormat("phone", func() {
+		Validator("example.com/formats", "ValidatePhone")
+	})
+	CustomFormat("phone", func() {
+		Validator("example.com/formats", "ValidatePhone")
+	})
+}
+
+var unknownFormatDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("phone", String, func() {
+					Format("phone")
+				})
+			})
+		})
+	})
+}
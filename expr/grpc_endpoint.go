@@ -170,6 +170,9 @@ func (e *GRPCEndpointExpr) Validate() error {
 		hasMetadata = true
 		verr.Merge(e.Metadata.Validate("gRPC request metadata", e))
 		verr.Merge(validateMetadata(e.Metadata, e.MethodExpr.Payload, e, true))
+		verr.Merge(validatePayloadRules(e, e.MethodExpr.Payload, "request message", func(n string) bool {
+			return AsObject(e.Metadata.Type).Attribute(n) != nil
+		}))
 	}
 
 	if pobj := AsObject(e.MethodExpr.Payload.Type); pobj != nil {
@@ -322,6 +325,9 @@ func (e *GRPCEndpointExpr) Finalize() {
 				}
 			}
 		}
+		if v := e.MethodExpr.Payload.Validation; v != nil {
+			e.Request.Validation.mergeRules(v.rulesOn(AsObject(e.Request.Type)))
+		}
 		for _, nat := range *AsObject(e.Request.Type) {
 			// initialize message attribute
 			patt := DupAtt(pobj.Attribute(nat.Name))
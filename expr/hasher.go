@@ -15,6 +15,7 @@ var (
 	unionTypePrefix          = "_u_"
 	unionAttributePrefix     = "_*_"
 	unionAttributeTypePrefix = "_|_"
+	unionDiscriminatorPrefix = "_d_"
 	objectPrefix             = "_o_"
 	tagPrefix                = "+"
 	userTypeHashPrefix       = "!"
@@ -38,7 +39,7 @@ func hash(dt DataType, ignoreFields, ignoreNames, ignoreTags bool, seen map[*Obj
 		seen = make(map[*Object]*string)
 	}
 	switch dt.Kind() {
-	case BooleanKind, IntKind, Int32Kind, Int64Kind, UIntKind, UInt32Kind, UInt64Kind, Float32Kind, Float64Kind, StringKind, BytesKind, AnyKind:
+	case BooleanKind, IntKind, Int32Kind, Int64Kind, UIntKind, UInt32Kind, UInt64Kind, Float32Kind, Float64Kind, StringKind, BytesKind, AnyKind, DecimalKind, BigIntKind:
 		n := dt.Name()
 		return &n
 	case ArrayKind:
@@ -74,6 +75,9 @@ func hashUnion(u *Union, ignoreFields, ignoreNames, ignoreTags bool, seen map[*O
 		return u.Values[i].Name < u.Values[j].Name
 	})
 	h := unionTypePrefix + u.TypeName
+	if u.Discriminator != "" {
+		h += unionDiscriminatorPrefix + u.Discriminator
+	}
 	for _, nat := range sorted {
 		h += unionAttributePrefix + nat.Name + unionAttributeTypePrefix + *hash(nat.Attribute.Type, ignoreFields, ignoreNames, ignoreTags, seen)
 	}
@@ -29,6 +29,9 @@ type (
 		Services []*HTTPServiceExpr
 		// Errors lists the error HTTP responses.
 		Errors []*HTTPErrorExpr
+		// CORSOrigins lists the CORS policies that apply to all the API
+		// endpoints.
+		CORSOrigins []*HTTPOriginExpr
 	}
 )
 
@@ -12,7 +12,14 @@ import (
 // HTTP requests and responses. The object has two fields: "Type" and "Value".
 // The "Type" field is a string that indicates the name of the union type. The
 // "Value" field is a string that contains the JSON encoded union value.
+//
+// If the union defines a discriminator then the object consists of the
+// discriminator string attribute followed by the attributes of all the union
+// values instead, see DiscriminatedUnionToObject.
 func UnionToObject(att *AttributeExpr) *AttributeExpr {
+	if AsUnion(att.Type).Discriminator != "" {
+		return DiscriminatedUnionToObject(att)
+	}
 	example := att.Example(Root.API.ExampleGenerator)
 	js, err := json.Marshal(example)
 	if err != nil {
@@ -57,6 +64,104 @@ func UnionToObject(att *AttributeExpr) *AttributeExpr {
 	}
 }
 
+// DiscriminatedUnionToObject returns an object adequate to serialize the given
+// discriminated union in HTTP requests and responses. The first attribute of
+// the object is the required discriminator whose value is the name of the union
+// value type. The other attributes are the attributes of the union value types
+// in order of definition, they are all optional as only the attributes of the
+// actual value are set. The required attributes and cross-field rules of each
+// value type are recorded as variant rules that apply when the discriminator
+// selects the value type.
+func DiscriminatedUnionToObject(att *AttributeExpr) *AttributeExpr {
+	u := AsUnion(att.Type)
+	names := make([]any, len(u.Values))
+	vals := make([]string, len(u.Values))
+	for i, nat := range u.Values {
+		names[i] = nat.Name
+		vals[i] = fmt.Sprintf("- %q", nat.Name)
+	}
+	obj := Object{{
+		Name: u.Discriminator,
+		Attribute: &AttributeExpr{
+			Type:        String,
+			Description: "Union type name, one of:\n" + strings.Join(vals, "\n"),
+			Validation:  &ValidationExpr{Values: names},
+			Meta: MetaExpr{
+				"struct:tag:form": {u.Discriminator},
+				"struct:tag:json": {u.Discriminator},
+				"struct:tag:xml":  {u.Discriminator},
+			},
+		},
+	}}
+	for _, nat := range u.Values {
+		for _, f := range *AsObject(nat.Attribute.Type) {
+			if obj.Attribute(f.Name) != nil {
+				continue
+			}
+			att := DupAtt(f.Attribute)
+			if att.Meta == nil {
+				att.Meta = MetaExpr{}
+			}
+			// Only the attributes of the actual value are serialized.
+			tag := []string{f.Name, "omitempty"}
+			att.Meta["struct:tag:form"] = tag
+			att.Meta["struct:tag:json"] = tag
+			att.Meta["struct:tag:xml"] = tag
+			obj = append(obj, &NamedAttributeExpr{Name: f.Name, Attribute: att})
+		}
+	}
+	validation := &ValidationExpr{Required: []string{u.Discriminator}}
+	for _, nat := range u.Values {
+		if v := variantValidation(nat.Attribute, u.Discriminator); v != nil {
+			validation.AddVariant(&VariantRuleExpr{Attribute: u.Discriminator, Value: nat.Name, Validation: v})
+		}
+	}
+	res := &AttributeExpr{
+		Type:        &obj,
+		Description: att.Description,
+		Validation:  validation,
+	}
+	if len(u.Values) > 0 && Root.API != nil {
+		if ex, ok := u.Values[0].Attribute.Example(Root.API.ExampleGenerator).(map[string]any); ok {
+			ex[u.Discriminator] = u.Values[0].Name
+			res.UserExamples = []*ExampleExpr{{Summary: "default", Value: ex}}
+		}
+	}
+	return res
+}
+
+// variantValidation returns the required attributes and cross-field rules of
+// the given discriminated union value type, nil if there are none. The
+// discriminator is omitted from the required attributes as it is always
+// required.
+func variantValidation(att *AttributeExpr, discriminator string) *ValidationExpr {
+	var v *ValidationExpr
+	if att.Validation != nil {
+		v = att.Validation.Dup()
+	}
+	if ut, ok := att.Type.(UserType); ok && ut.Attribute().Validation != nil {
+		if v == nil {
+			v = ut.Attribute().Validation.Dup()
+		} else {
+			v.Merge(ut.Attribute().Validation)
+		}
+	}
+	if v == nil {
+		return nil
+	}
+	v.RemoveRequired(discriminator)
+	if len(v.Required) == 0 && !v.HasRules() {
+		return nil
+	}
+	return &ValidationExpr{
+		Required:     v.Required,
+		ExactlyOneOf: v.ExactlyOneOf,
+		After:        v.After,
+		RequiredIf:   v.RequiredIf,
+		Variants:     v.Variants,
+	}
+}
+
 // defaultRequestHeaderAttributes returns a map keyed by the names of the
 // payload attributes that should come from the request HTTP headers by default.
 // This includes mapping done for certain authorization schemes (basic auth,
@@ -67,6 +172,10 @@ func UnionToObject(att *AttributeExpr) *AttributeExpr {
 // single "Authorization" header is used to compute both the username and
 // password attributes).
 func defaultRequestHeaderAttributes(e *HTTPEndpointExpr) map[string]bool {
+	if e.Service.IsJSONRPC() {
+		// JSON-RPC requests carry the credentials in the params.
+		return nil
+	}
 	var requirements []*SecurityExpr
 	if e.MethodExpr.Requirements != nil {
 		requirements = e.MethodExpr.Requirements
@@ -430,7 +539,9 @@ func renameType(att *AttributeExpr, name, suffix string) {
 	rt := att.Type
 	switch rtt := rt.(type) {
 	case UserType:
-		rtt.Rename(name)
+		if !IsTypedEnum(rtt) {
+			rtt.Rename(name)
+		}
 		appendSuffix(rtt.Attribute().Type, suffix)
 	case *Object:
 		appendSuffix(rt, suffix)
@@ -442,10 +553,14 @@ func renameType(att *AttributeExpr, name, suffix string) {
 }
 
 // RemovePkgPath traverses the given data type and removes the "struct:pkg:path"
-// metadata from all the user type attributes.
+// metadata from all the user type attributes. Types that use the "type:enum"
+// meta are left untouched as the transport types refer to the Go types
+// generated for them.
 func RemovePkgPath(attr *AttributeExpr) {
 	walk(attr.Type, func(ut UserType) {
-		delete(ut.Attribute().Meta, "struct:pkg:path")
+		if !IsTypedEnum(ut) {
+			delete(ut.Attribute().Meta, "struct:pkg:path")
+		}
 	})
 	for _, pt := range attr.Bases {
 		if dt, ok := pt.(UserType); ok {
@@ -455,10 +570,13 @@ func RemovePkgPath(attr *AttributeExpr) {
 }
 
 // appendSuffix recursively traverses the given data type and appends the given
-// suffix to all the user type names.
+// suffix to all the user type names except the types that use the "type:enum"
+// meta.
 func appendSuffix(dt DataType, suffix string) {
 	walk(dt, func(ut UserType) {
-		ut.Rename(ut.Name() + suffix)
+		if !IsTypedEnum(ut) {
+			ut.Rename(ut.Name() + suffix)
+		}
 	})
 }
 
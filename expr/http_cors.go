@@ -0,0 +1,94 @@
+package expr
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// HTTPOriginExpr describes a CORS policy: the origin allowed to make
+	// cross-origin requests and the corresponding response headers.
+	HTTPOriginExpr struct {
+		// Origin is the allowed origin, either a string that may contain a
+		// single "*" wildcard or a regular expression if Regexp is true.
+		Origin string
+		// Regexp is true if Origin is a regular expression.
+		Regexp bool
+		// Methods lists the HTTP methods allowed in preflight requests.
+		Methods []string
+		// Headers lists the request headers allowed in preflight requests.
+		Headers []string
+		// Exposed lists the response headers exposed to the client.
+		Exposed []string
+		// MaxAge is the number of seconds the preflight response may be
+		// cached by the client, 0 means not set.
+		MaxAge uint
+		// Credentials is true if the response may be exposed to the
+		// client when the request credentials mode is "include".
+		Credentials bool
+		// Parent expression, one of HTTPExpr or HTTPServiceExpr.
+		Parent eval.Expression
+	}
+)
+
+// EvalName returns the generic definition name used in error messages.
+func (o *HTTPOriginExpr) EvalName() string {
+	suffix := fmt.Sprintf("origin %q", o.Origin)
+	var prefix string
+	if o.Parent != nil {
+		prefix = o.Parent.EvalName() + " "
+	}
+	return prefix + suffix
+}
+
+// Validate makes sure the origin is a valid string or regular expression and
+// that the allowed methods are valid HTTP methods.
+func (o *HTTPOriginExpr) Validate() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if o.Origin == "" {
+		verr.Add(o, "origin cannot be empty")
+	}
+	if o.Regexp {
+		if _, err := regexp.Compile(o.Origin); err != nil {
+			verr.Add(o, "invalid origin regular expression: %s", err)
+		}
+	} else if strings.Count(o.Origin, "*") > 1 {
+		verr.Add(o, "origin may contain at most one wildcard")
+	}
+	for _, m := range o.Methods {
+		switch m {
+		case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+			http.MethodDelete, http.MethodConnect, http.MethodOptions, http.MethodTrace:
+		default:
+			verr.Add(o, "invalid HTTP method %q, method must be one of GET, HEAD, POST, PUT, PATCH, DELETE, CONNECT, OPTIONS or TRACE", m)
+		}
+	}
+	return verr
+}
+
+// Origins returns the CORS policies that apply to the service endpoints: the
+// service origins followed by the API origins.
+func (svc *HTTPServiceExpr) Origins() []*HTTPOriginExpr {
+	origins := append([]*HTTPOriginExpr{}, svc.CORSOrigins...)
+	if Root.API == nil {
+		return origins
+	}
+	if api := svc.api(); api != nil {
+		origins = append(origins, api.CORSOrigins...)
+	}
+	return origins
+}
+
+// ParseOrigin returns the origin string and whether it is a regular
+// expression. Regular expressions are delimited with forward slashes, for
+// example "/.*\.goa\.design/".
+func ParseOrigin(origin string) (string, bool) {
+	if len(origin) > 1 && strings.HasPrefix(origin, "/") && strings.HasSuffix(origin, "/") {
+		return origin[1 : len(origin)-1], true
+	}
+	return origin, false
+}
@@ -0,0 +1,98 @@
+package expr_test
+
+import (
+	"testing"
+
+	. "goa.design/goa/v3/dsl"
+	"goa.design/goa/v3/expr"
+)
+
+func TestHTTPOriginValidation(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"valid", validOriginDSL, ""},
+		{"invalid", invalidOriginDSL, `service "InvalidOrigin" origin "(": invalid origin regular expression: error parsing regexp: missing closing ): ` + "`(`" + `
+service "InvalidOrigin" origin "*.*": origin may contain at most one wildcard
+service "InvalidOrigin" origin "*.*": invalid HTTP method "FETCH", method must be one of GET, HEAD, POST, PUT, PATCH, DELETE, CONNECT, OPTIONS or TRACE
+API HTTP origin "": origin cannot be empty`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				expr.RunDSL(t, c.DSL)
+			} else {
+				err := expr.RunInvalidDSL(t, c.DSL)
+				if err.Error() != c.Error {
+					t.Errorf("got error %q, expected %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestHTTPServiceOrigins(t *testing.T) {
+	expr.RunDSL(t, validOriginDSL)
+	origins := expr.Root.API.HTTP.Service("ValidOrigin").Origins()
+	if len(origins) != 2 {
+		t.Fatalf("got %d origins, expected 2", len(origins))
+	}
+	o := origins[0]
+	if o.Origin != `.*\.goa\.design` || !o.Regexp {
+		t.Errorf("got origin %q (regexp: %v), expected service regular expression", o.Origin, o.Regexp)
+	}
+	if len(o.Methods) != 2 || o.Methods[0] != "GET" || o.Methods[1] != "POST" {
+		t.Errorf("got methods %v, expected [GET POST]", o.Methods)
+	}
+	if o.MaxAge != 600 || !o.Credentials {
+		t.Errorf("got max age %d and credentials %v, expected 600 and true", o.MaxAge, o.Credentials)
+	}
+	if origins[1].Origin != "https://goa.design" || origins[1].Regexp {
+		t.Errorf("got origin %q, expected API origin", origins[1].Origin)
+	}
+}
+
+var validOriginDSL = func() {
+	API("ValidOrigin", func() {
+		Origin("https://goa.design")
+	})
+	Service("ValidOrigin", func() {
+		HTTP(func() {
+			Origin(`/.*\.goa\.design/`, func() {
+				AllowMethods("get", "POST")
+				AllowHeaders("X-Shared-Secret")
+				ExposeHeaders("X-Time")
+				MaxAge(600)
+				AllowCredentials()
+			})
+		})
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var invalidOriginDSL = func() {
+	API("InvalidOrigin", func() {
+		HTTP(func() {
+			Origin("")
+		})
+	})
+	Service("InvalidOrigin", func() {
+		HTTP(func() {
+			Origin("/(/")
+			Origin("*.*", func() {
+				AllowMethods("FETCH")
+			})
+		})
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
@@ -66,6 +66,10 @@ type (
 		MultipartRequest bool
 		// Redirect defines a redirect for the endpoint.
 		Redirect *HTTPRedirectExpr
+		// SSE defines the Server-Sent Events configuration for endpoints
+		// that stream results using Server-Sent Events instead of
+		// WebSockets.
+		SSE *HTTPSSEExpr
 		// Meta is a set of key/value pairs with semantic that is
 		// specific to each generator, see dsl.Meta.
 		Meta MetaExpr
@@ -186,6 +190,10 @@ func (e *HTTPEndpointExpr) Prepare() {
 		return
 	}
 	e.prepared = true
+	if e.Service.IsJSONRPC() && len(e.Routes) == 0 {
+		// JSON-RPC requests are all sent to the service endpoint path.
+		e.Routes = []*RouteExpr{{Method: "POST", Path: "/", Endpoint: e}}
+	}
 	if e.Headers == nil {
 		e.Headers = NewEmptyMappedAttributeExpr()
 	}
@@ -198,15 +206,15 @@ func (e *HTTPEndpointExpr) Prepare() {
 
 	// Inherit headers, cookies and params from parent service and API
 	headers := NewEmptyMappedAttributeExpr()
-	headers.Merge(Root.API.HTTP.Headers)
+	headers.Merge(e.Service.api().Headers)
 	headers.Merge(e.Service.Headers)
 
 	cookies := NewEmptyMappedAttributeExpr()
-	cookies.Merge(Root.API.HTTP.Cookies)
+	cookies.Merge(e.Service.api().Cookies)
 	cookies.Merge(e.Service.Cookies)
 
 	params := NewEmptyMappedAttributeExpr()
-	params.Merge(Root.API.HTTP.Params)
+	params.Merge(e.Service.api().Params)
 	params.Merge(e.Service.Params)
 
 	if p := e.Service.Parent(); p != nil {
@@ -247,6 +255,11 @@ func (e *HTTPEndpointExpr) Prepare() {
 	e.Cookies = cookies
 	e.Params = params
 
+	// Map the Server-Sent Events request ID to the Last-Event-ID header.
+	if e.SSE != nil {
+		e.SSE.Prepare()
+	}
+
 	// Initialize path params that are not defined explicitly in
 	for _, r := range e.Routes {
 		for _, p := range r.Params() {
@@ -296,7 +309,7 @@ func (e *HTTPEndpointExpr) Prepare() {
 			continue
 		}
 		// Lookup undefined HTTP errors in API.
-		for _, v := range Root.API.HTTP.Errors {
+		for _, v := range e.Service.api().Errors {
 			if me.Name == v.Name {
 				e.HTTPErrors = append(e.HTTPErrors, v.Dup())
 			}
@@ -316,7 +329,7 @@ func (e *HTTPEndpointExpr) Prepare() {
 			}
 		}
 		if !found {
-			for _, ae := range Root.API.HTTP.Errors {
+			for _, ae := range e.Service.api().Errors {
 				if se.Name == ae.Name {
 					e.HTTPErrors = append(e.HTTPErrors, ae.Dup())
 					break
@@ -355,6 +368,11 @@ func (e *HTTPEndpointExpr) Validate() error {
 		if e.MethodExpr.IsResultStreaming() {
 			verr.Add(e, "Endpoint cannot use SkipRequestBodyEncodeDecode when method defines a StreamingResult. Use SkipResponseBodyEncodeDecode instead.")
 		}
+		for _, i := range e.MethodExpr.Interceptors() {
+			if i.HasPayloadAccess() {
+				verr.Add(e, "Endpoint cannot use SkipRequestBodyEncodeDecode when interceptor %q accesses payload attributes.", i.Name)
+			}
+		}
 	}
 
 	// SkipResponseBodyEncodeDecode is not compatible with gRPC or WebSocket.
@@ -375,6 +393,22 @@ func (e *HTTPEndpointExpr) Validate() error {
 				verr.Add(e, "Endpoint cannot use SkipResponseBodyEncodeDecode when method result type defines multiple views.")
 			}
 		}
+		for _, i := range e.MethodExpr.Interceptors() {
+			if i.HasResultAccess() {
+				verr.Add(e, "Endpoint cannot use SkipResponseBodyEncodeDecode when interceptor %q accesses result attributes.", i.Name)
+			}
+		}
+	}
+
+	// Server-Sent Events require a streaming result.
+	if e.SSE != nil {
+		verr.Merge(e.SSE.Validate())
+	}
+
+	// JSON-RPC endpoints carry the entire payload and result in the
+	// request and response bodies.
+	if e.Service.IsJSONRPC() {
+		verr.Merge(e.validateJSONRPC())
 	}
 
 	// Redirect is not compatible with Response.
@@ -470,6 +504,22 @@ func (e *HTTPEndpointExpr) Validate() error {
 	// Make sure parameters and headers use compatible types
 	verr.Merge(e.validateParams())
 	verr.Merge(e.validateHeadersAndCookies())
+	verr.Merge(validatePayloadRules(e, e.MethodExpr.Payload, "request body", func(n string) bool {
+		for _, ma := range []*MappedAttributeExpr{e.Params, e.Headers, e.Cookies} {
+			if ma != nil && AsObject(ma.Type) != nil && AsObject(ma.Type).Attribute(n) != nil {
+				return true
+			}
+		}
+		return false
+	}))
+	if p := e.MethodExpr.Pagination; p != nil {
+		if e.QueryParams().Find(p.Cursor) == nil {
+			verr.Add(e, "Pagination cursor %q must be mapped to a query string parameter.", p.Cursor)
+		}
+		if e.SkipResponseBodyEncodeDecode {
+			verr.Add(e, "Endpoint cannot use SkipResponseBodyEncodeDecode when method is paginated.")
+		}
+	}
 
 	// Validate body attribute (required fields exist etc.)
 	if e.Body != nil {
@@ -680,7 +730,10 @@ func (e *HTTPEndpointExpr) Finalize() {
 					field = TaggedAttribute(e.MethodExpr.Payload, "security:accesstoken")
 				}
 				sch.Name, sch.In = findKey(e, field)
-				if sch.Name == "" {
+				if e.Service.IsJSONRPC() {
+					// JSON-RPC requests carry the credentials in the params.
+					sch.Name, sch.In = field, "body"
+				} else if sch.Name == "" {
 					// Initialize Authorization header implicitly defined via
 					// security DSL if mapping isn't explicit.
 					sch.Name = "Authorization"
@@ -782,14 +835,18 @@ func (e *HTTPEndpointExpr) validateParams() *eval.ValidationErrors {
 		switch e.MethodExpr.Payload.Type.(type) {
 		case *Object, UserType:
 			WalkMappedAttr(pparams, func(name, _ string, _ *AttributeExpr) error { // nolint: errcheck
-				if e.MethodExpr.Payload.Find(name) == nil {
+				if att := e.MethodExpr.Payload.Find(name); att == nil {
 					verr.Add(e, "Path parameter %q not found in payload.", name)
+				} else if att.IsNullable() {
+					verr.Add(e, "Path parameter %q cannot be nullable, nullable attributes must be mapped to the request body.", name)
 				}
 				return nil
 			})
 			WalkMappedAttr(qparams, func(name, _ string, _ *AttributeExpr) error { // nolint: errcheck
-				if e.MethodExpr.Payload.Find(name) == nil {
+				if att := e.MethodExpr.Payload.Find(name); att == nil {
 					verr.Add(e, "Query string parameter %q not found in payload.", name)
+				} else if att.IsNullable() {
+					verr.Add(e, "Query string parameter %q cannot be nullable, nullable attributes must be mapped to the request body.", name)
 				}
 				return nil
 			})
@@ -847,8 +904,10 @@ func (e *HTTPEndpointExpr) validateHeadersAndCookies() *eval.ValidationErrors {
 	case *Object, UserType:
 		hasBasicAuth := TaggedAttribute(e.MethodExpr.Payload, "security:username") != ""
 		WalkMappedAttr(headers, func(name, elem string, _ *AttributeExpr) error { // nolint: errcheck
-			if e.MethodExpr.Payload.Find(name) == nil {
+			if att := e.MethodExpr.Payload.Find(name); att == nil {
 				verr.Add(e, "header %q not found in payload.", name)
+			} else if att.IsNullable() {
+				verr.Add(e, "header %q cannot be nullable, nullable attributes must be mapped to the request body.", name)
 			}
 			if elem == "Authorization" && hasBasicAuth {
 				// BasicAuth security implicitly sets the Authorization header. If any
@@ -859,8 +918,10 @@ func (e *HTTPEndpointExpr) validateHeadersAndCookies() *eval.ValidationErrors {
 			return nil
 		})
 		WalkMappedAttr(cookies, func(name, _ string, _ *AttributeExpr) error { // nolint: errcheck
-			if e.MethodExpr.Payload.Find(name) == nil {
+			if att := e.MethodExpr.Payload.Find(name); att == nil {
 				verr.Add(e, "cookie %q not found in payload.", name)
+			} else if att.IsNullable() {
+				verr.Add(e, "cookie %q cannot be nullable, nullable attributes must be mapped to the request body.", name)
 			}
 			return nil
 		})
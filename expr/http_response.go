@@ -194,6 +194,8 @@ func (r *HTTPResponseExpr) Validate(e *HTTPEndpointExpr) *eval.ValidationErrors
 					}
 				} else if !IsPrimitive(t) {
 					verr.Add(e, "attribute %q used in HTTP headers must be a primitive type or an array of primitive types.", h.Name)
+				} else if e.MethodExpr.Result.Find(h.Name).IsNullable() {
+					verr.Add(e, "attribute %q used in HTTP headers cannot be nullable.", h.Name)
 				}
 			}
 		} else if len(*AsObject(r.Headers.Type)) > 1 {
@@ -217,6 +219,8 @@ func (r *HTTPResponseExpr) Validate(e *HTTPEndpointExpr) *eval.ValidationErrors
 				}
 				if !IsPrimitive(t) {
 					verr.Add(e, "attribute %q used in HTTP cookies must be a primitive type.", c.Name)
+				} else if e.MethodExpr.Result.Find(c.Name).IsNullable() {
+					verr.Add(e, "attribute %q used in HTTP cookies cannot be nullable.", c.Name)
 				}
 			}
 		} else if len(*AsObject(r.Cookies.Type)) > 1 {
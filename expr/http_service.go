@@ -40,6 +40,9 @@ type (
 		HTTPErrors []*HTTPErrorExpr
 		// FileServers is the list of static asset serving endpoints
 		FileServers []*HTTPFileServerExpr
+		// CORSOrigins lists the CORS policies that apply to the service
+		// endpoints.
+		CORSOrigins []*HTTPOriginExpr
 		// Meta is a set of key/value pairs with semantic that is
 		// specific to each generator.
 		Meta MetaExpr
@@ -104,7 +107,7 @@ func (svc *HTTPServiceExpr) CanonicalEndpoint() *HTTPEndpointExpr {
 // API and parent service base paths as needed.
 func (svc *HTTPServiceExpr) FullPaths() []string {
 	if len(svc.Paths) == 0 {
-		return []string{path.Join(Root.API.HTTP.Path)}
+		return []string{path.Join(svc.api().Path)}
 	}
 	var paths []string
 	for _, p := range svc.Paths {
@@ -127,12 +130,13 @@ func (svc *HTTPServiceExpr) FullPaths() []string {
 				}
 			}
 		} else {
-			basePaths = []string{Root.API.HTTP.Path}
+			basePaths = []string{svc.api().Path}
 		}
 		for _, base := range basePaths {
 			v := httppath.Clean(path.Join(base, p))
-			// path has trailing slash
-			if strings.HasSuffix(p, "/") {
+			// path has trailing slash, JSON-RPC services are served at
+			// the API path when they do not define one.
+			if strings.HasSuffix(p, "/") && (p != "/" || !svc.IsJSONRPC()) {
 				v += "/"
 			}
 			paths = append(paths, v)
@@ -144,7 +148,7 @@ func (svc *HTTPServiceExpr) FullPaths() []string {
 // Parent returns the parent service if any, nil otherwise.
 func (svc *HTTPServiceExpr) Parent() *HTTPServiceExpr {
 	if svc.ParentName != "" {
-		if parent := Root.API.HTTP.Service(svc.ParentName); parent != nil {
+		if parent := svc.api().Service(svc.ParentName); parent != nil {
 			return parent
 		}
 	}
@@ -181,7 +185,7 @@ func (svc *HTTPServiceExpr) Prepare() {
 			}
 		}
 		if !found {
-			for _, herr := range Root.API.HTTP.Errors {
+			for _, herr := range svc.api().Errors {
 				if herr.Name == err.Name {
 					svc.HTTPErrors = append(svc.HTTPErrors, herr.Dup())
 				}
@@ -203,7 +207,7 @@ func (svc *HTTPServiceExpr) Validate() error {
 		verr.Merge(svc.Headers.Validate("headers", svc))
 	}
 	if n := svc.ParentName; n != "" {
-		if p := Root.API.HTTP.Service(n); p == nil {
+		if p := svc.api().Service(n); p == nil {
 			verr.Add(svc, "Parent service %s not found", n)
 		} else {
 			if p.CanonicalEndpoint() == nil {
@@ -224,7 +228,7 @@ func (svc *HTTPServiceExpr) Validate() error {
 	for _, er := range svc.HTTPErrors {
 		verr.Merge(er.Validate())
 	}
-	for _, er := range Root.API.HTTP.Errors {
+	for _, er := range svc.api().Errors {
 		// This may result in the same error being validated multiple
 		// times however service is the top level expression being
 		// walked and errors cannot be walked until all expressions have
@@ -234,6 +238,12 @@ func (svc *HTTPServiceExpr) Validate() error {
 		verr.Merge(er.Validate())
 	}
 
+	// Validate CORS policies, see above for why API origins are validated
+	// here.
+	for _, o := range svc.Origins() {
+		verr.Merge(o.Validate())
+	}
+
 	return verr
 }
 
@@ -0,0 +1,120 @@
+package expr
+
+import (
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// HTTPSSEExpr describes a HTTP endpoint that streams its results using
+	// Server-Sent Events instead of WebSockets.
+	HTTPSSEExpr struct {
+		// DataField is the name of the result attribute used to build the
+		// event "data" field. The entire result is used if empty.
+		DataField string
+		// IDField is the name of the result attribute used to build the
+		// event "id" field if any.
+		IDField string
+		// EventField is the name of the result attribute used to build the
+		// event "event" field if any.
+		EventField string
+		// RetryField is the name of the result attribute used to build the
+		// event "retry" field if any.
+		RetryField string
+		// RequestIDField is the name of the payload attribute initialized
+		// with the value of the "Last-Event-ID" request header if any.
+		RequestIDField string
+		// Endpoint is the parent endpoint.
+		Endpoint *HTTPEndpointExpr
+	}
+)
+
+// EvalName returns the generic expression name used in error messages.
+func (s *HTTPSSEExpr) EvalName() string {
+	suffix := "server-sent events"
+	var prefix string
+	if s.Endpoint != nil {
+		prefix = s.Endpoint.EvalName() + " "
+	}
+	return prefix + suffix
+}
+
+// Prepare maps the request ID attribute to the Last-Event-ID header unless
+// it is mapped explicitly already.
+func (s *HTTPSSEExpr) Prepare() {
+	if s.RequestIDField == "" {
+		return
+	}
+	e := s.Endpoint
+	if e.Headers.Find(s.RequestIDField) != nil {
+		return
+	}
+	att := e.MethodExpr.Payload.Find(s.RequestIDField)
+	if att == nil {
+		return // Validate reports the error
+	}
+	e.Headers.Merge(NewMappedAttributeExpr(&AttributeExpr{
+		Type: &Object{{Name: s.RequestIDField + ":Last-Event-ID", Attribute: &AttributeExpr{Type: att.Type}}},
+	}))
+}
+
+// Validate makes sure the endpoint streams results but not payloads and that
+// the event fields refer to existing result attributes of the proper types.
+func (s *HTTPSSEExpr) Validate() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	m := s.Endpoint.MethodExpr
+	if m.Stream != ServerStreamKind {
+		verr.Add(s, "server-sent events require the method to define a StreamingResult and no StreamingPayload")
+		return verr
+	}
+	if s.Endpoint.SkipResponseBodyEncodeDecode {
+		verr.Add(s, "server-sent events cannot be used with SkipResponseBodyEncodeDecode")
+	}
+	if rt, ok := m.Result.Type.(*ResultTypeExpr); ok && len(rt.Views) > 1 {
+		if _, ok := m.Result.Meta["view"]; !ok {
+			verr.Add(s, "server-sent events require an explicit view when the result type defines multiple views, use View to set one")
+		}
+	}
+	fields := map[string]string{
+		"data":  s.DataField,
+		"id":    s.IDField,
+		"event": s.EventField,
+		"retry": s.RetryField,
+	}
+	for _, kind := range []string{"data", "id", "event", "retry"} {
+		name := fields[kind]
+		if name == "" {
+			continue
+		}
+		if !IsObject(m.Result.Type) {
+			verr.Add(s, "result type must be an object to define the event %s field", kind)
+			continue
+		}
+		att := m.Result.Find(name)
+		if att == nil {
+			verr.Add(s, "event %s field %q is not an attribute of the method result", kind, name)
+			continue
+		}
+		switch kind {
+		case "id", "event":
+			if att.Type != String {
+				verr.Add(s, "event %s field %q must be a String", kind, name)
+			}
+		case "retry":
+			switch att.Type.Kind() {
+			case IntKind, Int32Kind, Int64Kind, UIntKind, UInt32Kind, UInt64Kind:
+			default:
+				verr.Add(s, "event retry field %q must be an integer", name)
+			}
+		}
+	}
+	if s.RequestIDField != "" {
+		att := m.Payload.Find(s.RequestIDField)
+		switch {
+		case att == nil:
+			verr.Add(s, "request ID field %q is not an attribute of the method payload", s.RequestIDField)
+		case att.Type != String:
+			verr.Add(s, "request ID field %q must be a String", s.RequestIDField)
+		}
+	}
+	return verr
+}
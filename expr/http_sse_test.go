@@ -0,0 +1,103 @@
+package expr_test
+
+import (
+	"testing"
+
+	. "goa.design/goa/v3/dsl"
+	"goa.design/goa/v3/expr"
+)
+
+func TestHTTPSSEValidation(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"valid", validSSEDSL, ""},
+		{"not streaming", notStreamingSSEDSL, `service "NotStreamingSSE" HTTP endpoint "Method" server-sent events: server-sent events require the method to define a StreamingResult and no StreamingPayload`},
+		{"invalid fields", invalidFieldsSSEDSL, `service "InvalidFieldsSSE" HTTP endpoint "Method" server-sent events: event data field "missing" is not an attribute of the method result
+service "InvalidFieldsSSE" HTTP endpoint "Method" server-sent events: event id field "count" must be a String
+service "InvalidFieldsSSE" HTTP endpoint "Method" server-sent events: event retry field "name" must be an integer
+service "InvalidFieldsSSE" HTTP endpoint "Method" server-sent events: request ID field "start" is not an attribute of the method payload`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				expr.RunDSL(t, c.DSL)
+			} else {
+				err := expr.RunInvalidDSL(t, c.DSL)
+				if err.Error() != c.Error {
+					t.Errorf("got error %q, expected %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestHTTPSSEPrepare(t *testing.T) {
+	expr.RunDSL(t, validSSEDSL)
+	e := expr.Root.API.HTTP.Service("ValidSSE").Endpoint("Method")
+	att := e.Headers.Find("start_id")
+	if att == nil {
+		t.Fatal("expected start_id to be mapped to a header")
+	}
+	if name := e.Headers.ElemName("start_id"); name != "Last-Event-ID" {
+		t.Errorf("got header %q, expected %q", name, "Last-Event-ID")
+	}
+}
+
+var validSSEDSL = func() {
+	Service("ValidSSE", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("start_id", String)
+			})
+			StreamingResult(func() {
+				Attribute("id", String)
+				Attribute("message", String)
+				Attribute("retry", Int)
+			})
+			HTTP(func() {
+				GET("/")
+				ServerSentEvents(func() {
+					SSEEventData("message")
+					SSEEventID("id")
+					SSEEventRetry("retry")
+					SSERequestID("start_id")
+				})
+			})
+		})
+	})
+}
+
+var notStreamingSSEDSL = func() {
+	Service("NotStreamingSSE", func() {
+		Method("Method", func() {
+			Result(String)
+			HTTP(func() {
+				GET("/")
+				ServerSentEvents()
+			})
+		})
+	})
+}
+
+var invalidFieldsSSEDSL = func() {
+	Service("InvalidFieldsSSE", func() {
+		Method("Method", func() {
+			StreamingResult(func() {
+				Attribute("name", String)
+				Attribute("count", Int)
+			})
+			HTTP(func() {
+				GET("/")
+				ServerSentEvents(func() {
+					SSEEventData("missing")
+					SSEEventID("count")
+					SSEEventRetry("name")
+					SSERequestID("start")
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,171 @@
+package expr
+
+import (
+	"fmt"
+
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// InterceptorExpr describes an interceptor definition. Interceptors
+	// are applied to the method endpoints of the services that reference
+	// them and are given typed access to the payload and result attributes
+	// listed in the design.
+	InterceptorExpr struct {
+		// Name is the name of the interceptor.
+		Name string
+		// Description is the optional description of the interceptor.
+		Description string
+		// ReadPayload lists the payload attributes read by the interceptor.
+		ReadPayload *AttributeExpr
+		// WritePayload lists the payload attributes written by the
+		// interceptor.
+		WritePayload *AttributeExpr
+		// ReadResult lists the result attributes read by the interceptor.
+		ReadResult *AttributeExpr
+		// WriteResult lists the result attributes written by the
+		// interceptor.
+		WriteResult *AttributeExpr
+	}
+)
+
+// EvalName returns the generic expression name used in error messages.
+func (i *InterceptorExpr) EvalName() string {
+	return fmt.Sprintf("interceptor %#v", i.Name)
+}
+
+// HasPayloadAccess returns true if the interceptor reads or writes payload
+// attributes.
+func (i *InterceptorExpr) HasPayloadAccess() bool {
+	return hasAttributes(i.ReadPayload) || hasAttributes(i.WritePayload)
+}
+
+// HasResultAccess returns true if the interceptor reads or writes result
+// attributes.
+func (i *InterceptorExpr) HasResultAccess() bool {
+	return hasAttributes(i.ReadResult) || hasAttributes(i.WriteResult)
+}
+
+// validateMethod makes sure the interceptor can be applied to the given
+// method: the attributes it accesses must exist in the method payload and
+// result.
+func (i *InterceptorExpr) validateMethod(m *MethodExpr) *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	check := func(kind string, att, parent *AttributeExpr) {
+		if !hasAttributes(att) {
+			return
+		}
+		if !IsObject(parent.Type) {
+			verr.Add(m, "interceptor %q accesses %s attributes but the method %s is not an object", i.Name, kind, kind)
+			return
+		}
+		_, viewed := parent.Type.(*ResultTypeExpr)
+		for _, nat := range *AsObject(att.Type) {
+			f := parent.Find(nat.Name)
+			if f == nil {
+				verr.Add(m, "interceptor %q accesses %s attribute %q which is not defined in the method %s", i.Name, kind, nat.Name, kind)
+				continue
+			}
+			if viewed && !IsPrimitive(f.Type) {
+				verr.Add(m, "interceptor %q accesses result attribute %q which is not a primitive, interceptors can only access primitive attributes of result types", i.Name, nat.Name)
+			}
+		}
+	}
+	check("payload", i.ReadPayload, m.Payload)
+	check("payload", i.WritePayload, m.Payload)
+	if i.HasResultAccess() && (m.Stream == ServerStreamKind || m.Stream == BidirectionalStreamKind) {
+		verr.Add(m, "interceptor %q accesses result attributes but the method streams its results", i.Name)
+		return verr
+	}
+	check("result", i.ReadResult, m.Result)
+	check("result", i.WriteResult, m.Result)
+	return verr
+}
+
+// validateInterceptors makes sure that the attributes accessed by the
+// interceptors applied to the service methods have the same types across all
+// methods so that the generated accessor interfaces are consistent.
+func (s *ServiceExpr) validateInterceptors() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	type access struct {
+		method string
+		hash   string
+	}
+	seen := make(map[string]access)
+	check := func(i *InterceptorExpr, m *MethodExpr, kind string, att, parent *AttributeExpr) {
+		if !hasAttributes(att) || !IsObject(parent.Type) {
+			return
+		}
+		for _, nat := range *AsObject(att.Type) {
+			f := parent.Find(nat.Name)
+			if f == nil {
+				continue
+			}
+			key := i.Name + "/" + kind + "/" + nat.Name
+			h := Hash(f.Type, false, false, true)
+			if a, ok := seen[key]; ok {
+				if a.hash != h {
+					verr.Add(s, "interceptor %q accesses %s attribute %q with different types in methods %q and %q", i.Name, kind, nat.Name, a.method, m.Name)
+				}
+				continue
+			}
+			seen[key] = access{m.Name, h}
+		}
+	}
+	for _, m := range s.Methods {
+		for _, i := range m.Interceptors() {
+			check(i, m, "payload", i.ReadPayload, m.Payload)
+			check(i, m, "payload", i.WritePayload, m.Payload)
+			check(i, m, "result", i.ReadResult, m.Result)
+			check(i, m, "result", i.WriteResult, m.Result)
+		}
+	}
+	return verr
+}
+
+// Interceptors returns the list of server and client interceptors applied to
+// the method without duplicates.
+func (m *MethodExpr) Interceptors() []*InterceptorExpr {
+	var res []*InterceptorExpr
+	seen := make(map[string]struct{})
+	for _, i := range append(m.ServerInterceptors, m.ClientInterceptors...) {
+		if _, ok := seen[i.Name]; ok {
+			continue
+		}
+		seen[i.Name] = struct{}{}
+		res = append(res, i)
+	}
+	return res
+}
+
+// prepareInterceptors prepends the service interceptors to the method
+// interceptors.
+func (m *MethodExpr) prepareInterceptors() {
+	merge := func(svc, meth []*InterceptorExpr) []*InterceptorExpr {
+		var res []*InterceptorExpr
+		for _, i := range svc {
+			found := false
+			for _, mi := range meth {
+				if mi.Name == i.Name {
+					found = true
+					break
+				}
+			}
+			if !found {
+				res = append(res, i)
+			}
+		}
+		return append(res, meth...)
+	}
+	m.ServerInterceptors = merge(m.Service.ServerInterceptors, m.ServerInterceptors)
+	m.ClientInterceptors = merge(m.Service.ClientInterceptors, m.ClientInterceptors)
+}
+
+// hasAttributes returns true if att is an object with at least one attribute.
+func hasAttributes(att *AttributeExpr) bool {
+	if att == nil {
+		return false
+	}
+	obj := AsObject(att.Type)
+	return obj != nil && len(*obj) > 0
+}
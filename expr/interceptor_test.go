@@ -0,0 +1,168 @@
+package expr_test
+
+import (
+	"testing"
+
+	. "goa.design/goa/v3/dsl"
+	"goa.design/goa/v3/expr"
+)
+
+func TestInterceptorValidation(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"valid", validInterceptorDSL, ""},
+		{"missing attributes", missingAttributesInterceptorDSL, `service "MissingAttributes" method "Method": interceptor "Cache" accesses payload attribute "missing" which is not defined in the method payload
+service "MissingAttributes" method "Method": interceptor "Cache" accesses result attribute "missing" which is not defined in the method result`},
+		{"not object", notObjectInterceptorDSL, `service "NotObject" method "Method": interceptor "Cache" accesses payload attributes but the method payload is not an object`},
+		{"streaming result", streamingResultInterceptorDSL, `service "StreamingResult" method "Method": interceptor "Cache" accesses result attributes but the method streams its results`},
+		{"viewed result", viewedResultInterceptorDSL, `service "ViewedResult" method "Method": interceptor "Cache" accesses result attribute "tags" which is not a primitive, interceptors can only access primitive attributes of result types`},
+		{"inconsistent types", inconsistentTypesInterceptorDSL, `service "InconsistentTypes": interceptor "Cache" accesses payload attribute "id" with different types in methods "Method" and "Method2"`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				expr.RunDSL(t, c.DSL)
+			} else {
+				err := expr.RunInvalidDSL(t, c.DSL)
+				if err.Error() != c.Error {
+					t.Errorf("got error %q, expected %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestInterceptorPrepare(t *testing.T) {
+	expr.RunDSL(t, validInterceptorDSL)
+	m := expr.Root.Service("Valid").Method("Method")
+	if len(m.ServerInterceptors) != 2 {
+		t.Fatalf("got %d server interceptors, expected 2", len(m.ServerInterceptors))
+	}
+	if m.ServerInterceptors[0].Name != "Logger" || m.ServerInterceptors[1].Name != "Cache" {
+		t.Errorf("got server interceptors %q and %q, expected %q and %q", m.ServerInterceptors[0].Name, m.ServerInterceptors[1].Name, "Logger", "Cache")
+	}
+	if len(m.ClientInterceptors) != 1 || m.ClientInterceptors[0].Name != "Logger" {
+		t.Errorf("expected client interceptor %q", "Logger")
+	}
+	if len(m.Interceptors()) != 2 {
+		t.Errorf("got %d interceptors, expected 2", len(m.Interceptors()))
+	}
+}
+
+var validInterceptorDSL = func() {
+	Interceptor("Logger")
+	var Cache = Interceptor("Cache", func() {
+		ReadPayload(func() {
+			Attribute("id")
+		})
+		WriteResult(func() {
+			Attribute("cached")
+		})
+	})
+	Service("Valid", func() {
+		ServerInterceptor("Logger")
+		ClientInterceptor("Logger")
+		Method("Method", func() {
+			ServerInterceptor(Cache)
+			Payload(func() {
+				Attribute("id", String)
+			})
+			Result(func() {
+				Attribute("cached", Boolean)
+			})
+		})
+	})
+}
+
+var missingAttributesInterceptorDSL = func() {
+	var Cache = Interceptor("Cache", func() {
+		ReadPayload(func() {
+			Attribute("missing")
+		})
+		ReadResult(func() {
+			Attribute("missing")
+		})
+	})
+	Service("MissingAttributes", func() {
+		Method("Method", func() {
+			ServerInterceptor(Cache)
+			Payload(func() {
+				Attribute("id", String)
+			})
+			Result(func() {
+				Attribute("value", String)
+			})
+		})
+	})
+}
+
+var notObjectInterceptorDSL = func() {
+	var Cache = Interceptor("Cache", func() {
+		ReadPayload(func() {
+			Attribute("id")
+		})
+	})
+	Service("NotObject", func() {
+		Method("Method", func() {
+			ServerInterceptor(Cache)
+			Payload(String)
+		})
+	})
+}
+
+var streamingResultInterceptorDSL = func() {
+	var Cache = Interceptor("Cache", func() {
+		ReadResult(func() {
+			Attribute("value")
+		})
+	})
+	Service("StreamingResult", func() {
+		Method("Method", func() {
+			ServerInterceptor(Cache)
+			StreamingResult(func() {
+				Attribute("value", String)
+			})
+		})
+	})
+}
+
+var viewedResultInterceptorDSL = func() {
+	var Cache = Interceptor("Cache", func() {
+		ReadResult(func() {
+			Attribute("tags")
+		})
+	})
+	var RT = ResultType("application/vnd.viewed", func() {
+		Attribute("tags", ArrayOf(String))
+	})
+	Service("ViewedResult", func() {
+		Method("Method", func() {
+			ServerInterceptor(Cache)
+			Result(RT)
+		})
+	})
+}
+
+var inconsistentTypesInterceptorDSL = func() {
+	var Cache = Interceptor("Cache", func() {
+		ReadPayload(func() {
+			Attribute("id")
+		})
+	})
+	Service("InconsistentTypes", func() {
+		ServerInterceptor(Cache)
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("id", String)
+			})
+		})
+		Method("Method2", func() {
+			Payload(func() {
+				Attribute("id", Int)
+			})
+		})
+	})
+}
@@ -0,0 +1,89 @@
+package expr
+
+import (
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// JSONRPCExpr contains the API level JSON-RPC specific expressions.
+	// JSON-RPC services are exposed over HTTP: each service is served by a
+	// single HTTP endpoint that dispatches requests on the JSON-RPC method
+	// name. JSONRPCExpr thus embeds a HTTPExpr and the JSON-RPC services and
+	// endpoints are described using HTTPServiceExpr and HTTPEndpointExpr.
+	JSONRPCExpr struct {
+		HTTPExpr
+	}
+)
+
+// EvalName returns the name printed in case of evaluation error.
+func (*JSONRPCExpr) EvalName() string {
+	return "API JSON-RPC"
+}
+
+// IsJSONRPC returns true if the service is exposed via the JSON-RPC transport.
+func (svc *HTTPServiceExpr) IsJSONRPC() bool {
+	if Root.API == nil || Root.API.JSONRPC == nil {
+		return false
+	}
+	for _, s := range Root.API.JSONRPC.Services {
+		if s == svc {
+			return true
+		}
+	}
+	return false
+}
+
+// api returns the API level expression that holds the properties inherited by
+// the service: the JSON-RPC expression for JSON-RPC services and the HTTP
+// expression otherwise.
+func (svc *HTTPServiceExpr) api() *HTTPExpr {
+	if svc.IsJSONRPC() {
+		return &Root.API.JSONRPC.HTTPExpr
+	}
+	return Root.API.HTTP
+}
+
+// validateJSONRPC makes sure the endpoint can be exposed via JSON-RPC. JSON-RPC
+// requests and responses carry the entire method payload and result in the
+// "params" and "result" members so that endpoints cannot use HTTP specific
+// mappings.
+func (e *HTTPEndpointExpr) validateJSONRPC() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if e.MethodExpr.IsStreaming() {
+		verr.Add(e, "JSON-RPC endpoints cannot stream payloads or results.")
+	}
+	if len(e.Routes) != 1 || e.Routes[0].Method != "POST" || e.Routes[0].Path != "/" {
+		verr.Add(e, "JSON-RPC endpoints cannot define HTTP routes, use Path in the service JSONRPC expression to set the path of the service JSON-RPC endpoint.")
+	}
+	for _, p := range e.Service.FullPaths() {
+		if len(ExtractHTTPWildcards(p)) > 0 {
+			verr.Add(e, "JSON-RPC endpoint path %q cannot define wildcards.", p)
+		}
+	}
+	if !e.Params.IsEmpty() || !e.Headers.IsEmpty() || !e.Cookies.IsEmpty() || e.MapQueryParams != nil {
+		verr.Add(e, "JSON-RPC endpoints cannot map payload attributes to HTTP parameters, headers or cookies.")
+	}
+	if e.Body != nil {
+		verr.Add(e, "JSON-RPC endpoints cannot define a HTTP body, the entire payload is sent in the request params.")
+	}
+	if e.SkipRequestBodyEncodeDecode || e.SkipResponseBodyEncodeDecode || e.MultipartRequest {
+		verr.Add(e, "JSON-RPC endpoints cannot use SkipRequestBodyEncodeDecode, SkipResponseBodyEncodeDecode or MultipartRequest.")
+	}
+	if e.Redirect != nil || e.SSE != nil {
+		verr.Add(e, "JSON-RPC endpoints cannot use Redirect or ServerSentEvents.")
+	}
+	if len(e.Responses) > 1 {
+		verr.Add(e, "JSON-RPC endpoints cannot define multiple success responses.")
+	}
+	for _, r := range e.Responses {
+		if !r.Headers.IsEmpty() || !r.Cookies.IsEmpty() {
+			verr.Add(r, "JSON-RPC responses cannot map result attributes to HTTP headers or cookies.")
+		}
+	}
+	if rt, ok := e.MethodExpr.Result.Type.(*ResultTypeExpr); ok && len(rt.Views) > 1 {
+		if _, ok := e.MethodExpr.Result.Meta["view"]; !ok {
+			verr.Add(e, "JSON-RPC endpoints require an explicit view when the result type defines multiple views, use View to set one.")
+		}
+	}
+	return verr
+}
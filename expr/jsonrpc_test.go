@@ -0,0 +1,167 @@
+package expr_test
+
+import (
+	"testing"
+
+	. "goa.design/goa/v3/dsl"
+	"goa.design/goa/v3/expr"
+)
+
+func TestJSONRPCValidation(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"valid", validJSONRPCDSL, ""},
+		{"http mappings", httpMappingsJSONRPCDSL, `service "HTTPMappingsJSONRPC" HTTP endpoint "Method": JSON-RPC endpoints cannot define HTTP routes, use Path in the service JSONRPC expression to set the path of the service JSON-RPC endpoint.
+service "HTTPMappingsJSONRPC" HTTP endpoint "Method": JSON-RPC endpoints cannot map payload attributes to HTTP parameters, headers or cookies.`},
+		{"streaming", streamingJSONRPCDSL, `service "StreamingJSONRPC" HTTP endpoint "Method": JSON-RPC endpoints cannot stream payloads or results.
+route POST "/" of service "StreamingJSONRPC" HTTP endpoint "Method": WebSocket endpoint supports only "GET" method. Got "POST".`},
+		{"wildcard path", wildcardPathJSONRPCDSL, `service "WildcardPathJSONRPC" HTTP endpoint "Method": JSON-RPC endpoint path "/{id}" cannot define wildcards.
+service "WildcardPathJSONRPC" HTTP endpoint "Method": JSON-RPC endpoints cannot map payload attributes to HTTP parameters, headers or cookies.`},
+		{"multiple views", multipleViewsJSONRPCDSL, `service "MultipleViewsJSONRPC" HTTP endpoint "Method": JSON-RPC endpoints require an explicit view when the result type defines multiple views, use View to set one.`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				expr.RunDSL(t, c.DSL)
+			} else {
+				err := expr.RunInvalidDSL(t, c.DSL)
+				if err.Error() != c.Error {
+					t.Errorf("got error %q, expected %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestJSONRPCPrepare(t *testing.T) {
+	expr.RunDSL(t, validJSONRPCDSL)
+	if svc := expr.Root.API.HTTP.Service("ValidJSONRPC"); svc != nil {
+		t.Errorf("got HTTP service %q, expected none", svc.Name())
+	}
+	svc := expr.Root.API.JSONRPC.Service("ValidJSONRPC")
+	if svc == nil {
+		t.Fatal("expected JSON-RPC service")
+	}
+	if !svc.IsJSONRPC() {
+		t.Error("expected service to be a JSON-RPC service")
+	}
+	e := svc.Endpoint("Method")
+	if len(e.Routes) != 1 {
+		t.Fatalf("got %d routes, expected one", len(e.Routes))
+	}
+	if paths := e.Routes[0].FullPaths(); len(paths) != 1 || paths[0] != "/api/rpc" {
+		t.Errorf("got paths %v, expected [/api/rpc]", paths)
+	}
+	if !expr.IsObject(e.Body.Type) || e.Body.Find("a") == nil {
+		t.Errorf("expected request body to contain the entire payload")
+	}
+}
+
+func TestJSONRPCAPIPath(t *testing.T) {
+	expr.RunDSL(t, apiPathJSONRPCDSL)
+	svc := expr.Root.API.JSONRPC.Service("APIPathJSONRPC")
+	if svc == nil {
+		t.Fatal("expected JSON-RPC service")
+	}
+	if paths := svc.FullPaths(); len(paths) != 1 || paths[0] != "/rpc" {
+		t.Errorf("got service paths %v, expected [/rpc]", paths)
+	}
+	if paths := svc.Endpoint("Method").Routes[0].FullPaths(); len(paths) != 1 || paths[0] != "/rpc" {
+		t.Errorf("got paths %v, expected [/rpc]", paths)
+	}
+}
+
+var validJSONRPCDSL = func() {
+	API("api", func() {
+		JSONRPC(func() {
+			Path("/api")
+		})
+	})
+	Service("ValidJSONRPC", func() {
+		JSONRPC(func() {
+			Path("/rpc")
+		})
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("a", String)
+			})
+			Result(String)
+			JSONRPC(func() {})
+		})
+	})
+}
+
+var httpMappingsJSONRPCDSL = func() {
+	Service("HTTPMappingsJSONRPC", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("a", String)
+			})
+			JSONRPC(func() {
+				GET("/{a}")
+			})
+		})
+	})
+}
+
+var streamingJSONRPCDSL = func() {
+	Service("StreamingJSONRPC", func() {
+		Method("Method", func() {
+			StreamingResult(String)
+			JSONRPC(func() {})
+		})
+	})
+}
+
+var wildcardPathJSONRPCDSL = func() {
+	Service("WildcardPathJSONRPC", func() {
+		JSONRPC(func() {
+			Path("/{id}")
+		})
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("id", String)
+			})
+			JSONRPC(func() {})
+		})
+	})
+}
+
+var multipleViewsJSONRPCDSL = func() {
+	var RT = ResultType("application/vnd.multiple.views", func() {
+		Attributes(func() {
+			Attribute("a", String)
+			Attribute("b", String)
+		})
+		View("default", func() {
+			Attribute("a")
+			Attribute("b")
+		})
+		View("tiny", func() {
+			Attribute("a")
+		})
+	})
+	Service("MultipleViewsJSONRPC", func() {
+		Method("Method", func() {
+			Result(RT)
+			JSONRPC(func() {})
+		})
+	})
+}
+
+var apiPathJSONRPCDSL = func() {
+	API("api", func() {
+		JSONRPC(func() {
+			Path("/rpc")
+		})
+	})
+	Service("APIPathJSONRPC", func() {
+		Method("Method", func() {
+			Result(String)
+			JSONRPC(func() {})
+		})
+	})
+}
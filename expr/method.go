@@ -32,6 +32,25 @@ type (
 		// schemes. Incoming requests must validate at least one
 		// requirement to be authorized.
 		Requirements []*SecurityExpr
+		// ServerInterceptors lists the server interceptors applied to
+		// the method including the service interceptors.
+		ServerInterceptors []*InterceptorExpr
+		// ClientInterceptors lists the client interceptors applied to
+		// the method including the service interceptors.
+		ClientInterceptors []*InterceptorExpr
+		// RateLimit is the rate limit applied to the method requests
+		// including the service rate limit if the method does not
+		// define its own.
+		RateLimit *RateLimitExpr
+		// MaxConcurrency is the maximum number of requests handled
+		// concurrently by the method including the service limit if the
+		// method does not define its own, 0 means no limit.
+		MaxConcurrency int
+		// Pagination describes the cursor based pagination of the method
+		// results if any.
+		Pagination *PaginationExpr
+		// Scenarios lists the example exchanges of the method.
+		Scenarios []*ScenarioExpr
 		// Service that owns method.
 		Service *ServiceExpr
 		// Meta is an arbitrary set of key/value pairs, see dsl.Meta
@@ -84,7 +103,8 @@ func (m *MethodExpr) EvalName() string {
 }
 
 // Prepare makes sure the payload and result types are initialized (to the Empty
-// type if nil).
+// type if nil). It also merges the service interceptors into the method
+// interceptors and applies the service rate and concurrency limits.
 func (m *MethodExpr) Prepare() {
 	if m.Payload == nil {
 		m.Payload = &AttributeExpr{Type: Empty}
@@ -95,6 +115,8 @@ func (m *MethodExpr) Prepare() {
 	if m.Result == nil {
 		m.Result = &AttributeExpr{Type: Empty}
 	}
+	m.prepareInterceptors()
+	m.prepareLimits()
 }
 
 // Validate validates the method payloads, results, and errors (if any).
@@ -191,6 +213,12 @@ func (m *MethodExpr) Validate() error {
 	if m.Result.Type != Empty {
 		verr.Merge(m.Result.Validate("result", m))
 	}
+	for _, i := range m.Interceptors() {
+		verr.Merge(i.validateMethod(m))
+	}
+	verr.Merge(m.validateLimits())
+	verr.Merge(m.validatePagination())
+	verr.Merge(m.validateScenarios())
 	for i, e := range m.Errors {
 		if err := e.Validate(); err != nil {
 			var verrs *eval.ValidationErrors
@@ -0,0 +1,163 @@
+package expr_test
+
+import (
+	"testing"
+
+	. "goa.design/goa/v3/dsl"
+	"goa.design/goa/v3/expr"
+)
+
+func TestNullableValidation(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"valid", validNullableDSL, ""},
+		{"not primitive", objectNullableDSL, `service "Service" method "Method": field nested - nullable attribute must be of a primitive type other than Any, got object`},
+		{"any", anyNullableDSL, `service "Service" method "Method": field value - nullable attribute must be of a primitive type other than Any, got any`},
+		{"default", defaultNullableDSL, `service "Service" method "Method": field name - nullable attribute cannot have a default value`},
+		{"required", requiredNullableDSL, `service "Service" method "Method": payload - nullable field "name" cannot be required`},
+		{"array element", arrayNullableDSL, `service "Service" method "Method": field names - array elements cannot be nullable`},
+		{"header", headerNullableDSL, `service "Service" HTTP endpoint "Method": header "name" cannot be nullable, nullable attributes must be mapped to the request body.`},
+		{"query", queryNullableDSL, `service "Service" HTTP endpoint "Method": Query string parameter "name" cannot be nullable, nullable attributes must be mapped to the request body.`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				expr.RunDSL(t, c.DSL)
+			} else {
+				err := expr.RunInvalidDSL(t, c.DSL)
+				if err.Error() != c.Error {
+					t.Errorf("got error %q, expected %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestAttributeExprIsNullable(t *testing.T) {
+	expr.RunDSL(t, validNullableDSL)
+	payload := expr.Root.Services[0].Methods[0].Payload
+	if !payload.Find("name").IsNullable() {
+		t.Errorf("expected name to be nullable")
+	}
+	if payload.Find("id").IsNullable() {
+		t.Errorf("expected id not to be nullable")
+	}
+	if payload.IsPrimitivePointer("name", true) {
+		t.Errorf("expected name not to be a primitive pointer")
+	}
+}
+
+var validNullableDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("id", Int)
+				Attribute("name", String, func() {
+					Nullable()
+				})
+				Required("id")
+			})
+			HTTP(func() {
+				PATCH("/{id}")
+			})
+		})
+	})
+}
+
+var objectNullableDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("nested", func() {
+					Attribute("name", String)
+					Nullable()
+				})
+			})
+		})
+	})
+}
+
+var anyNullableDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("value", Any, func() {
+					Nullable()
+				})
+			})
+		})
+	})
+}
+
+var defaultNullableDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("name", String, func() {
+					Nullable()
+					Default("goa")
+				})
+			})
+		})
+	})
+}
+
+var requiredNullableDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("name", String, func() {
+					Nullable()
+				})
+				Required("name")
+			})
+		})
+	})
+}
+
+var arrayNullableDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("names", ArrayOf(String, func() {
+					Nullable()
+				}))
+			})
+		})
+	})
+}
+
+var headerNullableDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("name", String, func() {
+					Nullable()
+				})
+			})
+			HTTP(func() {
+				POST("/")
+				Header("name")
+			})
+		})
+	})
+}
+
+var queryNullableDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("name", String, func() {
+					Nullable()
+				})
+			})
+			HTTP(func() {
+				GET("/")
+				Param("name")
+			})
+		})
+	})
+}
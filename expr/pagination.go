@@ -0,0 +1,86 @@
+package expr
+
+import (
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// PaginationExpr describes the cursor based pagination of the results
+	// of a method. The method payload holds the cursor of the requested
+	// page and the method result holds the page items and the cursor of
+	// the next page.
+	PaginationExpr struct {
+		// Cursor is the name of the payload attribute holding the cursor
+		// of the requested page.
+		Cursor string
+		// NextCursor is the name of the result attribute holding the
+		// cursor of the next page. An empty or absent next cursor
+		// indicates the last page.
+		NextCursor string
+		// Items is the name of the result attribute holding the page
+		// items.
+		Items string
+		// Method is the paginated method.
+		Method *MethodExpr
+	}
+)
+
+const (
+	// DefaultPaginationCursor is the default name of the payload attribute
+	// holding the page cursor.
+	DefaultPaginationCursor = "cursor"
+	// DefaultPaginationNextCursor is the default name of the result
+	// attribute holding the next page cursor.
+	DefaultPaginationNextCursor = "next_cursor"
+	// DefaultPaginationItems is the default name of the result attribute
+	// holding the page items.
+	DefaultPaginationItems = "items"
+)
+
+// EvalName returns the generic expression name used in error messages.
+func (p *PaginationExpr) EvalName() string {
+	var suffix string
+	if p.Method != nil {
+		suffix = " of " + p.Method.EvalName()
+	}
+	return "pagination" + suffix
+}
+
+// validatePagination makes sure the pagination attributes of the method exist
+// and are of the expected types.
+func (m *MethodExpr) validatePagination() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	p := m.Pagination
+	if p == nil {
+		return verr
+	}
+	if m.IsStreaming() {
+		verr.Add(m, "paginated methods cannot use streaming")
+	}
+	if !IsObject(m.Payload.Type) {
+		verr.Add(m, "pagination cursor %q must be a payload attribute but the method payload is not an object", p.Cursor)
+	} else if att := m.Payload.Find(p.Cursor); att == nil {
+		verr.Add(m, "pagination cursor %q is not a payload attribute", p.Cursor)
+	} else if att.Type != String {
+		verr.Add(m, "pagination cursor %q must be of type String, got %s", p.Cursor, att.Type.Name())
+	} else if att.IsNullable() || att.IsTime() {
+		verr.Add(m, "pagination cursor %q cannot be nullable nor mapped to a Go time type", p.Cursor)
+	}
+	if !IsObject(m.Result.Type) {
+		verr.Add(m, "pagination next cursor %q and items %q must be result attributes but the method result is not an object", p.NextCursor, p.Items)
+		return verr
+	}
+	if att := m.Result.Find(p.NextCursor); att == nil {
+		verr.Add(m, "pagination next cursor %q is not a result attribute", p.NextCursor)
+	} else if att.Type != String {
+		verr.Add(m, "pagination next cursor %q must be of type String, got %s", p.NextCursor, att.Type.Name())
+	} else if att.IsNullable() || att.IsTime() {
+		verr.Add(m, "pagination next cursor %q cannot be nullable nor mapped to a Go time type", p.NextCursor)
+	}
+	if att := m.Result.Find(p.Items); att == nil {
+		verr.Add(m, "pagination items %q is not a result attribute", p.Items)
+	} else if !IsArray(att.Type) {
+		verr.Add(m, "pagination items %q must be an array, got %s", p.Items, att.Type.Name())
+	}
+	return verr
+}
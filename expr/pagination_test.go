@@ -0,0 +1,168 @@
+package expr_test
+
+import (
+	"testing"
+
+	. "goa.design/goa/v3/dsl"
+	"goa.design/goa/v3/expr"
+)
+
+func TestPaginationValidation(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"valid", validPaginationDSL, ""},
+		{"custom names", customPaginationDSL, ""},
+		{"missing cursor", missingCursorPaginationDSL, `service "Service" method "Method": pagination cursor "cursor" is not a payload attribute`},
+		{"cursor type", cursorTypePaginationDSL, `service "Service" method "Method": pagination cursor "cursor" must be of type String, got int`},
+		{"missing next cursor", missingNextCursorPaginationDSL, `service "Service" method "Method": pagination next cursor "next_cursor" is not a result attribute`},
+		{"items type", itemsTypePaginationDSL, `service "Service" method "Method": pagination items "items" must be an array, got string`},
+		{"streaming", streamingPaginationDSL, `service "Service" method "Method": paginated methods cannot use streaming`},
+		{"cursor header", headerPaginationDSL, `service "Service" HTTP endpoint "Method": Pagination cursor "cursor" must be mapped to a query string parameter.`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				expr.RunDSL(t, c.DSL)
+			} else {
+				err := expr.RunInvalidDSL(t, c.DSL)
+				if err.Error() != c.Error {
+					t.Errorf("got error %q, expected %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
+
+var validPaginationDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("cursor", String)
+			})
+			Result(func() {
+				Attribute("items", ArrayOf(String))
+				Attribute("next_cursor", String)
+			})
+			Paginated()
+			HTTP(func() {
+				GET("/")
+				Param("cursor")
+			})
+		})
+	})
+}
+
+var customPaginationDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("page", String)
+			})
+			Result(func() {
+				Attribute("names", ArrayOf(String))
+				Attribute("next_page", String)
+			})
+			Paginated(func() {
+				Cursor("page")
+				NextCursor("next_page")
+				PageItems("names")
+			})
+		})
+	})
+}
+
+var missingCursorPaginationDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("page", String)
+			})
+			Result(func() {
+				Attribute("items", ArrayOf(String))
+				Attribute("next_cursor", String)
+			})
+			Paginated()
+		})
+	})
+}
+
+var cursorTypePaginationDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("cursor", Int)
+			})
+			Result(func() {
+				Attribute("items", ArrayOf(String))
+				Attribute("next_cursor", String)
+			})
+			Paginated()
+		})
+	})
+}
+
+var missingNextCursorPaginationDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("cursor", String)
+			})
+			Result(func() {
+				Attribute("items", ArrayOf(String))
+			})
+			Paginated()
+		})
+	})
+}
+
+var itemsTypePaginationDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("cursor", String)
+			})
+			Result(func() {
+				Attribute("items", String)
+				Attribute("next_cursor", String)
+			})
+			Paginated()
+		})
+	})
+}
+
+var streamingPaginationDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("cursor", String)
+			})
+			StreamingResult(func() {
+				Attribute("items", ArrayOf(String))
+				Attribute("next_cursor", String)
+			})
+			Paginated()
+		})
+	})
+}
+
+var headerPaginationDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("cursor", String)
+			})
+			Result(func() {
+				Attribute("items", ArrayOf(String))
+				Attribute("next_cursor", String)
+			})
+			Paginated()
+			HTTP(func() {
+				GET("/")
+				Header("cursor")
+			})
+		})
+	})
+}
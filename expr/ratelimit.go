@@ -0,0 +1,80 @@
+package expr
+
+import (
+	"time"
+
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// RateLimitExpr describes a token bucket rate limit applied to the
+	// requests made to a method endpoint.
+	RateLimitExpr struct {
+		// Limit is the maximum number of requests allowed per period.
+		Limit int
+		// Period is the duration of the period.
+		Period time.Duration
+		// Burst is the maximum number of requests that may be handled at
+		// once, defaults to Limit.
+		Burst int
+		// Key is the name of the payload attribute whose value identifies
+		// the token bucket used to rate limit the request. All requests
+		// share the same bucket if empty.
+		Key string
+		// Parent is the service or method expression that defines the
+		// rate limit.
+		Parent eval.Expression
+	}
+)
+
+// EvalName returns the generic expression name used in error messages.
+func (r *RateLimitExpr) EvalName() string {
+	var suffix string
+	if r.Parent != nil {
+		suffix = " of " + r.Parent.EvalName()
+	}
+	return "rate limit" + suffix
+}
+
+// BurstSize returns the maximum number of requests that may be handled at
+// once.
+func (r *RateLimitExpr) BurstSize() int {
+	if r.Burst > 0 {
+		return r.Burst
+	}
+	return r.Limit
+}
+
+// prepareLimits applies the service rate and concurrency limits to the method
+// if it does not define its own.
+func (m *MethodExpr) prepareLimits() {
+	if m.RateLimit == nil {
+		m.RateLimit = m.Service.RateLimit
+	}
+	if m.MaxConcurrency == 0 {
+		m.MaxConcurrency = m.Service.MaxConcurrency
+	}
+}
+
+// validateLimits makes sure the rate limit key of the method refers to a
+// String attribute of the method payload.
+func (m *MethodExpr) validateLimits() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if m.RateLimit == nil || m.RateLimit.Key == "" {
+		return verr
+	}
+	key := m.RateLimit.Key
+	if !IsObject(m.Payload.Type) {
+		verr.Add(m, "rate limit key %q must be a payload attribute but the method payload is not an object", key)
+		return verr
+	}
+	att := m.Payload.Find(key)
+	if att == nil {
+		verr.Add(m, "rate limit key %q is not a payload attribute", key)
+		return verr
+	}
+	if att.Type != String {
+		verr.Add(m, "rate limit key %q must be of type String, got %s", key, att.Type.Name())
+	}
+	return verr
+}
@@ -0,0 +1,114 @@
+package expr_test
+
+import (
+	"testing"
+	"time"
+
+	. "goa.design/goa/v3/dsl"
+	"goa.design/goa/v3/expr"
+)
+
+func TestRateLimitValidation(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"valid", validRateLimitDSL, ""},
+		{"missing key", missingKeyRateLimitDSL, `service "MissingKey" method "Method": rate limit key "missing" is not a payload attribute`},
+		{"not object", notObjectRateLimitDSL, `service "NotObject" method "Method": rate limit key "key" must be a payload attribute but the method payload is not an object`},
+		{"not string", notStringRateLimitDSL, `service "NotString" method "Method": rate limit key "key" must be of type String, got int`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				expr.RunDSL(t, c.DSL)
+			} else {
+				err := expr.RunInvalidDSL(t, c.DSL)
+				if err.Error() != c.Error {
+					t.Errorf("got error %q, expected %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestRateLimitPrepare(t *testing.T) {
+	expr.RunDSL(t, validRateLimitDSL)
+	svc := expr.Root.Service("Valid")
+	m := svc.Method("Method")
+	if m.RateLimit == nil || m.RateLimit.Limit != 10 || m.RateLimit.Period != time.Second {
+		t.Fatalf("got rate limit %+v, expected 10 requests per second", m.RateLimit)
+	}
+	if m.RateLimit.BurstSize() != 20 || m.RateLimit.Key != "key" {
+		t.Errorf("got burst %d and key %q, expected 20 and %q", m.RateLimit.BurstSize(), m.RateLimit.Key, "key")
+	}
+	if m.MaxConcurrency != 5 {
+		t.Errorf("got max concurrency %d, expected 5", m.MaxConcurrency)
+	}
+	m2 := svc.Method("Method2")
+	if m2.RateLimit != svc.RateLimit {
+		t.Errorf("expected method to inherit the service rate limit")
+	}
+	if m2.RateLimit.BurstSize() != 100 {
+		t.Errorf("got burst %d, expected 100", m2.RateLimit.BurstSize())
+	}
+	if m2.MaxConcurrency != 50 {
+		t.Errorf("got max concurrency %d, expected 50", m2.MaxConcurrency)
+	}
+}
+
+var validRateLimitDSL = func() {
+	Service("Valid", func() {
+		RateLimit(100, time.Minute)
+		MaxConcurrency(50)
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("key", String)
+			})
+			RateLimit(10, time.Second, func() {
+				Burst(20)
+				RateLimitKey("key")
+			})
+			MaxConcurrency(5)
+		})
+		Method("Method2", func() {})
+	})
+}
+
+var missingKeyRateLimitDSL = func() {
+	Service("MissingKey", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("key", String)
+			})
+			RateLimit(10, time.Second, func() {
+				RateLimitKey("missing")
+			})
+		})
+	})
+}
+
+var notObjectRateLimitDSL = func() {
+	Service("NotObject", func() {
+		RateLimit(10, time.Second, func() {
+			RateLimitKey("key")
+		})
+		Method("Method", func() {
+			Payload(String)
+		})
+	})
+}
+
+var notStringRateLimitDSL = func() {
+	Service("NotString", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("key", Int)
+			})
+			RateLimit(10, time.Second, func() {
+				RateLimitKey("key")
+			})
+		})
+	})
+}
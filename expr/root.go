@@ -34,6 +34,12 @@ type (
 		Creations []*TypeMap
 		// Schemes list the registered security schemes.
 		Schemes []*SchemeExpr
+		// Interceptors list the registered interceptors.
+		Interceptors []*InterceptorExpr
+		// Formats list the custom string formats.
+		Formats []*FormatExpr
+		// TypeTemplates list the instantiated type templates.
+		TypeTemplates []*TypeTemplateExpr
 	}
 
 	// MetaExpr is a set of key/value pairs
@@ -127,6 +133,21 @@ func (r *RootExpr) WalkSets(walk eval.SetWalker) {
 	walk(eval.ExpressionSet{r.API.GRPC})
 	walk(grpcsvcs)
 	walk(grpcepts)
+
+	// JSON-RPC services and endpoints
+	if r.API.JSONRPC != nil {
+		jsonrpcsvcs := make(eval.ExpressionSet, len(r.API.JSONRPC.Services))
+		var jsonrpcepts eval.ExpressionSet
+		for i, svc := range r.API.JSONRPC.Services {
+			jsonrpcsvcs[i] = svc
+			for _, e := range svc.HTTPEndpoints {
+				jsonrpcepts = append(jsonrpcepts, e)
+			}
+		}
+		walk(eval.ExpressionSet{r.API.JSONRPC})
+		walk(jsonrpcsvcs)
+		walk(jsonrpcepts)
+	}
 }
 
 // DependsOn returns nil, the core DSL has no dependency.
@@ -189,6 +210,26 @@ func (r *RootExpr) Error(name string) *ErrorExpr {
 	return nil
 }
 
+// Interceptor returns the interceptor with the given name if any.
+func (r *RootExpr) Interceptor(name string) *InterceptorExpr {
+	for _, i := range r.Interceptors {
+		if i.Name == name {
+			return i
+		}
+	}
+	return nil
+}
+
+// Format returns the custom format with the given name if any.
+func (r *RootExpr) Format(name ValidationFormat) *FormatExpr {
+	for _, f := range r.Formats {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
 // HTTPService returns the HTTP service with the given name if any.
 func (r *RootExpr) HTTPService(name string) *HTTPServiceExpr {
 	for _, res := range r.API.HTTP.Services {
@@ -223,9 +264,31 @@ func (r *RootExpr) Validate() error {
 	if r.API == nil {
 		verr.Add(r, "Missing API declaration")
 	}
+	for _, t := range r.Types {
+		if IsTypedEnum(t) {
+			verr.Merge(validateTypedEnum(t))
+		}
+	}
 	return &verr
 }
 
+// validateTypedEnum makes sure the user type using the "type:enum" meta
+// defines an enum validation on a string or integer type.
+func validateTypedEnum(ut UserType) *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	att := ut.Attribute()
+	switch att.Type {
+	case String, Int, Int32, Int64, UInt, UInt32, UInt64:
+	default:
+		verr.Add(ut, "type %q uses the \"type:enum\" meta but is not a String or integer type", ut.Name())
+		return verr
+	}
+	if att.Validation == nil || len(att.Validation.Values) == 0 {
+		verr.Add(ut, "type %q uses the \"type:enum\" meta but does not define an enum", ut.Name())
+	}
+	return verr
+}
+
 // Finalize finalizes the server expressions.
 func (r *RootExpr) Finalize() {
 	if r.API == nil {
@@ -0,0 +1,250 @@
+package expr
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// ScenarioExpr describes an example exchange of a method: the payload
+	// sent by the client and the result or error returned by the service.
+	// Scenarios are used to produce the examples of the OpenAPI
+	// specifications and of the generated command line tools.
+	ScenarioExpr struct {
+		// Name is the name of the scenario, unique in the method.
+		Name string
+		// Description is the optional description of the scenario.
+		Description string
+		// Payload is the payload value, nil if the method has no
+		// payload.
+		Payload any
+		// Result is the expected result value, nil if the scenario
+		// expects an error or if the method has no result.
+		Result any
+		// Error is the name of the expected error, empty if the scenario
+		// expects a successful response.
+		Error string
+		// ErrorValue is the expected error value if any.
+		ErrorValue any
+		// Method is the method the scenario applies to.
+		Method *MethodExpr
+	}
+)
+
+// EvalName returns the generic expression name used in error messages.
+func (s *ScenarioExpr) EvalName() string {
+	var suffix string
+	if s.Method != nil {
+		suffix = " of " + s.Method.EvalName()
+	}
+	return fmt.Sprintf("scenario %#v%s", s.Name, suffix)
+}
+
+// PayloadAttribute returns the value of the payload attribute with the given
+// name and true if the scenario sets it. If the payload is not an object then
+// PayloadAttribute returns the payload value.
+func (s *ScenarioExpr) PayloadAttribute(name string) (any, bool) {
+	return attributeValue(s.Payload, name)
+}
+
+// ResponseValue returns the expected result value or the expected error value
+// if the scenario expects an error.
+func (s *ScenarioExpr) ResponseValue() any {
+	if s.Error != "" {
+		return s.ErrorValue
+	}
+	return s.Result
+}
+
+// ResponseAttribute returns the value of the attribute with the given name of
+// the response value and true if the scenario sets it. If the response value
+// is not an object then ResponseAttribute returns the response value.
+func (s *ScenarioExpr) ResponseAttribute(name string) (any, bool) {
+	return attributeValue(s.ResponseValue(), name)
+}
+
+// HTTPRequestBody returns the part of the payload value encoded in the
+// request body of the given endpoint, nil if there is none.
+func (s *ScenarioExpr) HTTPRequestBody(e *HTTPEndpointExpr) any {
+	return httpBodyValue(e.Body, s.Payload)
+}
+
+// HTTPResponse returns the response of the given endpoint that corresponds to
+// the outcome of the scenario, nil if there is none.
+func (s *ScenarioExpr) HTTPResponse(e *HTTPEndpointExpr) *HTTPResponseExpr {
+	if s.Error != "" {
+		for _, er := range e.HTTPErrors {
+			if er.Name == s.Error {
+				return er.Response
+			}
+		}
+		return nil
+	}
+	var res *HTTPResponseExpr
+	for _, r := range e.Responses {
+		if r.Tag[0] == "" {
+			if res == nil {
+				res = r
+			}
+			continue
+		}
+		if v, ok := s.ResponseAttribute(r.Tag[0]); ok && fmt.Sprint(v) == r.Tag[1] {
+			return r
+		}
+	}
+	return res
+}
+
+// HTTPResponseBody returns the part of the response value encoded in the body
+// of the given response, nil if there is none.
+func (s *ScenarioExpr) HTTPResponseBody(r *HTTPResponseExpr) any {
+	return httpBodyValue(r.Body, s.ResponseValue())
+}
+
+// validateScenarios makes sure the scenarios of the method have unique names
+// and use values compatible with the method payload, result and errors.
+func (m *MethodExpr) validateScenarios() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	names := make(map[string]struct{})
+	for _, s := range m.Scenarios {
+		if _, ok := names[s.Name]; ok {
+			verr.Add(s, "scenario name must be unique in the method")
+		}
+		names[s.Name] = struct{}{}
+		if s.Payload != nil {
+			if m.Payload.Type == Empty {
+				verr.Add(s, "scenario defines a payload but the method has no payload")
+			} else {
+				s.validateValue(verr, "payload", m.Payload, s.Payload, true)
+			}
+		}
+		if s.Error != "" {
+			if s.Result != nil {
+				verr.Add(s, "scenario cannot define both a result and an error")
+			}
+			if er := m.Error(s.Error); er == nil {
+				verr.Add(s, "error %q is not defined by the method or its service", s.Error)
+			} else if s.ErrorValue != nil {
+				// Error values usually only describe the relevant fields
+				// (e.g. the message of a goa.ServiceError).
+				s.validateValue(verr, "error", er.AttributeExpr, s.ErrorValue, false)
+			}
+			continue
+		}
+		if s.Result != nil {
+			if m.Result.Type == Empty {
+				verr.Add(s, "scenario defines a result but the method has no result")
+			} else {
+				s.validateValue(verr, "result", m.Result, s.Result, true)
+			}
+		}
+	}
+	return verr
+}
+
+// validateValue records an error in verr if val is not a valid value for att.
+// ctx describes the value in error messages. required indicates whether the
+// value must set the required attributes.
+func (s *ScenarioExpr) validateValue(verr *eval.ValidationErrors, ctx string, att *AttributeExpr, val any, required bool) {
+	if val == nil {
+		return
+	}
+	switch {
+	case IsObject(att.Type):
+		m, ok := val.(map[string]any)
+		if !ok {
+			verr.Add(s, "%s must be an object, got %#v", ctx, val)
+			return
+		}
+		obj := AsObject(att.Type)
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			child := obj.Attribute(k)
+			if child == nil {
+				verr.Add(s, "%s: unknown attribute %q", ctx, k)
+				continue
+			}
+			s.validateValue(verr, ctx+"."+k, child, m[k], required)
+		}
+		if !required {
+			return
+		}
+		for _, n := range att.AllRequired() {
+			if _, ok := m[n]; !ok && att.GetDefault(n) == nil {
+				verr.Add(s, "%s: missing required attribute %q", ctx, n)
+			}
+		}
+	case IsArray(att.Type):
+		v := reflect.ValueOf(val)
+		if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+			verr.Add(s, "%s must be an array, got %#v", ctx, val)
+			return
+		}
+		elem := AsArray(att.Type).ElemType
+		for i := 0; i < v.Len(); i++ {
+			s.validateValue(verr, fmt.Sprintf("%s[%d]", ctx, i), elem, v.Index(i).Interface(), required)
+		}
+	case IsMap(att.Type):
+		v := reflect.ValueOf(val)
+		if v.Kind() != reflect.Map {
+			verr.Add(s, "%s must be a map, got %#v", ctx, val)
+			return
+		}
+		m := AsMap(att.Type)
+		for _, k := range v.MapKeys() {
+			s.validateValue(verr, fmt.Sprintf("%s key %v", ctx, k.Interface()), m.KeyType, k.Interface(), required)
+			s.validateValue(verr, fmt.Sprintf("%s[%v]", ctx, k.Interface()), m.ElemType, v.MapIndex(k).Interface(), required)
+		}
+	case IsUnion(att.Type):
+		// Union values are not validated.
+	default:
+		if !att.Type.IsCompatible(val) {
+			verr.Add(s, "%s: value %#v is not compatible with type %s", ctx, val, att.Type.Name())
+		}
+	}
+}
+
+// attributeValue returns the value of the attribute with the given name in
+// the object value val. It returns val if it is not an object value.
+func attributeValue(val any, name string) (any, bool) {
+	if val == nil {
+		return nil, false
+	}
+	m, ok := val.(map[string]any)
+	if !ok {
+		return val, true
+	}
+	v, ok := m[name]
+	return v, ok
+}
+
+// httpBodyValue returns the part of val encoded in the HTTP body described by
+// body.
+func httpBodyValue(body *AttributeExpr, val any) any {
+	if val == nil || body == nil || body.Type == Empty {
+		return nil
+	}
+	if o, ok := body.Meta["origin:attribute"]; ok {
+		v, _ := attributeValue(val, o[0])
+		return v
+	}
+	m, ok := val.(map[string]any)
+	obj := AsObject(body.Type)
+	if !ok || obj == nil {
+		return val
+	}
+	res := make(map[string]any)
+	for _, nat := range *obj {
+		if v, ok := m[nat.Name]; ok {
+			res[nat.Name] = v
+		}
+	}
+	return res
+}
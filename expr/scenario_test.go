@@ -0,0 +1,118 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+
+	. "goa.design/goa/v3/dsl"
+	"goa.design/goa/v3/expr"
+)
+
+func TestScenario(t *testing.T) {
+	expr.RunDSL(t, func() {
+		Service("Service", func() {
+			Error("not_found")
+			Method("Method", func() {
+				Payload(func() {
+					Attribute("id", Int)
+					Attribute("tags", ArrayOf(String))
+					Required("id")
+				})
+				Result(func() {
+					Attribute("name", String)
+				})
+				Scenario("success", func() {
+					Description("Success scenario")
+					Payload(Val{"id": 1, "tags": []string{"a", "b"}})
+					Result(Val{"name": "foo"})
+				})
+				Scenario("failure", func() {
+					Payload(Val{"id": 2})
+					Error("not_found", Val{"message": "no such id"})
+				})
+			})
+		})
+	})
+	m := expr.Root.Service("Service").Method("Method")
+	if len(m.Scenarios) != 2 {
+		t.Fatalf("got %d scenarios, expected 2", len(m.Scenarios))
+	}
+	success, failure := m.Scenarios[0], m.Scenarios[1]
+	if success.Description != "Success scenario" {
+		t.Errorf("got description %q, expected %q", success.Description, "Success scenario")
+	}
+	if v, ok := success.PayloadAttribute("tags"); !ok || len(v.([]any)) != 2 {
+		t.Errorf("got tags %#v, expected two tags", v)
+	}
+	if v, ok := success.ResponseAttribute("name"); !ok || v != "foo" {
+		t.Errorf("got name %#v, expected %q", v, "foo")
+	}
+	if failure.Error != "not_found" {
+		t.Errorf("got error %q, expected %q", failure.Error, "not_found")
+	}
+	if v, ok := failure.ResponseAttribute("message"); !ok || v != "no such id" {
+		t.Errorf("got error message %#v, expected %q", v, "no such id")
+	}
+}
+
+func TestScenarioErrors(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"duplicate name", func() {
+			Scenario("a", func() {})
+			Scenario("a", func() {})
+		}, "scenario name must be unique in the method"},
+		{"no payload", func() {
+			Scenario("a", func() { Payload(Val{"id": 1}) })
+		}, "scenario defines a payload but the method has no payload"},
+		{"result and error", func() {
+			Error("bad")
+			Result(String)
+			Scenario("a", func() {
+				Result("foo")
+				Error("bad")
+			})
+		}, "scenario cannot define both a result and an error"},
+		{"unknown error", func() {
+			Scenario("a", func() { Error("unknown") })
+		}, `error "unknown" is not defined by the method or its service`},
+		{"no result", func() {
+			Scenario("a", func() { Result("foo") })
+		}, "scenario defines a result but the method has no result"},
+		{"unknown attribute", func() {
+			Payload(func() { Attribute("id", Int) })
+			Scenario("a", func() { Payload(Val{"name": "foo"}) })
+		}, `payload: unknown attribute "name"`},
+		{"missing required", func() {
+			Payload(func() {
+				Attribute("id", Int)
+				Attribute("name", String)
+				Required("id")
+			})
+			Scenario("a", func() { Payload(Val{"name": "foo"}) })
+		}, `payload: missing required attribute "id"`},
+		{"incompatible", func() {
+			Payload(func() { Attribute("id", Int) })
+			Scenario("a", func() { Payload(Val{"id": "foo"}) })
+		}, `payload.id: value "foo" is not compatible with type int`},
+		{"not an object", func() {
+			Payload(func() { Attribute("id", Int) })
+			Scenario("a", func() { Payload("foo") })
+		}, `payload must be an object, got "foo"`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			err := expr.RunInvalidDSL(t, func() {
+				Service("Service", func() {
+					Method("Method", c.DSL)
+				})
+			})
+			if !strings.Contains(err.Error(), c.Error) {
+				t.Errorf("got error %q, expected %q", err.Error(), c.Error)
+			}
+		})
+	}
+}
@@ -27,6 +27,19 @@ type (
 		// potentially multiple schemes. Incoming requests must validate
 		// at least one requirement to be authorized.
 		Requirements []*SecurityExpr
+		// ServerInterceptors lists the server interceptors applied to
+		// all the service methods.
+		ServerInterceptors []*InterceptorExpr
+		// ClientInterceptors lists the client interceptors applied to
+		// all the service methods.
+		ClientInterceptors []*InterceptorExpr
+		// RateLimit is the rate limit applied to the service methods that
+		// do not define their own.
+		RateLimit *RateLimitExpr
+		// MaxConcurrency is the maximum number of requests handled
+		// concurrently by each service method that does not define its
+		// own limit, 0 means no limit.
+		MaxConcurrency int
 		// Meta is a set of key/value pairs with semantic that is
 		// specific to each generator.
 		Meta MetaExpr
@@ -71,6 +84,17 @@ func (s *ServiceExpr) Error(name string) *ErrorExpr {
 	return Root.Error(name)
 }
 
+// HasPagination returns true if at least one of the service methods is
+// paginated.
+func (s *ServiceExpr) HasPagination() bool {
+	for _, m := range s.Methods {
+		if m.Pagination != nil {
+			return true
+		}
+	}
+	return false
+}
+
 // Hash returns a unique hash value for s.
 func (s *ServiceExpr) Hash() string {
 	return "_service_+" + s.Name
@@ -87,6 +111,7 @@ func (s *ServiceExpr) Validate() error {
 			}
 		}
 	}
+	verr.Merge(s.validateInterceptors())
 	return verr
 }
 
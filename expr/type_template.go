@@ -0,0 +1,111 @@
+package expr
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type (
+	// TypeTemplateExpr describes a user type parameterized by other types.
+	// Instantiating a template with a list of types produces a user type
+	// whose name is derived from the names of the template and of the
+	// arguments, for example instantiating the template "Page" with the
+	// type "User" produces the type "PageOfUser".
+	TypeTemplateExpr struct {
+		// Name is the name of the template.
+		Name string
+		// Params is the number of type parameters.
+		Params int
+		// DSLFunc initializes the attributes of an instance given the
+		// template arguments.
+		DSLFunc func(args ...DataType)
+		// Instances lists the template instances in order of
+		// instantiation.
+		Instances []*TypeInstanceExpr
+	}
+
+	// TypeInstanceExpr describes a user type produced by instantiating a
+	// type template.
+	TypeInstanceExpr struct {
+		// Type is the user type produced by the instantiation.
+		Type *UserTypeExpr
+		// Template is the instantiated template.
+		Template *TypeTemplateExpr
+		// Args lists the template arguments.
+		Args []DataType
+	}
+)
+
+// EvalName returns the generic expression name used in error messages.
+func (t *TypeTemplateExpr) EvalName() string {
+	return fmt.Sprintf("type template %#v", t.Name)
+}
+
+// InstanceName returns the name of the user type produced by instantiating
+// the template with the given arguments.
+func (t *TypeTemplateExpr) InstanceName(args []DataType) string {
+	names := make([]string, len(args))
+	for i, arg := range args {
+		names[i] = typeArgName(arg)
+	}
+	return t.Name + "Of" + strings.Join(names, "And")
+}
+
+// Instance returns the instance produced by a previous instantiation of the
+// template with the same arguments, nil if there is none. Arguments are
+// compared using their hash so that repeated instantiations with equivalent
+// types - for example two arrays of the same user type - share the same
+// instance.
+func (t *TypeTemplateExpr) Instance(args []DataType) *TypeInstanceExpr {
+	key := typeArgsHash(args)
+	for _, inst := range t.Instances {
+		if typeArgsHash(inst.Args) == key {
+			return inst
+		}
+	}
+	return nil
+}
+
+// TypeInstance returns the template instance that produced the given type,
+// nil if the type is not a template instance.
+func (r *RootExpr) TypeInstance(dt DataType) *TypeInstanceExpr {
+	for _, t := range r.TypeTemplates {
+		for _, inst := range t.Instances {
+			if inst.Type == dt {
+				return inst
+			}
+		}
+	}
+	return nil
+}
+
+// typeArgsHash computes a hash of the given template arguments. User types
+// are hashed using their names only so that the hash may be computed before
+// their DSL has executed.
+func typeArgsHash(args []DataType) string {
+	hashes := make([]string, len(args))
+	for i, arg := range args {
+		hashes[i] = Hash(arg, true, false, true)
+	}
+	return strings.Join(hashes, ",")
+}
+
+// typeArgName returns the name of the given template argument used to build
+// the instance name.
+func typeArgName(dt DataType) string {
+	switch actual := dt.(type) {
+	case *Array:
+		return "ArrayOf" + typeArgName(actual.ElemType.Type)
+	case *Map:
+		return "MapOf" + typeArgName(actual.KeyType.Type) + "To" + typeArgName(actual.ElemType.Type)
+	default:
+		name := dt.Name()
+		if name == "" {
+			return name
+		}
+		r, n := utf8.DecodeRuneInString(name)
+		return string(unicode.ToUpper(r)) + name[n:]
+	}
+}
@@ -0,0 +1,118 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+
+	. "goa.design/goa/v3/dsl"
+	"goa.design/goa/v3/expr"
+)
+
+var (
+	pageTemplate = TypeTemplate("Page", func(T expr.DataType) {
+		Attribute("items", ArrayOf(T))
+		Attribute("next_cursor", String)
+		Required("items")
+	})
+
+	pairTemplate = TypeTemplate("Pair", func(K, V expr.DataType) {
+		Attribute("key", K)
+		Attribute("value", V)
+	})
+)
+
+func TestTypeTemplateInstanceName(t *testing.T) {
+	user := &expr.UserTypeExpr{TypeName: "user", AttributeExpr: &expr.AttributeExpr{Type: &expr.Object{}}}
+	cases := []struct {
+		Name     string
+		Template *expr.TypeTemplateExpr
+		Args     []expr.DataType
+		Expected string
+	}{
+		{"user type", &expr.TypeTemplateExpr{Name: "Page"}, []expr.DataType{user}, "PageOfUser"},
+		{"primitive", &expr.TypeTemplateExpr{Name: "Page"}, []expr.DataType{expr.Int64}, "PageOfInt64"},
+		{"array", &expr.TypeTemplateExpr{Name: "Page"}, []expr.DataType{&expr.Array{ElemType: &expr.AttributeExpr{Type: user}}}, "PageOfArrayOfUser"},
+		{"map", &expr.TypeTemplateExpr{Name: "Page"}, []expr.DataType{&expr.Map{KeyType: &expr.AttributeExpr{Type: expr.String}, ElemType: &expr.AttributeExpr{Type: user}}}, "PageOfMapOfStringToUser"},
+		{"multiple", &expr.TypeTemplateExpr{Name: "Pair"}, []expr.DataType{expr.String, user}, "PairOfStringAndUser"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if got := c.Template.InstanceName(c.Args); got != c.Expected {
+				t.Errorf("got %q, expected %q", got, c.Expected)
+			}
+		})
+	}
+}
+
+func TestTypeTemplateInstantiate(t *testing.T) {
+	var (
+		first, second, arrays, pair expr.UserType
+	)
+	expr.RunDSL(t, func() {
+		User := Type("User", func() {
+			Attribute("name", String)
+		})
+		first = pageTemplate(User)
+		pair = pairTemplate(String, User)
+		Service("Service", func() {
+			Method("Method", func() {
+				Result(func() {
+					second = pageTemplate(User)
+					arrays = pageTemplate(ArrayOf(User))
+					Attribute("users", second)
+					Attribute("arrays", arrays)
+					Attribute("again", pageTemplate(ArrayOf(User)))
+				})
+			})
+		})
+	})
+	if first != second {
+		t.Errorf("expected repeated instantiations to return the same type")
+	}
+	if first.Name() != "PageOfUser" {
+		t.Errorf("got name %q, expected %q", first.Name(), "PageOfUser")
+	}
+	if got := expr.AsObject(first).Attribute("items"); got == nil {
+		t.Errorf("expected instance to define attribute %q", "items")
+	}
+	if got := expr.AsObject(arrays).Attribute("items"); got == nil {
+		t.Errorf("expected instance created during DSL execution to define attribute %q", "items")
+	}
+	if pair.Name() != "PairOfStringAndUser" {
+		t.Errorf("got name %q, expected %q", pair.Name(), "PairOfStringAndUser")
+	}
+	inst := expr.Root.TypeInstance(arrays)
+	if inst == nil {
+		t.Fatalf("expected %q to be a template instance", arrays.Name())
+	}
+	if len(inst.Template.Instances) != 2 {
+		t.Errorf("got %d instances, expected 2", len(inst.Template.Instances))
+	}
+	if expr.Root.TypeInstance(expr.Root.UserType("User")) != nil {
+		t.Errorf("expected User not to be a template instance")
+	}
+}
+
+func TestTypeTemplateErrors(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"arity", func() {
+			pageTemplate(String, String)
+		}, `type template "Page" expects 1 type argument(s), got 2`},
+		{"defined twice", func() {
+			Type("PageOfString", String)
+			pageTemplate(String)
+		}, `type "PageOfString" defined twice`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			err := expr.RunInvalidDSL(t, c.DSL)
+			if !strings.Contains(err.Error(), c.Error) {
+				t.Errorf("got error %q, expected %q", err.Error(), c.Error)
+			}
+		})
+	}
+}
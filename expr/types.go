@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"reflect"
 	"sort"
+	"strconv"
 
 	"goa.design/goa/v3/eval"
+	goa "goa.design/goa/v3/pkg"
 )
 
 type (
@@ -58,6 +60,11 @@ type (
 	Union struct {
 		TypeName string
 		Values   []*NamedAttributeExpr
+		// Discriminator is the name of the property that identifies the
+		// union value type in the HTTP representation of the union if
+		// any. Values of discriminated unions must be objects whose
+		// attributes are serialized inline next to the discriminator.
+		Discriminator string
 	}
 
 	// UserType is the interface implemented by all user type
@@ -127,6 +134,10 @@ const (
 	ResultTypeKind
 	// AnyKind represents an unknown type.
 	AnyKind
+	// DecimalKind represents an arbitrary-precision decimal number.
+	DecimalKind
+	// BigIntKind represents an arbitrary-precision integer.
+	BigIntKind
 )
 
 const (
@@ -165,6 +176,12 @@ const (
 
 	// Any is the type for an arbitrary JSON value (any in Go).
 	Any = Primitive(AnyKind)
+
+	// Decimal is the type for an arbitrary-precision decimal number.
+	Decimal = Primitive(DecimalKind)
+
+	// BigInt is the type for an arbitrary-precision integer.
+	BigInt = Primitive(BigIntKind)
 )
 
 // Built-in composite types
@@ -269,6 +286,18 @@ func IsAlias(dt DataType) bool {
 	return isut && IsPrimitive(dt)
 }
 
+// IsTypedEnum returns true if the data type is a user type that uses the
+// "type:enum" meta. The code generators produce a named Go type with a
+// constant for each enum value for such types.
+func IsTypedEnum(dt DataType) bool {
+	ut, ok := dt.(UserType)
+	if !ok {
+		return false
+	}
+	_, ok = ut.Attribute().Meta["type:enum"]
+	return ok
+}
+
 // Equal compares the types recursively and returns true if they are equal. Two
 // types are equal if:
 //
@@ -316,6 +345,10 @@ func (p Primitive) Name() string {
 		return "bytes"
 	case Any:
 		return "any"
+	case Decimal:
+		return "decimal"
+	case BigInt:
+		return "bigint"
 	default:
 		panic("unknown primitive type") // bug
 	}
@@ -326,18 +359,27 @@ func (p Primitive) IsCompatible(val any) bool {
 	if p == Any {
 		return true
 	}
-	switch val.(type) {
+	switch v := val.(type) {
 	case bool:
 		return p == Boolean
 	case int, int8, int16, int32, uint, uint8, uint16, uint32:
 		return p == Int || p == Int32 || p == Int64 ||
 			p == UInt || p == UInt32 || p == UInt64 ||
-			p == Float32 || p == Float64
+			p == Float32 || p == Float64 || p == Decimal || p == BigInt
 	case int64, uint64:
-		return p == Int64 || p == UInt64 || p == Float32 || p == Float64
+		return p == Int64 || p == UInt64 || p == Float32 || p == Float64 ||
+			p == Decimal || p == BigInt
 	case float32, float64:
-		return p == Float32 || p == Float64
+		return p == Float32 || p == Float64 || p == Decimal
 	case string:
+		switch p {
+		case Decimal:
+			_, err := goa.ParseDecimal(v)
+			return err == nil
+		case BigInt:
+			_, err := goa.ParseBigInt(v)
+			return err == nil
+		}
 		return p == String || p == Bytes
 	case []byte:
 		return p == Bytes
@@ -371,6 +413,10 @@ func (p Primitive) Example(r *ExampleGenerator) any {
 		return r.String()
 	case Bytes:
 		return []byte(r.String())
+	case Decimal:
+		return strconv.FormatFloat(r.Float64(), 'f', 2, 64)
+	case BigInt:
+		return strconv.FormatInt(r.Int64(), 10)
 	default:
 		panic("unknown primitive type") // bug
 	}
@@ -642,12 +688,56 @@ func (u *Union) IsCompatible(val any) bool {
 	return false
 }
 
+// validateDiscriminator makes sure that the values of a discriminated union are
+// objects that can be serialized inline next to the discriminator.
+func (u *Union) validateDiscriminator(parent eval.Expression) *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	fields := make(map[string]*NamedAttributeExpr)
+	for _, nat := range u.Values {
+		obj := AsObject(nat.Attribute.Type)
+		if obj == nil {
+			verr.Add(parent, "union type %s uses discriminator %q but value %q is not an object", u.Name(), u.Discriminator, nat.Name)
+			continue
+		}
+		for _, f := range *obj {
+			if f.Name == u.Discriminator {
+				if f.Attribute.Type != String {
+					verr.Add(parent, "union type %s value %q defines discriminator %q with type %s, must be String", u.Name(), nat.Name, f.Name, f.Attribute.Type.Name())
+				}
+				continue
+			}
+			if other, ok := fields[f.Name]; ok {
+				if other.Attribute.Type.Hash() != f.Attribute.Type.Hash() {
+					verr.Add(parent, "union type %s values %q and %q define attribute %q with different types", u.Name(), other.Name, nat.Name, f.Name)
+				}
+				continue
+			}
+			fields[f.Name] = &NamedAttributeExpr{Name: nat.Name, Attribute: f.Attribute}
+		}
+	}
+	return verr
+}
+
 // Example returns a random example value.
 func (u *Union) Example(r *ExampleGenerator) any {
 	if len(u.Values) == 0 {
 		return nil
 	}
-	return u.Values[r.Int()%len(u.Values)].Attribute.Example(r)
+	nat := u.Values[r.Int()%len(u.Values)]
+	ex := nat.Attribute.Example(r)
+	if u.Discriminator != "" {
+		if m, ok := ex.(map[string]any); ok {
+			// Copy the example as it may be a user example shared by
+			// other attributes.
+			cp := make(map[string]any, len(m)+1)
+			for k, v := range m {
+				cp[k] = v
+			}
+			cp[u.Discriminator] = nat.Name
+			ex = cp
+		}
+	}
+	return ex
 }
 
 // QualifiedTypeName returns the qualified type name for the given data type.
@@ -700,6 +790,9 @@ func toReflectType(dtype DataType) reflect.Type {
 		return reflect.TypeOf(float64(0))
 	case StringKind:
 		return reflect.TypeOf("")
+	case DecimalKind, BigIntKind:
+		// Examples are strings or JSON numbers depending on the encoding.
+		return reflect.TypeOf((*any)(nil)).Elem()
 	case BytesKind:
 		return reflect.TypeOf([]byte{})
 	case ObjectKind:
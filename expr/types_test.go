@@ -564,6 +564,8 @@ func TestPrimitiveIsCompatible(t *testing.T) {
 		f64  = float64(20.2)
 		s    = string("string")
 		bs   = []byte("bytes")
+		ds   = string("-12.50e3")
+		bis  = string("12345678901234567890")
 		ss   = []string{"foo", "bar"}
 		is   = []int{1, 2}
 	)
@@ -687,6 +689,26 @@ func TestPrimitiveIsCompatible(t *testing.T) {
 			values:   []any{b, i, i8, i16, i32, ui, ui8, ui16, ui32, i64, ui64, f32, f64},
 			expected: false,
 		},
+		"decimal compatible": {
+			p:        Decimal,
+			values:   []any{i, i8, i16, i32, ui, ui8, ui16, ui32, i64, ui64, f32, f64, ds, bis},
+			expected: true,
+		},
+		"decimal not compatible": {
+			p:        Decimal,
+			values:   []any{b, s, bs},
+			expected: false,
+		},
+		"bigint compatible": {
+			p:        BigInt,
+			values:   []any{i, i8, i16, i32, ui, ui8, ui16, ui32, i64, ui64, bis},
+			expected: true,
+		},
+		"bigint not compatible": {
+			p:        BigInt,
+			values:   []any{b, f32, f64, s, ds, bs},
+			expected: false,
+		},
 		"not supported types": {
 			p:        Boolean,
 			values:   []any{ss, is},
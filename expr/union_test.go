@@ -0,0 +1,129 @@
+package expr_test
+
+import (
+	"testing"
+
+	. "goa.design/goa/v3/dsl"
+	"goa.design/goa/v3/expr"
+)
+
+func TestUnionDiscriminatorValidation(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"valid", validDiscriminatorDSL, ""},
+		{"not object", notObjectDiscriminatorDSL, `service "Service" method "Method": union type method uses discriminator "kind" but value "code" is not an object`},
+		{"discriminator type", discriminatorTypeDSL, `service "Service" method "Method": union type method value "card" defines discriminator "kind" with type int, must be String`},
+		{"conflicting attributes", conflictingDiscriminatorDSL, `service "Service" method "Method": union type method values "card" and "bank" define attribute "number" with different types`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				expr.RunDSL(t, c.DSL)
+			} else {
+				err := expr.RunInvalidDSL(t, c.DSL)
+				if err.Error() != c.Error {
+					t.Errorf("got error %q, expected %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestDiscriminatedUnionToObject(t *testing.T) {
+	expr.RunDSL(t, validDiscriminatorDSL)
+	att := expr.Root.UserType("Payment").Attribute().Find("method")
+	obj := expr.AsObject(expr.UnionToObject(att).Type)
+	var names []string
+	for _, nat := range *obj {
+		names = append(names, nat.Name)
+	}
+	if len(names) != 4 || names[0] != "kind" || names[1] != "number" || names[2] != "iban" || names[3] != "bic" {
+		t.Fatalf("got attributes %v, expected [kind number iban bic]", names)
+	}
+	res := expr.UnionToObject(att)
+	if !res.IsRequired("kind") || res.IsRequired("number") {
+		t.Errorf("expected only the discriminator to be required")
+	}
+	if tag := obj.Attribute("number").Meta["struct:tag:json"]; len(tag) != 2 || tag[1] != "omitempty" {
+		t.Errorf("got json tag %v, expected omitempty", tag)
+	}
+}
+
+var validDiscriminatorDSL = func() {
+	var Card = Type("Card", func() {
+		Attribute("number", String)
+		Required("number")
+	})
+	var Bank = Type("Bank", func() {
+		Attribute("kind", String)
+		Attribute("iban", String)
+		Attribute("bic", String)
+	})
+	var Payment = Type("Payment", func() {
+		OneOf("method", func() {
+			Discriminator("kind")
+			Attribute("card", Card)
+			Attribute("bank", Bank)
+		})
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(Payment)
+		})
+	})
+}
+
+var notObjectDiscriminatorDSL = func() {
+	var Payment = Type("Payment", func() {
+		OneOf("method", func() {
+			Discriminator("kind")
+			Attribute("code", String)
+		})
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(Payment)
+		})
+	})
+}
+
+var discriminatorTypeDSL = func() {
+	var Card = Type("Card", func() {
+		Attribute("kind", Int)
+	})
+	var Payment = Type("Payment", func() {
+		OneOf("method", func() {
+			Discriminator("kind")
+			Attribute("card", Card)
+		})
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(Payment)
+		})
+	})
+}
+
+var conflictingDiscriminatorDSL = func() {
+	var Card = Type("Card", func() {
+		Attribute("number", String)
+	})
+	var Bank = Type("Bank", func() {
+		Attribute("number", Int)
+	})
+	var Payment = Type("Payment", func() {
+		OneOf("method", func() {
+			Discriminator("kind")
+			Attribute("card", Card)
+			Attribute("bank", Bank)
+		})
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(Payment)
+		})
+	})
+}
@@ -0,0 +1,269 @@
+package expr
+
+import (
+	"reflect"
+	"slices"
+
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// AfterRuleExpr describes a validation rule that requires the value of
+	// an attribute of an object to be after (greater than) the value of
+	// another attribute of the same object. The rule only applies when
+	// both attributes are set.
+	AfterRuleExpr struct {
+		// Attribute is the name of the attribute whose value must be
+		// after the value of Other.
+		Attribute string
+		// Other is the name of the attribute Attribute is compared to.
+		Other string
+	}
+
+	// RequiredIfRuleExpr describes a validation rule that makes attributes
+	// of an object required when another attribute of the same object has
+	// a given value.
+	RequiredIfRuleExpr struct {
+		// Attribute is the name of the attribute whose value is
+		// compared to Value.
+		Attribute string
+		// Value is the value that makes the Required attributes
+		// required.
+		Value any
+		// Required lists the names of the attributes required when
+		// Attribute equals Value.
+		Required []string
+	}
+
+	// VariantRuleExpr describes the validations that apply to an object
+	// when its discriminator attribute has a given value. Variant rules
+	// are used to validate the objects discriminated unions are serialized
+	// to: the object holds the attributes of all the union values but
+	// only the validations of the actual value apply.
+	VariantRuleExpr struct {
+		// Attribute is the name of the discriminator attribute.
+		Attribute string
+		// Value is the discriminator value that selects the variant.
+		Value string
+		// Validation holds the required attributes and the cross-field
+		// rules of the variant.
+		Validation *ValidationExpr
+	}
+)
+
+// HasRules returns true if the validation defines cross-field rules, that is
+// ExactlyOneOf, After, RequiredIf or variant rules.
+func (v *ValidationExpr) HasRules() bool {
+	return len(v.ExactlyOneOf) > 0 || len(v.After) > 0 || len(v.RequiredIf) > 0 || len(v.Variants) > 0
+}
+
+// AddExactlyOneOf adds a rule that requires exactly one of the given
+// attributes to be set.
+func (v *ValidationExpr) AddExactlyOneOf(names ...string) {
+	for _, n := range v.ExactlyOneOf {
+		if slices.Equal(n, names) {
+			return
+		}
+	}
+	v.ExactlyOneOf = append(v.ExactlyOneOf, names)
+}
+
+// AddAfter adds the given after rule unless v already has it.
+func (v *ValidationExpr) AddAfter(r *AfterRuleExpr) {
+	for _, a := range v.After {
+		if *a == *r {
+			return
+		}
+	}
+	v.After = append(v.After, r)
+}
+
+// AddRequiredIf adds the given required if rule unless v already has it.
+func (v *ValidationExpr) AddRequiredIf(r *RequiredIfRuleExpr) {
+	for _, ri := range v.RequiredIf {
+		if ri.Attribute == r.Attribute && reflect.DeepEqual(ri.Value, r.Value) && slices.Equal(ri.Required, r.Required) {
+			return
+		}
+	}
+	v.RequiredIf = append(v.RequiredIf, r)
+}
+
+// AddVariant adds the given variant rule unless v already has a rule for the
+// same discriminator value.
+func (v *ValidationExpr) AddVariant(r *VariantRuleExpr) {
+	for _, vr := range v.Variants {
+		if vr.Attribute == r.Attribute && vr.Value == r.Value {
+			return
+		}
+	}
+	v.Variants = append(v.Variants, r)
+}
+
+// mergeRules merges the cross-field rules of other into v.
+func (v *ValidationExpr) mergeRules(other *ValidationExpr) {
+	for _, names := range other.ExactlyOneOf {
+		v.AddExactlyOneOf(names...)
+	}
+	for _, r := range other.After {
+		v.AddAfter(r)
+	}
+	for _, r := range other.RequiredIf {
+		v.AddRequiredIf(r)
+	}
+	for _, r := range other.Variants {
+		v.AddVariant(r)
+	}
+}
+
+// validateRules makes sure the cross-field validation rules of the object
+// attribute a refer to existing attributes of the proper types.
+func (a *AttributeExpr) validateRules(ctx string, parent eval.Expression) *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	v := a.Validation
+	if v == nil {
+		return verr
+	}
+	find := func(rule, n string) *AttributeExpr {
+		att := a.Find(n)
+		if att == nil {
+			verr.Add(parent, "%s%s field %q does not exist in type %s", ctx, rule, n, a.Type.Name())
+			return nil
+		}
+		if att.IsNullable() {
+			verr.Add(parent, "%s%s field %q cannot be nullable", ctx, rule, n)
+			return nil
+		}
+		return att
+	}
+	for _, names := range v.ExactlyOneOf {
+		if len(names) < 2 {
+			verr.Add(parent, "%sExactlyOneOf requires at least two fields", ctx)
+		}
+		for _, n := range names {
+			att := find("ExactlyOneOf", n)
+			if att == nil {
+				continue
+			}
+			if a.IsRequired(n) {
+				verr.Add(parent, "%sExactlyOneOf field %q cannot be required", ctx, n)
+			}
+			if att.DefaultValue != nil {
+				verr.Add(parent, "%sExactlyOneOf field %q cannot have a default value", ctx, n)
+			}
+		}
+	}
+	for _, r := range v.After {
+		att, other := find("After", r.Attribute), find("After", r.Other)
+		if att == nil || other == nil {
+			continue
+		}
+		if !IsOrdered(att) {
+			verr.Add(parent, "%sAfter field %q must be a number or a string with the date, date-time or duration format", ctx, r.Attribute)
+			continue
+		}
+		if att.Type != other.Type || att.IsTime() != other.IsTime() || formatOf(att) != formatOf(other) {
+			verr.Add(parent, "%sAfter fields %q and %q must have the same type and format", ctx, r.Attribute, r.Other)
+		}
+	}
+	for _, r := range v.RequiredIf {
+		if att := find("RequiredIf", r.Attribute); att != nil {
+			switch {
+			case !IsPrimitive(att.Type) || att.Type == Any || att.Type == Bytes || att.IsTime():
+				verr.Add(parent, "%sRequiredIf field %q must be a string, number or boolean", ctx, r.Attribute)
+			case !att.Type.IsCompatible(r.Value):
+				verr.Add(parent, "%sRequiredIf value %#v is not compatible with the type of field %q", ctx, r.Value, r.Attribute)
+			}
+		}
+		for _, n := range r.Required {
+			find("RequiredIf", n)
+		}
+	}
+	return verr
+}
+
+// IsOrdered returns true if the values of the given attribute can be compared
+// by an After validation rule: numbers and strings with the date, date-time or
+// duration format.
+func IsOrdered(att *AttributeExpr) bool {
+	switch att.Type {
+	case Int, Int32, Int64, UInt, UInt32, UInt64, Float32, Float64:
+		return true
+	case String:
+		switch formatOf(att) {
+		case FormatDate, FormatDateTime, FormatDuration:
+			return true
+		}
+	}
+	return false
+}
+
+// formatOf returns the format validation of the given attribute if any.
+func formatOf(att *AttributeExpr) ValidationFormat {
+	if att.Validation == nil {
+		return ""
+	}
+	return att.Validation.Format
+}
+
+// ruleAttributes returns the names of the attributes involved in each of the
+// cross-field rules of v.
+func (v *ValidationExpr) ruleAttributes() [][]string {
+	var res [][]string
+	res = append(res, v.ExactlyOneOf...)
+	for _, r := range v.After {
+		res = append(res, []string{r.Attribute, r.Other})
+	}
+	for _, r := range v.RequiredIf {
+		res = append(res, append([]string{r.Attribute}, r.Required...))
+	}
+	return res
+}
+
+// rulesOn returns a validation holding the cross-field rules of v that only
+// involve attributes of obj.
+func (v *ValidationExpr) rulesOn(obj *Object) *ValidationExpr {
+	has := func(names ...string) bool {
+		for _, n := range names {
+			if obj.Attribute(n) == nil {
+				return false
+			}
+		}
+		return true
+	}
+	res := &ValidationExpr{}
+	for _, names := range v.ExactlyOneOf {
+		if has(names...) {
+			res.AddExactlyOneOf(names...)
+		}
+	}
+	for _, r := range v.After {
+		if has(r.Attribute, r.Other) {
+			res.AddAfter(r)
+		}
+	}
+	for _, r := range v.RequiredIf {
+		if has(append([]string{r.Attribute}, r.Required...)...) {
+			res.AddRequiredIf(r)
+		}
+	}
+	return res
+}
+
+// validatePayloadRules makes sure the cross-field validation rules defined on
+// the given method payload do not involve attributes for which outside returns
+// true. The generated code only enforces rules that apply to attributes of the
+// same transport object (HTTP request body or gRPC request message).
+func validatePayloadRules(parent eval.Expression, payload *AttributeExpr, location string, outside func(string) bool) *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if payload == nil || payload.Validation == nil || !IsObject(payload.Type) {
+		return verr
+	}
+	for _, names := range payload.Validation.ruleAttributes() {
+		for _, n := range names {
+			if outside(n) {
+				verr.Add(parent, "attribute %q is used in a cross-field validation rule and must be mapped to the %s", n, location)
+			}
+		}
+	}
+	return verr
+}
@@ -0,0 +1,268 @@
+package expr_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	. "goa.design/goa/v3/dsl"
+	"goa.design/goa/v3/expr"
+)
+
+func TestValidationRules(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"valid", validRulesDSL, ""},
+		{"missing field", missingFieldRulesDSL, `service "Service" method "Method": payload - ExactlyOneOf field "phone" does not exist in type object`},
+		{"exactly one of required", requiredExactlyOneOfDSL, `service "Service" method "Method": payload - ExactlyOneOf field "email" cannot be required`},
+		{"exactly one of single field", singleExactlyOneOfDSL, `service "Service" method "Method": payload - ExactlyOneOf requires at least two fields`},
+		{"after not ordered", unorderedAfterDSL, `service "Service" method "Method": payload - After field "end" must be a number or a string with the date, date-time or duration format`},
+		{"after type mismatch", mismatchAfterDSL, `service "Service" method "Method": payload - After fields "max" and "min" must have the same type and format`},
+		{"required if value", incompatibleRequiredIfDSL, `service "Service" method "Method": payload - RequiredIf value "many" is not compatible with the type of field "count"`},
+		{"param", paramRulesDSL, `service "Service" HTTP endpoint "Method": attribute "email" is used in a cross-field validation rule and must be mapped to the request body`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				expr.RunDSL(t, c.DSL)
+			} else {
+				err := expr.RunInvalidDSL(t, c.DSL)
+				if err.Error() != c.Error {
+					t.Errorf("got error %q, expected %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestValidationRulesDSL(t *testing.T) {
+	expr.RunDSL(t, validRulesDSL)
+	v := expr.Root.Services[0].Methods[0].Payload.Validation
+	if len(v.ExactlyOneOf) != 1 || len(v.ExactlyOneOf[0]) != 2 {
+		t.Errorf("got ExactlyOneOf %v, expected one group of two fields", v.ExactlyOneOf)
+	}
+	if len(v.After) != 1 || v.After[0].Attribute != "end" || v.After[0].Other != "start" {
+		t.Errorf("got After %v, expected end after start", v.After)
+	}
+	if len(v.RequiredIf) != 1 || v.RequiredIf[0].Value != "rejected" {
+		t.Errorf("got RequiredIf %v, expected reason required if status is rejected", v.RequiredIf)
+	}
+}
+
+func TestValidationRulesExample(t *testing.T) {
+	expr.RunDSL(t, rulesExampleDSL)
+	payload := expr.Root.Services[0].Methods[0].Payload
+	for i := 0; i < 50; i++ {
+		ex, ok := payload.Example(expr.NewRandom(fmt.Sprint(i))).(map[string]any)
+		if !ok {
+			t.Fatalf("got example %#v, expected a map", ex)
+		}
+		_, email := ex["email"]
+		_, sms := ex["sms"]
+		if email == sms {
+			t.Errorf("got example %v, expected exactly one of email and sms", ex)
+		}
+		if ex["max"].(int) <= ex["min"].(int) {
+			t.Errorf("got max %v and min %v, expected max after min", ex["max"], ex["min"])
+		}
+		if ex["end"].(string) <= ex["start"].(string) {
+			t.Errorf("got end %v and start %v, expected end after start", ex["end"], ex["start"])
+		}
+		if _, ok := ex["reason"]; ex["status"] == "rejected" && !ok {
+			t.Errorf("got example %v, expected reason to be set", ex)
+		}
+		if err := payload.ValidateExample("payload", ex); err != nil {
+			t.Errorf("got example %v, expected it to validate: %v", ex, err)
+		}
+	}
+}
+
+func TestValidateExample(t *testing.T) {
+	expr.RunDSL(t, rulesExampleDSL)
+	payload := expr.Root.Services[0].Methods[0].Payload
+	cases := []struct {
+		Name    string
+		Example map[string]any
+		Error   string
+	}{
+		{"valid", map[string]any{"email": "a", "min": 1, "max": 2}, ""},
+		{"exactly-one-of", map[string]any{"email": "a", "sms": "b"}, "exactly one of email, sms must be set in payload but got 2"},
+		{"after", map[string]any{"email": "a", "min": 2, "max": 1}, "payload.max must be after min"},
+		{"required-if", map[string]any{"email": "a", "status": "rejected"}, `"reason" is missing from payload`},
+		{"enum", map[string]any{"email": "a", "status": "pending"}, "payload.status must be one of"},
+		{"range", map[string]any{"email": "a", "min": 4}, "payload.min must be lesser or equal than 3"},
+		{"format", map[string]any{"email": "a", "start": "not a date"}, "payload.start must be formatted as a date"},
+		{"custom-format", map[string]any{"email": "a", "phone": "+15551234567"}, ""},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			err := payload.ValidateExample("payload", c.Example)
+			if c.Error == "" {
+				if err != nil {
+					t.Errorf("got error %q, expected none", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), c.Error) {
+				t.Errorf("got error %v, expected %q", err, c.Error)
+			}
+		})
+	}
+}
+
+var rulesExampleDSL = func() {
+	var Phone = CustomFormat("phone", func() {
+		Validator("example.com/formats", "ValidatePhone")
+		Example("+15551234567")
+	})
+	var Record = Type("Record", func() {
+		Attribute("email", String)
+		Attribute("sms", String)
+		Attribute("min", Int, func() {
+			Minimum(0)
+			Maximum(3)
+		})
+		Attribute("max", Int, func() {
+			Minimum(0)
+			Maximum(3)
+		})
+		Attribute("start", String, func() {
+			Format(FormatDate)
+		})
+		Attribute("end", String, func() {
+			Format(FormatDate)
+		})
+		Attribute("status", String, func() {
+			Enum("approved", "rejected")
+		})
+		Attribute("reason", String)
+		Attribute("phone", String, func() {
+			Format(Phone)
+		})
+		ExactlyOneOf("email", "sms")
+		After("max", "min")
+		After("end", "start")
+		RequiredIf("status", "rejected", "reason")
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(Record)
+		})
+	})
+}
+
+var validRulesDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("id", Int)
+				Attribute("email", String)
+				Attribute("phone", String)
+				Attribute("start", String, func() {
+					Format(FormatDateTime)
+				})
+				Attribute("end", String, func() {
+					Format(FormatDateTime)
+				})
+				Attribute("status", String)
+				Attribute("reason", String)
+				ExactlyOneOf("email", "phone")
+				After("end", "start")
+				RequiredIf("status", "rejected", "reason")
+			})
+			HTTP(func() {
+				POST("/{id}")
+			})
+		})
+	})
+}
+
+var missingFieldRulesDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("email", String)
+				ExactlyOneOf("email", "phone")
+			})
+		})
+	})
+}
+
+var requiredExactlyOneOfDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("email", String)
+				Attribute("phone", String)
+				Required("email")
+				ExactlyOneOf("email", "phone")
+			})
+		})
+	})
+}
+
+var singleExactlyOneOfDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("email", String)
+				ExactlyOneOf("email")
+			})
+		})
+	})
+}
+
+var unorderedAfterDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("start", String)
+				Attribute("end", String)
+				After("end", "start")
+			})
+		})
+	})
+}
+
+var mismatchAfterDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("min", Int)
+				Attribute("max", Float64)
+				After("max", "min")
+			})
+		})
+	})
+}
+
+var incompatibleRequiredIfDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("count", Int)
+				Attribute("reason", String)
+				RequiredIf("count", "many", "reason")
+			})
+		})
+	})
+}
+
+var paramRulesDSL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("email", String)
+				Attribute("phone", String)
+				ExactlyOneOf("email", "phone")
+			})
+			HTTP(func() {
+				POST("/")
+				Param("email")
+			})
+		})
+	})
+}
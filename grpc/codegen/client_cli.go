@@ -79,6 +79,12 @@ func endpointParser(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr, da
 			Path: path.Join(genpkg, "grpc", svcName, pbPkgName),
 			Name: svcName + pbPkgName,
 		})
+		if svc.ServiceExpr.HasPagination() {
+			specs = append(specs, &codegen.ImportSpec{
+				Path: path.Join(genpkg, svcName),
+				Name: sd.Service.PkgName,
+			})
+		}
 		specs = append(specs, sd.Service.UserTypeImports...)
 	}
 
@@ -0,0 +1,174 @@
+package codegen
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/service"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// ContractTestData contains the data needed to render the contract
+	// tests of a service.
+	ContractTestData struct {
+		// Service is the gRPC service data.
+		Service *ServiceData
+		// MockPkg is the name of the service mock package.
+		MockPkg string
+		// ClientPkg is the name of the gRPC client package.
+		ClientPkg string
+		// ServerPkg is the name of the gRPC server package.
+		ServerPkg string
+		// ServerInterceptors is true if the service defines server
+		// interceptors.
+		ServerInterceptors bool
+		// Endpoints lists the data needed to test each endpoint.
+		Endpoints []*ContractEndpointData
+	}
+
+	// ContractEndpointData contains the data needed to render the contract
+	// test of an endpoint.
+	ContractEndpointData struct {
+		*EndpointData
+		// Mock is the data needed to call the method mock.
+		Mock *service.MockMethodData
+		// Skip is the reason why the endpoint is not tested if any.
+		Skip string
+		// Headers lists the names of the metadata that the response
+		// headers must define.
+		Headers []string
+		// Errors lists the errors returned by the mock together with
+		// the status code of the corresponding responses.
+		Errors []*ContractErrorData
+	}
+
+	// ContractErrorData contains the data needed to test the encoding of
+	// an error.
+	ContractErrorData struct {
+		// Name is the error name as defined in the design.
+		Name string
+		// StatusCode is the gRPC status code of the error response.
+		StatusCode string
+	}
+)
+
+// ContractTestFiles returns the files that test the gRPC server and client of
+// the services that enable contract tests with the "test:contract" meta. The
+// tests serve the service mocks with the generated server over an in-memory
+// connection, call each endpoint with the generated client using the design
+// examples and check that the payloads, results and errors make the round
+// trip.
+func ContractTestFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
+	var files []*codegen.File
+	for _, svc := range root.API.GRPC.Services {
+		if len(svc.GRPCEndpoints) == 0 || !service.HasContractTests(root, svc.ServiceExpr) {
+			continue
+		}
+		files = append(files, contractTestFile(genpkg, svc))
+	}
+	return files
+}
+
+// contractTestFile returns the file that implements the contract tests of the
+// given service.
+func contractTestFile(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File {
+	data := GRPCServices.Get(svc.Name())
+	td := buildContractTestData(data, svc.ServiceExpr)
+	specs := []*codegen.ImportSpec{
+		{Path: "context"},
+		{Path: "encoding/json"},
+		{Path: "errors"},
+		{Path: "net"},
+		{Path: "reflect"},
+		{Path: "testing"},
+		{Path: "time"},
+		{Path: "google.golang.org/grpc"},
+		{Path: "google.golang.org/grpc/codes"},
+		{Path: "google.golang.org/grpc/credentials/insecure"},
+		{Path: "google.golang.org/grpc/metadata"},
+		{Path: "google.golang.org/grpc/status"},
+		{Path: "google.golang.org/grpc/test/bufconn"},
+		codegen.GoaImport(""),
+		{Path: path.Join(genpkg, data.Service.PathName), Name: data.Service.PkgName},
+		{Path: path.Join(genpkg, data.Service.PathName, "mock"), Name: td.MockPkg},
+		{Path: path.Join(genpkg, "grpc", data.Service.PathName, "client"), Name: td.ClientPkg},
+		{Path: path.Join(genpkg, "grpc", data.Service.PathName, "server"), Name: td.ServerPkg},
+		{Path: path.Join(genpkg, "grpc", data.Service.PathName, pbPkgName), Name: data.PkgName},
+	}
+	specs = append(specs, data.Service.UserTypeImports...)
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(svc.Name()+" gRPC contract tests", "server_test", specs),
+		{
+			Name:    "contract-test",
+			Source:  readTemplate("contract_test"),
+			Data:    td,
+			FuncMap: map[string]any{"goify": codegen.Goify, "hasPtr": contractHasPtr},
+		},
+	}
+	return &codegen.File{
+		Path:             filepath.Join(codegen.Gendir, "grpc", data.Service.PathName, "server", "contract_test.go"),
+		SectionTemplates: sections,
+	}
+}
+
+// buildContractTestData builds the data needed to render the contract tests of
+// the given service.
+func buildContractTestData(data *ServiceData, svc *expr.ServiceExpr) *ContractTestData {
+	var (
+		mocks = make(map[string]*service.MockMethodData)
+		scope = codegen.NewNameScope()
+	)
+	for _, m := range service.MockMethods(svc) {
+		mocks[m.Name] = m
+	}
+	scope.Unique(data.Service.PkgName)
+	scope.Unique(data.PkgName)
+	td := &ContractTestData{
+		Service:            data,
+		MockPkg:            scope.Unique(data.Service.PkgName + "mock"),
+		ClientPkg:          scope.Unique(data.Service.PkgName + "c"),
+		ServerPkg:          scope.Unique(data.Service.PkgName + "svr"),
+		ServerInterceptors: len(data.Service.ServerInterceptors) > 0,
+	}
+	for _, e := range data.Endpoints {
+		ed := &ContractEndpointData{EndpointData: e, Mock: mocks[e.Method.Name]}
+		switch {
+		case e.ServerStream != nil || e.ClientStream != nil:
+			ed.Skip = "streaming endpoints are not tested"
+		case ed.Mock.ExampleError != "":
+			ed.Skip = "the design examples do not validate: " + ed.Mock.ExampleError
+		case e.Method.Payload != "" && ed.Mock.PayloadValue == "":
+			ed.Skip = "the payload cannot be initialized from the design example"
+		}
+		if e.Response != nil {
+			for _, h := range e.Response.Headers {
+				if h.Required {
+					ed.Headers = append(ed.Headers, h.Name)
+				}
+			}
+		}
+		for _, me := range ed.Mock.Errors {
+			for _, er := range e.Errors {
+				if er.Name == me.Name {
+					ed.Errors = append(ed.Errors, &ContractErrorData{Name: me.Name, StatusCode: er.Response.StatusCode})
+				}
+			}
+		}
+		td.Endpoints = append(td.Endpoints, ed)
+	}
+	return td
+}
+
+// contractHasPtr returns true if the payload of a tested endpoint is
+// initialized with the ptr helper function.
+func contractHasPtr(endpoints []*ContractEndpointData) bool {
+	for _, e := range endpoints {
+		if e.Skip == "" && strings.Contains(e.Mock.PayloadValue, "ptr(") {
+			return true
+		}
+	}
+	return false
+}
@@ -7,7 +7,9 @@ compiler (protoc) generated clients and servers.
 The code generator uses "proto3" syntax for generating the proto files.
 
 The code generator compiles the proto files using the protocol buffer compiler
-(protoc) with the gRPC in Go plugin. It hooks up the generated protocol buffer
+(protoc) with the gRPC in Go plugin. Alternatively the "protoc:compiler" meta
+may be set to "builtin" to compile the proto files in-process without requiring
+protoc or the plugins to be installed. It hooks up the generated protocol buffer
 types to the goa generated types as follows:
 
   - It generates a server that implements the protoc-generated gRPC server interface.
@@ -0,0 +1,140 @@
+package codegen
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/service"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// FuzzTestData contains the data needed to render the fuzz tests of a
+	// service.
+	FuzzTestData struct {
+		// Service is the gRPC service data.
+		Service *ServiceData
+		// ClientPkg is the name of the gRPC client package.
+		ClientPkg string
+		// Endpoints lists the data needed to fuzz the request decoder of
+		// each endpoint.
+		Endpoints []*FuzzEndpointData
+	}
+
+	// FuzzEndpointData contains the data needed to render the fuzz test of
+	// an endpoint request decoder.
+	FuzzEndpointData struct {
+		*EndpointData
+		// PayloadValue is the code that initializes the payload with the
+		// design example if any. It is empty if the example does not
+		// validate so that the target is not seeded with a request the
+		// decoder rejects.
+		PayloadValue string
+		// MessageType is the name of the request message type qualified
+		// with the package name.
+		MessageType string
+	}
+)
+
+// FuzzTestFiles returns the files that implement the fuzz tests of the gRPC
+// request decoders of the services that enable fuzz tests with the "test:fuzz"
+// meta. The fuzz targets are seeded with the messages and metadata built from
+// the design examples by the generated client.
+func FuzzTestFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
+	var files []*codegen.File
+	for _, svc := range root.API.GRPC.Services {
+		if !service.HasFuzzTests(root, svc.ServiceExpr) {
+			continue
+		}
+		if f := fuzzTestFile(genpkg, svc); f != nil {
+			files = append(files, f)
+		}
+	}
+	return files
+}
+
+// fuzzTestFile returns the file that implements the fuzz tests of the given
+// service, nil if the service has no request decoder to fuzz.
+func fuzzTestFile(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File {
+	data := GRPCServices.Get(svc.Name())
+	td := buildFuzzTestData(data, svc.ServiceExpr)
+	if len(td.Endpoints) == 0 {
+		return nil
+	}
+	specs := []*codegen.ImportSpec{
+		{Path: "context"},
+		{Path: "errors"},
+		{Path: "testing"},
+		{Path: "time"},
+		{Path: "google.golang.org/grpc/metadata"},
+		{Path: "google.golang.org/protobuf/proto"},
+		codegen.GoaImport(""),
+		{Path: path.Join(genpkg, data.Service.PathName), Name: data.Service.PkgName},
+		{Path: path.Join(genpkg, "grpc", data.Service.PathName, "client"), Name: td.ClientPkg},
+		{Path: path.Join(genpkg, "grpc", data.Service.PathName, pbPkgName), Name: data.PkgName},
+	}
+	specs = append(specs, data.Service.UserTypeImports...)
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(svc.Name()+" gRPC request decoder fuzz tests", "server", specs),
+	}
+	for _, e := range td.Endpoints {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:    "fuzz-test",
+			Source:  readTemplate("fuzz_test"),
+			Data:    map[string]any{"ClientPkg": td.ClientPkg, "Endpoint": e},
+			FuncMap: map[string]any{"goify": codegen.Goify},
+		})
+	}
+	sections = append(sections, &codegen.SectionTemplate{
+		Name:    "fuzz-test-helpers",
+		Source:  readTemplate("fuzz_test_helpers"),
+		Data:    td,
+		FuncMap: map[string]any{"hasPtr": fuzzHasPtr},
+	})
+	return &codegen.File{
+		Path:             filepath.Join(codegen.Gendir, "grpc", data.Service.PathName, "server", "fuzz_test.go"),
+		SectionTemplates: sections,
+	}
+}
+
+// buildFuzzTestData builds the data needed to render the fuzz tests of the
+// given service. Only the unary endpoints with payloads are fuzzed.
+func buildFuzzTestData(data *ServiceData, svc *expr.ServiceExpr) *FuzzTestData {
+	var (
+		values = make(map[string]string)
+		scope  = codegen.NewNameScope()
+	)
+	for _, m := range service.MockMethods(svc) {
+		values[m.Name] = m.PayloadValue
+	}
+	scope.Unique(data.Service.PkgName)
+	scope.Unique(data.PkgName)
+	td := &FuzzTestData{
+		Service:   data,
+		ClientPkg: scope.Unique(data.Service.PkgName + "c"),
+	}
+	for _, e := range data.Endpoints {
+		if e.PayloadRef == "" || e.ServerStream != nil || e.ClientStream != nil {
+			continue
+		}
+		td.Endpoints = append(td.Endpoints, &FuzzEndpointData{
+			EndpointData: e,
+			PayloadValue: values[e.Method.Name],
+			MessageType:  strings.TrimPrefix(e.Request.Message.Ref, "*"),
+		})
+	}
+	return td
+}
+
+// fuzzHasPtr returns true if the payload of a fuzzed endpoint is initialized
+// with the ptr helper function.
+func fuzzHasPtr(endpoints []*FuzzEndpointData) bool {
+	for _, e := range endpoints {
+		if strings.Contains(e.PayloadValue, "ptr(") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+package codegen
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/service"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/grpc/codegen/testdata"
+)
+
+func TestFuzzTestFiles(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+	}{
+		{"fuzz", testdata.FuzzDSL},
+		{"fuzz-enum", testdata.FuzzEnumDSL},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			// reset global variable
+			GRPCServices = make(ServicesData)
+			service.Services = make(service.ServicesData)
+			codegen.RunDSL(t, c.DSL)
+			fs := FuzzTestFiles("", expr.Root)
+			require.Len(t, fs, 1)
+			assert.Equal(t, filepath.Join("gen", "grpc", "service_fuzz", "server", "fuzz_test.go"), fs[0].Path)
+			var buf bytes.Buffer
+			for _, s := range fs[0].SectionTemplates[1:] {
+				require.NoError(t, s.Write(&buf))
+			}
+			code := codegen.FormatTestCode(t, "package foo\n"+buf.String())
+			golden := filepath.Join("testdata", "fuzz-test-"+c.Name+".golden")
+			compareOrUpdateGolden(t, code, golden)
+		})
+	}
+}
+
+func TestFuzzTestFilesDisabled(t *testing.T) {
+	GRPCServices = make(ServicesData)
+	service.Services = make(service.ServicesData)
+	codegen.RunDSL(t, testdata.FuzzDisabledDSL)
+	assert.Empty(t, FuzzTestFiles("", expr.Root))
+}
@@ -0,0 +1,66 @@
+package codegen
+
+import (
+	"path"
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+// MockServerFile returns the file that serves the service mocks over gRPC in
+// the mock server generated by service.MockServerFiles, nil if the API does
+// not define gRPC services.
+func MockServerFile(genpkg string, root *expr.RootExpr) *codegen.File {
+	var (
+		svcdata []*ServiceData
+
+		scope = codegen.NewNameScope()
+		specs = []*codegen.ImportSpec{
+			{Path: "context"},
+			{Path: "log"},
+			{Path: "net"},
+			{Path: "sync"},
+			{Path: "google.golang.org/grpc"},
+			{Path: "google.golang.org/grpc/metadata"},
+			{Path: "google.golang.org/grpc/reflection"},
+		}
+	)
+	for _, svc := range root.Services {
+		if root.API.GRPC.Service(svc.Name) == nil || len(svc.Methods) == 0 {
+			continue
+		}
+		sd := GRPCServices.Get(svc.Name)
+		svcdata = append(svcdata, sd)
+		specs = append(specs,
+			&codegen.ImportSpec{
+				Path: path.Join(genpkg, "grpc", sd.Service.PathName, "server"),
+				Name: scope.Unique(sd.Service.PkgName + "svr"),
+			},
+			&codegen.ImportSpec{
+				Path: path.Join(genpkg, sd.Service.PathName),
+				Name: sd.Service.PkgName,
+			},
+			&codegen.ImportSpec{
+				Path: path.Join(genpkg, "grpc", sd.Service.PathName, pbPkgName),
+				Name: sd.PkgName,
+			},
+		)
+	}
+	if len(svcdata) == 0 {
+		return nil
+	}
+	sections := []*codegen.SectionTemplate{
+		codegen.Header("Mock gRPC server", "main", specs),
+		{
+			Name:    "mock-server-grpc",
+			Source:  readTemplate("mock_server"),
+			Data:    map[string]any{"Services": svcdata},
+			FuncMap: map[string]any{"goify": codegen.Goify},
+		},
+	}
+	return &codegen.File{
+		Path:             filepath.Join(codegen.Gendir, "mockserver", "grpc.go"),
+		SectionTemplates: sections,
+	}
+}
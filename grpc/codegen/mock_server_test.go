@@ -0,0 +1,29 @@
+package codegen
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"goa.design/goa/v3/codegen"
+	ctestdata "goa.design/goa/v3/codegen/example/testdata"
+	"goa.design/goa/v3/codegen/service"
+	"goa.design/goa/v3/expr"
+)
+
+func TestMockServerFile(t *testing.T) {
+	// reset global variable
+	GRPCServices = make(ServicesData)
+	service.Services = make(service.ServicesData)
+	codegen.RunDSL(t, ctestdata.ServerHostingMultipleServicesDSL)
+	f := MockServerFile("", expr.Root)
+	require.NotNil(t, f)
+	var buf bytes.Buffer
+	for _, s := range f.SectionTemplates[1:] {
+		require.NoError(t, s.Write(&buf))
+	}
+	code := codegen.FormatTestCode(t, "package foo\n"+buf.String())
+	golden := filepath.Join("testdata", "mock-server.golden")
+	compareOrUpdateGolden(t, code, golden)
+}
@@ -83,6 +83,9 @@ func protoFile(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File {
 	runProtoc := func(path string) error {
 		includes := svc.ServiceExpr.Meta["protoc:include"]
 		includes = append(includes, expr.Root.API.Meta["protoc:include"]...)
+		if protocCompiler(svc) == ProtocCompilerBuiltin {
+			return compileProto(path, includes)
+		}
 		return protoc(path, includes)
 	}
 
@@ -100,6 +103,19 @@ func pkgName(svc *expr.GRPCServiceExpr, svcName string) string {
 	return codegen.SnakeCase(svcName)
 }
 
+// protocCompiler returns the compiler used to compile the .proto file of the
+// given service as specified by the "protoc:compiler" meta of the service or of
+// the API.
+func protocCompiler(svc *expr.GRPCServiceExpr) string {
+	if c, ok := svc.ServiceExpr.Meta.Last("protoc:compiler"); ok {
+		return c
+	}
+	if c, ok := expr.Root.API.Meta.Last("protoc:compiler"); ok {
+		return c
+	}
+	return ProtocCompilerProtoc
+}
+
 func protoc(path string, includes []string) error {
 	dir := filepath.Dir(path)
 	if err := os.MkdirAll(dir, 0750); err != nil {
@@ -1,6 +1,8 @@
 package codegen
 
 import (
+	"os"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"testing"
@@ -89,3 +91,52 @@ func TestMessageDefSection(t *testing.T) {
 		})
 	}
 }
+
+func TestCompileProto(t *testing.T) {
+	cases := []struct {
+		Name     string
+		DSL      func()
+		Contains []string
+	}{
+		{"unary-rpcs", testdata.UnaryRPCsDSL, []string{
+			"func RegisterServiceUnaryRPCsServer(s grpc.ServiceRegistrar, srv ServiceUnaryRPCsServer)",
+			"func NewServiceUnaryRPCsClient(cc grpc.ClientConnInterface) ServiceUnaryRPCsClient",
+			"type UnimplementedServiceUnaryRPCsServer struct{}",
+		}},
+		{"server-streaming-rpc", testdata.ServerStreamingRPCDSL, []string{
+			"type ServiceServerStreamingRPC_MethodServerStreamingRPCServer = grpc.ServerStreamingServer[MethodServerStreamingRPCResponse]",
+			"type ServiceServerStreamingRPC_MethodServerStreamingRPCClient = grpc.ServerStreamingClient[MethodServerStreamingRPCResponse]",
+		}},
+		{"client-streaming-rpc", testdata.ClientStreamingRPCDSL, []string{
+			"type ServiceClientStreamingRPC_MethodClientStreamingRPCServer = grpc.ClientStreamingServer[",
+		}},
+		{"bidirectional-streaming-rpc", testdata.BidirectionalStreamingRPCDSL, []string{
+			"type ServiceBidirectionalStreamingRPC_MethodBidirectionalStreamingRPCServer = grpc.BidiStreamingServer[",
+		}},
+		{"custom-package-name", testdata.ServiceWithPackageDSL, []string{
+			"package custompb",
+		}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunGRPCDSL(t, c.DSL)
+			fs := ProtoFiles("", expr.Root)
+			require.Len(t, fs, 1)
+			code := sectionCode(t, fs[0].SectionTemplates[1:]...)
+			dir := t.TempDir()
+			fpath := filepath.Join(dir, "goagen_test.proto")
+			require.NoError(t, os.WriteFile(fpath, []byte(code), 0600))
+
+			require.NoError(t, compileProto(fpath, nil))
+
+			pb, err := os.ReadFile(filepath.Join(dir, "goagen_test.pb.go"))
+			require.NoError(t, err)
+			assert.Contains(t, string(pb), "// source: goagen_test.proto")
+			grpc, err := os.ReadFile(filepath.Join(dir, "goagen_test_grpc.pb.go"))
+			require.NoError(t, err)
+			for _, s := range c.Contains {
+				assert.Contains(t, string(grpc)+string(pb), s)
+			}
+		})
+	}
+}
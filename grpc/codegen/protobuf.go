@@ -9,6 +9,7 @@ import (
 	"goa.design/goa/v3/expr"
 
 	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/service"
 )
 
 type (
@@ -113,6 +114,12 @@ func makeProtoBufMessageR(att *expr.AttributeExpr, tname *string, sd *ServiceDat
 
 	switch {
 	case expr.IsPrimitive(att.Type):
+		if att.IsTime() && att.Meta["struct:field:proto"] == nil {
+			att.AddMeta("struct:field:proto", protoBufTimeMeta(att)...)
+		}
+		if att.IsNullable() && att.Meta["struct:field:proto"] == nil {
+			att.AddMeta("struct:field:proto", protoBufNullableMeta(att)...)
+		}
 		return
 	case isut:
 		if expr.IsArray(ut) {
@@ -291,6 +298,9 @@ func protoBufMessageDef(att *expr.AttributeExpr, sd *ServiceData) string {
 		if actual == expr.Empty {
 			return " {}"
 		}
+		if isProtoBufEnum(att) {
+			return protoBufMessageName(att, sd.Scope)
+		}
 		if prim := getPrimitive(att); prim != nil {
 			return protoBufMessageDef(prim, sd)
 		}
@@ -334,6 +344,79 @@ func protoBufMessageDef(att *expr.AttributeExpr, sd *ServiceData) string {
 	}
 }
 
+// isProtoBufEnum returns true if the given attribute is a String user type
+// that uses the "type:enum" meta. Such types are mapped to protocol buffer
+// enums.
+func isProtoBufEnum(att *expr.AttributeExpr) bool {
+	if !expr.IsTypedEnum(att.Type) {
+		return false
+	}
+	return att.Type.(expr.UserType).Attribute().Type == expr.String
+}
+
+// protoBufTimeMeta returns the "struct:field:proto" meta values that map the
+// given Go time attribute to the google.protobuf.Timestamp or
+// google.protobuf.Duration well-known message.
+func protoBufTimeMeta(att *expr.AttributeExpr) []string {
+	if att.Validation.Format == expr.FormatDuration {
+		return []string{"google.protobuf.Duration", "google/protobuf/duration.proto", "Duration", "google.golang.org/protobuf/types/known/durationpb"}
+	}
+	return []string{"google.protobuf.Timestamp", "google/protobuf/timestamp.proto", "Timestamp", "google.golang.org/protobuf/types/known/timestamppb"}
+}
+
+// protoBufNullableMeta returns the "struct:field:proto" meta values that map
+// the given nullable attribute to the corresponding google.protobuf wrapper
+// message.
+func protoBufNullableMeta(att *expr.AttributeExpr) []string {
+	name := protoBufWrapperName(att)
+	return []string{"google.protobuf." + name, "google/protobuf/wrappers.proto", name, "google.golang.org/protobuf/types/known/wrapperspb"}
+}
+
+// protoBufWrapperName returns the name of the google.protobuf wrapper message
+// used to represent the given nullable attribute.
+func protoBufWrapperName(att *expr.AttributeExpr) string {
+	switch att.Type.Kind() {
+	case expr.BooleanKind:
+		return "BoolValue"
+	case expr.IntKind, expr.Int32Kind:
+		return "Int32Value"
+	case expr.Int64Kind:
+		return "Int64Value"
+	case expr.UIntKind, expr.UInt32Kind:
+		return "UInt32Value"
+	case expr.UInt64Kind:
+		return "UInt64Value"
+	case expr.Float32Kind:
+		return "FloatValue"
+	case expr.Float64Kind:
+		return "DoubleValue"
+	case expr.BytesKind:
+		return "BytesValue"
+	default:
+		return "StringValue"
+	}
+}
+
+// protoBufEnumValues returns the protocol buffer enum values of the given
+// attribute which must be a protocol buffer enum. The first value is the zero
+// value which does not correspond to any value of the design enum.
+func protoBufEnumValues(att *expr.AttributeExpr, s *codegen.NameScope) []*service.EnumValueData {
+	name := codegen.SnakeCase(protoBufMessageName(att, s))
+	vals := att.Type.(expr.UserType).Attribute().Validation.Values
+	res := make([]*service.EnumValueData, len(vals)+1)
+	res[0] = &service.EnumValueData{Name: strings.ToUpper(name + "_unspecified"), Value: "0"}
+	seen := make(map[string]int)
+	for i, v := range vals {
+		vn := strings.ToUpper(name + "_" + codegen.SnakeCase(protoBufify(fmt.Sprint(v), true, false)))
+		if n := seen[vn]; n > 0 {
+			vn = fmt.Sprintf("%s_%d", vn, n+1)
+		}
+		seen[vn]++
+		res[i+1] = &service.EnumValueData{Name: vn, Value: strconv.Itoa(i + 1)}
+	}
+	return res
+}
+
 // protoBufGoFullTypeRef returns the Go code qualified with package name that
 // refers to the Go type generated by compiling the protocol buffer
 // (in *.pb.go) for the given attribute.
@@ -425,7 +508,7 @@ func protoNativeType(t expr.DataType) string {
 		return "float"
 	case expr.Float64Kind:
 		return "double"
-	case expr.StringKind:
+	case expr.StringKind, expr.DecimalKind, expr.BigIntKind:
 		return "string"
 	case expr.BytesKind:
 		return "bytes"
@@ -457,7 +540,7 @@ func protoBufNativeGoTypeName(t expr.DataType) string {
 		return "float32"
 	case expr.Float64Kind:
 		return "float64"
-	case expr.StringKind:
+	case expr.StringKind, expr.DecimalKind, expr.BigIntKind:
 		return "string"
 	case expr.BytesKind:
 		return "[]byte"
@@ -212,6 +212,14 @@ func transformObject(source, target *expr.AttributeExpr, sourceVar, targetVar st
 				_, isSrcUT   = srcc.Type.(expr.UserType)
 				_, isTgtUT   = tgtc.Type.(expr.UserType)
 			)
+			if srcc.IsTime() && tgtc.IsTime() {
+				postInitCode += transformTime(srcc, srcField, targetVar+"."+tgtField, srcPtr, tgtPtr, ta)
+				return
+			}
+			if srcc.IsNullable() && tgtc.IsNullable() {
+				postInitCode += transformNullable(srcc, srcField, targetVar+"."+tgtField, ta)
+				return
+			}
 			switch {
 			case isSrcUT || isTgtUT || (srcField != srcFieldConv):
 				var deref string
@@ -219,7 +227,7 @@ func transformObject(source, target *expr.AttributeExpr, sourceVar, targetVar st
 					deref = "*"
 				}
 				exp = srcFieldConv
-				if isSrcUT && !ta.proto {
+				if isSrcUT && !ta.proto && !isProtoBufEnum(srcc) {
 					// If the source is an alias type and the code is initializing a service
 					// type then we must cast to the alias type.
 					exp = fmt.Sprintf("%s(%s%s)", ta.TargetCtx.Scope.Ref(tgtc, ta.TargetCtx.Pkg(tgtc)), deref, srcField)
@@ -393,6 +401,10 @@ func transformArray(source, target *expr.Array, sourceVar, targetVar string, new
 		elem = unAlias(elem)
 	}
 	targetRef := ta.TargetCtx.Scope.Ref(elem, ta.TargetCtx.Pkg(elem))
+	if ta.proto && elem.IsTime() {
+		// Well-known protocol buffer messages are referenced by pointer.
+		targetRef = "*" + targetRef
+	}
 
 	var (
 		code string
@@ -606,6 +618,18 @@ func transformUnionFromProto(source, target *expr.AttributeExpr, sourceVar, targ
 // convertType produces code to initialize a target type from a source type
 // held by sourceVar.
 func convertType(src, tgt *expr.AttributeExpr, srcPtr bool, tgtPtr bool, srcVar string, ta *transformAttrs) string {
+	if src.IsTime() && tgt.IsTime() {
+		if srcPtr {
+			srcVar = "*" + srcVar
+		}
+		return convertTime(src, srcVar, ta)
+	}
+	if isProtoBufEnum(src) || isProtoBufEnum(tgt) {
+		if srcPtr {
+			srcVar = "*" + srcVar
+		}
+		return fmt.Sprintf("%s(%s)", transformHelperName(src, tgt, ta), srcVar)
+	}
 	if expr.IsAlias(src.Type) || expr.IsAlias(tgt.Type) {
 		srcp, tgtp := unAlias(src), unAlias(tgt)
 		if srcp.Type == tgtp.Type {
@@ -657,6 +681,87 @@ func convertPrimitiveFromProto(_, tgt *expr.AttributeExpr, srcPtr, _ bool, srcVa
 	return fmt.Sprintf("%s(%s)", tgtType, srcVar)
 }
 
+// convertTime returns the code that converts the Go time value held by srcVar
+// to the corresponding google.protobuf.Timestamp or google.protobuf.Duration
+// message or vice versa.
+func convertTime(att *expr.AttributeExpr, srcVar string, ta *transformAttrs) string {
+	isDuration := att.Validation.Format == expr.FormatDuration
+	switch {
+	case ta.proto && isDuration:
+		return fmt.Sprintf("durationpb.New(%s)", srcVar)
+	case ta.proto:
+		return fmt.Sprintf("timestamppb.New(%s)", srcVar)
+	case isDuration:
+		return srcVar + ".AsDuration()"
+	default:
+		return srcVar + ".AsTime()"
+	}
+}
+
+// transformTime returns the code that initializes the struct field targetVar
+// with the Go time value or protocol buffer message held by sourceVar. Nil
+// protocol buffer messages leave the target untouched when it is a pointer.
+func transformTime(att *expr.AttributeExpr, sourceVar, targetVar string, srcPtr, tgtPtr bool, ta *transformAttrs) string {
+	switch {
+	case ta.proto && srcPtr:
+		return fmt.Sprintf("if %s != nil {\n%s = %s\n}\n", sourceVar, targetVar, convertTime(att, "*"+sourceVar, ta))
+	case ta.proto:
+		return fmt.Sprintf("%s = %s\n", targetVar, convertTime(att, sourceVar, ta))
+	case tgtPtr:
+		return fmt.Sprintf("if %s != nil {\ntmp := %s\n%s = &tmp\n}\n", sourceVar, convertTime(att, sourceVar, ta), targetVar)
+	default:
+		return fmt.Sprintf("%s = %s\n", targetVar, convertTime(att, sourceVar, ta))
+	}
+}
+
+// transformNullable returns the code that initializes the struct field
+// targetVar with the goa.Nullable value or protocol buffer wrapper message
+// held by sourceVar. Both absent and null values map to nil wrapper messages
+// and nil wrapper messages leave the target absent.
+func transformNullable(att *expr.AttributeExpr, sourceVar, targetVar string, ta *transformAttrs) string {
+	if ta.proto {
+		val := sourceVar + ".Value"
+		if att.Type == expr.Int || att.Type == expr.UInt {
+			val = fmt.Sprintf("%s(%s)", protoBufNativeGoTypeName(att.Type), val)
+		}
+		fn := strings.TrimSuffix(protoBufWrapperName(att), "Value")
+		return fmt.Sprintf("if %s.IsValue() {\n%s = wrapperspb.%s(%s)\n}\n", sourceVar, targetVar, fn, val)
+	}
+	val := sourceVar + ".GetValue()"
+	if att.Type == expr.Int || att.Type == expr.UInt {
+		val = fmt.Sprintf("%s(%s)", codegen.GoNativeTypeName(att.Type), val)
+	}
+	return fmt.Sprintf("if %s != nil {\n%s = goa.NewNullable(%s)\n}\n", sourceVar, targetVar, val)
+}
+
+// transformEnum returns the code of the function that converts a value of a
+// String type that uses the "type:enum" meta to the corresponding protocol
+// buffer enum value or vice versa. Values that are not part of the enum map to
+// the zero value.
+func transformEnum(source, target *expr.AttributeExpr, ta *transformAttrs) string {
+	var (
+		pb  *expr.AttributeExpr
+		ctx *codegen.AttributeContext
+	)
+	if ta.proto {
+		pb, ctx = target, ta.TargetCtx
+	} else {
+		pb, ctx = source, ta.SourceCtx
+	}
+	pbRef := ctx.Scope.Ref(pb, ctx.Pkg(pb))
+	vals := protoBufEnumValues(pb, ctx.Scope.Scope())
+	code := fmt.Sprintf("var res %s\nswitch v {\n", ta.TargetCtx.Scope.Ref(target, ta.TargetCtx.Pkg(target)))
+	for i, v := range pb.Type.(expr.UserType).Attribute().Validation.Values {
+		pbVal := pbRef + "_" + vals[i+1].Name
+		if ta.proto {
+			code += fmt.Sprintf("case %#v:\n\tres = %s\n", v, pbVal)
+		} else {
+			code += fmt.Sprintf("case %s:\n\tres = %#v\n", pbVal, v)
+		}
+	}
+	return code + "}"
+}
+
 // transformUnionData returns data needed by both transformUnion functions.
 func transformUnionData(source, target *expr.AttributeExpr, ta *transformAttrs) *unionData {
 	src := expr.AsUnion(source.Type)
@@ -773,6 +878,19 @@ func transformAttributeHelpers(source, target *expr.AttributeExpr, ta *transform
 func collectHelpers(source, target *expr.AttributeExpr, req bool, ta *transformAttrs, seen map[string]*codegen.TransformFunctionData) ([]*codegen.TransformFunctionData, error) {
 	var data []*codegen.TransformFunctionData
 	switch {
+	case isProtoBufEnum(source) || isProtoBufEnum(target):
+		name := transformHelperName(source, target, ta)
+		if _, ok := seen[name]; ok {
+			return nil, nil
+		}
+		tfd := &codegen.TransformFunctionData{
+			Name:          name,
+			ParamTypeRef:  ta.SourceCtx.Scope.Ref(source, ta.SourceCtx.Pkg(source)),
+			ResultTypeRef: ta.TargetCtx.Scope.Ref(target, ta.TargetCtx.Pkg(target)),
+			Code:          transformEnum(source, target, ta),
+		}
+		seen[name] = tfd
+		data = append(data, tfd)
 	case expr.IsArray(source.Type):
 		helpers, err := transformAttributeHelpers(
 			expr.AsArray(source.Type).ElemType,
@@ -0,0 +1,369 @@
+package codegen
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/bufbuild/protocompile"
+	"google.golang.org/protobuf/cmd/protoc-gen-go/internal_gengo"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+const (
+	// ProtocCompilerProtoc is the value of the "protoc:compiler" meta that
+	// selects the protoc executable to compile the generated .proto files.
+	// This is the default.
+	ProtocCompilerProtoc = "protoc"
+
+	// ProtocCompilerBuiltin is the value of the "protoc:compiler" meta that
+	// selects the builtin pure Go compiler to compile the generated .proto
+	// files. The builtin compiler does not require protoc or the Go plugins
+	// to be installed.
+	ProtocCompilerBuiltin = "builtin"
+)
+
+// Package names used by the code generated by compileProto.
+const (
+	contextPackage = protogen.GoImportPath("context")
+	grpcPackage    = protogen.GoImportPath("google.golang.org/grpc")
+	codesPackage   = protogen.GoImportPath("google.golang.org/grpc/codes")
+	statusPackage  = protogen.GoImportPath("google.golang.org/grpc/status")
+)
+
+// compileProto compiles the .proto file at the given path and writes the
+// corresponding .pb.go and _grpc.pb.go files in the same directory. It produces
+// the same output as running protoc with the protoc-gen-go and
+// protoc-gen-go-grpc plugins but does not rely on any external executable.
+func compileProto(path string, includes []string) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return err
+	}
+	fname := filepath.Base(path)
+	compiler := protocompile.Compiler{
+		Resolver: protocompile.WithStandardImports(&protocompile.SourceResolver{
+			ImportPaths: append([]string{dir}, includes...),
+		}),
+		SourceInfoMode: protocompile.SourceInfoStandard,
+	}
+	files, err := compiler.Compile(context.Background(), fname)
+	if err != nil {
+		return fmt.Errorf("failed to compile %s: %w", path, err)
+	}
+
+	req := &pluginpb.CodeGeneratorRequest{
+		FileToGenerate: []string{fname},
+		Parameter:      proto.String("paths=source_relative"),
+		ProtoFile:      fileDescriptorProtos(files[0], make(map[string]bool)),
+	}
+	gen, err := protogen.Options{}.New(req)
+	if err != nil {
+		return err
+	}
+	gen.SupportedFeatures = internal_gengo.SupportedFeatures
+	for _, f := range gen.Files {
+		if !f.Generate {
+			continue
+		}
+		internal_gengo.GenerateFile(gen, f)
+		generateGRPCFile(gen, f)
+	}
+	resp := gen.Response()
+	if resp.Error != nil {
+		return fmt.Errorf("failed to generate Go code for %s: %s", path, resp.GetError())
+	}
+	for _, f := range resp.File {
+		if err := os.WriteFile(filepath.Join(dir, f.GetName()), []byte(f.GetContent()), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fileDescriptorProtos returns the descriptors of fd and of all its transitive
+// dependencies in topological order as expected by protoc plugins.
+func fileDescriptorProtos(fd protoreflect.FileDescriptor, seen map[string]bool) []*descriptorpb.FileDescriptorProto {
+	if seen[fd.Path()] {
+		return nil
+	}
+	seen[fd.Path()] = true
+	var fdps []*descriptorpb.FileDescriptorProto
+	imports := fd.Imports()
+	for i := 0; i < imports.Len(); i++ {
+		fdps = append(fdps, fileDescriptorProtos(imports.Get(i).FileDescriptor, seen)...)
+	}
+	return append(fdps, protodesc.ToFileDescriptorProto(fd))
+}
+
+// generateGRPCFile generates the _grpc.pb.go file for the given proto file. The
+// generated code is equivalent to the code produced by protoc-gen-go-grpc.
+func generateGRPCFile(gen *protogen.Plugin, file *protogen.File) {
+	if len(file.Services) == 0 {
+		return
+	}
+	g := gen.NewGeneratedFile(file.GeneratedFilenamePrefix+"_grpc.pb.go", file.GoImportPath)
+	g.P("// Code generated by goa (protoc-gen-go-grpc compatible). DO NOT EDIT.")
+	g.P("// source: ", file.Desc.Path())
+	g.P()
+	g.P("package ", file.GoPackageName)
+	g.P()
+	g.P("// This is a compile-time assertion to ensure that this generated file")
+	g.P("// is compatible with the grpc package it is being compiled against.")
+	g.P("// Requires gRPC-Go v1.64.0 or later.")
+	g.P("const _ = ", grpcPackage.Ident("SupportPackageIsVersion9"))
+	g.P()
+	for _, svc := range file.Services {
+		genService(g, file, svc)
+	}
+}
+
+// genService generates the client and server code for the given service.
+func genService(g *protogen.GeneratedFile, file *protogen.File, svc *protogen.Service) {
+	var (
+		name        = svc.GoName
+		clientName  = name + "Client"
+		clientImpl  = unexport(clientName)
+		serverName  = name + "Server"
+		unimpl      = "Unimplemented" + serverName
+		mustEmbed   = "mustEmbed" + unimpl
+		serviceDesc = name + "_ServiceDesc"
+	)
+
+	g.P("const (")
+	for _, m := range svc.Methods {
+		g.P(fullMethodName(svc, m), " = ", strconv.Quote(fmt.Sprintf("/%s/%s", svc.Desc.FullName(), m.Desc.Name())))
+	}
+	g.P(")")
+	g.P()
+
+	// Client
+	g.P("// ", clientName, " is the client API for ", name, " service.")
+	g.P("type ", clientName, " interface {")
+	for _, m := range svc.Methods {
+		g.P(m.Comments.Leading, clientSignature(g, m))
+	}
+	g.P("}")
+	g.P()
+	g.P("type ", clientImpl, " struct {")
+	g.P("cc ", grpcPackage.Ident("ClientConnInterface"))
+	g.P("}")
+	g.P()
+	g.P("func New", clientName, "(cc ", grpcPackage.Ident("ClientConnInterface"), ") ", clientName, " {")
+	g.P("return &", clientImpl, "{cc}")
+	g.P("}")
+	g.P()
+	streamIndex := 0
+	for _, m := range svc.Methods {
+		g.P("func (c *", clientImpl, ") ", clientSignature(g, m), " {")
+		g.P("cOpts := append([]", grpcPackage.Ident("CallOption"), "{", grpcPackage.Ident("StaticMethod"), "()}, opts...)")
+		if !m.Desc.IsStreamingClient() && !m.Desc.IsStreamingServer() {
+			g.P("out := new(", m.Output.GoIdent, ")")
+			g.P("err := c.cc.Invoke(ctx, ", fullMethodName(svc, m), ", in, out, cOpts...)")
+			g.P("if err != nil { return nil, err }")
+			g.P("return out, nil")
+			g.P("}")
+			g.P()
+			continue
+		}
+		g.P("stream, err := c.cc.NewStream(ctx, &", serviceDesc, ".Streams[", streamIndex, "], ", fullMethodName(svc, m), ", cOpts...)")
+		g.P("if err != nil { return nil, err }")
+		g.P("x := &", grpcPackage.Ident("GenericClientStream"), "[", m.Input.GoIdent, ", ", m.Output.GoIdent, "]{ClientStream: stream}")
+		if !m.Desc.IsStreamingClient() {
+			g.P("if err := x.ClientStream.SendMsg(in); err != nil { return nil, err }")
+			g.P("if err := x.ClientStream.CloseSend(); err != nil { return nil, err }")
+		}
+		g.P("return x, nil")
+		g.P("}")
+		g.P()
+		g.P("// ", name, "_", m.GoName, "Client is provided for backwards compatibility with code that references the non-generic stream type by name.")
+		g.P("type ", name, "_", m.GoName, "Client = ", clientStreamType(g, m))
+		g.P()
+		streamIndex++
+	}
+
+	// Server
+	g.P("// ", serverName, " is the server API for ", name, " service.")
+	g.P("// All implementations must embed ", unimpl)
+	g.P("// for forward compatibility.")
+	g.P("type ", serverName, " interface {")
+	for _, m := range svc.Methods {
+		g.P(m.Comments.Leading, serverSignature(g, m))
+	}
+	g.P(mustEmbed, "()")
+	g.P("}")
+	g.P()
+	g.P("// ", unimpl, " must be embedded to have forward compatible implementations.")
+	g.P("type ", unimpl, " struct{}")
+	g.P()
+	for _, m := range svc.Methods {
+		nilArg := ""
+		if !m.Desc.IsStreamingClient() && !m.Desc.IsStreamingServer() {
+			nilArg = "nil, "
+		}
+		g.P("func (", unimpl, ") ", serverSignature(g, m), " {")
+		g.P("return ", nilArg, statusPackage.Ident("Errorf"), "(", codesPackage.Ident("Unimplemented"), ", ", strconv.Quote("method "+m.GoName+" not implemented"), ")")
+		g.P("}")
+	}
+	g.P("func (", unimpl, ") ", mustEmbed, "() {}")
+	g.P()
+	g.P("// Unsafe", serverName, " may be embedded to opt out of forward compatibility for this service.")
+	g.P("// Use of this interface is not recommended, as added methods to ", serverName, " will")
+	g.P("// result in compilation errors.")
+	g.P("type Unsafe", serverName, " interface {")
+	g.P(mustEmbed, "()")
+	g.P("}")
+	g.P()
+	g.P("func Register", serverName, "(s ", grpcPackage.Ident("ServiceRegistrar"), ", srv ", serverName, ") {")
+	g.P("s.RegisterService(&", serviceDesc, ", srv)")
+	g.P("}")
+	g.P()
+	for _, m := range svc.Methods {
+		handler := fmt.Sprintf("_%s_%s_Handler", name, m.GoName)
+		if !m.Desc.IsStreamingClient() && !m.Desc.IsStreamingServer() {
+			g.P("func ", handler, "(srv any, ctx ", contextPackage.Ident("Context"), ", dec func(any) error, interceptor ", grpcPackage.Ident("UnaryServerInterceptor"), ") (any, error) {")
+			g.P("in := new(", m.Input.GoIdent, ")")
+			g.P("if err := dec(in); err != nil { return nil, err }")
+			g.P("if interceptor == nil { return srv.(", serverName, ").", m.GoName, "(ctx, in) }")
+			g.P("info := &", grpcPackage.Ident("UnaryServerInfo"), "{")
+			g.P("Server: srv,")
+			g.P("FullMethod: ", fullMethodName(svc, m), ",")
+			g.P("}")
+			g.P("handler := func(ctx ", contextPackage.Ident("Context"), ", req any) (any, error) {")
+			g.P("return srv.(", serverName, ").", m.GoName, "(ctx, req.(*", m.Input.GoIdent, "))")
+			g.P("}")
+			g.P("return interceptor(ctx, in, info, handler)")
+			g.P("}")
+			g.P()
+			continue
+		}
+		stream := g.QualifiedGoIdent(grpcPackage.Ident("GenericServerStream")) + "[" + g.QualifiedGoIdent(m.Input.GoIdent) + ", " + g.QualifiedGoIdent(m.Output.GoIdent) + "]{ServerStream: stream}"
+		g.P("func ", handler, "(srv any, stream ", grpcPackage.Ident("ServerStream"), ") error {")
+		if !m.Desc.IsStreamingClient() {
+			g.P("m := new(", m.Input.GoIdent, ")")
+			g.P("if err := stream.RecvMsg(m); err != nil { return err }")
+			g.P("return srv.(", serverName, ").", m.GoName, "(m, &", stream, ")")
+		} else {
+			g.P("return srv.(", serverName, ").", m.GoName, "(&", stream, ")")
+		}
+		g.P("}")
+		g.P()
+		g.P("// ", name, "_", m.GoName, "Server is provided for backwards compatibility with code that references the non-generic stream type by name.")
+		g.P("type ", name, "_", m.GoName, "Server = ", serverStreamType(g, m))
+		g.P()
+	}
+
+	// Service descriptor
+	g.P("// ", serviceDesc, " is the grpc.ServiceDesc for ", name, " service.")
+	g.P("// It's only intended for direct use with grpc.RegisterService,")
+	g.P("// and not to be introspected or modified (even as a copy)")
+	g.P("var ", serviceDesc, " = ", grpcPackage.Ident("ServiceDesc"), "{")
+	g.P("ServiceName: ", strconv.Quote(string(svc.Desc.FullName())), ",")
+	g.P("HandlerType: (*", serverName, ")(nil),")
+	g.P("Methods: []", grpcPackage.Ident("MethodDesc"), "{")
+	for _, m := range svc.Methods {
+		if m.Desc.IsStreamingClient() || m.Desc.IsStreamingServer() {
+			continue
+		}
+		g.P("{")
+		g.P("MethodName: ", strconv.Quote(string(m.Desc.Name())), ",")
+		g.P("Handler: _", name, "_", m.GoName, "_Handler,")
+		g.P("},")
+	}
+	g.P("},")
+	g.P("Streams: []", grpcPackage.Ident("StreamDesc"), "{")
+	for _, m := range svc.Methods {
+		if !m.Desc.IsStreamingClient() && !m.Desc.IsStreamingServer() {
+			continue
+		}
+		g.P("{")
+		g.P("StreamName: ", strconv.Quote(string(m.Desc.Name())), ",")
+		g.P("Handler: _", name, "_", m.GoName, "_Handler,")
+		if m.Desc.IsStreamingServer() {
+			g.P("ServerStreams: true,")
+		}
+		if m.Desc.IsStreamingClient() {
+			g.P("ClientStreams: true,")
+		}
+		g.P("},")
+	}
+	g.P("},")
+	g.P("Metadata: ", strconv.Quote(file.Desc.Path()), ",")
+	g.P("}")
+	g.P()
+}
+
+// clientSignature returns the signature of the client method for m.
+func clientSignature(g *protogen.GeneratedFile, m *protogen.Method) string {
+	s := m.GoName + "(ctx " + g.QualifiedGoIdent(contextPackage.Ident("Context"))
+	if !m.Desc.IsStreamingClient() {
+		s += ", in *" + g.QualifiedGoIdent(m.Input.GoIdent)
+	}
+	s += ", opts ..." + g.QualifiedGoIdent(grpcPackage.Ident("CallOption")) + ") ("
+	if !m.Desc.IsStreamingClient() && !m.Desc.IsStreamingServer() {
+		s += "*" + g.QualifiedGoIdent(m.Output.GoIdent)
+	} else {
+		s += clientStreamType(g, m)
+	}
+	return s + ", error)"
+}
+
+// serverSignature returns the signature of the server method for m.
+func serverSignature(g *protogen.GeneratedFile, m *protogen.Method) string {
+	if !m.Desc.IsStreamingClient() && !m.Desc.IsStreamingServer() {
+		return m.GoName + "(" + g.QualifiedGoIdent(contextPackage.Ident("Context")) + ", *" + g.QualifiedGoIdent(m.Input.GoIdent) + ") (*" + g.QualifiedGoIdent(m.Output.GoIdent) + ", error)"
+	}
+	var in string
+	if !m.Desc.IsStreamingClient() {
+		in = "*" + g.QualifiedGoIdent(m.Input.GoIdent) + ", "
+	}
+	return m.GoName + "(" + in + serverStreamType(g, m) + ") error"
+}
+
+// clientStreamType returns the generic grpc client stream type for m.
+func clientStreamType(g *protogen.GeneratedFile, m *protogen.Method) string {
+	in, out := g.QualifiedGoIdent(m.Input.GoIdent), g.QualifiedGoIdent(m.Output.GoIdent)
+	switch {
+	case m.Desc.IsStreamingClient() && m.Desc.IsStreamingServer():
+		return g.QualifiedGoIdent(grpcPackage.Ident("BidiStreamingClient")) + "[" + in + ", " + out + "]"
+	case m.Desc.IsStreamingClient():
+		return g.QualifiedGoIdent(grpcPackage.Ident("ClientStreamingClient")) + "[" + in + ", " + out + "]"
+	default:
+		return g.QualifiedGoIdent(grpcPackage.Ident("ServerStreamingClient")) + "[" + out + "]"
+	}
+}
+
+// serverStreamType returns the generic grpc server stream type for m.
+func serverStreamType(g *protogen.GeneratedFile, m *protogen.Method) string {
+	in, out := g.QualifiedGoIdent(m.Input.GoIdent), g.QualifiedGoIdent(m.Output.GoIdent)
+	switch {
+	case m.Desc.IsStreamingClient() && m.Desc.IsStreamingServer():
+		return g.QualifiedGoIdent(grpcPackage.Ident("BidiStreamingServer")) + "[" + in + ", " + out + "]"
+	case m.Desc.IsStreamingClient():
+		return g.QualifiedGoIdent(grpcPackage.Ident("ClientStreamingServer")) + "[" + in + ", " + out + "]"
+	default:
+		return g.QualifiedGoIdent(grpcPackage.Ident("ServerStreamingServer")) + "[" + out + "]"
+	}
+}
+
+// fullMethodName returns the name of the constant holding the full method
+// name of m.
+func fullMethodName(svc *protogen.Service, m *protogen.Method) string {
+	return svc.GoName + "_" + m.GoName + "_FullMethodName"
+}
+
+// unexport lowercases the first character of s.
+func unexport(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
@@ -24,6 +24,9 @@ func TestServerTypeFiles(t *testing.T) {
 		{"server-with-errors", testdata.UnaryRPCWithErrorsDSL, testdata.WithErrorsServerTypeCode},
 		{"server-elem-validation", testdata.ElemValidationDSL, testdata.ElemValidationServerTypesFile},
 		{"server-alias-validation", testdata.AliasValidationDSL, testdata.AliasValidationServerTypesFile},
+		{"server-typed-enum", testdata.TypedEnumDSL, testdata.TypedEnumServerTypesFile},
+		{"server-time", testdata.TimeDSL, testdata.TimeServerTypesFile},
+		{"server-nullable", testdata.NullableDSL, testdata.NullableServerTypesFile},
 		{"server-struct-meta-type", testdata.StructMetaTypeDSL, testdata.StructMetaTypeServerTypeCode},
 		{"server-struct-field-name-meta-type", testdata.StructFieldNameMetaTypeDSL, testdata.StructFieldNameMetaTypeServerTypesCode},
 		{"server-default-fields", testdata.DefaultFieldsDSL, testdata.DefaultFieldsServerTypeCode},
@@ -674,6 +674,28 @@ func collectMessages(at *expr.AttributeExpr, sd *ServiceData, seen map[string]st
 			}
 		}
 	}
+	if isProtoBufEnum(at) {
+		name := at.Type.Name()
+		if _, ok := seen[name]; ok {
+			return
+		}
+		seen[name] = struct{}{}
+		vals := protoBufEnumValues(at, sd.Scope)
+		def := " {"
+		for _, v := range vals {
+			def += fmt.Sprintf("\n\t%s = %s;", v.Name, v.Value)
+		}
+		data = append(data, &service.UserTypeData{
+			Name:        name,
+			VarName:     protoBufMessageName(at, sd.Scope),
+			Description: at.Type.(expr.UserType).Attribute().Description,
+			Def:         def + "\n}",
+			Ref:         protoBufGoFullTypeRef(at, sd.PkgName, sd.Scope),
+			Type:        at.Type.(expr.UserType),
+			Enum:        &service.EnumData{Values: vals, IsString: true},
+		})
+		return
+	}
 	if expr.IsPrimitive(at.Type) {
 		return
 	}
@@ -753,7 +775,8 @@ func addValidation(att *expr.AttributeExpr, attName string, sd *ServiceData, req
 	}
 	vtx := protoBufTypeContext(sd.PkgName, sd.Scope, false)
 	removeMeta(att)
-	if def := codegen.ValidationCode(att, ut, vtx, true, expr.IsAlias(att.Type), false, attName); def != "" {
+	vatt := removeProtoBufEnumValidations(att)
+	if def := codegen.ValidationCode(vatt, ut, vtx, true, expr.IsAlias(vatt.Type), false, attName); def != "" {
 		v := &ValidationData{
 			Name:    "Validate" + name,
 			Def:     def,
@@ -783,7 +806,8 @@ func collectValidations(att *expr.AttributeExpr, attName string, ctx *codegen.At
 			return
 		}
 		vtx := protoBufTypeContext(sd.PkgName, sd.Scope, false)
-		def := codegen.AttributeValidationCode(att, dt, vtx, true, false, gattName, attName)
+		vatt := removeProtoBufEnumValidations(att)
+		def := codegen.AttributeValidationCode(vatt, vatt.Type.(expr.UserType), vtx, true, false, gattName, attName)
 		name := protoBufMessageName(att, sd.Scope)
 		kind := validateClient
 		if req {
@@ -827,6 +851,25 @@ func collectValidations(att *expr.AttributeExpr, attName string, ctx *codegen.At
 	}
 }
 
+// removeProtoBufEnumValidations returns a copy of the given attribute where
+// the enum validations of the attributes mapped to protocol buffer enums are
+// removed. The protocol buffer enum types only accept the enum values.
+func removeProtoBufEnumValidations(att *expr.AttributeExpr) *expr.AttributeExpr {
+	att = expr.DupAtt(att)
+	_ = codegen.Walk(att, func(a *expr.AttributeExpr) error {
+		if isProtoBufEnum(a) {
+			if a.Validation != nil {
+				a.Validation.Values = nil
+			}
+			if v := a.Type.(expr.UserType).Attribute().Validation; v != nil {
+				v.Values = nil
+			}
+		}
+		return nil
+	})
+	return att
+}
+
 // userTypeAttribute returns the attribute of the given user type.
 func userTypeAttribute(ut expr.UserType) *expr.AttributeExpr {
 	att := ut.Attribute()
@@ -1357,7 +1400,11 @@ func pkgWithDefault(loc *codegen.Location, def string) string {
 }
 
 // getPrimitive returns the primitive expression if the given expression is an alias to one
+// that is not mapped to a protocol buffer enum.
 func getPrimitive(att *expr.AttributeExpr) *expr.AttributeExpr {
+	if isProtoBufEnum(att) {
+		return nil
+	}
 	if ut, ok := att.Type.(*expr.UserTypeExpr); ok {
 		if _, ok := ut.Type.(expr.Primitive); ok {
 			return ut.AttributeExpr
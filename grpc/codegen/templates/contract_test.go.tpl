@@ -0,0 +1,156 @@
+{{ printf "TestContract serves the %q service mock with the generated gRPC server and calls each endpoint with the generated gRPC client using the design examples." .Service.Service.Name | comment }}
+func TestContract(t *testing.T) {
+{{- range $e := .Endpoints }}
+	t.Run({{ printf "%q" .Method.Name }}, func(t *testing.T) {
+	{{- if .Skip }}
+		t.Skip({{ printf "%q" .Skip }})
+	{{- else }}
+		var (
+			m      = {{ $.MockPkg }}.New()
+			c, rec = newContractClient(t, m)
+			ctx    = context.Background()
+		{{- if .Mock.PayloadVar }}
+			p      = {{ .Mock.PayloadValue }}
+		{{- end }}
+		)
+		{{ if .Mock.ResultRef }}res{{ else }}_{{ end }}, err := c.{{ .Method.VarName }}()(ctx, {{ if .Mock.PayloadVar }}p{{ else }}nil{{ end }})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		assertCall(t, m, {{ printf "%q" .Method.Name }}, {{ if .Mock.PayloadVar }}p{{ else }}nil{{ end }})
+		{{- if .Mock.ResultRef }}
+		{{- if and .Method.ViewedResult (not .Method.ViewedResult.ViewName) }}
+		expected, view, _ := {{ $.MockPkg }}.New().{{ .Method.VarName }}({{ .Mock.Args }})
+		{{- else }}
+		expected, _ := {{ $.MockPkg }}.New().{{ .Method.VarName }}({{ .Mock.Args }})
+		{{- end }}
+		{{- if .Method.ViewedResult }}
+		expected = {{ $.Service.Service.PkgName }}.{{ .Method.ViewedResult.ResultInit.Name }}({{ $.Service.Service.PkgName }}.{{ .Method.ViewedResult.Init.Name }}(expected, {{ if .Method.ViewedResult.ViewName }}{{ printf "%q" .Method.ViewedResult.ViewName }}{{ else }}view{{ end }}))
+		{{- end }}
+		assertEqual(t, "result", res, expected)
+		{{- end }}
+		rec.assertResponse(t, codes.OK{{ range .Headers }}, {{ printf "%q" . }}{{ end }})
+		{{- range .Errors }}
+
+		t.Run({{ printf "%q" .Name }}, func(t *testing.T) {
+			m := {{ $.MockPkg }}.New()
+			m.Set{{ $e.Method.VarName }}(func({{ $e.Mock.Params }}) ({{ $e.Mock.Results }}) {
+				err = {{ $.MockPkg }}.NewError({{ printf "%q" $e.Method.Name }}, {{ printf "%q" .Name }})
+				return
+			})
+			c, rec := newContractClient(t, m)
+			_, err := c.{{ $e.Method.VarName }}()(ctx, {{ if $e.Mock.PayloadVar }}p{{ else }}nil{{ end }})
+			assertError(t, err, {{ $.MockPkg }}.NewError({{ printf "%q" $e.Method.Name }}, {{ printf "%q" .Name }}))
+			rec.assertResponse(t, {{ .StatusCode }})
+		})
+		{{- end }}
+	{{- end }}
+	})
+{{- end }}
+}
+
+// contractRecorder records the status and the headers of the last response
+// received by the client.
+type contractRecorder struct {
+	code   codes.Code
+	header metadata.MD
+}
+
+// intercept invokes the RPC and records the response status and headers.
+func (r *contractRecorder) intercept(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	var header metadata.MD
+	err := invoker(ctx, method, req, reply, cc, append(opts, grpc.Header(&header))...)
+	r.code, r.header = status.Code(err), header
+	return err
+}
+
+// assertResponse checks the status code and the headers of the response.
+func (r *contractRecorder) assertResponse(t *testing.T, code codes.Code, headers ...string) {
+	t.Helper()
+	if r.code != code {
+		t.Errorf("unexpected status code %s, expected %s", r.code, code)
+	}
+	for _, h := range headers {
+		if len(r.header.Get(h)) == 0 {
+			t.Errorf("missing response header %q", h)
+		}
+	}
+}
+
+{{ printf "newContractClient starts an in-memory gRPC server that serves the endpoints of the %q service implemented by m and returns a client connected to the server together with the recorder of the responses." .Service.Service.Name | comment }}
+func newContractClient(t *testing.T, m *{{ .MockPkg }}.Mock) (*{{ .ClientPkg }}.{{ .Service.ClientStruct }}, *contractRecorder) {
+	t.Helper()
+	e := {{ .Service.Service.PkgName }}.NewEndpoints(m{{ if .ServerInterceptors }}, {{ .MockPkg }}.ServerInterceptors{}{{ end }})
+	srv := grpc.NewServer()
+	{{ .Service.PkgName }}.Register{{ goify .Service.Service.VarName true }}Server(srv, {{ .ServerPkg }}.{{ .Service.ServerInit }}(e{{ if .Service.HasUnaryEndpoint }}, nil{{ end }}{{ if .Service.HasStreamingEndpoint }}, nil{{ end }}))
+	lis := bufconn.Listen(1 << 20)
+	go func() {
+		if err := srv.Serve(lis); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			t.Errorf("failed to serve: %v", err)
+		}
+	}()
+	t.Cleanup(srv.Stop)
+
+	rec := &contractRecorder{}
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(rec.intercept),
+	)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return {{ .ClientPkg }}.New{{ .Service.ClientStruct }}(conn), rec
+}
+
+// assertCall checks that the mock method was called once with the expected
+// payload.
+func assertCall(t *testing.T, m *{{ .MockPkg }}.Mock, method string, expected any) {
+	t.Helper()
+	calls := m.Calls()
+	if len(calls) != 1 || calls[0].Method != method {
+		t.Fatalf("expected a single call to %q, got %d calls", method, len(calls))
+	}
+	assertEqual(t, "payload", calls[0].Payload, expected)
+}
+
+// assertEqual checks that the value received on the other side of the
+// transport is equal to the value sent.
+func assertEqual(t *testing.T, name string, got, expected any) {
+	t.Helper()
+	if reflect.DeepEqual(got, expected) {
+		return
+	}
+	g, _ := json.Marshal(got)
+	e, _ := json.Marshal(expected)
+	t.Errorf("%s mismatch:\ngot:      %s\nexpected: %s", name, g, e)
+}
+
+// assertError checks that the error returned by the client matches the error
+// returned by the service.
+func assertError(t *testing.T, err, expected error) {
+	t.Helper()
+	if err == nil {
+		t.Fatal("expected an error, got none")
+	}
+	var se *goa.ServiceError
+	if errors.As(expected, &se) {
+		var got *goa.ServiceError
+		if !errors.As(err, &got) {
+			t.Fatalf("expected a service error, got %v", err)
+		}
+		assertEqual(t, "error", got.Name+": "+got.Message, se.Name+": "+se.Message)
+		return
+	}
+	assertEqual(t, "error", err, expected)
+}
+{{- if hasPtr .Endpoints }}
+
+// ptr returns a pointer to v.
+func ptr[T any](v T) *T {
+	return &v
+}
+{{- end }}
@@ -0,0 +1,49 @@
+{{ printf "FuzzDecode%s checks that the %q endpoint request decoder does not panic when decoding arbitrary messages and metadata and that the payloads it accepts are accepted again once encoded by the generated client.%s" .Endpoint.Method.VarName .Endpoint.Method.Name (or (and .Endpoint.PayloadValue " The corpus is seeded with the request built from the design example.") "") | comment }}
+func FuzzDecode{{ .Endpoint.Method.VarName }}(f *testing.F) {
+{{- if .Endpoint.PayloadValue }}
+	seed, md, err := fuzzEncode{{ .Endpoint.Method.VarName }}({{ .Endpoint.PayloadValue }})
+	if err != nil {
+		f.Fatalf("failed to encode the seed request: %v", err)
+	}
+	if _, err := fuzzDecode{{ .Endpoint.Method.VarName }}(seed, md); err != nil {
+		f.Fatalf("failed to decode the seed request: %v", err)
+	}
+	f.Add(seed{{ range .Endpoint.Request.Metadata }}, fuzzMetadataValue(md, {{ printf "%q" .Name }}){{ end }})
+{{- end }}
+	f.Fuzz(func(t *testing.T, data []byte{{ range .Endpoint.Request.Metadata }}, md{{ goify .VarName true }} string{{ end }}) {
+		p, err := fuzzDecode{{ .Endpoint.Method.VarName }}(data, fuzzMetadata({{ range $i, $m := .Endpoint.Request.Metadata }}{{ if $i }}, {{ end }}{{ printf "%q" .Name }}, md{{ goify .VarName true }}{{ end }}))
+		if err != nil {
+			return
+		}
+		data, md, err := fuzzEncode{{ .Endpoint.Method.VarName }}(p)
+		if err != nil {
+			t.Fatalf("failed to encode the decoded payload: %v", err)
+		}
+		if _, err := fuzzDecode{{ .Endpoint.Method.VarName }}(data, md); err != nil {
+			t.Errorf("failed to decode the request encoded from the decoded payload: %v", err)
+		}
+	})
+}
+
+{{ printf "fuzzEncode%s returns the %q endpoint request message built for p by the generated client in wire format together with the request metadata." .Endpoint.Method.VarName .Endpoint.Method.Name | comment }}
+func fuzzEncode{{ .Endpoint.Method.VarName }}(p any) ([]byte, metadata.MD, error) {
+	md := metadata.MD{}
+	v, err := {{ .ClientPkg }}.Encode{{ .Endpoint.Method.VarName }}Request(context.Background(), p, &md)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := proto.Marshal(v.(proto.Message))
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, md, nil
+}
+
+{{ printf "fuzzDecode%s decodes the %q endpoint request message data and metadata md." .Endpoint.Method.VarName .Endpoint.Method.Name | comment }}
+func fuzzDecode{{ .Endpoint.Method.VarName }}(data []byte, md metadata.MD) (any, error) {
+	var message {{ .Endpoint.MessageType }}
+	if err := proto.Unmarshal(data, &message); err != nil {
+		return nil, errFuzzInvalid
+	}
+	return {{ .Endpoint.Method.VarName | printf "Decode%sRequest" }}(context.Background(), &message, md)
+}
@@ -0,0 +1,30 @@
+// errFuzzInvalid is returned when the fuzzed data is not a valid message.
+var errFuzzInvalid = errors.New("invalid message")
+
+// fuzzMetadata returns the metadata made of the given key and value pairs,
+// empty values are omitted.
+func fuzzMetadata(kv ...string) metadata.MD {
+	md := metadata.MD{}
+	for i := 0; i < len(kv); i += 2 {
+		if kv[i+1] != "" {
+			md.Set(kv[i], kv[i+1])
+		}
+	}
+	return md
+}
+
+// fuzzMetadataValue returns the first value of the metadata key, the empty
+// string if there is none.
+func fuzzMetadataValue(md metadata.MD, key string) string {
+	if vals := md.Get(key); len(vals) > 0 {
+		return vals[0]
+	}
+	return ""
+}
+{{- if hasPtr .Endpoints }}
+
+// ptr returns a pointer to v.
+func ptr[T any](v T) *T {
+	return &v
+}
+{{- end }}
@@ -1,2 +1,2 @@
 {{ comment .Description }}
-message {{ .VarName }}{{ .Def }}
+{{ if .Enum }}enum{{ else }}message{{ end }} {{ .VarName }}{{ .Def }}
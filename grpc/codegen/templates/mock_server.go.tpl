@@ -0,0 +1,83 @@
+{{ comment "handleGRPCServer starts the mock gRPC server listening on addr and stops it when ctx is canceled." }}
+func handleGRPCServer(ctx context.Context, addr string{{ range .Services }}, {{ .Service.VarName }}Endpoints *{{ .Service.PkgName }}.Endpoints{{ end }}, wg *sync.WaitGroup, errc chan error) {
+	// Wrap the endpoints with the generated gRPC servers so that requests
+	// are decoded and validated as defined in the design.
+	var (
+{{- range .Services }}
+		{{ .Service.VarName }}Server *{{ .Service.PkgName }}svr.Server
+{{- end }}
+	)
+	{
+{{- range .Services }}
+		{{ .Service.VarName }}Server = {{ .Service.PkgName }}svr.New({{ .Service.VarName }}Endpoints{{ if .HasUnaryEndpoint }}, nil{{ end }}{{ if .HasStreamingEndpoint }}, nil{{ end }})
+{{- end }}
+	}
+
+	srv := grpc.NewServer(grpc.ChainUnaryInterceptor(mockUnaryInterceptor), grpc.ChainStreamInterceptor(mockStreamInterceptor))
+{{- range .Services }}
+	{{ .PkgName }}.Register{{ goify .Service.VarName true }}Server(srv, {{ .Service.VarName }}Server)
+{{- end }}
+	for svc, info := range srv.GetServiceInfo() {
+		for _, m := range info.Methods {
+			log.Printf("serving gRPC method %s", svc+"/"+m.Name)
+		}
+	}
+	reflection.Register(srv)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		// Start gRPC server in a separate goroutine.
+		go func() {
+			lis, err := net.Listen("tcp", addr)
+			if err != nil {
+				errc <- err
+				return
+			}
+			log.Printf("gRPC mock server listening on %q", addr)
+			errc <- srv.Serve(lis)
+		}()
+
+		<-ctx.Done()
+		log.Printf("shutting down gRPC server at %q", addr)
+		srv.Stop()
+	}()
+}
+
+{{ comment "mockUnaryInterceptor records the names of the error and of the scenario requested with the x-goa-mock-error and x-goa-mock-scenario metadata in the request context." }}
+func mockUnaryInterceptor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	return handler(withMockMetadata(ctx), req)
+}
+
+{{ comment "mockStreamInterceptor records the names of the error and of the scenario requested with the x-goa-mock-error and x-goa-mock-scenario metadata in the stream context." }}
+func mockStreamInterceptor(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &mockServerStream{ServerStream: ss, ctx: withMockMetadata(ss.Context())})
+}
+
+// mockServerStream overrides the context of a server stream.
+type mockServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the stream context.
+func (s *mockServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// withMockMetadata returns a copy of ctx that records the names of the error
+// and of the scenario listed in the incoming metadata.
+func withMockMetadata(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return withMock(ctx, metadataValue(md, "x-goa-mock-error"), metadataValue(md, "x-goa-mock-scenario"))
+}
+
+// metadataValue returns the first value of the metadata key, the empty string
+// if there is none.
+func metadataValue(md metadata.MD, key string) string {
+	if vals := md.Get(key); len(vals) > 0 {
+		return vals[0]
+	}
+	return ""
+}
@@ -21,6 +21,11 @@ func ParseEndpoint(cc *grpc.ClientConn, opts ...grpc.CallOption) (goa.Endpoint,
 			{{- else if .Conversion }}
 				{{ .Conversion }}
 			{{- end }}
+			{{- if .AllEndpoint }}
+				if *{{ .FullName }}AllFlag {
+					endpoint = {{ .AllEndpoint }}(endpoint)
+				}
+			{{- end }}
 		{{- end }}
 			}
 	{{- end }}
@@ -64,6 +64,12 @@
 			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "array of booleans"))
 		}
 		{{ .VarName }}[i] = v
+	{{- else if or (eq .Type.ElemType.Type.Name "decimal") (eq .Type.ElemType.Type.Name "bigint") }}
+		v, err2 := goa.Parse{{ if eq .Type.ElemType.Type.Name "decimal" }}Decimal{{ else }}BigInt{{ end }}(rv)
+		if err2 != nil {
+			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "array of {{ if eq .Type.ElemType.Type.Name "decimal" }}decimals{{ else }}integers{{ end }}"))
+		}
+		{{ .VarName }}[i] = {{ goTypeRef .Type.ElemType.Type }}(v)
 	{{- else if eq .Type.ElemType.Type.Name "any" }}
 		{{ .VarName }}[i] = rv
 	{{- else }}
@@ -153,6 +159,17 @@
 			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "boolean"))
 		}
 		{{ .VarName }} = {{ if .Pointer }}&{{ end }}v
+	{{- else if or (eq .Type.Name "decimal") (eq .Type.Name "bigint") }}
+		v, err2 := goa.Parse{{ if eq .Type.Name "decimal" }}Decimal{{ else }}BigInt{{ end }}({{ .VarName }}Raw)
+		if err2 != nil {
+			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "{{ if eq .Type.Name "decimal" }}decimal{{ else }}integer{{ end }}"))
+		}
+		{{- if .Pointer }}
+		pv := {{ slice .TypeRef 1 (len .TypeRef) }}(v)
+		{{ .VarName }} = &pv
+		{{- else }}
+		{{ .VarName }} = {{ .TypeRef }}(v)
+		{{- end }}
 	{{- else }}
 		// unsupported type {{ .Type.Name }} for var {{ .VarName }}
 	{{- end }}
@@ -19,7 +19,7 @@
 		{{ .VarName }} := strconv.FormatFloat({{ .Target }}, 'f', -1, 64)
 	{{- else if eq .Type.Name "string" -}}
 		{{ .VarName }} := {{ .Target }}
-	{{- else if eq .Type.Name "bytes" -}}
+	{{- else if or (eq .Type.Name "bytes") (eq .Type.Name "decimal") (eq .Type.Name "bigint") -}}
 		{{ .VarName }} := string({{ .Target }})
 	{{- else if eq .Type.Name "any" -}}
 		{{ .VarName }} := fmt.Sprintf("%v", {{ .Target }})
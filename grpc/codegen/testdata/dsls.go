@@ -110,6 +110,51 @@ var AliasValidationDSL = func() {
 	})
 }
 
+var TypedEnumDSL = func() {
+	var Color = Type("Color", String, func() {
+		Enum("red", "dark-green")
+		Meta("type:enum")
+	})
+	Service("ServiceTypedEnum", func() {
+		Method("MethodTypedEnum", func() {
+			Payload(func() {
+				Field(1, "color", Color)
+				Field(2, "colors", ArrayOf(Color))
+				Required("color")
+			})
+			Result(func() {
+				Field(1, "color", Color)
+			})
+			GRPC(func() {})
+		})
+	})
+}
+
+var TimeDSL = func() {
+	Service("ServiceTime", func() {
+		Method("MethodTime", func() {
+			Payload(func() {
+				Field(1, "since", String, func() {
+					Format(FormatDateTime)
+					Meta("struct:field:time")
+				})
+				Field(2, "timeouts", ArrayOf(String, func() {
+					Format(FormatDuration)
+					Meta("struct:field:time")
+				}))
+				Required("since")
+			})
+			Result(func() {
+				Field(1, "until", String, func() {
+					Format(FormatDate)
+					Meta("struct:field:time")
+				})
+			})
+			GRPC(func() {})
+		})
+	})
+}
+
 var UnaryRPCAcronymDSL = func() {
 	Service("ServiceUnaryRPCAcronym", func() {
 		Method("MethodUnaryRPCAcronym_jwt", func() {
@@ -1009,3 +1054,201 @@ var CustomMessageNameDSL = func() {
 		})
 	})
 }
+
+var NullableDSL = func() {
+	Service("ServiceNullable", func() {
+		Method("MethodNullable", func() {
+			Payload(func() {
+				Field(1, "nickname", String, func() {
+					Nullable()
+					MinLength(2)
+				})
+				Field(2, "age", Int, func() {
+					Nullable()
+				})
+			})
+			Result(func() {
+				Field(1, "score", UInt64, func() {
+					Nullable()
+				})
+			})
+			GRPC(func() {})
+		})
+	})
+}
+
+var ContractDSL = func() {
+	API("ContractAPI", func() {
+		Meta("test:contract")
+	})
+	Service("ServiceContract", func() {
+		Method("MethodContract", func() {
+			Payload(func() {
+				Field(1, "id", Int)
+				Field(2, "auth", String)
+				Required("id", "auth")
+			})
+			Result(func() {
+				Field(1, "name", String)
+				Field(2, "count", Int)
+				Required("count")
+			})
+			Error("not_found")
+			GRPC(func() {
+				Metadata(func() {
+					Attribute("auth")
+				})
+				Response(func() {
+					Headers(func() {
+						Attribute("count")
+					})
+				})
+				Response("not_found", CodeNotFound)
+			})
+		})
+		Method("MethodContractNoPayload", func() {
+			GRPC(func() {})
+		})
+		Method("MethodContractStreaming", func() {
+			StreamingResult(String)
+			GRPC(func() {})
+		})
+	})
+}
+
+var ContractRulesDSL = func() {
+	API("ContractAPI", func() {
+		Meta("test:contract")
+	})
+	var Color = Type("Color", String, func() {
+		Enum("red", "green", "blue")
+	})
+	var Contact = Type("Contact", func() {
+		Field(1, "email", String, func() {
+			Format(FormatEmail)
+		})
+		Field(2, "sms", String)
+		Field(3, "color", Color)
+		Field(4, "start", String, func() {
+			Format(FormatDate)
+		})
+		Field(5, "end", String, func() {
+			Format(FormatDate)
+		})
+		Field(6, "status", String, func() {
+			Enum("ok", "rejected")
+		})
+		Field(7, "reason", String)
+		Required("color")
+		ExactlyOneOf("email", "sms")
+		After("end", "start")
+		RequiredIf("status", "rejected", "reason")
+	})
+	Service("ServiceContract", func() {
+		Method("MethodContractRules", func() {
+			Payload(Contact)
+			Result(Contact)
+			GRPC(func() {})
+		})
+		Method("MethodContractInvalidExample", func() {
+			Payload(func() {
+				Field(1, "email", String)
+				Field(2, "sms", String)
+				ExactlyOneOf("email", "sms")
+				Example(map[string]any{"email": "me@example.com", "sms": "555-0100"})
+			})
+			GRPC(func() {})
+		})
+	})
+}
+
+var ContractDisabledDSL = func() {
+	API("ContractAPI", func() {
+		Meta("test:contract")
+	})
+	Service("ServiceContractDisabled", func() {
+		Meta("test:contract", "false")
+		Method("MethodContractDisabled", func() {
+			GRPC(func() {})
+		})
+	})
+}
+
+var FuzzDSL = func() {
+	API("FuzzAPI", func() {
+		Meta("test:fuzz")
+	})
+	Service("ServiceFuzz", func() {
+		Method("MethodFuzz", func() {
+			Payload(func() {
+				Field(1, "id", Int)
+				Field(2, "filter", String, func() {
+					MaxLength(10)
+				})
+				Field(3, "auth", String)
+				Required("id", "auth")
+			})
+			GRPC(func() {
+				Metadata(func() {
+					Attribute("auth")
+				})
+			})
+		})
+		Method("MethodFuzzNoPayload", func() {
+			GRPC(func() {})
+		})
+		Method("MethodFuzzStreaming", func() {
+			Payload(String)
+			StreamingResult(String)
+			GRPC(func() {})
+		})
+	})
+}
+
+var FuzzEnumDSL = func() {
+	API("FuzzAPI", func() {
+		Meta("test:fuzz")
+	})
+	var Color = Type("Color", String, func() {
+		Enum("red", "green", "blue")
+	})
+	var Phone = CustomFormat("phone", func() {
+		Validator("example.com/formats", "ValidatePhone")
+		Example("+15551234567")
+	})
+	Service("ServiceFuzz", func() {
+		Method("MethodFuzzEnum", func() {
+			Payload(func() {
+				Field(1, "color", Color)
+				Field(2, "email", String)
+				Field(3, "sms", String)
+				Field(4, "phone", String, func() {
+					Format(Phone)
+				})
+				Required("color")
+				ExactlyOneOf("email", "sms")
+			})
+			GRPC(func() {})
+		})
+		Method("MethodFuzzInvalidExample", func() {
+			Payload(func() {
+				Field(1, "color", Color)
+				Example(map[string]any{"color": "purple"})
+			})
+			GRPC(func() {})
+		})
+	})
+}
+
+var FuzzDisabledDSL = func() {
+	API("FuzzAPI", func() {
+		Meta("test:fuzz")
+	})
+	Service("ServiceFuzzDisabled", func() {
+		Meta("test:fuzz", "false")
+		Method("MethodFuzzDisabled", func() {
+			Payload(String)
+			GRPC(func() {})
+		})
+	})
+}
@@ -622,3 +622,116 @@ func NewProtoMethodResponse() *default_fieldspb.MethodResponse {
 	return message
 }
 `
+
+const TypedEnumServerTypesFile = `// NewMethodTypedEnumPayload builds the payload of the "MethodTypedEnum"
+// endpoint of the "ServiceTypedEnum" service from the gRPC request type.
+func NewMethodTypedEnumPayload(message *service_typed_enumpb.MethodTypedEnumRequest) *servicetypedenum.MethodTypedEnumPayload {
+	v := &servicetypedenum.MethodTypedEnumPayload{
+		Color: protobufServiceTypedEnumpbColorToServicetypedenumColor(message.Color),
+	}
+	if message.Colors != nil {
+		v.Colors = make([]servicetypedenum.Color, len(message.Colors))
+		for i, val := range message.Colors {
+			v.Colors[i] = protobufServiceTypedEnumpbColorToServicetypedenumColor(val)
+		}
+	}
+	return v
+}
+
+// NewProtoMethodTypedEnumResponse builds the gRPC response type from the
+// result of the "MethodTypedEnum" endpoint of the "ServiceTypedEnum" service.
+func NewProtoMethodTypedEnumResponse(result *servicetypedenum.MethodTypedEnumResult) *service_typed_enumpb.MethodTypedEnumResponse {
+	message := &service_typed_enumpb.MethodTypedEnumResponse{}
+	if result.Color != nil {
+		color := svcServicetypedenumColorToServiceTypedEnumpbColor(*result.Color)
+		message.Color = &color
+	}
+	return message
+}
+
+// protobufServiceTypedEnumpbColorToServicetypedenumColor builds a value of
+// type servicetypedenum.Color from a value of type service_typed_enumpb.Color.
+func protobufServiceTypedEnumpbColorToServicetypedenumColor(v service_typed_enumpb.Color) servicetypedenum.Color {
+	var res servicetypedenum.Color
+	switch v {
+	case service_typed_enumpb.Color_COLOR_RED:
+		res = "red"
+	case service_typed_enumpb.Color_COLOR_DARK_GREEN:
+		res = "dark-green"
+	}
+	return res
+}
+
+// svcServicetypedenumColorToServiceTypedEnumpbColor builds a value of type
+// service_typed_enumpb.Color from a value of type servicetypedenum.Color.
+func svcServicetypedenumColorToServiceTypedEnumpbColor(v servicetypedenum.Color) service_typed_enumpb.Color {
+	var res service_typed_enumpb.Color
+	switch v {
+	case "red":
+		res = service_typed_enumpb.Color_COLOR_RED
+	case "dark-green":
+		res = service_typed_enumpb.Color_COLOR_DARK_GREEN
+	}
+	return res
+}
+`
+
+const TimeServerTypesFile = `// NewMethodTimePayload builds the payload of the "MethodTime" endpoint of the
+// "ServiceTime" service from the gRPC request type.
+func NewMethodTimePayload(message *service_timepb.MethodTimeRequest) *servicetime.MethodTimePayload {
+	v := &servicetime.MethodTimePayload{}
+	v.Since = message.Since.AsTime()
+	if message.Timeouts != nil {
+		v.Timeouts = make([]time.Duration, len(message.Timeouts))
+		for i, val := range message.Timeouts {
+			v.Timeouts[i] = val.AsDuration()
+		}
+	}
+	return v
+}
+
+// NewProtoMethodTimeResponse builds the gRPC response type from the result of
+// the "MethodTime" endpoint of the "ServiceTime" service.
+func NewProtoMethodTimeResponse(result *servicetime.MethodTimeResult) *service_timepb.MethodTimeResponse {
+	message := &service_timepb.MethodTimeResponse{}
+	if result.Until != nil {
+		message.Until = timestamppb.New(*result.Until)
+	}
+	return message
+}
+`
+
+const NullableServerTypesFile = `// NewMethodNullablePayload builds the payload of the "MethodNullable" endpoint
+// of the "ServiceNullable" service from the gRPC request type.
+func NewMethodNullablePayload(message *service_nullablepb.MethodNullableRequest) *servicenullable.MethodNullablePayload {
+	v := &servicenullable.MethodNullablePayload{}
+	if message.Nickname != nil {
+		v.Nickname = goa.NewNullable(message.Nickname.GetValue())
+	}
+	if message.Age != nil {
+		v.Age = goa.NewNullable(int(message.Age.GetValue()))
+	}
+	return v
+}
+
+// NewProtoMethodNullableResponse builds the gRPC response type from the result
+// of the "MethodNullable" endpoint of the "ServiceNullable" service.
+func NewProtoMethodNullableResponse(result *servicenullable.MethodNullableResult) *service_nullablepb.MethodNullableResponse {
+	message := &service_nullablepb.MethodNullableResponse{}
+	if result.Score.IsValue() {
+		message.Score = wrapperspb.UInt64(result.Score.Value)
+	}
+	return message
+}
+
+// ValidateMethodNullableRequest runs the validations defined on
+// MethodNullableRequest.
+func ValidateMethodNullableRequest(message *service_nullablepb.MethodNullableRequest) (err error) {
+	if message.Nickname != nil {
+		if utf8.RuneCountInString(message.Nickname.Value) < 2 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError("message.nickname", message.Nickname.Value, utf8.RuneCountInString(message.Nickname.Value), 2, true))
+		}
+	}
+	return
+}
+`
@@ -6,10 +6,12 @@ import (
 
 	goapb "goa.design/goa/v3/grpc/pb"
 	goa "goa.design/goa/v3/pkg"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/runtime/protoiface"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 type (
@@ -80,7 +82,9 @@ func NewStatusError(code codes.Code, err error, details ...protoiface.MessageV1)
 // it implements a heuristic to compute the status code from the Timeout,
 // Fault, and Temporary characteristics of the ServiceError. If error is not a
 // ServiceError or a gRPC status error it returns a gRPC status error with
-// Unknown code and Fault characteristic set.
+// Unknown code and Fault characteristic set. Errors caused by rate or
+// concurrency limits produce ResourceExhausted status errors whose details
+// include the retry delay.
 func EncodeError(err error) error {
 	if st, ok := status.FromError(err); ok {
 		if s, err := st.WithDetails(NewErrorResponse(err)); err == nil {
@@ -88,6 +92,13 @@ func EncodeError(err error) error {
 		}
 		return st.Err()
 	}
+	var rerr *goa.RateLimitError
+	if errors.As(err, &rerr) {
+		// Request rejected because of a rate or concurrency limit, add the
+		// retry delay to the status details.
+		return NewStatusError(codes.ResourceExhausted, err, NewErrorResponse(err),
+			&errdetails.RetryInfo{RetryDelay: durationpb.New(rerr.RetryAfter)})
+	}
 	var gerr *goa.ServiceError
 	if errors.As(err, &gerr) {
 		// goa service error type. Compute the status code from the service error
@@ -0,0 +1,18 @@
+/*
+Package otel contains unary and streaming server and client interceptors that
+instrument gRPC requests and responses with OpenTelemetry spans and metrics.
+
+The server interceptors extract the W3C trace context from the incoming request
+metadata, start a server span named after the gRPC method and record the
+request duration as well as the request and response message sizes. The
+goa.design/goa/v3/middleware/otel Endpoint middleware renames the span after
+the goa service and method.
+
+The client interceptors start a client span, inject the W3C trace context in
+the outgoing request metadata and record the same metrics.
+
+The interceptors default to the global OpenTelemetry providers, use the
+goa.design/goa/v3/middleware/otel/oteltest package to record the spans and
+metrics in memory in tests.
+*/
+package otel
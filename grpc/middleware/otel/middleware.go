@@ -0,0 +1,314 @@
+package otel
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	grpcm "goa.design/goa/v3/grpc/middleware"
+	"goa.design/goa/v3/middleware/otel"
+	"google.golang.org/grpc"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+type (
+	// instruments contains the tracer and metric instruments used by the
+	// interceptors.
+	instruments struct {
+		config   *otel.Config
+		tracer   trace.Tracer
+		kind     trace.SpanKind
+		duration metric.Float64Histogram
+		reqSize  metric.Int64Histogram
+		respSize metric.Int64Histogram
+	}
+
+	// call records the span and metrics of a single RPC.
+	call struct {
+		*instruments
+		ctx     context.Context
+		span    trace.Span
+		start   time.Time
+		attrs   []attribute.KeyValue
+		labeler *otel.Labeler
+		once    sync.Once
+	}
+
+	// serverStream wraps the gRPC server stream to record the size of the
+	// messages.
+	serverStream struct {
+		*grpcm.WrappedServerStream
+		call *call
+	}
+
+	// clientStream wraps the gRPC client stream to record the size of the
+	// messages and end the span once the stream completes.
+	clientStream struct {
+		grpc.ClientStream
+		call *call
+	}
+
+	// metadataCarrier adapts metadata.MD to the propagation.TextMapCarrier
+	// interface.
+	metadataCarrier metadata.MD
+)
+
+// NewUnaryServer returns a server interceptor that creates a span for each
+// request and records the request duration and the request and response
+// message sizes. The trace context is extracted from the incoming request
+// metadata.
+func NewUnaryServer(opts ...otel.Option) grpc.UnaryServerInterceptor {
+	ins := newServerInstruments(otel.NewConfig(opts...))
+	return grpc.UnaryServerInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		c := ins.startServer(ctx, info.FullMethod)
+		c.recordRequest(req)
+		resp, err := handler(c.ctx, req)
+		if err == nil {
+			c.recordResponse(resp)
+		}
+		c.end(err)
+		return resp, err
+	})
+}
+
+// NewStreamServer returns a server interceptor that creates a span for each
+// stream and records the stream duration and the size of the messages
+// received and sent. The trace context is extracted from the incoming request
+// metadata.
+func NewStreamServer(opts ...otel.Option) grpc.StreamServerInterceptor {
+	ins := newServerInstruments(otel.NewConfig(opts...))
+	return grpc.StreamServerInterceptor(func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		c := ins.startServer(ss.Context(), info.FullMethod)
+		err := handler(srv, &serverStream{WrappedServerStream: grpcm.NewWrappedServerStream(c.ctx, ss), call: c})
+		c.end(err)
+		return err
+	})
+}
+
+// UnaryClient returns a client interceptor that creates a span for each
+// request and records the request duration and the request and response
+// message sizes. The trace context is injected in the outgoing request
+// metadata.
+func UnaryClient(opts ...otel.Option) grpc.UnaryClientInterceptor {
+	ins := newClientInstruments(otel.NewConfig(opts...))
+	return grpc.UnaryClientInterceptor(func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		c := ins.startClient(ctx, method)
+		c.recordRequest(req)
+		err := invoker(c.ctx, method, req, reply, cc, opts...)
+		if err == nil {
+			c.recordResponse(reply)
+		}
+		c.end(err)
+		return err
+	})
+}
+
+// StreamClient returns a client interceptor that creates a span for each
+// stream and records the stream duration and the size of the messages sent
+// and received. The trace context is injected in the outgoing request
+// metadata. The span ends once the stream has been fully consumed or has
+// failed.
+func StreamClient(opts ...otel.Option) grpc.StreamClientInterceptor {
+	ins := newClientInstruments(otel.NewConfig(opts...))
+	return grpc.StreamClientInterceptor(func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		c := ins.startClient(ctx, method)
+		cs, err := streamer(c.ctx, desc, cc, method, opts...)
+		if err != nil {
+			c.end(err)
+			return nil, err
+		}
+		return &clientStream{ClientStream: cs, call: c}, nil
+	})
+}
+
+// RecvMsg records the size of the received message.
+func (s *serverStream) RecvMsg(m any) error {
+	err := s.WrappedServerStream.RecvMsg(m)
+	if err == nil {
+		s.call.recordRequest(m)
+	}
+	return err
+}
+
+// SendMsg records the size of the sent message.
+func (s *serverStream) SendMsg(m any) error {
+	err := s.WrappedServerStream.SendMsg(m)
+	if err == nil {
+		s.call.recordResponse(m)
+	}
+	return err
+}
+
+// SendMsg records the size of the sent message and ends the span if the
+// stream failed.
+func (s *clientStream) SendMsg(m any) error {
+	err := s.ClientStream.SendMsg(m)
+	if err != nil {
+		s.call.end(err)
+		return err
+	}
+	s.call.recordRequest(m)
+	return nil
+}
+
+// RecvMsg records the size of the received message and ends the span once the
+// stream completes.
+func (s *clientStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			s.call.end(nil)
+		} else {
+			s.call.end(err)
+		}
+		return err
+	}
+	s.call.recordResponse(m)
+	return nil
+}
+
+// newServerInstruments creates the instruments used by the server interceptors.
+func newServerInstruments(c *otel.Config) *instruments {
+	return &instruments{
+		config:   c,
+		tracer:   c.Tracer(),
+		kind:     trace.SpanKindServer,
+		duration: c.Float64Histogram(semconv.RPCServerDurationName, semconv.RPCServerDurationUnit, semconv.RPCServerDurationDescription),
+		reqSize:  c.Int64Histogram(semconv.RPCServerRequestSizeName, semconv.RPCServerRequestSizeUnit, semconv.RPCServerRequestSizeDescription),
+		respSize: c.Int64Histogram(semconv.RPCServerResponseSizeName, semconv.RPCServerResponseSizeUnit, semconv.RPCServerResponseSizeDescription),
+	}
+}
+
+// newClientInstruments creates the instruments used by the client interceptors.
+func newClientInstruments(c *otel.Config) *instruments {
+	return &instruments{
+		config:   c,
+		tracer:   c.Tracer(),
+		kind:     trace.SpanKindClient,
+		duration: c.Float64Histogram(semconv.RPCClientDurationName, semconv.RPCClientDurationUnit, semconv.RPCClientDurationDescription),
+		reqSize:  c.Int64Histogram(semconv.RPCClientRequestSizeName, semconv.RPCClientRequestSizeUnit, semconv.RPCClientRequestSizeDescription),
+		respSize: c.Int64Histogram(semconv.RPCClientResponseSizeName, semconv.RPCClientResponseSizeUnit, semconv.RPCClientResponseSizeDescription),
+	}
+}
+
+// startServer extracts the trace context from the incoming metadata and starts
+// the server span.
+func (ins *instruments) startServer(ctx context.Context, fullMethod string) *call {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx = ins.config.Propagators.Extract(ctx, metadataCarrier(md))
+	c := ins.start(ctx, fullMethod)
+	c.ctx = otel.ContextWithLabeler(c.ctx, c.labeler)
+	return c
+}
+
+// startClient starts the client span and injects the trace context in the
+// outgoing metadata.
+func (ins *instruments) startClient(ctx context.Context, fullMethod string) *call {
+	c := ins.start(ctx, fullMethod)
+	md, ok := metadata.FromOutgoingContext(c.ctx)
+	if ok {
+		md = md.Copy()
+	} else {
+		md = metadata.MD{}
+	}
+	ins.config.Propagators.Inject(c.ctx, metadataCarrier(md))
+	c.ctx = metadata.NewOutgoingContext(c.ctx, md)
+	return c
+}
+
+// start starts a span for the given gRPC method.
+func (ins *instruments) start(ctx context.Context, fullMethod string) *call {
+	name, attrs := rpcAttributes(fullMethod)
+	ctx, span := ins.tracer.Start(ctx, name, trace.WithSpanKind(ins.kind), trace.WithAttributes(attrs...))
+	return &call{
+		instruments: ins,
+		ctx:         ctx,
+		span:        span,
+		start:       time.Now(),
+		attrs:       attrs,
+		labeler:     &otel.Labeler{},
+	}
+}
+
+// recordRequest records the size of a request message.
+func (c *call) recordRequest(msg any) {
+	c.reqSize.Record(c.ctx, messageLength(msg), metric.WithAttributes(c.metricAttributes()...))
+}
+
+// recordResponse records the size of a response message.
+func (c *call) recordResponse(msg any) {
+	c.respSize.Record(c.ctx, messageLength(msg), metric.WithAttributes(c.metricAttributes()...))
+}
+
+// end records the RPC status and duration and ends the span. It is safe to
+// call end multiple times, only the first call has an effect.
+func (c *call) end(err error) {
+	c.once.Do(func() {
+		code := status.Code(err)
+		c.span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(code)))
+		if code != grpccodes.OK {
+			c.span.RecordError(err)
+			c.span.SetStatus(codes.Error, status.Convert(err).Message())
+		}
+		attrs := append(c.metricAttributes(), semconv.RPCGRPCStatusCodeKey.Int(int(code)))
+		elapsed := float64(time.Since(c.start)) / float64(time.Millisecond)
+		c.duration.Record(c.ctx, elapsed, metric.WithAttributes(attrs...))
+		c.span.End()
+	})
+}
+
+// metricAttributes returns the attributes recorded with the RPC metrics.
+func (c *call) metricAttributes() []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, len(c.attrs))
+	copy(attrs, c.attrs)
+	return append(attrs, c.labeler.Get()...)
+}
+
+// rpcAttributes returns the span name and the RPC attributes for the given
+// full gRPC method name of the form "/package.service/method".
+func rpcAttributes(fullMethod string) (string, []attribute.KeyValue) {
+	name := strings.TrimPrefix(fullMethod, "/")
+	attrs := []attribute.KeyValue{semconv.RPCSystemGRPC}
+	if svc, meth, ok := strings.Cut(name, "/"); ok {
+		attrs = append(attrs, semconv.RPCService(svc), semconv.RPCMethod(meth))
+	}
+	return name, attrs
+}
+
+// messageLength returns the size of the given protobuf message.
+func messageLength(msg any) int64 {
+	if m, ok := msg.(proto.Message); ok {
+		return int64(proto.Size(m))
+	}
+	return 0
+}
+
+// Get returns the first value associated with the given key.
+func (c metadataCarrier) Get(key string) string {
+	return grpcm.MetadataValue(metadata.MD(c), key)
+}
+
+// Set sets the value associated with the given key.
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+// Keys lists the metadata keys.
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
@@ -0,0 +1,263 @@
+package otel
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"goa.design/goa/v3/middleware/otel"
+	"goa.design/goa/v3/middleware/otel/oteltest"
+	goa "goa.design/goa/v3/pkg"
+	"google.golang.org/grpc"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const (
+	traceID     = "4bf92f3577b34da6a3ce929d0e0e4736"
+	spanID      = "00f067aa0ba902b7"
+	traceparent = "00-" + traceID + "-" + spanID + "-01"
+	fullMethod  = "/test.Test/Method"
+)
+
+type (
+	// testServerStream is a server stream that receives and sends a single
+	// message.
+	testServerStream struct {
+		grpc.ServerStream
+		ctx context.Context
+	}
+
+	// testClientStream is a client stream that receives a single message.
+	testClientStream struct {
+		grpc.ClientStream
+		received bool
+	}
+)
+
+func TestNewUnaryServer(t *testing.T) {
+	cases := []struct {
+		Name         string
+		Traceparent  string
+		Endpoint     bool
+		Error        error
+		ExpectedName string
+	}{
+		{"no trace", "", false, nil, "test.Test/Method"},
+		{"traceparent", traceparent, false, nil, "test.Test/Method"},
+		{"endpoint", "", true, nil, "svc/meth"},
+		{"error", "", false, status.Error(grpccodes.Internal, "error"), "test.Test/Method"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			rec := oteltest.NewRecorder()
+			ctx := context.Background()
+			if tc.Traceparent != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("traceparent", tc.Traceparent))
+			}
+			handler := func(ctx context.Context, req any) (any, error) {
+				if tc.Endpoint {
+					ctx = context.WithValue(ctx, goa.ServiceKey, "svc")
+					ctx = context.WithValue(ctx, goa.MethodKey, "meth")
+					if _, err := otel.Endpoint(func(context.Context, any) (any, error) { return nil, nil })(ctx, req); err != nil {
+						return nil, err
+					}
+				}
+				return wrapperspb.String("response"), tc.Error
+			}
+
+			_, err := NewUnaryServer(rec.Options()...)(ctx, wrapperspb.String("request"), &grpc.UnaryServerInfo{FullMethod: fullMethod}, handler)
+
+			if err != tc.Error {
+				t.Fatalf("got error %v, expected %v", err, tc.Error)
+			}
+			spans := rec.Spans()
+			if len(spans) != 1 {
+				t.Fatalf("got %d spans, expected 1", len(spans))
+			}
+			span := spans[0]
+			if span.Name != tc.ExpectedName {
+				t.Errorf("got span name %q, expected %q", span.Name, tc.ExpectedName)
+			}
+			if span.SpanKind != trace.SpanKindServer {
+				t.Errorf("got span kind %v, expected server", span.SpanKind)
+			}
+			if tc.Traceparent != "" {
+				if got := span.SpanContext.TraceID().String(); got != traceID {
+					t.Errorf("got trace ID %q, expected %q", got, traceID)
+				}
+				if got := span.Parent.SpanID().String(); got != spanID {
+					t.Errorf("got parent span ID %q, expected %q", got, spanID)
+				}
+			}
+			attrs := attribute.NewSet(span.Attributes...)
+			if v, _ := attrs.Value(semconv.RPCServiceKey); v.AsString() != "test.Test" {
+				t.Errorf("got rpc service %q, expected %q", v.AsString(), "test.Test")
+			}
+			if isErr := span.Status.Code == codes.Error; isErr != (tc.Error != nil) {
+				t.Errorf("got error status %v, expected %v", isErr, tc.Error != nil)
+			}
+			m := rec.Metric(semconv.RPCServerDurationName)
+			if m == nil {
+				t.Fatal("duration metric not recorded")
+			}
+			dps := m.Data.(metricdata.Histogram[float64]).DataPoints
+			if len(dps) != 1 {
+				t.Fatalf("got %d duration data points, expected 1", len(dps))
+			}
+			if _, ok := dps[0].Attributes.Value(otel.ServiceKey); ok != tc.Endpoint {
+				t.Errorf("got service attribute %v, expected %v", ok, tc.Endpoint)
+			}
+			assertSize(t, rec, semconv.RPCServerRequestSizeName, int64(len("request")+2))
+			if tc.Error == nil {
+				assertSize(t, rec, semconv.RPCServerResponseSizeName, int64(len("response")+2))
+			}
+		})
+	}
+}
+
+func TestNewStreamServer(t *testing.T) {
+	rec := oteltest.NewRecorder()
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("traceparent", traceparent))
+	handler := func(srv any, stream grpc.ServerStream) error {
+		if got := trace.SpanContextFromContext(stream.Context()).TraceID().String(); got != traceID {
+			t.Errorf("got trace ID %q in stream context, expected %q", got, traceID)
+		}
+		var msg wrapperspb.StringValue
+		if err := stream.RecvMsg(&msg); err != nil {
+			return err
+		}
+		return stream.SendMsg(wrapperspb.String("response"))
+	}
+
+	err := NewStreamServer(rec.Options()...)(nil, &testServerStream{ctx: ctx}, &grpc.StreamServerInfo{FullMethod: fullMethod}, handler)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	spans := rec.Spans()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, expected 1", len(spans))
+	}
+	if got := spans[0].SpanContext.TraceID().String(); got != traceID {
+		t.Errorf("got trace ID %q, expected %q", got, traceID)
+	}
+	assertSize(t, rec, semconv.RPCServerRequestSizeName, int64(len("request")+2))
+	assertSize(t, rec, semconv.RPCServerResponseSizeName, int64(len("response")+2))
+}
+
+func TestUnaryClient(t *testing.T) {
+	rec := oteltest.NewRecorder()
+	ctx, parent := rec.TracerProvider.Tracer("test").Start(context.Background(), "parent")
+	ctx = metadata.AppendToOutgoingContext(ctx, "key", "value")
+	var md metadata.MD
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ = metadata.FromOutgoingContext(ctx)
+		return nil
+	}
+
+	err := UnaryClient(rec.Options()...)(ctx, fullMethod, wrapperspb.String("request"), wrapperspb.String("response"), nil, invoker)
+	parent.End()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	spans := rec.Spans()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, expected 2", len(spans))
+	}
+	span := spans[0]
+	if span.SpanKind != trace.SpanKindClient {
+		t.Errorf("got span kind %v, expected client", span.SpanKind)
+	}
+	if span.Parent.SpanID() != parent.SpanContext().SpanID() {
+		t.Errorf("got parent span %s, expected %s", span.Parent.SpanID(), parent.SpanContext().SpanID())
+	}
+	expected := "00-" + span.SpanContext.TraceID().String() + "-" + span.SpanContext.SpanID().String() + "-01"
+	if got := md.Get("traceparent"); len(got) != 1 || got[0] != expected {
+		t.Errorf("got traceparent %v, expected %q", got, expected)
+	}
+	if got := md.Get("key"); len(got) != 1 || got[0] != "value" {
+		t.Errorf("got key metadata %v, expected %q", got, "value")
+	}
+	assertSize(t, rec, semconv.RPCClientRequestSizeName, int64(len("request")+2))
+	assertSize(t, rec, semconv.RPCClientResponseSizeName, int64(len("response")+2))
+}
+
+func TestStreamClient(t *testing.T) {
+	rec := oteltest.NewRecorder()
+	var md metadata.MD
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		md, _ = metadata.FromOutgoingContext(ctx)
+		return &testClientStream{}, nil
+	}
+
+	cs, err := StreamClient(rec.Options()...)(context.Background(), &grpc.StreamDesc{}, nil, fullMethod, streamer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(md.Get("traceparent")) != 1 {
+		t.Error("traceparent not injected in outgoing metadata")
+	}
+	var msg wrapperspb.StringValue
+	if err := cs.RecvMsg(&msg); err != nil {
+		t.Fatal(err)
+	}
+	if len(rec.Spans()) != 0 {
+		t.Fatal("span ended before the stream completed")
+	}
+	if err := cs.RecvMsg(&msg); err != io.EOF {
+		t.Fatalf("got error %v, expected EOF", err)
+	}
+	spans := rec.Spans()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, expected 1", len(spans))
+	}
+	if spans[0].Status.Code == codes.Error {
+		t.Error("unexpected error status")
+	}
+	assertSize(t, rec, semconv.RPCClientResponseSizeName, int64(len("response")+2))
+}
+
+// assertSize checks that the histogram with the given name recorded a single
+// value equal to expected.
+func assertSize(t *testing.T, rec *oteltest.Recorder, name string, expected int64) {
+	t.Helper()
+	m := rec.Metric(name)
+	if m == nil {
+		t.Fatalf("metric %q not recorded", name)
+	}
+	dps := m.Data.(metricdata.Histogram[int64]).DataPoints
+	if len(dps) != 1 || dps[0].Sum != expected {
+		t.Errorf("got %+v for metric %q, expected a single value of %d", dps, name, expected)
+	}
+}
+
+func (s *testServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *testServerStream) RecvMsg(m any) error {
+	m.(*wrapperspb.StringValue).Value = "request"
+	return nil
+}
+
+func (s *testServerStream) SendMsg(any) error {
+	return nil
+}
+
+func (s *testClientStream) RecvMsg(m any) error {
+	if s.received {
+		return io.EOF
+	}
+	s.received = true
+	m.(*wrapperspb.StringValue).Value = "response"
+	return nil
+}
@@ -127,6 +127,12 @@ func endpointParser(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr, da
 			Path: genpkg + "/http/" + sd.Service.PathName + "/client",
 			Name: sd.Service.PkgName + "c",
 		})
+		if svc.HasPagination() {
+			specs = append(specs, &codegen.ImportSpec{
+				Path: genpkg + "/" + sd.Service.PathName,
+				Name: sd.Service.PkgName,
+			})
+		}
 	}
 
 	cliData := make([]*cli.CommandData, len(data))
@@ -195,13 +201,21 @@ func buildFlags(svc *ServiceData, e *EndpointData) ([]*cli.FlagData, *cli.BuildF
 
 	svcn := svc.Service.Name
 	en := e.Method.Name
+	scenario, endpoint := payloadScenario(svcn, en)
 	if e.Payload != nil {
 		if e.Payload.Request.PayloadInit != nil {
 			args := e.Payload.Request.PayloadInit.ClientArgs
 			args = append(args, e.Payload.Request.PayloadInit.CLIArgs...)
-			flags, buildFunction = makeFlags(e, args, e.Payload.Request.PayloadType)
+			if scenario != nil {
+				args = scenarioArgs(args, scenario, endpoint)
+			}
+			flags, buildFunction = makeFlags(svc, e, args, e.Payload.Request.PayloadType)
 		} else if e.Payload.Ref != "" {
-			flags = append(flags, cli.NewFlagData(svcn, en, "p", e.Method.PayloadRef, e.Method.PayloadDesc, true, e.Method.PayloadEx, e.Method.PayloadDefault))
+			ex := e.Method.PayloadEx
+			if scenario != nil {
+				ex = scenario.Payload
+			}
+			flags = append(flags, cli.NewFlagData(svcn, en, "p", e.Method.PayloadRef, e.Method.PayloadDesc, true, ex, e.Method.PayloadDefault))
 		}
 	}
 	if e.Method.SkipRequestBodyEncodeDecode {
@@ -211,7 +225,52 @@ func buildFlags(svc *ServiceData, e *EndpointData) ([]*cli.FlagData, *cli.BuildF
 	return flags, buildFunction
 }
 
-func makeFlags(e *EndpointData, args []*InitArgData, payload expr.DataType) ([]*cli.FlagData, *cli.BuildFunctionData) {
+// payloadScenario returns the first scenario of the given endpoint that
+// defines a payload value and the endpoint expression, nil if there is none.
+func payloadScenario(svcName, endpointName string) (*expr.ScenarioExpr, *expr.HTTPEndpointExpr) {
+	svc := expr.Root.API.HTTP.Service(svcName)
+	if svc == nil {
+		return nil, nil
+	}
+	e := svc.Endpoint(endpointName)
+	if e == nil {
+		return nil, nil
+	}
+	for _, s := range e.MethodExpr.Scenarios {
+		if s.Payload != nil {
+			return s, e
+		}
+	}
+	return nil, nil
+}
+
+// scenarioArgs returns a copy of args where the examples are replaced with the
+// values of the payload of the given scenario.
+func scenarioArgs(args []*InitArgData, s *expr.ScenarioExpr, e *expr.HTTPEndpointExpr) []*InitArgData {
+	res := make([]*InitArgData, len(args))
+	for i, arg := range args {
+		var (
+			val any
+			ok  bool
+		)
+		if arg.FieldName == "" && arg.VarName == "body" {
+			val = s.HTTPRequestBody(e)
+			ok = val != nil
+		} else {
+			val, ok = s.PayloadAttribute(arg.Name)
+		}
+		if !ok {
+			res[i] = arg
+			continue
+		}
+		ad := *arg.AttributeData
+		ad.Example = val
+		res[i] = &InitArgData{Ref: arg.Ref, AttributeData: &ad}
+	}
+	return res
+}
+
+func makeFlags(svc *ServiceData, e *EndpointData, args []*InitArgData, payload expr.DataType) ([]*cli.FlagData, *cli.BuildFunctionData) {
 	var (
 		fdata     []*cli.FieldData
 		flags     = make([]*cli.FlagData, len(args))
@@ -226,6 +285,7 @@ func makeFlags(e *EndpointData, args []*InitArgData, payload expr.DataType) ([]*
 			FieldName:    arg.FieldName,
 			FieldPointer: arg.FieldPointer,
 			FieldType:    arg.FieldType,
+			FieldTime:    arg.FieldTime,
 			Type:         arg.Type,
 		}
 
@@ -235,9 +295,22 @@ func makeFlags(e *EndpointData, args []*InitArgData, payload expr.DataType) ([]*
 		if arg.FieldName == "" && arg.VarName != "body" {
 			continue
 		}
-		code, chek := cli.FieldLoadCode(f, arg.VarName, arg.TypeName, arg.Validate, arg.DefaultValue, payload, e.Payload.Ref)
+		var (
+			code string
+			chek bool
+			tn   = arg.TypeRef
+		)
+		if typeName, parse := enumParseFunc(svc, arg.FieldType); parse != "" && f.Type != "JSON" {
+			// Parse the flag with the function generated for the enum type.
+			tn = typeName
+			if arg.Pointer {
+				tn = "*" + typeName
+			}
+			code, chek = cli.EnumFieldLoadCode(f, arg.VarName, parse, typeName, arg.DefaultValue, payload, e.Payload.Ref), true
+		} else {
+			code, chek = cli.FieldLoadCode(f, arg.VarName, arg.TypeName, arg.Validate, arg.DefaultValue, payload, e.Payload.Ref)
+		}
 		check = check || chek
-		tn := arg.TypeRef
 		if f.Type == "JSON" {
 			// We need to declare the variable without
 			// a pointer to be able to unmarshal the JSON
@@ -277,6 +350,21 @@ func makeFlags(e *EndpointData, args []*InitArgData, payload expr.DataType) ([]*
 
 // streamFlag returns the flag used to specify the upload file for endpoints
 // that use SkipRequestBodyEncodeDecode.
+// enumParseFunc returns the qualified name of the Go type generated for the
+// given data type and the qualified name of its parse function if the type
+// uses the "type:enum" meta, empty strings otherwise.
+func enumParseFunc(svc *ServiceData, dt expr.DataType) (string, string) {
+	if !expr.IsTypedEnum(dt) {
+		return "", ""
+	}
+	pkg := svc.Service.PkgName
+	if loc := codegen.UserTypeLocation(dt); loc != nil {
+		pkg = loc.PackageName()
+	}
+	name := svc.Service.Scope.GoTypeName(&expr.AttributeExpr{Type: dt})
+	return pkg + "." + name, pkg + ".Parse" + name
+}
+
 func streamFlag(svcn, en string) *cli.FlagData {
 	return cli.NewFlagData(svcn, en, "stream", "string", "path to file containing the streamed request body", true, "goa.png", nil)
 }
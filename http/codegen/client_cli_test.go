@@ -33,6 +33,7 @@ func TestClientCLIFiles(t *testing.T) {
 		{"string-build", testdata.PayloadQueryStringDSL, testdata.QueryStringBuildCode, 1, 1},
 		{"string-required-build", testdata.PayloadQueryStringValidateDSL, testdata.QueryStringRequiredBuildCode, 1, 1},
 		{"string-default-build", testdata.PayloadQueryStringDefaultDSL, testdata.QueryStringDefaultBuildCode, 1, 1},
+		{"typed-enum-build", testdata.PayloadQueryTypedEnumDSL, testdata.QueryTypedEnumBuildCode, 1, 1},
 		{"body-query-path-object-build", testdata.PayloadBodyQueryPathObjectDSL, testdata.BodyQueryPathObjectBuildCode, 1, 1},
 		{"param-validation-build", testdata.ParamValidateDSL, testdata.ParamValidateBuildCode, 1, 1},
 		{"payload-primitive-type", testdata.PayloadBodyPrimitiveBoolValidateDSL, testdata.PayloadPrimitiveTypeParseCode, 0, 3},
@@ -50,6 +51,7 @@ func TestClientCLIFiles(t *testing.T) {
 		{"query-custom-name", testdata.PayloadQueryCustomNameDSL, testdata.PayloadQueryCustomNameBuildCode, 1, 1},
 		{"header-custom-name", testdata.PayloadHeaderCustomNameDSL, testdata.PayloadHeaderCustomNameBuildCode, 1, 1},
 		{"cookie-custom-name", testdata.PayloadCookieCustomNameDSL, testdata.PayloadCookieCustomNameBuildCode, 1, 1},
+		{"scenario-usage-examples", testdata.ScenarioDSL, testdata.ScenarioUsageExamplesCode, 0, 2},
 	}
 
 	for _, c := range cases {
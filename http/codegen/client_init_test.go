@@ -20,6 +20,7 @@ func TestClientInit(t *testing.T) {
 		SectionNum int
 	}{
 		{"multiple endpoints", testdata.ServerMultiEndpointsDSL, testdata.MultipleEndpointsClientInitCode, 2, 2},
+		{"compression", testdata.ServerCompressionDSL, testdata.CompressionClientInitCode, 2, 2},
 		{"streaming", testdata.StreamingResultDSL, testdata.StreamingClientInitCode, 3, 2},
 	}
 	for _, c := range cases {
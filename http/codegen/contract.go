@@ -0,0 +1,181 @@
+package codegen
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/service"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// ContractTestData contains the data needed to render the contract
+	// tests of a service.
+	ContractTestData struct {
+		// Service is the HTTP service data.
+		Service *ServiceData
+		// MockPkg is the name of the service mock package.
+		MockPkg string
+		// ClientPkg is the name of the HTTP client package.
+		ClientPkg string
+		// ServerPkg is the name of the HTTP server package.
+		ServerPkg string
+		// ServerInterceptors is true if the service defines server
+		// interceptors.
+		ServerInterceptors bool
+		// Endpoints lists the data needed to test each endpoint.
+		Endpoints []*ContractEndpointData
+	}
+
+	// ContractEndpointData contains the data needed to render the contract
+	// test of an endpoint.
+	ContractEndpointData struct {
+		*EndpointData
+		// Mock is the data needed to call the method mock.
+		Mock *service.MockMethodData
+		// Skip is the reason why the endpoint is not tested if any.
+		Skip string
+		// StatusCodes lists the status codes of the success responses.
+		StatusCodes []string
+		// Headers lists the canonical names of the headers that the
+		// success response must define.
+		Headers []string
+		// Errors lists the errors returned by the mock together with
+		// the status code of the corresponding responses.
+		Errors []*ContractErrorData
+	}
+
+	// ContractErrorData contains the data needed to test the encoding of
+	// an error.
+	ContractErrorData struct {
+		// Name is the error name as defined in the design.
+		Name string
+		// StatusCode is the status code of the error response.
+		StatusCode string
+	}
+)
+
+// ContractTestFiles returns the files that test the HTTP server and client of
+// the services that enable contract tests with the "test:contract" meta. The
+// tests serve the service mocks with the generated server, call each endpoint
+// with the generated client using the design examples and check that the
+// payloads, results and errors make the round trip.
+func ContractTestFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
+	var files []*codegen.File
+	for _, svc := range root.API.HTTP.Services {
+		if len(svc.HTTPEndpoints) == 0 || !service.HasContractTests(root, svc.ServiceExpr) {
+			continue
+		}
+		files = append(files, contractTestFile(genpkg, svc))
+	}
+	return files
+}
+
+// contractTestFile returns the file that implements the contract tests of the
+// given service.
+func contractTestFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
+	data := HTTPServices.Get(svc.Name())
+	td := buildContractTestData(data, svc.ServiceExpr)
+	specs := []*codegen.ImportSpec{
+		{Path: "context"},
+		{Path: "encoding/json"},
+		{Path: "errors"},
+		{Path: "net/http"},
+		{Path: "net/http/httptest"},
+		{Path: "net/url"},
+		{Path: "reflect"},
+		{Path: "testing"},
+		{Path: "time"},
+		codegen.GoaImport(""),
+		codegen.GoaNamedImport("http", "goahttp"),
+		{Path: path.Join(genpkg, data.Service.PathName), Name: data.Service.PkgName},
+		{Path: path.Join(genpkg, data.Service.PathName, "mock"), Name: td.MockPkg},
+		{Path: path.Join(genpkg, "http", data.Service.PathName, "client"), Name: td.ClientPkg},
+		{Path: path.Join(genpkg, "http", data.Service.PathName, "server"), Name: td.ServerPkg},
+	}
+	specs = append(specs, data.Service.UserTypeImports...)
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(svc.Name()+" HTTP contract tests", "server_test", specs),
+		{
+			Name:    "contract-test",
+			Source:  readTemplate("contract_test"),
+			Data:    td,
+			FuncMap: map[string]any{"hasWebSocket": hasWebSocket, "hasPtr": contractHasPtr},
+		},
+	}
+	return &codegen.File{
+		Path:             filepath.Join(codegen.Gendir, "http", data.Service.PathName, "server", "contract_test.go"),
+		SectionTemplates: sections,
+	}
+}
+
+// buildContractTestData builds the data needed to render the contract tests of
+// the given service.
+func buildContractTestData(data *ServiceData, svc *expr.ServiceExpr) *ContractTestData {
+	var (
+		mocks = make(map[string]*service.MockMethodData)
+		scope = codegen.NewNameScope()
+	)
+	for _, m := range service.MockMethods(svc) {
+		mocks[m.Name] = m
+	}
+	scope.Unique(data.Service.PkgName)
+	td := &ContractTestData{
+		Service:            data,
+		MockPkg:            scope.Unique(data.Service.PkgName + "mock"),
+		ClientPkg:          scope.Unique(data.Service.PkgName + "c"),
+		ServerPkg:          scope.Unique(data.Service.PkgName + "svr"),
+		ServerInterceptors: len(data.Service.ServerInterceptors) > 0,
+	}
+	for _, e := range data.Endpoints {
+		ed := &ContractEndpointData{EndpointData: e, Mock: mocks[e.Method.Name]}
+		switch {
+		case e.Method.ServerStream != nil || e.Method.ClientStream != nil:
+			ed.Skip = "streaming endpoints are not tested"
+		case e.MultipartRequestEncoder != nil:
+			ed.Skip = "multipart requests are not tested"
+		case e.Method.SkipRequestBodyEncodeDecode || e.Method.SkipResponseBodyEncodeDecode:
+			ed.Skip = "endpoints that skip the body encoding are not tested"
+		case ed.Mock.ExampleError != "":
+			ed.Skip = "the design examples do not validate: " + ed.Mock.ExampleError
+		case e.Method.Payload != "" && ed.Mock.PayloadValue == "":
+			ed.Skip = "the payload cannot be initialized from the design example"
+		}
+		if e.Result != nil {
+			for _, r := range e.Result.Responses {
+				ed.StatusCodes = append(ed.StatusCodes, r.StatusCode)
+			}
+			if len(e.Result.Responses) == 1 {
+				for _, h := range e.Result.Responses[0].Headers {
+					if h.Required {
+						ed.Headers = append(ed.Headers, h.CanonicalName)
+					}
+				}
+			}
+		}
+		for _, me := range ed.Mock.Errors {
+			for _, gerr := range e.Errors {
+				for _, er := range gerr.Errors {
+					if er.Name == me.Name {
+						ed.Errors = append(ed.Errors, &ContractErrorData{Name: me.Name, StatusCode: gerr.StatusCode})
+					}
+				}
+			}
+		}
+		td.Endpoints = append(td.Endpoints, ed)
+	}
+	return td
+}
+
+// contractHasPtr returns true if the payload of a tested endpoint is
+// initialized with the ptr helper function.
+func contractHasPtr(endpoints []*ContractEndpointData) bool {
+	for _, e := range endpoints {
+		if e.Skip == "" && strings.Contains(e.Mock.PayloadValue, "ptr(") {
+			return true
+		}
+	}
+	return false
+}
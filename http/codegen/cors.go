@@ -0,0 +1,119 @@
+package codegen
+
+import (
+	"fmt"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// CORSData contains the data needed to render the CORS handlers of a
+	// service.
+	CORSData struct {
+		// VarName is the name of the server struct field that holds the
+		// preflight handler.
+		VarName string
+		// OriginHandler is the name of the function that applies the
+		// CORS response headers.
+		OriginHandler string
+		// MountHandler is the name of the function that mounts the
+		// preflight handler.
+		MountHandler string
+		// HandlerInit is the name of the constructor of the preflight
+		// handler.
+		HandlerInit string
+		// Paths lists the paths the preflight handler is mounted on.
+		Paths []string
+		// Origins lists the CORS policies in order of precedence.
+		Origins []*CORSOriginData
+	}
+
+	// CORSOriginData describes a CORS policy.
+	CORSOriginData struct {
+		// Origin is the allowed origin string or regular expression.
+		Origin string
+		// Regexp is true if Origin is a regular expression.
+		Regexp bool
+		// Methods lists the methods allowed in preflight requests.
+		Methods []string
+		// Headers lists the headers allowed in preflight requests.
+		Headers []string
+		// Exposed lists the response headers exposed to the client.
+		Exposed []string
+		// MaxAge is the preflight response cache duration in seconds.
+		MaxAge uint
+		// Credentials is true if credentials are allowed.
+		Credentials bool
+	}
+)
+
+// initCORSData initializes the CORS data of the service and of its endpoints
+// and file servers if the service defines CORS policies.
+func initCORSData(rd *ServiceData, hs *expr.HTTPServiceExpr) {
+	origins := hs.Origins()
+	if len(origins) == 0 {
+		return
+	}
+	data := &CORSData{
+		VarName:       "CORS",
+		OriginHandler: fmt.Sprintf("Handle%sOrigin", codegen.Goify(hs.Name(), true)),
+		MountHandler:  "MountCORSHandler",
+		HandlerInit:   "NewCORSHandler",
+	}
+	for _, o := range origins {
+		data.Origins = append(data.Origins, &CORSOriginData{
+			Origin:      o.Origin,
+			Regexp:      o.Regexp,
+			Methods:     o.Methods,
+			Headers:     o.Headers,
+			Exposed:     o.Exposed,
+			MaxAge:      o.MaxAge,
+			Credentials: o.Credentials,
+		})
+	}
+
+	// Mount the preflight handler on all the paths except the ones that
+	// already define an OPTIONS endpoint.
+	var (
+		seen    = make(map[string]bool)
+		options = make(map[string]bool)
+		paths   []string
+	)
+	for _, e := range rd.Endpoints {
+		for _, r := range e.Routes {
+			if r.Verb == "OPTIONS" {
+				options[r.Path] = true
+			}
+		}
+	}
+	add := func(p string) {
+		if seen[p] || options[p] {
+			return
+		}
+		seen[p] = true
+		paths = append(paths, p)
+	}
+	for _, e := range rd.Endpoints {
+		e.OriginHandler = data.OriginHandler
+		for _, r := range e.Routes {
+			add(r.Path)
+		}
+	}
+	for _, s := range rd.FileServers {
+		s.OriginHandler = data.OriginHandler
+		for _, p := range s.RequestPaths {
+			if !s.IsDir {
+				add(p)
+				continue
+			}
+			if p != "/" {
+				p += "/"
+			}
+			add(p)
+			add(p + "{*" + s.PathParam + "}")
+		}
+	}
+	data.Paths = paths
+	rd.CORS = data
+}
@@ -0,0 +1,51 @@
+package codegen
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/codegentest"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestServerCORS(t *testing.T) {
+	const genpkg = "gen"
+	cases := []struct {
+		Name        string
+		DSL         func()
+		Code        string
+		SectionName string
+	}{
+		{"service struct", testdata.CORSServiceDSL, testdata.CORSServiceServerStructCode, "server-struct"},
+		{"service constructor", testdata.CORSServiceDSL, testdata.CORSServiceServerInitCode, "server-init"},
+		{"service use", testdata.CORSServiceDSL, testdata.CORSServiceServerUseCode, "server-use"},
+		{"service mount", testdata.CORSServiceDSL, testdata.CORSServiceServerMountCode, "server-mount"},
+		{"service handler mounter", testdata.CORSServiceDSL, testdata.CORSServiceServerHandlerCode, "server-handler"},
+		{"service files mounter", testdata.CORSServiceDSL, testdata.CORSServiceServerFilesCode, "server-files"},
+		{"service cors", testdata.CORSServiceDSL, testdata.CORSServiceServerCORSCode, "server-cors"},
+		{"api constructor", testdata.CORSAPIDSL, testdata.CORSAPIServerInitCode, "server-init"},
+		{"api cors", testdata.CORSAPIDSL, testdata.CORSAPIServerCORSCode, "server-cors"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunHTTPDSL(t, c.DSL)
+			fs := ServerFiles(genpkg, expr.Root)
+			sections := codegentest.Sections(fs, filepath.Join("", "server.go"), c.SectionName)
+			require.Greater(t, len(sections), 0)
+			code := codegen.SectionCode(t, sections[0])
+			assert.Equal(t, c.Code, code)
+		})
+	}
+}
+
+func TestServerNoCORS(t *testing.T) {
+	RunHTTPDSL(t, testdata.ServerSimpleRoutingDSL)
+	fs := ServerFiles("gen", expr.Root)
+	sections := codegentest.Sections(fs, filepath.Join("", "server.go"), "server-cors")
+	assert.Empty(t, sections)
+}
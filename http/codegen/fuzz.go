@@ -0,0 +1,140 @@
+package codegen
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/service"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// FuzzTestData contains the data needed to render the fuzz tests of a
+	// service.
+	FuzzTestData struct {
+		// Service is the HTTP service data.
+		Service *ServiceData
+		// ClientPkg is the name of the HTTP client package.
+		ClientPkg string
+		// Endpoints lists the data needed to fuzz the request decoder of
+		// each endpoint.
+		Endpoints []*FuzzEndpointData
+	}
+
+	// FuzzEndpointData contains the data needed to render the fuzz test of
+	// an endpoint request decoder.
+	FuzzEndpointData struct {
+		*EndpointData
+		// PayloadValue is the code that initializes the payload with the
+		// design example if any. It is empty if the example does not
+		// validate so that the target is not seeded with a request the
+		// decoder rejects.
+		PayloadValue string
+	}
+)
+
+// FuzzTestFiles returns the files that implement the fuzz tests of the HTTP
+// request decoders of the services that enable fuzz tests with the "test:fuzz"
+// meta. The fuzz targets are seeded with the requests built from the design
+// examples by the generated client.
+func FuzzTestFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
+	var files []*codegen.File
+	for _, svc := range root.API.HTTP.Services {
+		if !service.HasFuzzTests(root, svc.ServiceExpr) {
+			continue
+		}
+		if f := fuzzTestFile(genpkg, svc); f != nil {
+			files = append(files, f)
+		}
+	}
+	return files
+}
+
+// fuzzTestFile returns the file that implements the fuzz tests of the given
+// service, nil if the service has no request decoder to fuzz.
+func fuzzTestFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
+	data := HTTPServices.Get(svc.Name())
+	td := buildFuzzTestData(data, svc.ServiceExpr)
+	if len(td.Endpoints) == 0 {
+		return nil
+	}
+	specs := []*codegen.ImportSpec{
+		{Path: "bufio"},
+		{Path: "bytes"},
+		{Path: "context"},
+		{Path: "errors"},
+		{Path: "net/http"},
+		{Path: "net/http/httptest"},
+		{Path: "testing"},
+		{Path: "time"},
+		codegen.GoaImport(""),
+		codegen.GoaNamedImport("http", "goahttp"),
+		{Path: path.Join(genpkg, data.Service.PathName), Name: data.Service.PkgName},
+		{Path: path.Join(genpkg, "http", data.Service.PathName, "client"), Name: td.ClientPkg},
+	}
+	specs = append(specs, data.Service.UserTypeImports...)
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(svc.Name()+" HTTP request decoder fuzz tests", "server", specs),
+	}
+	for _, e := range td.Endpoints {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:    "fuzz-test",
+			Source:  readTemplate("fuzz_test"),
+			Data:    map[string]any{"ClientPkg": td.ClientPkg, "Service": data, "Endpoint": e},
+			FuncMap: map[string]any{"hasWebSocket": hasWebSocket},
+		})
+	}
+	sections = append(sections, &codegen.SectionTemplate{
+		Name:    "fuzz-test-helpers",
+		Source:  readTemplate("fuzz_test_helpers"),
+		Data:    td,
+		FuncMap: map[string]any{"hasPtr": fuzzHasPtr},
+	})
+	return &codegen.File{
+		Path:             filepath.Join(codegen.Gendir, "http", data.Service.PathName, "server", "fuzz_test.go"),
+		SectionTemplates: sections,
+	}
+}
+
+// buildFuzzTestData builds the data needed to render the fuzz tests of the
+// given service. Only the endpoints whose request decoders decode payloads
+// into the payload type are fuzzed: streaming, multipart and endpoints that
+// skip the request body encoding are omitted.
+func buildFuzzTestData(data *ServiceData, svc *expr.ServiceExpr) *FuzzTestData {
+	var (
+		values = make(map[string]string)
+		scope  = codegen.NewNameScope()
+	)
+	for _, m := range service.MockMethods(svc) {
+		values[m.Name] = m.PayloadValue
+	}
+	scope.Unique(data.Service.PkgName)
+	td := &FuzzTestData{
+		Service:   data,
+		ClientPkg: scope.Unique(data.Service.PkgName + "c"),
+	}
+	for _, e := range data.Endpoints {
+		switch {
+		case e.Payload.Ref == "",
+			e.Method.ServerStream != nil || e.Method.ClientStream != nil,
+			e.MultipartRequestDecoder != nil,
+			e.Method.SkipRequestBodyEncodeDecode:
+			continue
+		}
+		td.Endpoints = append(td.Endpoints, &FuzzEndpointData{EndpointData: e, PayloadValue: values[e.Method.Name]})
+	}
+	return td
+}
+
+// fuzzHasPtr returns true if the payload of a fuzzed endpoint is initialized
+// with the ptr helper function.
+func fuzzHasPtr(endpoints []*FuzzEndpointData) bool {
+	for _, e := range endpoints {
+		if strings.Contains(e.PayloadValue, "ptr(") {
+			return true
+		}
+	}
+	return false
+}
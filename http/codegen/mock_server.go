@@ -0,0 +1,65 @@
+package codegen
+
+import (
+	"path"
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+// MockServerFile returns the file that serves the service mocks over HTTP in
+// the mock server generated by service.MockServerFiles, nil if the API does
+// not define HTTP services.
+func MockServerFile(genpkg string, root *expr.RootExpr) *codegen.File {
+	var (
+		svcdata []*ServiceData
+
+		scope = codegen.NewNameScope()
+		specs = []*codegen.ImportSpec{
+			{Path: "context"},
+			{Path: "log"},
+			{Path: "mime/multipart"},
+			{Path: "net/http"},
+			{Path: "sync"},
+			{Path: "time"},
+			codegen.GoaImport(""),
+			codegen.GoaNamedImport("http", "goahttp"),
+			{Path: "github.com/gorilla/websocket"},
+		}
+	)
+	for _, svc := range root.Services {
+		if root.API.HTTP.Service(svc.Name) == nil {
+			continue
+		}
+		sd := HTTPServices.Get(svc.Name)
+		svcdata = append(svcdata, sd)
+		specs = append(specs,
+			&codegen.ImportSpec{
+				Path: path.Join(genpkg, "http", sd.Service.PathName, "server"),
+				Name: scope.Unique(sd.Service.PkgName + "svr"),
+			},
+			&codegen.ImportSpec{
+				Path: path.Join(genpkg, sd.Service.PathName),
+				Name: sd.Service.PkgName,
+			},
+		)
+		specs = append(specs, sd.Service.UserTypeImports...)
+	}
+	if len(svcdata) == 0 {
+		return nil
+	}
+	sections := []*codegen.SectionTemplate{
+		codegen.Header("Mock HTTP server", "main", specs),
+		{
+			Name:    "mock-server-http",
+			Source:  readTemplate("mock_server"),
+			Data:    map[string]any{"Services": svcdata},
+			FuncMap: map[string]any{"needStream": needStream, "hasWebSocket": hasWebSocket},
+		},
+	}
+	return &codegen.File{
+		Path:             filepath.Join(codegen.Gendir, "mockserver", "http.go"),
+		SectionTemplates: sections,
+	}
+}
@@ -0,0 +1,42 @@
+package codegen
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"goa.design/goa/v3/codegen"
+	ctestdata "goa.design/goa/v3/codegen/example/testdata"
+	"goa.design/goa/v3/codegen/service"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestMockServerFile(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+	}{
+		{"multiple-services", ctestdata.ServerHostingMultipleServicesDSL},
+		{"streaming", testdata.StreamingResultDSL},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			// reset global variable
+			HTTPServices = make(ServicesData)
+			service.Services = make(service.ServicesData)
+			codegen.RunDSL(t, c.DSL)
+			f := MockServerFile("", expr.Root)
+			require.NotNil(t, f)
+			var buf bytes.Buffer
+			for _, s := range f.SectionTemplates[1:] {
+				require.NoError(t, s.Write(&buf))
+			}
+			code := codegen.FormatTestCode(t, "package foo\n"+buf.String())
+			golden := filepath.Join("testdata", "mock-server-"+c.Name+".golden")
+			compareOrUpdateGolden(t, code, golden)
+		})
+	}
+}
@@ -55,3 +55,104 @@ func extensionsFromExprWithPrefix(mdata expr.MetaExpr, prefix string) map[string
 	}
 	return extensions
 }
+
+// AddCORSExtension adds the "x-cors" extension describing the CORS policies
+// that apply to the given service to exts and returns the result. exts is
+// returned unchanged if no CORS policy applies to the service.
+func AddCORSExtension(exts map[string]any, svc *expr.HTTPServiceExpr) map[string]any {
+	if svc == nil {
+		return exts
+	}
+	origins := svc.Origins()
+	if len(origins) == 0 {
+		return exts
+	}
+	policies := make([]map[string]any, len(origins))
+	for i, o := range origins {
+		p := map[string]any{"origin": o.Origin}
+		if o.Regexp {
+			p["regexp"] = true
+		}
+		if len(o.Methods) > 0 {
+			p["methods"] = o.Methods
+		}
+		if len(o.Headers) > 0 {
+			p["headers"] = o.Headers
+		}
+		if len(o.Exposed) > 0 {
+			p["expose"] = o.Exposed
+		}
+		if o.MaxAge > 0 {
+			p["max_age"] = o.MaxAge
+		}
+		if o.Credentials {
+			p["credentials"] = true
+		}
+		policies[i] = p
+	}
+	if exts == nil {
+		exts = make(map[string]any)
+	}
+	exts["x-cors"] = policies
+	return exts
+}
+
+// AddRateLimitExtensions adds the "x-rate-limit" and "x-max-concurrency"
+// extensions describing the limits enforced by the endpoint of the given
+// method to exts and returns the result. exts is returned unchanged if the
+// method is not limited.
+func AddRateLimitExtensions(exts map[string]any, m *expr.MethodExpr) map[string]any {
+	if m.RateLimit == nil && m.MaxConcurrency == 0 {
+		return exts
+	}
+	if exts == nil {
+		exts = make(map[string]any)
+	}
+	if r := m.RateLimit; r != nil {
+		l := map[string]any{
+			"limit":  r.Limit,
+			"period": r.Period.String(),
+			"burst":  r.BurstSize(),
+		}
+		if r.Key != "" {
+			l["key"] = r.Key
+		}
+		exts["x-rate-limit"] = l
+	}
+	if m.MaxConcurrency > 0 {
+		exts["x-max-concurrency"] = m.MaxConcurrency
+	}
+	return exts
+}
+
+// AddValidationRuleExtensions adds the "x-exactly-one-of", "x-after" and
+// "x-required-if" extensions describing the given cross-field validation rules
+// to exts and returns the result. exactlyOneOf lists the ExactlyOneOf groups
+// that cannot be described with a "oneOf" schema. exts is returned unchanged
+// if there is no rule to describe.
+func AddValidationRuleExtensions(exts map[string]any, exactlyOneOf [][]string, v *expr.ValidationExpr) map[string]any {
+	if len(exactlyOneOf) == 0 && len(v.After) == 0 && len(v.RequiredIf) == 0 {
+		return exts
+	}
+	if exts == nil {
+		exts = make(map[string]any)
+	}
+	if len(exactlyOneOf) > 0 {
+		exts["x-exactly-one-of"] = exactlyOneOf
+	}
+	if len(v.After) > 0 {
+		rules := make([]map[string]any, len(v.After))
+		for i, r := range v.After {
+			rules[i] = map[string]any{"field": r.Attribute, "after": r.Other}
+		}
+		exts["x-after"] = rules
+	}
+	if len(v.RequiredIf) > 0 {
+		rules := make([]map[string]any, len(v.RequiredIf))
+		for i, r := range v.RequiredIf {
+			rules[i] = map[string]any{"field": r.Attribute, "value": r.Value, "required": r.Required}
+		}
+		exts["x-required-if"] = rules
+	}
+	return exts
+}
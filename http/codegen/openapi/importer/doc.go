@@ -0,0 +1,14 @@
+/*
+Package importer generates Goa design packages from OpenAPI 3 documents.
+
+The generated design describes the API, its servers, security schemes and user
+types as well as one service per operation tag. Operations without tags are
+grouped in a service named after the API. Each operation is mapped to a method
+whose payload holds the operation parameters, security credentials and request
+body and whose result and errors are derived from the operation responses.
+
+OpenAPI constructs that cannot be expressed with the Goa DSL, for example
+nullable schemas, response headers or OpenID Connect security schemes, are
+reported as warnings and are either ignored or approximated.
+*/
+package importer
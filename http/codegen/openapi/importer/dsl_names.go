@@ -0,0 +1,292 @@
+package importer
+
+// dslNames lists the identifiers exported by the dsl package. The generated
+// design dot imports the dsl package so these names cannot be used by the
+// generated variables.
+var dslNames = map[string]bool{
+	"API":                                 true,
+	"APIKey":                              true,
+	"APIKeyField":                         true,
+	"APIKeySecurity":                      true,
+	"AccessToken":                         true,
+	"AccessTokenField":                    true,
+	"After":                               true,
+	"AllowCredentials":                    true,
+	"AllowHeaders":                        true,
+	"AllowMethods":                        true,
+	"Any":                                 true,
+	"ArrayOf":                             true,
+	"Attribute":                           true,
+	"Attributes":                          true,
+	"AuthorizationCodeFlow":               true,
+	"BasicAuthSecurity":                   true,
+	"BigInt":                              true,
+	"Body":                                true,
+	"Boolean":                             true,
+	"Burst":                               true,
+	"Bytes":                               true,
+	"CONNECT":                             true,
+	"CanonicalMethod":                     true,
+	"ClientCredentialsFlow":               true,
+	"ClientInterceptor":                   true,
+	"Code":                                true,
+	"CodeAborted":                         true,
+	"CodeAlreadyExists":                   true,
+	"CodeCanceled":                        true,
+	"CodeDataLoss":                        true,
+	"CodeDeadlineExceeded":                true,
+	"CodeFailedPrecondition":              true,
+	"CodeInternal":                        true,
+	"CodeInvalidArgument":                 true,
+	"CodeNotFound":                        true,
+	"CodeOK":                              true,
+	"CodeOutOfRange":                      true,
+	"CodePermissionDenied":                true,
+	"CodeResourceExhausted":               true,
+	"CodeUnauthenticated":                 true,
+	"CodeUnavailable":                     true,
+	"CodeUnimplemented":                   true,
+	"CodeUnknown":                         true,
+	"CollectionOf":                        true,
+	"Consumes":                            true,
+	"Contact":                             true,
+	"ContentType":                         true,
+	"ConvertTo":                           true,
+	"Cookie":                              true,
+	"CookieDomain":                        true,
+	"CookieHTTPOnly":                      true,
+	"CookieMaxAge":                        true,
+	"CookiePath":                          true,
+	"CookieSameSite":                      true,
+	"CookieSameSiteDefault":               true,
+	"CookieSameSiteLax":                   true,
+	"CookieSameSiteNone":                  true,
+	"CookieSameSiteStrict":                true,
+	"CookieSecure":                        true,
+	"CreateFrom":                          true,
+	"Cursor":                              true,
+	"CustomFormat":                        true,
+	"DELETE":                              true,
+	"Decimal":                             true,
+	"Default":                             true,
+	"Deprecated":                          true,
+	"Description":                         true,
+	"Discriminator":                       true,
+	"Docs":                                true,
+	"Elem":                                true,
+	"Email":                               true,
+	"Empty":                               true,
+	"Enum":                                true,
+	"Error":                               true,
+	"ErrorName":                           true,
+	"ErrorResult":                         true,
+	"ErrorResultIdentifier":               true,
+	"ExactlyOneOf":                        true,
+	"Example":                             true,
+	"ExclusiveMaximum":                    true,
+	"ExclusiveMinimum":                    true,
+	"ExposeHeaders":                       true,
+	"Extend":                              true,
+	"Fault":                               true,
+	"Field":                               true,
+	"Files":                               true,
+	"Float32":                             true,
+	"Float64":                             true,
+	"Format":                              true,
+	"FormatBase64":                        true,
+	"FormatCIDR":                          true,
+	"FormatCurrency":                      true,
+	"FormatDate":                          true,
+	"FormatDateTime":                      true,
+	"FormatDuration":                      true,
+	"FormatE164":                          true,
+	"FormatEmail":                         true,
+	"FormatHostname":                      true,
+	"FormatIP":                            true,
+	"FormatIPv4":                          true,
+	"FormatIPv6":                          true,
+	"FormatJSON":                          true,
+	"FormatMAC":                           true,
+	"FormatRFC1123":                       true,
+	"FormatRegexp":                        true,
+	"FormatSemver":                        true,
+	"FormatULID":                          true,
+	"FormatURI":                           true,
+	"FormatUUID":                          true,
+	"GET":                                 true,
+	"GRPC":                                true,
+	"HEAD":                                true,
+	"HTTP":                                true,
+	"Header":                              true,
+	"Headers":                             true,
+	"Host":                                true,
+	"ImplicitFlow":                        true,
+	"Int":                                 true,
+	"Int32":                               true,
+	"Int64":                               true,
+	"Interceptor":                         true,
+	"InvalidEnumValue":                    true,
+	"InvalidFieldType":                    true,
+	"InvalidFormat":                       true,
+	"InvalidLength":                       true,
+	"InvalidPattern":                      true,
+	"InvalidRange":                        true,
+	"JSONRPC":                             true,
+	"JWTSecurity":                         true,
+	"Key":                                 true,
+	"License":                             true,
+	"MapOf":                               true,
+	"MapParams":                           true,
+	"MaxAge":                              true,
+	"MaxConcurrency":                      true,
+	"MaxLength":                           true,
+	"Maximum":                             true,
+	"Message":                             true,
+	"Meta":                                true,
+	"Metadata":                            true,
+	"Method":                              true,
+	"MinLength":                           true,
+	"Minimum":                             true,
+	"MissingField":                        true,
+	"MultipartRequest":                    true,
+	"Name":                                true,
+	"NextCursor":                          true,
+	"NoSecurity":                          true,
+	"Nullable":                            true,
+	"OAuth2Security":                      true,
+	"OPTIONS":                             true,
+	"OneOf":                               true,
+	"Origin":                              true,
+	"PATCH":                               true,
+	"POST":                                true,
+	"PUT":                                 true,
+	"Package":                             true,
+	"PageItems":                           true,
+	"Paginated":                           true,
+	"Param":                               true,
+	"Params":                              true,
+	"Parent":                              true,
+	"Password":                            true,
+	"PasswordField":                       true,
+	"PasswordFlow":                        true,
+	"Path":                                true,
+	"Pattern":                             true,
+	"Payload":                             true,
+	"Produces":                            true,
+	"Randomizer":                          true,
+	"RateLimit":                           true,
+	"RateLimitKey":                        true,
+	"ReadPayload":                         true,
+	"ReadResult":                          true,
+	"Redirect":                            true,
+	"Reference":                           true,
+	"Required":                            true,
+	"RequiredIf":                          true,
+	"Response":                            true,
+	"Result":                              true,
+	"ResultType":                          true,
+	"SSEEventData":                        true,
+	"SSEEventID":                          true,
+	"SSEEventRetry":                       true,
+	"SSEEventType":                        true,
+	"SSERequestID":                        true,
+	"Scenario":                            true,
+	"Scope":                               true,
+	"Security":                            true,
+	"Server":                              true,
+	"ServerInterceptor":                   true,
+	"ServerSentEvents":                    true,
+	"Service":                             true,
+	"Services":                            true,
+	"SkipRequestBodyEncodeDecode":         true,
+	"SkipResponseBodyEncodeDecode":        true,
+	"StatusAccepted":                      true,
+	"StatusAlreadyReported":               true,
+	"StatusBadGateway":                    true,
+	"StatusBadRequest":                    true,
+	"StatusConflict":                      true,
+	"StatusContinue":                      true,
+	"StatusCreated":                       true,
+	"StatusExpectationFailed":             true,
+	"StatusFailedDependency":              true,
+	"StatusForbidden":                     true,
+	"StatusFound":                         true,
+	"StatusGatewayTimeout":                true,
+	"StatusGone":                          true,
+	"StatusHTTPVersionNotSupported":       true,
+	"StatusIMUsed":                        true,
+	"StatusInsufficientStorage":           true,
+	"StatusInternalServerError":           true,
+	"StatusLengthRequired":                true,
+	"StatusLocked":                        true,
+	"StatusLoopDetected":                  true,
+	"StatusMethodNotAllowed":              true,
+	"StatusMovedPermanently":              true,
+	"StatusMultiStatus":                   true,
+	"StatusMultipleChoices":               true,
+	"StatusNetworkAuthenticationRequired": true,
+	"StatusNoContent":                     true,
+	"StatusNonAuthoritativeInfo":          true,
+	"StatusNotAcceptable":                 true,
+	"StatusNotExtended":                   true,
+	"StatusNotFound":                      true,
+	"StatusNotImplemented":                true,
+	"StatusNotModified":                   true,
+	"StatusOK":                            true,
+	"StatusPartialContent":                true,
+	"StatusPaymentRequired":               true,
+	"StatusPermanentRedirect":             true,
+	"StatusPreconditionFailed":            true,
+	"StatusPreconditionRequired":          true,
+	"StatusProcessing":                    true,
+	"StatusProxyAuthRequired":             true,
+	"StatusRequestEntityTooLarge":         true,
+	"StatusRequestHeaderFieldsTooLarge":   true,
+	"StatusRequestTimeout":                true,
+	"StatusRequestURITooLong":             true,
+	"StatusRequestedRangeNotSatisfiable":  true,
+	"StatusResetContent":                  true,
+	"StatusSeeOther":                      true,
+	"StatusServiceUnavailable":            true,
+	"StatusSwitchingProtocols":            true,
+	"StatusTeapot":                        true,
+	"StatusTemporaryRedirect":             true,
+	"StatusTooManyRequests":               true,
+	"StatusUnauthorized":                  true,
+	"StatusUnavailableForLegalReasons":    true,
+	"StatusUnprocessableEntity":           true,
+	"StatusUnsupportedMediaType":          true,
+	"StatusUpgradeRequired":               true,
+	"StatusUseProxy":                      true,
+	"StatusVariantAlsoNegotiates":         true,
+	"StreamingPayload":                    true,
+	"StreamingResult":                     true,
+	"String":                              true,
+	"TRACE":                               true,
+	"Tag":                                 true,
+	"Temporary":                           true,
+	"TermsOfService":                      true,
+	"Timeout":                             true,
+	"Title":                               true,
+	"Token":                               true,
+	"TokenField":                          true,
+	"Trailers":                            true,
+	"Type":                                true,
+	"TypeName":                            true,
+	"TypeTemplate":                        true,
+	"UInt":                                true,
+	"UInt32":                              true,
+	"UInt64":                              true,
+	"URI":                                 true,
+	"URL":                                 true,
+	"Username":                            true,
+	"UsernameField":                       true,
+	"Val":                                 true,
+	"Validator":                           true,
+	"Value":                               true,
+	"Variable":                            true,
+	"Version":                             true,
+	"View":                                true,
+	"WritePayload":                        true,
+	"WriteResult":                         true,
+}
@@ -0,0 +1,283 @@
+package importer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"goa.design/goa/v3/codegen"
+)
+
+type (
+	// Design is the result of importing an OpenAPI document.
+	Design struct {
+		// Source is the formatted Go source code of the design package.
+		Source []byte
+		// Warnings lists the constructs of the OpenAPI document that could
+		// not be mapped to the Goa DSL and were ignored or approximated.
+		Warnings []string
+	}
+
+	// importer holds the state used to produce the design source code.
+	importer struct {
+		// doc is the OpenAPI document being imported.
+		doc *openapi3.T
+		// buf holds the generated source code.
+		buf *bytes.Buffer
+		// types maps the component schema names to the names of the
+		// variables holding the corresponding Goa user types.
+		types map[string]string
+		// deps maps the component schema names to the names of the
+		// component schemas they reference directly.
+		deps map[string][]string
+		// schemes maps the security scheme names to the names of the
+		// variables holding the corresponding Goa security schemes.
+		schemes map[string]string
+		// inline lists the types synthesized from inline object schemas
+		// that remain to be written.
+		inline []*inlineType
+		// names records the package level identifiers already in use.
+		names map[string]bool
+		// warnings lists the constructs that could not be mapped.
+		warnings []string
+	}
+)
+
+// Load reads and validates the OpenAPI 3 document at the given path. The path
+// may refer to a JSON or YAML document. External references are resolved
+// relative to the document location.
+func Load(path string) (*openapi3.T, error) {
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	doc, err := loader.LoadFromFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load OpenAPI document %q: %w", path, err)
+	}
+	if err := doc.Validate(context.Background()); err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI document %q: %w", path, err)
+	}
+	return doc, nil
+}
+
+// Import produces the source code of a Goa design package with the given name
+// that describes the API defined by doc. The design uses the API, Server,
+// Service, Method, Type, HTTP and security DSLs. Constructs that cannot be
+// expressed with the Goa DSL are listed in the returned design warnings.
+func Import(doc *openapi3.T, pkg string) (*Design, error) {
+	imp := &importer{
+		doc:     doc,
+		buf:     new(bytes.Buffer),
+		types:   make(map[string]string),
+		deps:    make(map[string][]string),
+		schemes: make(map[string]string),
+		names:   make(map[string]bool),
+	}
+	imp.printf("package %s\n\n", pkg)
+	imp.printf("import (\n\t. %q\n)\n\n", "goa.design/goa/v3/dsl")
+	imp.collectTypes()
+	imp.api()
+	imp.securitySchemes()
+	imp.services()
+	imp.userTypes()
+
+	src, err := format.Source(imp.buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated design: %w\n%s", err, imp.buf.String())
+	}
+	return &Design{Source: src, Warnings: imp.warnings}, nil
+}
+
+// api writes the API and Server expressions.
+func (imp *importer) api() {
+	info := imp.doc.Info
+	title := "api"
+	if info != nil && info.Title != "" {
+		title = info.Title
+	}
+	name := codegen.SnakeCase(strings.ToLower(title))
+	imp.printf("var _ = API(%q, func() {\n", name)
+	imp.printf("Title(%q)\n", title)
+	if info != nil {
+		if info.Description != "" {
+			imp.printf("Description(%q)\n", info.Description)
+		}
+		if info.Version != "" {
+			imp.printf("Version(%q)\n", info.Version)
+		}
+		if info.TermsOfService != "" {
+			imp.printf("TermsOfService(%q)\n", info.TermsOfService)
+		}
+		if c := info.Contact; c != nil {
+			imp.printf("Contact(func() {\n")
+			imp.printfIf(c.Name, "Name(%q)\n", c.Name)
+			imp.printfIf(c.Email, "Email(%q)\n", c.Email)
+			imp.printfIf(c.URL, "URL(%q)\n", c.URL)
+			imp.printf("})\n")
+		}
+		if l := info.License; l != nil {
+			imp.printf("License(func() {\n")
+			imp.printfIf(l.Name, "Name(%q)\n", l.Name)
+			imp.printfIf(l.URL, "URL(%q)\n", l.URL)
+			imp.printf("})\n")
+		}
+	}
+	if d := imp.doc.ExternalDocs; d != nil {
+		imp.printf("Docs(func() {\n")
+		imp.printfIf(d.Description, "Description(%q)\n", d.Description)
+		imp.printfIf(d.URL, "URL(%q)\n", d.URL)
+		imp.printf("})\n")
+	}
+	imp.servers(name)
+	imp.printf("})\n\n")
+}
+
+// servers writes the Server expression that lists the API hosts.
+func (imp *importer) servers(name string) {
+	var hosts []*openapi3.Server
+	for i, s := range imp.doc.Servers {
+		if !strings.HasPrefix(s.URL, "http://") && !strings.HasPrefix(s.URL, "https://") {
+			imp.warn(fmt.Sprintf("#/servers/%d", i), "server URL %q must be absolute, ignored", s.URL)
+			continue
+		}
+		hosts = append(hosts, s)
+	}
+	if len(hosts) == 0 {
+		return
+	}
+	imp.printf("Server(%q, func() {\n", name)
+	seen := make(map[string]bool)
+	for i, s := range hosts {
+		host := "default"
+		if s.Description != "" {
+			host = codegen.SnakeCase(strings.ToLower(s.Description))
+		}
+		if seen[host] {
+			host = fmt.Sprintf("host_%d", i+1)
+		}
+		seen[host] = true
+		imp.printf("Host(%q, func() {\n", host)
+		imp.printfIf(s.Description, "Description(%q)\n", s.Description)
+		imp.printf("URI(%q)\n", s.URL)
+		for _, vn := range sortedKeys(s.Variables) {
+			v := s.Variables[vn]
+			imp.printf("Variable(%q, String", vn)
+			if v.Description != "" {
+				imp.printf(", %q", v.Description)
+			}
+			imp.printf(", func() {\n")
+			imp.printf("Default(%q)\n", v.Default)
+			if len(v.Enum) > 0 {
+				imp.printf("Enum(%s)\n", quoteAll(v.Enum))
+			}
+			imp.printf("})\n")
+		}
+		imp.printf("})\n")
+	}
+	imp.printf("})\n")
+}
+
+// printf writes the formatted string to the generated source.
+func (imp *importer) printf(f string, args ...any) {
+	fmt.Fprintf(imp.buf, f, args...)
+}
+
+// printfIf writes the formatted string to the generated source if cond is
+// not empty.
+func (imp *importer) printfIf(cond string, f string, args ...any) {
+	if cond != "" {
+		imp.printf(f, args...)
+	}
+}
+
+// warn records a construct that could not be mapped to the Goa DSL.
+func (imp *importer) warn(loc, f string, args ...any) {
+	imp.warnings = append(imp.warnings, loc+": "+fmt.Sprintf(f, args...))
+}
+
+// identifier returns a unique package level identifier derived from name.
+func (imp *importer) identifier(name, suffix string) string {
+	id := codegen.Goify(name, true)
+	if id == "" {
+		id = "T"
+	}
+	if !strings.HasSuffix(id, suffix) {
+		id += suffix
+	}
+	base := id
+	if dslNames[id] {
+		base = id + "Type"
+		id = base
+	}
+	for i := 2; imp.names[id]; i++ {
+		id = base + strconv.Itoa(i)
+	}
+	imp.names[id] = true
+	return id
+}
+
+// literal returns the Go literal for the given JSON value.
+func literal(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return "nil"
+	case string:
+		return strconv.Quote(val)
+	case bool:
+		return strconv.FormatBool(val)
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case int:
+		return strconv.Itoa(val)
+	case int64:
+		return strconv.FormatInt(val, 10)
+	case []any:
+		elems := make([]string, len(val))
+		for i, e := range val {
+			elems[i] = literal(e)
+		}
+		return "[]any{" + strings.Join(elems, ", ") + "}"
+	case map[string]any:
+		keys := sortedKeys(val)
+		elems := make([]string, len(keys))
+		for i, k := range keys {
+			elems[i] = strconv.Quote(k) + ": " + literal(val[k])
+		}
+		return "map[string]any{" + strings.Join(elems, ", ") + "}"
+	default:
+		return fmt.Sprintf("%#v", val)
+	}
+}
+
+// literals returns the comma separated Go literals for the given values.
+func literals(vals []any) string {
+	elems := make([]string, len(vals))
+	for i, v := range vals {
+		elems[i] = literal(v)
+	}
+	return strings.Join(elems, ", ")
+}
+
+// quoteAll returns the comma separated quoted strings.
+func quoteAll(vals []string) string {
+	elems := make([]string, len(vals))
+	for i, v := range vals {
+		elems[i] = strconv.Quote(v)
+	}
+	return strings.Join(elems, ", ")
+}
+
+// sortedKeys returns the keys of m in lexicographical order.
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,67 @@
+package importer
+
+import (
+	"flag"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update .golden files")
+
+func TestImport(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Warnings []string
+	}{
+		{"petstore", []string{
+			"#/servers/1: server URL \"/relative\" must be absolute, ignored",
+			"#/components/securitySchemes/oidc: security scheme type \"openIdConnect\" is not supported, ignored",
+			"#/paths/~1pets/get/responses/200/headers: response headers are not supported, ignored",
+			"#/components/schemas/NewPet/properties/tag: nullable is not supported, ignored",
+			"#/components/schemas/Pet/allOf/1/properties/id: readOnly and writeOnly are not supported, ignored",
+			"#/components/schemas/Cat/properties/lives: multipleOf is not supported, ignored",
+		}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			doc, err := Load(filepath.Join("testdata", c.Name+".yaml"))
+			require.NoError(t, err)
+
+			design, err := Import(doc, "design")
+			require.NoError(t, err)
+
+			golden := filepath.Join("testdata", "golden", c.Name+".golden")
+			if *update {
+				require.NoError(t, os.WriteFile(golden, design.Source, 0644))
+			}
+			want, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			assert.Equal(t, string(want), string(design.Source))
+			assert.ElementsMatch(t, c.Warnings, design.Warnings)
+		})
+	}
+}
+
+func TestDSLNames(t *testing.T) {
+	pkgs, err := parser.ParseDir(token.NewFileSet(), filepath.Join("..", "..", "..", "..", "dsl"), func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	require.NoError(t, err)
+	pkg, ok := pkgs["dsl"]
+	require.True(t, ok)
+	for _, f := range pkg.Files {
+		for name, obj := range f.Scope.Objects {
+			if ast.IsExported(name) && obj.Kind != ast.Bad {
+				assert.True(t, dslNames[name], "missing dsl name %q", name)
+			}
+		}
+	}
+}
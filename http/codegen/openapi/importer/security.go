@@ -0,0 +1,179 @@
+package importer
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"goa.design/goa/v3/codegen"
+)
+
+// securitySchemes writes the security scheme expressions for the document
+// security schemes.
+func (imp *importer) securitySchemes() {
+	if imp.doc.Components == nil {
+		return
+	}
+	for _, name := range sortedKeys(imp.doc.Components.SecuritySchemes) {
+		ss := imp.doc.Components.SecuritySchemes[name].Value
+		loc := "#/components/securitySchemes/" + name
+		var dslFunc string
+		switch ss.Type {
+		case "http":
+			switch strings.ToLower(ss.Scheme) {
+			case "basic":
+				dslFunc = "BasicAuthSecurity"
+			case "bearer":
+				dslFunc = "JWTSecurity"
+			default:
+				imp.warn(loc, "HTTP authentication scheme %q is not supported, ignored", ss.Scheme)
+				continue
+			}
+		case "apiKey":
+			dslFunc = "APIKeySecurity"
+		case "oauth2":
+			dslFunc = "OAuth2Security"
+		default:
+			imp.warn(loc, "security scheme type %q is not supported, ignored", ss.Type)
+			continue
+		}
+		varName := imp.identifier(name, "Auth")
+		imp.schemes[name] = varName
+		body := imp.capture(func() {
+			imp.printfIf(ss.Description, "Description(%q)\n", ss.Description)
+			if ss.Flows != nil {
+				imp.flows(ss.Flows)
+			}
+		})
+		if body == "" {
+			imp.printf("var %s = %s(%q)\n\n", varName, dslFunc, name)
+			continue
+		}
+		imp.printf("var %s = %s(%q, func() {\n%s})\n\n", varName, dslFunc, name, body)
+	}
+}
+
+// flows writes the OAuth2 flows and scopes.
+func (imp *importer) flows(f *openapi3.OAuthFlows) {
+	scopes := make(map[string]string)
+	add := func(flow *openapi3.OAuthFlow) {
+		for s, d := range flow.Scopes {
+			scopes[s] = d
+		}
+	}
+	if fl := f.AuthorizationCode; fl != nil {
+		imp.printf("AuthorizationCodeFlow(%q, %q, %q)\n", fl.AuthorizationURL, fl.TokenURL, fl.RefreshURL)
+		add(fl)
+	}
+	if fl := f.Implicit; fl != nil {
+		imp.printf("ImplicitFlow(%q, %q)\n", fl.AuthorizationURL, fl.RefreshURL)
+		add(fl)
+	}
+	if fl := f.Password; fl != nil {
+		imp.printf("PasswordFlow(%q, %q)\n", fl.TokenURL, fl.RefreshURL)
+		add(fl)
+	}
+	if fl := f.ClientCredentials; fl != nil {
+		imp.printf("ClientCredentialsFlow(%q, %q)\n", fl.TokenURL, fl.RefreshURL)
+		add(fl)
+	}
+	for _, s := range sortedKeys(scopes) {
+		if scopes[s] == "" {
+			imp.printf("Scope(%q)\n", s)
+			continue
+		}
+		imp.printf("Scope(%q, %q)\n", s, scopes[s])
+	}
+}
+
+// securityRequirements writes the Security expressions for the given
+// requirements and returns the names of the schemes used by the requirements.
+func (imp *importer) securityRequirements(reqs openapi3.SecurityRequirements, explicit bool, loc string) []string {
+	if len(reqs) == 0 {
+		if explicit {
+			imp.printf("NoSecurity()\n")
+		}
+		return nil
+	}
+	var (
+		used []string
+		seen = make(map[string]bool)
+	)
+	for _, req := range reqs {
+		if len(req) == 0 {
+			imp.warn(loc, "optional security requirements are not supported, ignored")
+			continue
+		}
+		var (
+			vars   []string
+			scopes []string
+		)
+		for _, name := range sortedKeys(req) {
+			v, ok := imp.schemes[name]
+			if !ok {
+				imp.warn(loc, "security scheme %q is not supported, ignored", name)
+				continue
+			}
+			vars = append(vars, v)
+			scopes = append(scopes, req[name]...)
+			if !seen[name] {
+				seen[name] = true
+				used = append(used, name)
+			}
+		}
+		if len(vars) == 0 {
+			continue
+		}
+		imp.printf("Security(%s", strings.Join(vars, ", "))
+		if len(scopes) > 0 {
+			sort.Strings(scopes)
+			imp.printf(", func() {\n")
+			for _, s := range scopes {
+				imp.printf("Scope(%q)\n", s)
+			}
+			imp.printf("}")
+		}
+		imp.printf(")\n")
+	}
+	return used
+}
+
+// securityAttributes writes the payload attributes that hold the credentials
+// of the given schemes and returns the names of the attributes as well as the
+// HTTP DSL that maps the API keys to the request.
+func (imp *importer) securityAttributes(schemes []string) (attrs []string, mappings []string) {
+	token := "token"
+	for _, name := range schemes {
+		ss := imp.doc.Components.SecuritySchemes[name].Value
+		switch ss.Type {
+		case "http":
+			if strings.ToLower(ss.Scheme) == "basic" {
+				imp.printf("Username(\"username\", String)\n")
+				imp.printf("Password(\"password\", String)\n")
+				attrs = append(attrs, "username", "password")
+				continue
+			}
+			imp.printf("Token(%q, String)\n", token)
+			attrs = append(attrs, token)
+			token = codegen.SnakeCase(name) + "_token"
+		case "oauth2":
+			imp.printf("AccessToken(%q, String)\n", token)
+			attrs = append(attrs, token)
+			token = codegen.SnakeCase(name) + "_token"
+		case "apiKey":
+			attr := codegen.SnakeCase(ss.Name)
+			imp.printf("APIKey(%q, %q, String)\n", name, attr)
+			attrs = append(attrs, attr)
+			switch ss.In {
+			case "header":
+				mappings = append(mappings, mapping("Header", attr, ss.Name))
+			case "query":
+				mappings = append(mappings, mapping("Param", attr, ss.Name))
+			case "cookie":
+				mappings = append(mappings, mapping("Cookie", attr, ss.Name))
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,423 @@
+package importer
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"goa.design/goa/v3/codegen"
+)
+
+type (
+	// operation is an OpenAPI operation together with its path and verb.
+	operation struct {
+		// path is the operation path.
+		path string
+		// verb is the operation HTTP method.
+		verb string
+		// item is the path item that contains the operation.
+		item *openapi3.PathItem
+		// op is the operation.
+		op *openapi3.Operation
+	}
+
+	// param describes a payload attribute mapped to an HTTP parameter.
+	param struct {
+		// attr is the name of the payload attribute.
+		attr string
+		// p is the OpenAPI parameter.
+		p *openapi3.Parameter
+	}
+)
+
+// identRegexp matches the parameter names that can be used as attribute names
+// without mapping.
+var identRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// verbs lists the HTTP methods in the order the operations are rendered.
+var verbs = []string{
+	http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+	http.MethodDelete, http.MethodOptions, http.MethodTrace, http.MethodConnect,
+}
+
+// services writes a Service expression for each tag used by the document
+// operations. Operations without tags are grouped in a service named after
+// the API.
+func (imp *importer) services() {
+	var (
+		names  []string
+		groups = make(map[string][]*operation)
+	)
+	if imp.doc.Paths != nil {
+		paths := imp.doc.Paths.Map()
+		for _, path := range sortedKeys(paths) {
+			item := paths[path]
+			for _, verb := range verbs {
+				op := item.GetOperation(verb)
+				if op == nil {
+					continue
+				}
+				svc := imp.defaultServiceName()
+				if len(op.Tags) > 0 {
+					svc = op.Tags[0]
+				}
+				if _, ok := groups[svc]; !ok {
+					names = append(names, svc)
+				}
+				groups[svc] = append(groups[svc], &operation{path: path, verb: verb, item: item, op: op})
+			}
+		}
+	}
+	for _, name := range names {
+		imp.service(name, groups[name])
+	}
+}
+
+// defaultServiceName returns the name of the service used for operations
+// without tags.
+func (imp *importer) defaultServiceName() string {
+	if imp.doc.Info != nil && imp.doc.Info.Title != "" {
+		return imp.doc.Info.Title
+	}
+	return "api"
+}
+
+// service writes the Service expression for the given operations.
+func (imp *importer) service(name string, ops []*operation) {
+	imp.printf("var _ = Service(%q, func() {\n", codegen.SnakeCase(name))
+	if tag := imp.doc.Tags.Get(name); tag != nil && tag.Description != "" {
+		imp.printf("Description(%q)\n", tag.Description)
+	}
+	methods := make(map[string]bool)
+	for _, o := range ops {
+		imp.method(o, methods)
+	}
+	imp.printf("})\n\n")
+}
+
+// method writes the Method expression for the given operation.
+func (imp *importer) method(o *operation, methods map[string]bool) {
+	var (
+		op   = o.op
+		loc  = fmt.Sprintf("#/paths/%s/%s", strings.ReplaceAll(o.path, "/", "~1"), strings.ToLower(o.verb))
+		name = op.OperationID
+	)
+	if name == "" {
+		name = strings.ToLower(o.verb) + " " + o.path
+	}
+	name = methodName(name)
+	for i := 2; methods[name]; i++ {
+		name = methodName(fmt.Sprintf("%s %d", name, i))
+	}
+	methods[name] = true
+
+	imp.printf("Method(%q, func() {\n", name)
+	switch {
+	case op.Description != "":
+		imp.printf("Description(%q)\n", op.Description)
+	case op.Summary != "":
+		imp.printf("Description(%q)\n", op.Summary)
+	}
+	reqs, explicit := imp.doc.Security, false
+	if op.Security != nil {
+		reqs, explicit = *op.Security, true
+	}
+	schemes := imp.securityRequirements(reqs, explicit, loc+"/security")
+	mappings, path := imp.payload(o, name, schemes, loc)
+	imp.result(op, name, loc)
+	errors := imp.errors(op, name, loc)
+
+	imp.printf("HTTP(func() {\n")
+	imp.printf("%s(%q)\n", o.verb, path)
+	if op.Deprecated {
+		imp.printf("Deprecated()\n")
+	}
+	for _, m := range mappings {
+		imp.printf("%s\n", m)
+	}
+	imp.printf("%s\n", imp.successResponse(op))
+	for _, e := range errors {
+		imp.printf("Response(%q, %s)\n", e.name, statusCode(e.code))
+	}
+	imp.printf("})\n")
+	if len(op.Callbacks) > 0 {
+		imp.warn(loc+"/callbacks", "callbacks are not supported, ignored")
+	}
+	imp.printf("})\n")
+}
+
+// payload writes the Payload expression of the given operation and returns
+// the HTTP DSL mapping the payload attributes to the request as well as the
+// request path.
+func (imp *importer) payload(o *operation, method string, schemes []string, loc string) ([]string, string) {
+	var (
+		path   = o.path
+		params []*param
+		seen   = make(map[string]bool)
+	)
+	// Operation parameters override path item parameters.
+	overridden := make(map[string]bool)
+	for _, ref := range o.op.Parameters {
+		overridden[ref.Value.In+" "+ref.Value.Name] = true
+	}
+	var all openapi3.Parameters
+	for _, ref := range o.item.Parameters {
+		if !overridden[ref.Value.In+" "+ref.Value.Name] {
+			all = append(all, ref)
+		}
+	}
+	all = append(all, o.op.Parameters...)
+	for _, ref := range all {
+		p := ref.Value
+		attr := p.Name
+		if p.In == openapi3.ParameterInHeader || p.In == openapi3.ParameterInCookie || !identRegexp.MatchString(attr) {
+			attr = codegen.SnakeCase(attr)
+		}
+		if seen[attr] {
+			attr = codegen.SnakeCase(p.In + "_" + attr)
+		}
+		seen[attr] = true
+		if p.In == openapi3.ParameterInPath && attr != p.Name {
+			path = strings.ReplaceAll(path, "{"+p.Name+"}", "{"+attr+"}")
+		}
+		params = append(params, &param{attr: attr, p: p})
+	}
+
+	var (
+		body     *openapi3.SchemaRef
+		required bool
+	)
+	if rb := o.op.RequestBody; rb != nil && rb.Value != nil {
+		body = imp.mediaSchema(rb.Value.Content, loc+"/requestBody")
+		required = rb.Value.Required
+	}
+	if len(params) == 0 && len(schemes) == 0 {
+		if body != nil {
+			sc := scope{lazy: true, loc: loc + "/requestBody", name: method + " request body"}
+			if t := imp.typeExpr(body, sc); t != "" {
+				imp.printf("Payload(%s)\n", t)
+			} else {
+				imp.printf("Payload(func() {\n")
+				imp.objectBody(body.Value, sc)
+				imp.printf("})\n")
+			}
+		}
+		return nil, path
+	}
+
+	var (
+		mappings []string
+		reqs     []string
+	)
+	imp.printf("Payload(func() {\n")
+	attrs, secMappings := imp.securityAttributes(schemes)
+	reqs = append(reqs, attrs...)
+	mappings = append(mappings, secMappings...)
+	for _, p := range params {
+		ploc := fmt.Sprintf("%s/parameters/%s", loc, p.p.Name)
+		ref := p.p.Schema
+		if ref == nil {
+			imp.warn(ploc, "parameters without schema are not supported, mapped to String")
+			ref = openapi3.NewStringSchema().NewRef()
+		}
+		schema := *ref
+		if p.p.Description != "" && schema.Value != nil && schema.Value.Description == "" && schema.Ref == "" {
+			val := *schema.Value
+			val.Description = p.p.Description
+			schema.Value = &val
+		}
+		imp.attribute(p.attr, &schema, scope{lazy: true, loc: ploc, name: method + " " + p.attr})
+		if p.p.Required {
+			reqs = append(reqs, p.attr)
+		}
+		if p.p.Style != "" && p.p.Style != "form" && p.p.Style != "simple" {
+			imp.warn(ploc, "parameter style %q is not supported, ignored", p.p.Style)
+		}
+		switch p.p.In {
+		case openapi3.ParameterInQuery:
+			mappings = append(mappings, mapping("Param", p.attr, p.p.Name))
+		case openapi3.ParameterInHeader:
+			mappings = append(mappings, mapping("Header", p.attr, p.p.Name))
+		case openapi3.ParameterInCookie:
+			mappings = append(mappings, mapping("Cookie", p.attr, p.p.Name))
+		}
+	}
+	if body != nil {
+		imp.attribute("body", body, scope{lazy: true, loc: loc + "/requestBody", name: method + " request body"})
+		if required {
+			reqs = append(reqs, "body")
+		}
+		mappings = append(mappings, `Body("body")`)
+	}
+	if len(reqs) > 0 {
+		imp.printf("Required(%s)\n", quoteAll(reqs))
+	}
+	imp.printf("})\n")
+	return mappings, path
+}
+
+// result writes the Result expression of the given operation.
+func (imp *importer) result(op *openapi3.Operation, method string, loc string) {
+	code, resp := successResponse(op)
+	if resp == nil {
+		return
+	}
+	rloc := fmt.Sprintf("%s/responses/%d", loc, code)
+	for _, c := range sortedKeys(op.Responses.Map()) {
+		if c != strconv.Itoa(code) && strings.HasPrefix(c, "2") {
+			imp.warn(fmt.Sprintf("%s/responses/%s", loc, c), "only one success response is supported, ignored")
+		}
+	}
+	if len(resp.Headers) > 0 {
+		imp.warn(rloc+"/headers", "response headers are not supported, ignored")
+	}
+	schema := imp.mediaSchema(resp.Content, rloc)
+	if schema == nil {
+		return
+	}
+	sc := scope{lazy: true, loc: rloc, name: method + " result"}
+	if t := imp.typeExpr(schema, sc); t != "" {
+		imp.printf("Result(%s)\n", t)
+		return
+	}
+	imp.printf("Result(func() {\n")
+	imp.objectBody(schema.Value, sc)
+	imp.printf("})\n")
+}
+
+// successResponse returns the HTTP Response expression of the successful
+// response.
+func (imp *importer) successResponse(op *openapi3.Operation) string {
+	code, _ := successResponse(op)
+	if code == 0 {
+		code = http.StatusOK
+	}
+	return fmt.Sprintf("Response(%s)", statusCode(code))
+}
+
+// methodError describes an error response of an operation.
+type methodError struct {
+	// name is the name of the error.
+	name string
+	// code is the response status code.
+	code int
+}
+
+// errors writes the Error expressions for the error responses of the given
+// operation and returns the corresponding errors.
+func (imp *importer) errors(op *openapi3.Operation, method string, loc string) []*methodError {
+	if op.Responses == nil {
+		return nil
+	}
+	var (
+		errs  []*methodError
+		resps = op.Responses.Map()
+	)
+	for _, c := range sortedKeys(resps) {
+		rloc := fmt.Sprintf("%s/responses/%s", loc, c)
+		code, err := strconv.Atoi(c)
+		if err != nil {
+			imp.warn(rloc, "response code %q is not supported, ignored", c)
+			continue
+		}
+		if code < 300 {
+			continue
+		}
+		resp := resps[c].Value
+		name := codegen.SnakeCase(strings.ToLower(http.StatusText(code)))
+		if name == "" {
+			name = "error_" + c
+		}
+		name = strings.ReplaceAll(name, "'", "")
+		imp.printf("Error(%q", name)
+		if schema := imp.mediaSchema(resp.Content, rloc); schema != nil {
+			sc := scope{lazy: true, loc: rloc, name: method + " " + name}
+			t := imp.typeExpr(schema, sc)
+			if t == "" {
+				t = imp.inlineTypeVar(schema.Value, sc)
+			}
+			imp.printf(", %s", t)
+			if resp.Description != nil && *resp.Description != "" {
+				imp.printf(", %q", *resp.Description)
+			}
+		} else if resp.Description != nil && *resp.Description != "" {
+			imp.printf(", ErrorResult, %q", *resp.Description)
+		}
+		imp.printf(")\n")
+		if len(resp.Headers) > 0 {
+			imp.warn(rloc+"/headers", "response headers are not supported, ignored")
+		}
+		errs = append(errs, &methodError{name: name, code: code})
+	}
+	return errs
+}
+
+// mediaSchema returns the schema of the JSON media type of the given content
+// or nil if the content is empty.
+func (imp *importer) mediaSchema(content openapi3.Content, loc string) *openapi3.SchemaRef {
+	if len(content) == 0 {
+		return nil
+	}
+	types := sortedKeys(content)
+	for _, t := range types {
+		if t == "application/json" || strings.HasSuffix(t, "+json") {
+			return content[t].Schema
+		}
+	}
+	imp.warn(loc+"/content", "media type %q is not supported, mapped as JSON", types[0])
+	return content[types[0]].Schema
+}
+
+// successResponse returns the status code and response of the successful
+// response with the lowest status code or 0 and nil if there is none.
+func successResponse(op *openapi3.Operation) (int, *openapi3.Response) {
+	if op.Responses == nil {
+		return 0, nil
+	}
+	var codes []int
+	resps := op.Responses.Map()
+	for c := range resps {
+		if code, err := strconv.Atoi(c); err == nil && code >= 200 && code < 300 {
+			codes = append(codes, code)
+		}
+	}
+	if len(codes) == 0 {
+		return 0, nil
+	}
+	sort.Ints(codes)
+	return codes[0], resps[strconv.Itoa(codes[0])].Value
+}
+
+// statusCode returns the DSL constant for the given status code or the code
+// itself if there is no such constant.
+func statusCode(code int) string {
+	name := "Status" + codegen.Goify(http.StatusText(code), true)
+	if dslNames[name] {
+		return name
+	}
+	return strconv.Itoa(code)
+}
+
+// mapping returns the HTTP DSL that maps the given attribute to the HTTP
+// element with the given name.
+func mapping(dslFunc, attr, name string) string {
+	if attr == name {
+		return fmt.Sprintf("%s(%q)", dslFunc, attr)
+	}
+	return fmt.Sprintf("%s(%q)", dslFunc, attr+":"+name)
+}
+
+// methodName returns the name of the method for the given operation ID.
+func methodName(id string) string {
+	return codegen.SnakeCase(strings.Map(func(r rune) rune {
+		if r == '/' || r == '{' || r == '}' || r == '.' {
+			return ' '
+		}
+		return r
+	}, id))
+}
@@ -0,0 +1,487 @@
+package importer
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// componentPrefix is the prefix of references to component schemas.
+const componentPrefix = "#/components/schemas/"
+
+type (
+	// scope describes where a schema is being rendered.
+	scope struct {
+		// component is the name of the component schema being rendered
+		// or the empty string if the schema is not part of a component.
+		component string
+		// lazy is true if the schema is rendered in a DSL function body
+		// which is executed after all the user types are defined.
+		lazy bool
+		// loc is the location of the schema in the OpenAPI document used
+		// in warnings.
+		loc string
+		// name is used to name the types synthesized from inline schemas.
+		name string
+	}
+
+	// inlineType is a type synthesized from an inline object schema that
+	// is used where the DSL requires a type, for example as array element.
+	inlineType struct {
+		// varName is the name of the variable holding the type.
+		varName string
+		// schema is the inline schema.
+		schema *openapi3.Schema
+		// scope is the scope the inline schema was found in.
+		scope scope
+	}
+)
+
+// formats maps the OpenAPI string formats to the Goa validation formats.
+var formats = map[string]string{
+	"date":      "FormatDate",
+	"date-time": "FormatDateTime",
+	"uuid":      "FormatUUID",
+	"email":     "FormatEmail",
+	"hostname":  "FormatHostname",
+	"ipv4":      "FormatIPv4",
+	"ipv6":      "FormatIPv6",
+	"uri":       "FormatURI",
+	"regex":     "FormatRegexp",
+	"e164":      "FormatE164",
+	"ulid":      "FormatULID",
+	"semver":    "FormatSemver",
+	"iso4217":   "FormatCurrency",
+}
+
+// child returns the scope of a schema nested in the schema of sc.
+func (sc scope) child(loc, name string) scope {
+	return scope{
+		component: sc.component,
+		lazy:      sc.lazy,
+		loc:       sc.loc + "/" + loc,
+		name:      sc.name + " " + name,
+	}
+}
+
+// collectTypes records the names of the variables holding the user types
+// corresponding to the component schemas and their dependencies.
+func (imp *importer) collectTypes() {
+	if imp.doc.Components == nil {
+		return
+	}
+	for _, name := range sortedKeys(imp.doc.Components.Schemas) {
+		imp.types[name] = imp.identifier(name, "")
+		imp.deps[name] = componentRefs(imp.doc.Components.Schemas[name].Value, make(map[*openapi3.Schema]bool))
+	}
+}
+
+// userTypes writes the Type expressions for the component schemas and the
+// inline object schemas that require a named type.
+func (imp *importer) userTypes() {
+	if imp.doc.Components != nil {
+		for _, name := range sortedKeys(imp.doc.Components.Schemas) {
+			ref := imp.doc.Components.Schemas[name]
+			sc := scope{component: name, loc: componentPrefix + name, name: name}
+			varName := imp.types[name]
+			if ref.Ref != "" {
+				imp.printf("var %s = Type(%q, %s)\n\n", varName, varName, imp.typeExpr(ref, sc))
+				continue
+			}
+			imp.userType(varName, ref.Value, sc)
+		}
+	}
+	for len(imp.inline) > 0 {
+		it := imp.inline[0]
+		imp.inline = imp.inline[1:]
+		imp.userType(it.varName, it.schema, it.scope)
+	}
+}
+
+// userType writes the Type expression for the given schema.
+func (imp *importer) userType(varName string, s *openapi3.Schema, sc scope) {
+	if base := imp.typeExpr(&openapi3.SchemaRef{Value: s}, sc); base != "" {
+		sc.lazy = true
+		body := imp.capture(func() {
+			imp.printfIf(s.Description, "Description(%q)\n", s.Description)
+			imp.validations(s, sc)
+		})
+		if body == "" {
+			imp.printf("var %s = Type(%q, %s)\n\n", varName, varName, base)
+			return
+		}
+		imp.printf("var %s = Type(%q, %s, func() {\n%s})\n\n", varName, varName, base, body)
+		return
+	}
+	sc.lazy = true
+	imp.printf("var %s = Type(%q, func() {\n", varName, varName)
+	imp.printfIf(s.Description, "Description(%q)\n", s.Description)
+	imp.objectBody(s, sc)
+	imp.printf("})\n\n")
+}
+
+// attribute writes the Attribute expression for the given property schema.
+func (imp *importer) attribute(name string, ref *openapi3.SchemaRef, sc scope) {
+	imp.attributeWith("Attribute", name, ref, sc)
+}
+
+// attributeWith writes the attribute expression using the given DSL function,
+// for example Attribute or Token.
+func (imp *importer) attributeWith(dslFunc, name string, ref *openapi3.SchemaRef, sc scope) {
+	sc.lazy = true
+	s := ref.Value
+	if t := imp.typeExpr(ref, sc); t != "" {
+		imp.printf("%s(%q, %s", dslFunc, name, t)
+		if imp.isTypeRef(ref) {
+			imp.printf(")\n")
+			return
+		}
+		imp.printfIf(s.Description, ", %q", s.Description)
+		if body := imp.capture(func() { imp.validations(s, sc) }); body != "" {
+			imp.printf(", func() {\n%s}", body)
+		}
+		imp.printf(")\n")
+		return
+	}
+	if len(s.OneOf) > 0 || len(s.AnyOf) > 0 {
+		// Unions must be defined in named types for the generated code to
+		// compile.
+		imp.printf("%s(%q, %s)\n", dslFunc, name, imp.inlineTypeVar(s, sc))
+		return
+	}
+	imp.printf("%s(%q, func() {\n", dslFunc, name)
+	imp.printfIf(s.Description, "Description(%q)\n", s.Description)
+	imp.objectBody(s, sc)
+	imp.printf("})\n")
+}
+
+// objectBody writes the attributes of the given object schema.
+func (imp *importer) objectBody(s *openapi3.Schema, sc scope) {
+	for i, ref := range s.AllOf {
+		loc := fmt.Sprintf("%s/allOf/%d", sc.loc, i)
+		if imp.isTypeRef(ref) {
+			name := componentName(ref.Ref)
+			if imp.reaches(name, sc.component) {
+				imp.warn(loc, "recursive allOf reference to %q is not supported, ignored", name)
+				continue
+			}
+			imp.printf("Extend(%s)\n", imp.types[name])
+			continue
+		}
+		asc := sc.child(fmt.Sprintf("allOf/%d", i), "")
+		asc.lazy = true
+		imp.objectBody(ref.Value, asc)
+	}
+	if len(s.OneOf) > 0 {
+		imp.union(s.OneOf, "oneOf", s.Discriminator, sc)
+	}
+	if len(s.AnyOf) > 0 {
+		imp.warn(sc.loc+"/anyOf", "anyOf is mapped to OneOf, only one of the schemas may be set")
+		imp.union(s.AnyOf, "anyOf", nil, sc)
+	}
+	if s.Discriminator != nil && len(s.OneOf) == 0 {
+		imp.warn(sc.loc+"/discriminator", "discriminator is only supported with oneOf, ignored")
+	}
+	if s.Not != nil {
+		imp.warn(sc.loc+"/not", "not is not supported, ignored")
+	}
+	if len(s.Properties) > 0 && s.AdditionalProperties.Schema != nil {
+		imp.warn(sc.loc+"/additionalProperties", "additionalProperties is not supported together with properties, ignored")
+	}
+	for _, name := range sortedKeys(s.Properties) {
+		imp.attribute(name, s.Properties[name], sc.child("properties/"+name, name))
+	}
+	if len(s.Required) > 0 {
+		imp.printf("Required(%s)\n", quoteAll(s.Required))
+	}
+	imp.unsupported(s, sc)
+}
+
+// union writes a OneOf expression for the given alternatives. The union uses
+// the given discriminator if not nil, the names of the union attributes are
+// then the discriminator values given by the mapping if any.
+func (imp *importer) union(refs openapi3.SchemaRefs, kind string, disc *openapi3.Discriminator, sc scope) {
+	imp.printf("OneOf(\"value\", func() {\n")
+	values := make(map[string]string)
+	if disc != nil {
+		imp.printf("Discriminator(%q)\n", disc.PropertyName)
+		for value, ref := range disc.Mapping {
+			values[ref] = value
+		}
+	}
+	for i, ref := range refs {
+		name := componentName(ref.Ref)
+		if v, ok := values[ref.Ref]; ok {
+			name = v
+		} else if name == "" {
+			name = fmt.Sprintf("option%d", i+1)
+		}
+		usc := sc.child(fmt.Sprintf("%s/%d", kind, i), name)
+		usc.lazy = true
+		t := imp.typeExpr(ref, usc)
+		if t == "" {
+			t = imp.inlineTypeVar(ref.Value, usc)
+		}
+		imp.printf("Attribute(%q, %s)\n", name, t)
+	}
+	imp.printf("})\n")
+}
+
+// typeExpr returns the Go expression for the type described by ref or the
+// empty string if the schema is an inline object which must be described with
+// a DSL function.
+func (imp *importer) typeExpr(ref *openapi3.SchemaRef, sc scope) string {
+	if name := componentName(ref.Ref); name != "" {
+		if _, ok := imp.types[name]; ok {
+			if sc.lazy && imp.reaches(name, sc.component) {
+				// Reference the type by name to avoid an initialization
+				// cycle, the type is looked up when the DSL executes.
+				return strconv.Quote(imp.types[name])
+			}
+			return imp.types[name]
+		}
+	}
+	s := ref.Value
+	if s == nil {
+		return "Any"
+	}
+	if len(s.AllOf) > 0 || len(s.OneOf) > 0 || len(s.AnyOf) > 0 || len(s.Properties) > 0 {
+		return ""
+	}
+	switch {
+	case s.Type.Is(openapi3.TypeString):
+		if s.Format == "binary" || s.Format == "byte" {
+			return "Bytes"
+		}
+		return "String"
+	case s.Type.Is(openapi3.TypeInteger):
+		switch s.Format {
+		case "int32":
+			return "Int32"
+		case "int64":
+			return "Int64"
+		case "bigint":
+			return "BigInt"
+		}
+		return "Int"
+	case s.Type.Is(openapi3.TypeNumber):
+		switch s.Format {
+		case "float":
+			return "Float32"
+		case "decimal":
+			return "Decimal"
+		}
+		return "Float64"
+	case s.Type.Is(openapi3.TypeBoolean):
+		return "Boolean"
+	case s.Type.Is(openapi3.TypeArray):
+		if s.Items == nil {
+			return "ArrayOf(Any)"
+		}
+		t, body := imp.elemExpr(s.Items, sc.child("items", "item"))
+		if body == "" {
+			return "ArrayOf(" + t + ")"
+		}
+		return "ArrayOf(" + t + ", func() {\n" + body + "})"
+	case s.Type.Is(openapi3.TypeObject), s.Type == nil:
+		if s.AdditionalProperties.Schema != nil {
+			t, body := imp.elemExpr(s.AdditionalProperties.Schema, sc.child("additionalProperties", "value"))
+			if body == "" {
+				return "MapOf(String, " + t + ")"
+			}
+			return "MapOf(String, " + t + ", func() {\nElem(func() {\n" + body + "})\n})"
+		}
+		if s.AdditionalProperties.Has != nil && *s.AdditionalProperties.Has {
+			return "MapOf(String, Any)"
+		}
+		if s.Type == nil {
+			return "Any"
+		}
+		return ""
+	}
+	imp.warn(sc.loc, "type %v is not supported, mapped to Any", s.Type.Slice())
+	return "Any"
+}
+
+// elemExpr returns the type expression for an array element or map value
+// schema and the DSL describing the element validations if any.
+func (imp *importer) elemExpr(ref *openapi3.SchemaRef, sc scope) (string, string) {
+	t := imp.typeExpr(ref, sc)
+	if t == "" {
+		return imp.inlineTypeVar(ref.Value, sc), ""
+	}
+	if imp.isTypeRef(ref) {
+		return t, ""
+	}
+	return t, imp.capture(func() { imp.validations(ref.Value, sc) })
+}
+
+// inlineTypeVar registers a named type for the given inline object schema and
+// returns the name of the variable holding it.
+func (imp *importer) inlineTypeVar(s *openapi3.Schema, sc scope) string {
+	varName := imp.identifier(sc.name, "")
+	imp.inline = append(imp.inline, &inlineType{varName: varName, schema: s, scope: sc})
+	return varName
+}
+
+// isTypeRef returns true if ref is a reference to a component schema mapped
+// to a user type.
+func (imp *importer) isTypeRef(ref *openapi3.SchemaRef) bool {
+	_, ok := imp.types[componentName(ref.Ref)]
+	return ok
+}
+
+// validations writes the validations, default value and example of the given
+// schema.
+func (imp *importer) validations(s *openapi3.Schema, sc scope) {
+	if len(s.Enum) > 0 {
+		imp.printf("Enum(%s)\n", literals(s.Enum))
+	}
+	if s.Format != "" {
+		if f, ok := formats[s.Format]; ok {
+			imp.printf("Format(%s)\n", f)
+		} else {
+			switch s.Format {
+			case "int32", "int64", "bigint", "float", "double", "decimal", "byte", "binary", "password":
+			default:
+				imp.warn(sc.loc, "format %q is not supported, ignored", s.Format)
+			}
+		}
+	}
+	imp.printfIf(s.Pattern, "Pattern(%q)\n", s.Pattern)
+	if s.MinLength > 0 {
+		imp.printf("MinLength(%d)\n", s.MinLength)
+	}
+	if s.MaxLength != nil {
+		imp.printf("MaxLength(%d)\n", *s.MaxLength)
+	}
+	if s.MinItems > 0 {
+		imp.printf("MinLength(%d)\n", s.MinItems)
+	}
+	if s.MaxItems != nil {
+		imp.printf("MaxLength(%d)\n", *s.MaxItems)
+	}
+	if s.Min != nil {
+		if s.ExclusiveMin {
+			imp.printf("ExclusiveMinimum(%s)\n", literal(*s.Min))
+		} else {
+			imp.printf("Minimum(%s)\n", literal(*s.Min))
+		}
+	}
+	if s.Max != nil {
+		if s.ExclusiveMax {
+			imp.printf("ExclusiveMaximum(%s)\n", literal(*s.Max))
+		} else {
+			imp.printf("Maximum(%s)\n", literal(*s.Max))
+		}
+	}
+	if s.Default != nil {
+		imp.printf("Default(%s)\n", literal(s.Default))
+	}
+	if s.Example != nil {
+		imp.printf("Example(%s)\n", literal(s.Example))
+	}
+	imp.unsupported(s, sc)
+}
+
+// unsupported records the schema keywords that cannot be mapped.
+func (imp *importer) unsupported(s *openapi3.Schema, sc scope) {
+	if s.Nullable {
+		imp.warn(sc.loc, "nullable is not supported, ignored")
+	}
+	if s.ReadOnly || s.WriteOnly {
+		imp.warn(sc.loc, "readOnly and writeOnly are not supported, ignored")
+	}
+	if s.MultipleOf != nil {
+		imp.warn(sc.loc, "multipleOf is not supported, ignored")
+	}
+	if s.UniqueItems {
+		imp.warn(sc.loc, "uniqueItems is not supported, ignored")
+	}
+	if s.MinProps > 0 || s.MaxProps != nil {
+		imp.warn(sc.loc, "minProperties and maxProperties are not supported, ignored")
+	}
+}
+
+// capture returns the source code written by fn.
+func (imp *importer) capture(fn func()) string {
+	buf := imp.buf
+	imp.buf = new(bytes.Buffer)
+	defer func() { imp.buf = buf }()
+	fn()
+	return imp.buf.String()
+}
+
+// reaches returns true if the component schema from references the
+// component schema to directly or indirectly.
+func (imp *importer) reaches(from, to string) bool {
+	if to == "" {
+		return false
+	}
+	seen := make(map[string]bool)
+	var visit func(string) bool
+	visit = func(n string) bool {
+		if n == to {
+			return true
+		}
+		if seen[n] {
+			return false
+		}
+		seen[n] = true
+		for _, d := range imp.deps[n] {
+			if visit(d) {
+				return true
+			}
+		}
+		return false
+	}
+	return visit(from)
+}
+
+// componentRefs returns the names of the component schemas referenced by s
+// without following the references.
+func componentRefs(s *openapi3.Schema, seen map[*openapi3.Schema]bool) []string {
+	if s == nil || seen[s] {
+		return nil
+	}
+	seen[s] = true
+	var (
+		names []string
+		refs  []*openapi3.SchemaRef
+	)
+	refs = append(refs, s.Items, s.AdditionalProperties.Schema, s.Not)
+	refs = append(refs, s.AllOf...)
+	refs = append(refs, s.OneOf...)
+	refs = append(refs, s.AnyOf...)
+	for _, n := range sortedKeys(s.Properties) {
+		refs = append(refs, s.Properties[n])
+	}
+	for _, ref := range refs {
+		if ref == nil {
+			continue
+		}
+		if name := componentName(ref.Ref); name != "" {
+			names = append(names, name)
+			continue
+		}
+		names = append(names, componentRefs(ref.Value, seen)...)
+	}
+	return names
+}
+
+// componentName returns the name of the component schema referenced by ref or
+// the empty string if ref is not a reference to a component schema.
+func componentName(ref string) string {
+	idx := strings.Index(ref, componentPrefix)
+	if idx < 0 {
+		return ""
+	}
+	name := ref[idx+len(componentPrefix):]
+	if strings.Contains(name, "/") {
+		return ""
+	}
+	return name
+}
@@ -24,6 +24,8 @@ type (
 		Description  string             `json:"description,omitempty" yaml:"description,omitempty"`
 		DefaultValue any                `json:"default,omitempty" yaml:"default,omitempty"`
 		Example      any                `json:"example,omitempty" yaml:"example,omitempty"`
+		// Nullable is only supported by OpenAPI v3.
+		Nullable bool `json:"nullable,omitempty" yaml:"nullable,omitempty"`
 
 		// Hyper schema
 		Media     *Media  `json:"media,omitempty" yaml:"media,omitempty"`
@@ -48,12 +50,23 @@ type (
 		AdditionalProperties any      `json:"additionalProperties,omitempty" yaml:"additionalProperties,omitempty"`
 
 		// Union
-		AnyOf []*Schema `json:"anyOf,omitempty" yaml:"anyOf,omitempty"`
+		AnyOf         []*Schema      `json:"anyOf,omitempty" yaml:"anyOf,omitempty"`
+		OneOf         []*Schema      `json:"oneOf,omitempty" yaml:"oneOf,omitempty"`
+		Discriminator *Discriminator `json:"discriminator,omitempty" yaml:"discriminator,omitempty"`
 
 		// Extensions defines the OpenAPI extensions.
 		Extensions map[string]any `json:"-" yaml:"-"`
 	}
 
+	// Discriminator describes the property used to identify the schema of
+	// the value of a "oneOf" schema (OpenAPI v3 only).
+	Discriminator struct {
+		// PropertyName is the name of the discriminator property.
+		PropertyName string `json:"propertyName" yaml:"propertyName"`
+		// Mapping maps the discriminator values to schema references.
+		Mapping map[string]string `json:"mapping,omitempty" yaml:"mapping,omitempty"`
+	}
+
 	// Type is the JSON type enum.
 	Type string
 
@@ -338,6 +351,12 @@ func TypeSchemaWithPrefix(api *expr.APIExpr, t expr.DataType, prefix string) *Sc
 		case expr.BytesKind:
 			s.Type = Type("string")
 			s.Format = "byte"
+		case expr.DecimalKind:
+			s.Type = Type("number")
+			s.Format = "decimal"
+		case expr.BigIntKind:
+			s.Type = Type("integer")
+			s.Format = "bigint"
 		}
 	case *expr.Array:
 		s.Type = Array
@@ -446,6 +465,7 @@ func (s *Schema) Dup() *Schema {
 		Title:                s.Title,
 		Media:                s.Media,
 		ReadOnly:             s.ReadOnly,
+		Nullable:             s.Nullable,
 		PathStart:            s.PathStart,
 		Links:                s.Links,
 		Ref:                  s.Ref,
@@ -481,10 +501,21 @@ func buildAttributeSchema(api *expr.APIExpr, s *Schema, at *expr.AttributeExpr)
 		// Ref is exclusive with other fields
 		return s
 	}
+	if at.IsStringEncoded() {
+		s.Type = Type("string")
+	}
 	s.DefaultValue = ToStringMap(at.DefaultValue)
 	s.Description = at.Description
 	s.Example = at.Example(api.ExampleGenerator)
 	s.Extensions = ExtensionsFromExpr(at.Meta)
+	if at.IsNullable() {
+		// Swagger does not support null values, use the de facto standard
+		// extension instead.
+		if s.Extensions == nil {
+			s.Extensions = make(map[string]any)
+		}
+		s.Extensions["x-nullable"] = true
+	}
 	initAttributeValidation(s, at)
 
 	return s
@@ -535,6 +566,9 @@ func initAttributeValidation(s *Schema, at *expr.AttributeExpr) {
 		}
 		s.Required = append(s.Required, v)
 	}
+	// Swagger does not support "oneOf", describe all the rules with
+	// extensions.
+	s.Extensions = AddValidationRuleExtensions(s.Extensions, val.ExactlyOneOf, val)
 }
 
 // toSchemaHrefs produces hrefs that replace the path wildcards with JSON
@@ -348,6 +348,12 @@ func paramFor(at *expr.AttributeExpr, name, in string, required bool) *Parameter
 	case expr.Bytes:
 		p.Type = "string"
 		p.Format = "byte"
+	case expr.Decimal:
+		p.Type = "number"
+		p.Format = "decimal"
+	case expr.BigInt:
+		p.Type = "integer"
+		p.Format = "bigint"
 	}
 	p.Extensions = openapi.ExtensionsFromExpr(at.Meta)
 	initValidations(alias, p)
@@ -359,9 +365,9 @@ func itemsFromExpr(at *expr.AttributeExpr) *Items {
 	p, ok := at.Type.(expr.Primitive)
 	if ok {
 		switch p.Kind() {
-		case expr.IntKind, expr.Int64Kind, expr.UIntKind, expr.UInt64Kind, expr.Int32Kind, expr.UInt32Kind:
+		case expr.IntKind, expr.Int64Kind, expr.UIntKind, expr.UInt64Kind, expr.Int32Kind, expr.UInt32Kind, expr.BigIntKind:
 			items.Type = "integer"
-		case expr.Float32Kind, expr.Float64Kind:
+		case expr.Float32Kind, expr.Float64Kind, expr.DecimalKind:
 			items.Type = "number"
 		case expr.BytesKind:
 			items.Type = "string"
@@ -487,7 +493,7 @@ func buildPathFromFileServer(s *V2, root *expr.RootExpr, fs *expr.HTTPFileServer
 		}
 		p := path.(*Path)
 		p.Get = operation
-		p.Extensions = openapi.ExtensionsFromExpr(fs.Meta)
+		p.Extensions = openapi.AddCORSExtension(openapi.ExtensionsFromExpr(fs.Meta), fs.Service)
 	}
 }
 
@@ -509,7 +515,12 @@ func buildPathFromExpr(s *V2, root *expr.RootExpr, h *expr.HostExpr, route *expr
 
 		responses := make(map[string]*Response, len(endpoint.Responses))
 		for _, r := range endpoint.Responses {
-			if endpoint.MethodExpr.IsStreaming() {
+			if endpoint.SSE != nil {
+				// Server-sent events streams are described as a single
+				// "text/event-stream" response.
+				r = r.Dup()
+				r.ContentType = "text/event-stream"
+			} else if endpoint.MethodExpr.IsStreaming() {
 				// A streaming endpoint allows at most one successful response
 				// definition. So it is okay to change the first successful
 				// response to a HTTP 101 response for openapi docs.
@@ -580,7 +591,7 @@ func buildPathFromExpr(s *V2, root *expr.RootExpr, h *expr.HostExpr, route *expr
 		}
 
 		// replace http with ws for streaming endpoints
-		if endpoint.MethodExpr.IsStreaming() {
+		if endpoint.MethodExpr.IsStreaming() && endpoint.SSE == nil {
 			for i := len(schemes) - 1; i >= 0; i-- {
 				if schemes[i] == "http" {
 					news := append([]string{"ws"}, schemes[i+1:]...)
@@ -634,7 +645,7 @@ func buildPathFromExpr(s *V2, root *expr.RootExpr, h *expr.HostExpr, route *expr
 			Responses:    responses,
 			Schemes:      schemes,
 			Deprecated:   deprecated,
-			Extensions:   openapi.ExtensionsFromExpr(endpoint.MethodExpr.Meta),
+			Extensions:   openapi.AddRateLimitExtensions(openapi.ExtensionsFromExpr(endpoint.MethodExpr.Meta), endpoint.MethodExpr),
 			Security:     requirements,
 		}
 
@@ -673,7 +684,7 @@ func buildPathFromExpr(s *V2, root *expr.RootExpr, h *expr.HostExpr, route *expr
 		case "PATCH":
 			p.Patch = operation
 		}
-		p.Extensions = openapi.ExtensionsFromExpr(route.Endpoint.Meta)
+		p.Extensions = openapi.AddCORSExtension(openapi.ExtensionsFromExpr(route.Endpoint.Meta), route.Endpoint.Service)
 	}
 }
 
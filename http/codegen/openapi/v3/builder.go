@@ -165,6 +165,7 @@ func buildPaths(h *expr.HTTPExpr, bodies map[string]map[string]*EndpointBodies,
 							path.Extensions[k] = v
 						}
 					}
+					path.Extensions = openapi.AddCORSExtension(path.Extensions, svc)
 				}
 			}
 		}
@@ -183,6 +184,7 @@ func buildPaths(h *expr.HTTPExpr, bodies map[string]map[string]*EndpointBodies,
 					paths[key] = path
 				}
 				path.Get = operation
+				path.Extensions = openapi.AddCORSExtension(path.Extensions, svc)
 			}
 		}
 	}
@@ -285,7 +287,12 @@ func buildOperation(key string, r *expr.RouteExpr, bodies *EndpointBodies, rand
 	{
 		responses = make(map[string]*ResponseRef, len(e.Responses))
 		for _, r := range e.Responses {
-			if e.MethodExpr.IsStreaming() {
+			if e.SSE != nil {
+				// Server-sent events streams are described as a single
+				// "text/event-stream" response.
+				r = r.Dup()
+				r.ContentType = "text/event-stream"
+			} else if e.MethodExpr.IsStreaming() {
 				// A streaming endpoint allows at most one successful response
 				// definition. So it is okay to change the first successful
 				// response to a HTTP 101 response for openapi docs.
@@ -319,6 +326,8 @@ func buildOperation(key string, r *expr.RouteExpr, bodies *EndpointBodies, rand
 		}
 	}
 
+	initScenarioExamples(e, params, requestBody, responses, rand)
+
 	// tag names
 	var tagNames []string
 	{
@@ -352,7 +361,7 @@ func buildOperation(key string, r *expr.RouteExpr, bodies *EndpointBodies, rand
 		Security:     buildSecurityRequirements(e.Requirements),
 		Deprecated:   deprecated,
 		ExternalDocs: openapi.DocsFromExpr(m.Docs, m.Meta),
-		Extensions:   openapi.ExtensionsFromExpr(m.Meta),
+		Extensions:   openapi.AddRateLimitExtensions(openapi.ExtensionsFromExpr(m.Meta), m),
 	}
 }
 
@@ -1,6 +1,10 @@
 package openapiv3
 
-import "goa.design/goa/v3/expr"
+import (
+	"strconv"
+
+	"goa.design/goa/v3/expr"
+)
 
 type (
 	// exampler is the interface used to initialize the example of an
@@ -33,3 +37,125 @@ func initExamples(obj exampler, attr *expr.AttributeExpr, r *expr.ExampleGenerat
 		obj.setExample(attr.Example(r))
 	}
 }
+
+// initScenarioExamples adds the examples described by the scenarios of the
+// endpoint method to the given operation parameters, request body and
+// responses. Scenario examples replace the single example of the objects they
+// apply to as OpenAPI does not allow setting both. Error values usually only
+// describe some of the error fields, the missing required fields are set
+// using generated examples.
+func initScenarioExamples(e *expr.HTTPEndpointExpr, params []*ParameterRef, body *RequestBodyRef, responses map[string]*ResponseRef, rand *expr.ExampleGenerator) {
+	keys := map[string]map[string]string{
+		"path":   elemKeys(e.Params),
+		"query":  elemKeys(e.Params),
+		"header": elemKeys(e.Headers),
+		"cookie": elemKeys(e.Cookies),
+	}
+	for _, s := range e.MethodExpr.Scenarios {
+		if s.Payload != nil {
+			for _, p := range params {
+				key, ok := keys[p.Value.In][p.Value.Name]
+				if !ok {
+					continue
+				}
+				if v, ok := s.PayloadAttribute(key); ok {
+					addScenarioExample(&p.Value.Example, &p.Value.Examples, s, v)
+				}
+			}
+			if v := s.HTTPRequestBody(e); v != nil && body != nil {
+				for _, mt := range body.Value.Content {
+					addScenarioExample(&mt.Example, &mt.Examples, s, v)
+				}
+			}
+		}
+		r := s.HTTPResponse(e)
+		if r == nil || s.ResponseValue() == nil {
+			continue
+		}
+		resp, ok := responses[strconv.Itoa(r.StatusCode)]
+		if !ok {
+			continue
+		}
+		if v := s.HTTPResponseBody(r); v != nil {
+			if s.Error != "" {
+				var name string
+				if e.MethodExpr.Error(s.Error).Type == expr.ErrorResult {
+					name = s.Error
+				}
+				v = completeValue(v, r.Body, name, rand)
+			}
+			for _, mt := range resp.Value.Content {
+				addScenarioExample(&mt.Example, &mt.Examples, s, v)
+			}
+		}
+		hkeys := elemKeys(r.Headers)
+		for name, h := range resp.Value.Headers {
+			key, ok := hkeys[name]
+			if !ok {
+				continue
+			}
+			if v, ok := s.ResponseAttribute(key); ok {
+				addScenarioExample(&h.Value.Example, &h.Value.Examples, s, v)
+			}
+		}
+	}
+}
+
+// addScenarioExample adds the value of the given scenario to examples and
+// clears example.
+func addScenarioExample(example *any, examples *map[string]*ExampleRef, s *expr.ScenarioExpr, val any) {
+	if *examples == nil {
+		*examples = make(map[string]*ExampleRef)
+	}
+	*example = nil
+	(*examples)[s.Name] = &ExampleRef{Value: &Example{
+		Summary:     s.Name,
+		Description: s.Description,
+		Value:       val,
+	}}
+}
+
+// completeValue returns a copy of the object value val where the required
+// attributes of att that val does not set are initialized with generated
+// examples. The "name" attribute is initialized with errName instead if not
+// empty. It returns val if it is not an object value.
+func completeValue(val any, att *expr.AttributeExpr, errName string, rand *expr.ExampleGenerator) any {
+	m, ok := val.(map[string]any)
+	if !ok || expr.AsObject(att.Type) == nil {
+		return val
+	}
+	var ex map[string]any
+	res := make(map[string]any, len(m))
+	for k, v := range m {
+		res[k] = v
+	}
+	if _, ok := res["name"]; !ok && errName != "" {
+		res["name"] = errName
+	}
+	for _, n := range att.AllRequired() {
+		if _, ok := res[n]; ok {
+			continue
+		}
+		if ex == nil {
+			ex, _ = att.Example(rand).(map[string]any)
+		}
+		if v, ok := ex[n]; ok {
+			res[n] = v
+		}
+	}
+	return res
+}
+
+// elemKeys returns the attribute names of the given mapped attribute indexed
+// by transport element name.
+func elemKeys(ma *expr.MappedAttributeExpr) map[string]string {
+	keys := make(map[string]string)
+	if ma == nil || expr.AsObject(ma.Type) == nil {
+		return keys
+	}
+	expr.WalkMappedAttr(ma, func(name, elem string, _ *expr.AttributeExpr) error { // nolint: errcheck
+		keys[elem] = name
+		return nil
+	})
+	return keys
+}
@@ -41,6 +41,11 @@ func TestFiles(t *testing.T) {
 		{"server-host-with-variables", testdata.ServerHostWithVariablesDSL},
 		{"with-spaces", testdata.WithSpacesDSL},
 		{"with-map", testdata.WithMapDSL},
+		{"discriminated-union", testdata.DiscriminatedUnionDSL},
+		{"nullable", testdata.NullableDSL},
+		{"validation-rules", testdata.ValidationRulesDSL},
+		{"decimal", testdata.DecimalDSL},
+		{"scenario", testdata.ScenarioDSL},
 		{"path-with-wildcards", testdata.PathWithWildcardDSL},
 		{"path-with-multiple-wildcards", testdata.PathWithMultipleWildcardDSL},
 		{"path-with-multiple-explicit-wildcards", testdata.PathWithMultipleExplicitWildcardDSL},
@@ -166,6 +166,18 @@ func (sf *schemafier) schemafy(attr *expr.AttributeExpr, noref ...bool) *openapi
 		case expr.Float64Kind:
 			s.Type = openapi.Type("number")
 			s.Format = "double"
+		case expr.DecimalKind:
+			s.Type = openapi.Type("number")
+			s.Format = "decimal"
+			if attr.IsStringEncoded() {
+				s.Type = openapi.Type("string")
+			}
+		case expr.BigIntKind:
+			s.Type = openapi.Type("integer")
+			s.Format = "bigint"
+			if attr.IsStringEncoded() {
+				s.Type = openapi.Type("string")
+			}
 		case expr.BytesKind, expr.AnyKind:
 			if bases := attr.Bases; len(bases) > 0 {
 				for _, b := range bases {
@@ -206,6 +218,10 @@ func (sf *schemafier) schemafy(attr *expr.AttributeExpr, noref ...bool) *openapi
 			s.AdditionalProperties = true
 		}
 	case *expr.Union:
+		if t.Discriminator != "" {
+			sf.discriminatedUnion(s, t)
+			break
+		}
 		for _, val := range t.Values {
 			s.AnyOf = append(s.AnyOf, sf.schemafy(val.Attribute))
 		}
@@ -258,6 +274,7 @@ func (sf *schemafier) schemafy(attr *expr.AttributeExpr, noref ...bool) *openapi
 	s.DefaultValue = toStringMap(attr.DefaultValue)
 	s.Example = attr.Example(sf.rand)
 	s.Extensions = openapi.ExtensionsFromExpr(attr.Meta)
+	s.Nullable = attr.IsNullable()
 
 	// Validations
 	val := attr.Validation
@@ -303,10 +320,40 @@ func (sf *schemafier) schemafy(attr *expr.AttributeExpr, noref ...bool) *openapi
 		}
 		s.Required = append(s.Required, v)
 	}
+	groups := val.ExactlyOneOf
+	if len(groups) == 1 && len(s.OneOf) == 0 {
+		for _, n := range groups[0] {
+			s.OneOf = append(s.OneOf, &openapi.Schema{Required: []string{n}})
+		}
+		groups = nil
+	}
+	s.Extensions = openapi.AddValidationRuleExtensions(s.Extensions, groups, val)
 
 	return s
 }
 
+// discriminatedUnion initializes s with the "oneOf" schema describing the given
+// discriminated union. The discriminator mapping lists the union values whose
+// schemas are references.
+func (sf *schemafier) discriminatedUnion(s *openapi.Schema, u *expr.Union) {
+	names := make([]any, len(u.Values))
+	s.Discriminator = &openapi.Discriminator{PropertyName: u.Discriminator}
+	for i, val := range u.Values {
+		names[i] = val.Name
+		vs := sf.schemafy(val.Attribute)
+		s.OneOf = append(s.OneOf, vs)
+		if vs.Ref != "" {
+			if s.Discriminator.Mapping == nil {
+				s.Discriminator.Mapping = make(map[string]string)
+			}
+			s.Discriminator.Mapping[val.Name] = vs.Ref
+		}
+	}
+	s.Type = openapi.Object
+	s.Properties[u.Discriminator] = &openapi.Schema{Type: openapi.String, Enum: names}
+	s.Required = []string{u.Discriminator}
+}
+
 // uniquify returns n if n is not a known type name. Otherwise uniquify appends
 // the smallest integer greater than 1 to n so the result is not a known type
 // name.
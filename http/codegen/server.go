@@ -19,6 +19,9 @@ func ServerFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
 		if f := websocketServerFile(genpkg, svc); f != nil {
 			files = append(files, f)
 		}
+		if f := sseServerFile(genpkg, svc); f != nil {
+			files = append(files, f)
+		}
 	}
 	for _, svc := range root.API.HTTP.Services {
 		if f := serverEncodeDecodeFile(genpkg, svc); f != nil {
@@ -38,6 +41,7 @@ func serverFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
 		"join":                    strings.Join,
 		"hasWebSocket":            hasWebSocket,
 		"isWebSocketEndpoint":     isWebSocketEndpoint,
+		"isSSEEndpoint":           isSSEEndpoint,
 		"viewedServerBody":        viewedServerBody,
 		"mustDecodeRequest":       mustDecodeRequest,
 		"addLeadingSlash":         addLeadingSlash,
@@ -51,6 +55,7 @@ func serverFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
 		{Path: "mime/multipart"},
 		{Path: "net/http"},
 		{Path: "path"},
+		{Path: "regexp"},
 		{Path: "strings"},
 		{Path: "github.com/gorilla/websocket"},
 		codegen.GoaImport(""),
@@ -89,6 +94,9 @@ func serverFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
 	for _, s := range data.FileServers {
 		sections = append(sections, &codegen.SectionTemplate{Name: "server-files", Source: readTemplate("file_server"), FuncMap: funcs, Data: s})
 	}
+	if data.CORS != nil {
+		sections = append(sections, &codegen.SectionTemplate{Name: "server-cors", Source: readTemplate("server_cors"), Data: data})
+	}
 
 	return &codegen.File{Path: path, SectionTemplates: sections}
 }
@@ -120,7 +128,7 @@ func serverEncodeDecodeFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.F
 	sections := []*codegen.SectionTemplate{codegen.Header(title, "server", imports)}
 
 	for _, e := range data.Endpoints {
-		if e.Redirect == nil && !isWebSocketEndpoint(e) {
+		if e.Redirect == nil && !isWebSocketEndpoint(e) && !isSSEEndpoint(e) {
 			sections = append(sections, &codegen.SectionTemplate{
 				Name:    "response-encoder",
 				FuncMap: transTmplFuncs(svc),
@@ -186,6 +194,7 @@ func transTmplFuncs(s *expr.HTTPServiceExpr) map[string]any {
 		"conversionData":       conversionData,
 		"headerConversionData": headerConversionData,
 		"printValue":           printValue,
+		"timeFormat":           codegen.TimeFormatCode,
 		"viewedServerBody":     viewedServerBody,
 	}
 }
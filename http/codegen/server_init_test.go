@@ -21,6 +21,7 @@ func TestServerInit(t *testing.T) {
 		SectionNum int
 	}{
 		{"multiple endpoints", testdata.ServerMultiEndpointsDSL, testdata.ServerMultiEndpointsConstructorCode, 2, 3},
+		{"compression", testdata.ServerCompressionDSL, testdata.ServerCompressionConstructorCode, 2, 3},
 		{"multiple bases", testdata.ServerMultiBasesDSL, testdata.ServerMultiBasesConstructorCode, 2, 3},
 		{"file server", testdata.ServerFileServerDSL, testdata.ServerFileServerConstructorCode, 1, 3},
 		{"file server with a redirect", testdata.ServerFileServerWithRedirectDSL, testdata.ServerFileServerConstructorCode, 1, 3},
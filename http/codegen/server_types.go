@@ -223,6 +223,7 @@ func fieldCode(init *InitData, typ string) string {
 			FieldName:    arg.FieldName,
 			FieldPointer: arg.FieldPointer,
 			FieldType:    arg.FieldType,
+			FieldTime:    arg.FieldTime,
 		}
 	}
 	// We can ignore the transform helpers as there won't be any generated
@@ -32,6 +32,9 @@ func TestServerTypes(t *testing.T) {
 		{"server-query-custom-name", testdata.PayloadQueryCustomNameDSL, QueryCustomNameServerTypesFile},
 		{"server-header-custom-name", testdata.PayloadHeaderCustomNameDSL, HeaderCustomNameServerTypesFile},
 		{"server-cookie-custom-name", testdata.PayloadCookieCustomNameDSL, CookieCustomNameServerTypesFile},
+		{"server-time", testdata.PayloadTimeDSL, TimeServerTypesFile},
+		{"server-nullable", testdata.PayloadNullableDSL, NullableServerTypesFile},
+		{"server-discriminated-union", testdata.PayloadDiscriminatedUnionDSL, DiscriminatedUnionServerTypesFile},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -380,3 +383,135 @@ func NewMethodCookieCustomNamePayload(c2 *string) *servicecookiecustomname.Metho
 	return v
 }
 `
+
+const TimeServerTypesFile = `// MethodTimeRequestBody is the type of the "ServiceTime" service "MethodTime"
+// endpoint HTTP request body.
+type MethodTimeRequestBody struct {
+	Until *string ` + "`" + `form:"until,omitempty" json:"until,omitempty" xml:"until,omitempty"` + "`" + `
+}
+
+// NewMethodTimePayload builds a ServiceTime service MethodTime endpoint
+// payload.
+func NewMethodTimePayload(body *MethodTimeRequestBody, since string, timeout *string) *servicetime.MethodTimePayload {
+	v := &servicetime.MethodTimePayload{}
+	if body.Until != nil {
+		tmp, _ := time.Parse(time.DateOnly, *body.Until)
+		v.Until = &tmp
+	}
+	v.Since, _ = time.Parse(time.RFC3339, since)
+	if timeout != nil {
+		tmp, _ := time.ParseDuration(*timeout)
+		v.Timeout = &tmp
+	}
+
+	return v
+}
+
+// ValidateMethodTimeRequestBody runs the validations defined on
+// MethodTimeRequestBody
+func ValidateMethodTimeRequestBody(body *MethodTimeRequestBody) (err error) {
+	if body.Until != nil {
+		err = goa.MergeErrors(err, goa.ValidateFormat("body.until", *body.Until, goa.FormatDate))
+	}
+	return
+}
+`
+
+const NullableServerTypesFile = `// MethodNullableRequestBody is the type of the "ServiceNullable" service
+// "MethodNullable" endpoint HTTP request body.
+type MethodNullableRequestBody struct {
+	Nickname goa.Nullable[string] ` + "`" + `form:"nickname,omitzero" json:"nickname,omitzero" xml:"nickname,omitzero"` + "`" + `
+	Age      goa.Nullable[int]    ` + "`" + `form:"age,omitzero" json:"age,omitzero" xml:"age,omitzero"` + "`" + `
+}
+
+// NewMethodNullablePayload builds a ServiceNullable service MethodNullable
+// endpoint payload.
+func NewMethodNullablePayload(body *MethodNullableRequestBody, id int) *servicenullable.MethodNullablePayload {
+	v := &servicenullable.MethodNullablePayload{
+		Nickname: body.Nickname,
+		Age:      body.Age,
+	}
+	v.ID = id
+
+	return v
+}
+
+// ValidateMethodNullableRequestBody runs the validations defined on
+// MethodNullableRequestBody
+func ValidateMethodNullableRequestBody(body *MethodNullableRequestBody) (err error) {
+	if body.Nickname.IsValue() {
+		if utf8.RuneCountInString(body.Nickname.Value) < 2 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError("body.nickname", body.Nickname.Value, utf8.RuneCountInString(body.Nickname.Value), 2, true))
+		}
+	}
+	return
+}
+`
+
+const DiscriminatedUnionServerTypesFile = `// MethodDiscriminatedUnionRequestBody is the type of the
+// "ServiceDiscriminatedUnion" service "MethodDiscriminatedUnion" endpoint HTTP
+// request body.
+type MethodDiscriminatedUnionRequestBody struct {
+	Payment *struct {
+		// Union type name, one of:
+		// - "bank"
+		// - "card"
+		Kind   *string ` + "`" + `form:"kind" json:"kind" xml:"kind"` + "`" + `
+		Iban   *string ` + "`" + `form:"iban,omitempty" json:"iban,omitempty" xml:"iban,omitempty"` + "`" + `
+		Number *string ` + "`" + `form:"number,omitempty" json:"number,omitempty" xml:"number,omitempty"` + "`" + `
+	} ` + "`" + `form:"payment,omitempty" json:"payment,omitempty" xml:"payment,omitempty"` + "`" + `
+}
+
+// NewMethodDiscriminatedUnionPayload builds a ServiceDiscriminatedUnion
+// service MethodDiscriminatedUnion endpoint payload.
+func NewMethodDiscriminatedUnionPayload(body *MethodDiscriminatedUnionRequestBody) *servicediscriminatedunion.MethodDiscriminatedUnionPayload {
+	v := &servicediscriminatedunion.MethodDiscriminatedUnionPayload{}
+	switch *body.Payment.Kind {
+	case "bank":
+		val := &servicediscriminatedunion.Bank{}
+		if body.Payment.Iban != nil {
+			val.Iban = *body.Payment.Iban
+		}
+		v.Payment = val
+	case "card":
+		val := &servicediscriminatedunion.Card{}
+		if body.Payment.Number != nil {
+			val.Number = *body.Payment.Number
+		}
+		v.Payment = val
+	}
+
+	return v
+}
+
+// ValidateMethodDiscriminatedUnionRequestBody runs the validations defined on
+// MethodDiscriminatedUnionRequestBody
+func ValidateMethodDiscriminatedUnionRequestBody(body *MethodDiscriminatedUnionRequestBody) (err error) {
+	if body.Payment == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("payment", "body"))
+	}
+	if body.Payment != nil {
+		if body.Payment.Kind == nil {
+			err = goa.MergeErrors(err, goa.MissingFieldError("kind", "body.payment"))
+		}
+		if body.Payment.Kind != nil {
+			switch *body.Payment.Kind {
+			case "bank":
+				if body.Payment.Iban == nil {
+					err = goa.MergeErrors(err, goa.MissingFieldError("iban", "body.payment"))
+				}
+			case "card":
+				if body.Payment.Number == nil {
+					err = goa.MergeErrors(err, goa.MissingFieldError("number", "body.payment"))
+				}
+			}
+		}
+		if body.Payment.Kind != nil {
+			if !(*body.Payment.Kind == "bank" || *body.Payment.Kind == "card") {
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError("body.payment.kind", *body.Payment.Kind, []any{"bank", "card"}))
+			}
+		}
+	}
+	return
+}
+`
@@ -18,6 +18,11 @@ import (
 // transport code of the services.
 var HTTPServices = make(ServicesData)
 
+// JSONRPCServices holds the data computed from the design needed to generate
+// the JSON-RPC transport code of the services. JSON-RPC services are served
+// over HTTP and thus share the HTTP transport data structures.
+var JSONRPCServices = make(JSONRPCServicesData)
+
 var (
 	// pathInitTmpl is the template used to render path constructors code.
 	pathInitTmpl = template.Must(
@@ -36,6 +41,7 @@ var (
 					_, ok := dt.(expr.UserType)
 					return ok
 				},
+				"timeFormat": codegen.TimeFormatCode,
 			}).
 			Parse(readTemplate("request_init")),
 	)
@@ -45,6 +51,10 @@ type (
 	// ServicesData encapsulates the data computed from the design.
 	ServicesData map[string]*ServiceData
 
+	// JSONRPCServicesData encapsulates the data computed from the JSON-RPC
+	// design.
+	JSONRPCServicesData map[string]*ServiceData
+
 	// ServiceData contains the data used to render the code related to a
 	// single service.
 	ServiceData struct {
@@ -95,6 +105,13 @@ type (
 		ClientTransformHelpers []*codegen.TransformFunctionData
 		// Scope initialized with all the server and client types.
 		Scope *codegen.NameScope
+		// CORS contains the data needed to render the CORS handlers if
+		// the service defines CORS policies.
+		CORS *CORSData
+		// Compression is true if the generated server and client
+		// compress the HTTP bodies as enabled with the
+		// "http:compression" meta.
+		Compression bool
 	}
 
 	// EndpointData contains the data used to render the code related to a
@@ -152,8 +169,14 @@ type (
 		// ServerWebSocket holds the data to render the server struct which
 		// implements the server stream interface.
 		ServerWebSocket *WebSocketData
+		// ServerSSE holds the data to render the server struct which
+		// implements the server stream interface using Server-Sent Events.
+		ServerSSE *SSEData
 		// Redirect defines a redirect for the endpoint.
 		Redirect *RedirectData
+		// OriginHandler is the name of the function that applies the
+		// CORS response headers if the service defines CORS policies.
+		OriginHandler string
 
 		// client
 
@@ -174,9 +197,32 @@ type (
 		// ClientWebSocket holds the data to render the client struct which
 		// implements the client stream interface.
 		ClientWebSocket *WebSocketData
+		// ClientSSE holds the data to render the client struct which
+		// implements the client stream interface using Server-Sent Events.
+		ClientSSE *SSEData
 		// BuildStreamPayload is the name of the function used to create the
 		// payload for endpoints that use SkipRequestBodyEncodeDecode.
 		BuildStreamPayload string
+		// Pagination holds the data needed to render the Link header of
+		// paginated endpoints.
+		Pagination *PaginationData
+	}
+
+	// PaginationData contains the data needed to set the Link header of the
+	// responses of paginated endpoints.
+	PaginationData struct {
+		// CursorParam is the name of the query string parameter holding
+		// the page cursor.
+		CursorParam string
+		// ResultRef is the reference to the type of the value returned by
+		// the endpoint.
+		ResultRef string
+		// NextCursor is the expression used to access the next cursor
+		// field of the value returned by the endpoint.
+		NextCursor string
+		// NextCursorPointer is true if the next cursor field is a
+		// pointer.
+		NextCursorPointer bool
 	}
 
 	// FileServerData lists the data needed to generate file servers.
@@ -200,6 +246,9 @@ type (
 		// ArgName is the name of the argument used to initialize the
 		// file server.
 		ArgName string
+		// OriginHandler is the name of the function that applies the
+		// CORS response headers if the service defines CORS policies.
+		OriginHandler string
 	}
 
 	// RedirectData lists the data needed to generate a redirect.
@@ -438,6 +487,9 @@ type (
 		// FieldPointer if true indicates that the data structure field is a
 		// pointer.
 		FieldPointer bool
+		// FieldTime is the attribute of the data structure field if it is
+		// a Go time value encoded as a string, nil otherwise.
+		FieldTime *expr.AttributeExpr
 		// DefaultValue is the default value of the attribute if any.
 		DefaultValue any
 		// Validate contains the validation code for the attribute value if any.
@@ -577,6 +629,21 @@ func (d ServicesData) Get(name string) *ServiceData {
 	return d[name]
 }
 
+// Get retrieves the JSON-RPC transport data for the service with the given
+// name computing it if needed. It returns nil if there is no JSON-RPC service
+// with the given name.
+func (d JSONRPCServicesData) Get(name string) *ServiceData {
+	if data, ok := d[name]; ok {
+		return data
+	}
+	service := expr.Root.API.JSONRPC.Service(name)
+	if service == nil {
+		return nil
+	}
+	d[name] = ServicesData(d).analyze(service)
+	return d[name]
+}
+
 // Endpoint returns the service method transport data for the endpoint with the
 // given name, nil if there isn't one.
 func (svc *ServiceData) Endpoint(name string) *EndpointData {
@@ -588,6 +655,19 @@ func (svc *ServiceData) Endpoint(name string) *EndpointData {
 	return nil
 }
 
+// hasCompression returns true if the "http:compression" meta is set on the
+// service or on the API with a value other than "false". The service meta
+// overrides the API meta.
+func hasCompression(svc *expr.ServiceExpr) bool {
+	for _, m := range []expr.MetaExpr{svc.Meta, expr.Root.API.Meta} {
+		if _, ok := m["http:compression"]; ok {
+			v, _ := m.Last("http:compression")
+			return v != "false"
+		}
+	}
+	return false
+}
+
 // analyze creates the data necessary to render the code of the given service.
 // It records the user types needed by the service definition in userTypes.
 func (ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
@@ -606,6 +686,7 @@ func (ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 		ServerTypeNames:  make(map[string]bool),
 		ClientTypeNames:  make(map[string]bool),
 		Scope:            scope,
+		Compression:      hasCompression(hs.ServiceExpr),
 	}
 
 	for _, s := range hs.FileServers {
@@ -686,6 +767,7 @@ func (ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 								Description: att.Description,
 								FieldName:   codegen.Goify(arg, true),
 								FieldType:   patt.Type,
+								FieldTime:   timeField(a.MethodExpr.Payload, arg),
 								TypeName:    rd.Scope.GoTypeName(att),
 								TypeRef:     rd.Scope.GoTypeRef(att),
 								Type:        att.Type,
@@ -800,7 +882,7 @@ func (ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 				"Args":         args,
 				"PathInit":     routes[0].PathInit,
 				"Verb":         routes[0].Verb,
-				"IsStreaming":  a.MethodExpr.IsStreaming(),
+				"IsStreaming":  a.MethodExpr.IsStreaming() && a.SSE == nil,
 			}
 			if a.SkipRequestBodyEncodeDecode {
 				data["RequestStruct"] = pkg + "." + ep.RequestStruct
@@ -842,9 +924,14 @@ func (ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 			RequestEncoder:  requestEncoder,
 			ResponseDecoder: fmt.Sprintf("Decode%sResponse", ep.VarName),
 			Requirements:    reqs,
+			Pagination:      buildPaginationData(a, rd),
 		}
 		if a.MethodExpr.IsStreaming() {
-			initWebSocketData(ad, a, rd)
+			if a.SSE != nil {
+				initSSEData(ad, a, rd)
+			} else {
+				initWebSocketData(ad, a, rd)
+			}
 		}
 
 		if a.MultipartRequest {
@@ -926,25 +1013,48 @@ func (ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 		}
 	}
 
+	initCORSData(rd, hs)
+
 	return rd
 }
 
 // makeHTTPType traverses the attribute recursively and performs these actions:
 //
 // * removes aliased user type by replacing them with the underlying type.
-// * changes unions into structs with Type and Value fields.
+// * changes unions into structs with Type and Value fields or into structs
+// with the discriminator and value fields for discriminated unions.
 func makeHTTPType(att *expr.AttributeExpr) *expr.AttributeExpr {
 	if att == nil {
 		return nil
 	}
 	att = expr.DupAtt(att)
-	return makeHTTPTypeRecursive(att, make(map[string]struct{}))
+	return makeHTTPTypeRecursive(att, make(map[string]struct{}), nil)
 }
 
-func makeHTTPTypeRecursive(att *expr.AttributeExpr, seen map[string]struct{}) *expr.AttributeExpr {
+// makeHTTPBodyType is similar to makeHTTPType but the body attributes whose
+// types use the "type:enum" meta keep the Go type generated in the service
+// package.
+func makeHTTPBodyType(att *expr.AttributeExpr, svc *service.Data) *expr.AttributeExpr {
+	if att == nil {
+		return nil
+	}
+	att = expr.DupAtt(att)
+	return makeHTTPTypeRecursive(att, make(map[string]struct{}), svc)
+}
+
+func makeHTTPTypeRecursive(att *expr.AttributeExpr, seen map[string]struct{}, svc *service.Data) *expr.AttributeExpr {
 	switch dt := att.Type.(type) {
 	case expr.UserType:
 		if _, ok := dt.(*expr.ResultTypeExpr); !ok && !expr.IsObject(dt) {
+			if svc != nil && expr.IsTypedEnum(dt) {
+				if _, ok := att.Meta["struct:field:type"]; !ok {
+					pkg := svc.PkgName
+					if loc := codegen.UserTypeLocation(dt); loc != nil {
+						pkg = loc.PackageName()
+					}
+					att.AddMeta("struct:field:type", svc.Scope.GoFullTypeRef(&expr.AttributeExpr{Type: dt}, pkg))
+				}
+			}
 			// Aliased user type. Use the underlying aliased type instead of
 			// generating new types in the client and server packages
 			att.Type = dt.Attribute().Type
@@ -961,20 +1071,29 @@ func makeHTTPTypeRecursive(att *expr.AttributeExpr, seen map[string]struct{}) *e
 			return att
 		}
 		seen[dt.ID()] = struct{}{}
-		dt.SetAttribute(makeHTTPTypeRecursive(dt.Attribute(), seen))
+		dt.SetAttribute(makeHTTPTypeRecursive(dt.Attribute(), seen, svc))
 	case *expr.Array:
-		dt.ElemType = makeHTTPTypeRecursive(dt.ElemType, seen)
+		dt.ElemType = makeHTTPTypeRecursive(dt.ElemType, seen, svc)
 	case *expr.Map:
-		dt.KeyType = makeHTTPTypeRecursive(dt.KeyType, seen)
-		dt.ElemType = makeHTTPTypeRecursive(dt.ElemType, seen)
+		dt.KeyType = makeHTTPTypeRecursive(dt.KeyType, seen, svc)
+		dt.ElemType = makeHTTPTypeRecursive(dt.ElemType, seen, svc)
 	case *expr.Object:
 		obj := make(expr.Object, len(*dt))
 		for i, nat := range *dt {
-			obj[i] = &expr.NamedAttributeExpr{Name: nat.Name, Attribute: makeHTTPTypeRecursive(nat.Attribute, seen)}
+			obj[i] = &expr.NamedAttributeExpr{Name: nat.Name, Attribute: makeHTTPTypeRecursive(nat.Attribute, seen, svc)}
 		}
 		att.Type = &obj
 	case *expr.Union:
 		att = expr.UnionToObject(att)
+		if dt.Discriminator != "" {
+			// The attributes of discriminated union values are serialized
+			// inline and may use types that need to be converted too.
+			att = makeHTTPTypeRecursive(att, seen, svc)
+		}
+	}
+	if att.IsTime() {
+		// Time values are encoded as strings in HTTP bodies.
+		att.AddMeta("struct:field:time", "false")
 	}
 	return att
 }
@@ -983,7 +1102,7 @@ func makeHTTPTypeRecursive(att *expr.AttributeExpr, seen map[string]struct{}) *e
 // payload including the HTTP request details. It also returns the user types
 // used by the request body type recursively if any.
 func buildPayloadData(e *expr.HTTPEndpointExpr, sd *ServiceData) *PayloadData {
-	e.Body = makeHTTPType(e.Body)
+	e.Body = makeHTTPBodyType(e.Body, sd.Service)
 	var (
 		payload    = e.MethodExpr.Payload
 		svc        = sd.Service
@@ -1181,6 +1300,7 @@ func buildPayloadData(e *expr.HTTPEndpointExpr, sd *ServiceData) *PayloadData {
 					FieldName:    p.FieldName,
 					FieldPointer: p.FieldPointer,
 					FieldType:    p.FieldType,
+					FieldTime:    p.FieldTime,
 					TypeName:     p.TypeName,
 					TypeRef:      p.TypeRef,
 					Type:         p.Type,
@@ -1200,6 +1320,7 @@ func buildPayloadData(e *expr.HTTPEndpointExpr, sd *ServiceData) *PayloadData {
 					FieldName:    p.FieldName,
 					FieldPointer: p.FieldPointer,
 					FieldType:    p.FieldType,
+					FieldTime:    p.FieldTime,
 					TypeName:     p.TypeName,
 					TypeRef:      p.TypeRef,
 					Type:         p.Type,
@@ -1220,6 +1341,7 @@ func buildPayloadData(e *expr.HTTPEndpointExpr, sd *ServiceData) *PayloadData {
 					FieldName:    h.FieldName,
 					FieldPointer: h.FieldPointer,
 					FieldType:    h.FieldType,
+					FieldTime:    h.FieldTime,
 					TypeName:     h.TypeName,
 					TypeRef:      h.TypeRef,
 					Type:         h.Type,
@@ -1240,6 +1362,7 @@ func buildPayloadData(e *expr.HTTPEndpointExpr, sd *ServiceData) *PayloadData {
 					FieldName:    c.FieldName,
 					FieldPointer: c.FieldPointer,
 					FieldType:    c.FieldType,
+					FieldTime:    c.FieldTime,
 					TypeName:     c.TypeName,
 					TypeRef:      c.TypeRef,
 					Type:         c.Type,
@@ -1415,6 +1538,35 @@ func buildPayloadData(e *expr.HTTPEndpointExpr, sd *ServiceData) *PayloadData {
 	}
 }
 
+// buildPaginationData builds the data needed to set the Link header of the
+// given endpoint responses, nil if the endpoint method is not paginated.
+func buildPaginationData(e *expr.HTTPEndpointExpr, sd *ServiceData) *PaginationData {
+	p := e.MethodExpr.Pagination
+	if p == nil {
+		return nil
+	}
+	var (
+		svc  = sd.Service
+		ep   = svc.Method(e.MethodExpr.Name)
+		next = ep.Pagination.NextCursorField
+	)
+	if ep.ViewedResult != nil {
+		// Projected types always use pointers.
+		return &PaginationData{
+			CursorParam:       e.QueryParams().ElemName(p.Cursor),
+			ResultRef:         ep.ViewedResult.FullRef,
+			NextCursor:        "Projected." + next,
+			NextCursorPointer: true,
+		}
+	}
+	return &PaginationData{
+		CursorParam:       e.QueryParams().ElemName(p.Cursor),
+		ResultRef:         svc.Scope.GoFullTypeRef(e.MethodExpr.Result, pkgWithDefault(ep.ResultLoc, svc.PkgName)),
+		NextCursor:        next,
+		NextCursorPointer: ep.Pagination.NextCursorPointer,
+	}
+}
+
 // buildResultData builds the result data for the given service endpoint.
 func buildResultData(e *expr.HTTPEndpointExpr, sd *ServiceData) *ResultData {
 	var (
@@ -1490,7 +1642,7 @@ func buildResponses(e *expr.HTTPEndpointExpr, result *expr.AttributeExpr, viewed
 		notag := -1
 		for i, resp := range e.Responses {
 			resp.Body = expr.DupAtt(resp.Body)
-			resp.Body = makeHTTPType(resp.Body)
+			resp.Body = makeHTTPBodyType(resp.Body, sd.Service)
 			if resp.Tag[0] == "" {
 				if notag > -1 {
 					continue // we don't want more than one response with no tag
@@ -1663,6 +1815,7 @@ func buildResponses(e *expr.HTTPEndpointExpr, result *expr.AttributeExpr, viewed
 									FieldName:    h.FieldName,
 									FieldPointer: h.FieldPointer,
 									FieldType:    h.FieldType,
+									FieldTime:    h.FieldTime,
 									Required:     h.Required,
 									Pointer:      h.Pointer,
 									TypeRef:      h.TypeRef,
@@ -1681,6 +1834,7 @@ func buildResponses(e *expr.HTTPEndpointExpr, result *expr.AttributeExpr, viewed
 									FieldName:    c.FieldName,
 									FieldPointer: c.FieldPointer,
 									FieldType:    c.FieldType,
+									FieldTime:    c.FieldTime,
 									Required:     c.Required,
 									Pointer:      c.Pointer,
 									TypeRef:      c.TypeRef,
@@ -1756,7 +1910,7 @@ func buildErrorsData(e *expr.HTTPEndpointExpr, sd *ServiceData) []*ErrorGroupDat
 
 	data := make(map[string][]*ErrorData)
 	for _, v := range e.HTTPErrors {
-		v.Response.Body = makeHTTPType(v.Response.Body)
+		v.Response.Body = makeHTTPBodyType(v.Response.Body, sd.Service)
 		var (
 			init *InitData
 			body = v.Response.Body.Type
@@ -1796,6 +1950,7 @@ func buildErrorsData(e *expr.HTTPEndpointExpr, sd *ServiceData) []*ErrorGroupDat
 							FieldName:    h.FieldName,
 							FieldPointer: false,
 							FieldType:    h.FieldType,
+							FieldTime:    h.FieldTime,
 							TypeRef:      h.TypeRef,
 							Type:         h.Type,
 							Validate:     h.Validate,
@@ -1812,6 +1967,7 @@ func buildErrorsData(e *expr.HTTPEndpointExpr, sd *ServiceData) []*ErrorGroupDat
 							FieldName:    c.FieldName,
 							FieldPointer: false,
 							FieldType:    c.FieldType,
+							FieldTime:    c.FieldTime,
 							TypeRef:      c.TypeRef,
 							Type:         c.Type,
 							Validate:     c.Validate,
@@ -2289,6 +2445,20 @@ func buildResponseBodyType(body, att *expr.AttributeExpr, loc *codegen.Location,
 	}
 }
 
+// timeField returns the attribute of the field with the given name of the
+// service type if it is a Go time value, nil otherwise. It returns the service
+// attribute itself if it is not an object.
+func timeField(service *expr.AttributeExpr, name string) *expr.AttributeExpr {
+	att := service
+	if expr.IsObject(service.Type) {
+		att = service.Find(name)
+	}
+	if !att.IsTime() {
+		return nil
+	}
+	return att
+}
+
 func extractPathParams(a *expr.MappedAttributeExpr, service *expr.AttributeExpr, scope *codegen.NameScope) []*ParamData {
 	var params []*ParamData
 	codegen.WalkMappedAttr(a, func(name, elem string, _ bool, c *expr.AttributeExpr) error { // nolint: errcheck
@@ -2328,6 +2498,7 @@ func extractPathParams(a *expr.MappedAttributeExpr, service *expr.AttributeExpr,
 					FieldName:    fieldName,
 					FieldPointer: fptr,
 					FieldType:    ft,
+					FieldTime:    timeField(service, name),
 					VarName:      varn,
 					Required:     true,
 					Type:         c.Type,
@@ -2395,6 +2566,7 @@ func extractQueryParams(a *expr.MappedAttributeExpr, service *expr.AttributeExpr
 					FieldName:    fieldName,
 					FieldPointer: fptr,
 					FieldType:    ft,
+					FieldTime:    timeField(service, name),
 					VarName:      varn,
 					Required:     required,
 					Type:         c.Type,
@@ -2463,6 +2635,7 @@ func extractHeaders(a *expr.MappedAttributeExpr, svcAtt *expr.AttributeExpr, svc
 					FieldName:    fieldName,
 					FieldPointer: fptr,
 					FieldType:    ft,
+					FieldTime:    timeField(svcAtt, name),
 					VarName:      varn,
 					TypeName:     scope.GoTypeName(hattr),
 					TypeRef:      typeRef,
@@ -2520,6 +2693,7 @@ func extractCookies(a *expr.MappedAttributeExpr, svcAtt *expr.AttributeExpr, svc
 					FieldName:    fieldName,
 					FieldPointer: fptr,
 					FieldType:    ft,
+					FieldTime:    timeField(svcAtt, name),
 					VarName:      varn,
 					TypeName:     scope.GoTypeName(hattr),
 					TypeRef:      typeRef,
@@ -2763,6 +2937,10 @@ func AddMarshalTags(att *expr.AttributeExpr, seen map[string]struct{}) {
 		if natt.Attribute.Meta == nil {
 			natt.Attribute.Meta = expr.MetaExpr{}
 		}
+		if _, ok := natt.Attribute.Meta["struct:tag:json"]; ok {
+			// Tags already set, e.g. by expr.UnionToObject.
+			continue
+		}
 		ns := []string{natt.Name}
 		natt.Attribute.Meta["struct:tag:form"] = ns
 		natt.Attribute.Meta["struct:tag:json"] = ns
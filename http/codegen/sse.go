@@ -0,0 +1,245 @@
+package codegen
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// SSEData contains the data needed to render the struct types that
+	// implement the server and client stream interfaces using Server-Sent
+	// Events.
+	SSEData struct {
+		// VarName is the name of the struct.
+		VarName string
+		// Type is type of the stream (server or client).
+		Type string
+		// Interface is the fully qualified name of the interface that
+		// the struct implements.
+		Interface string
+		// Endpoint is endpoint data that defines the streaming result.
+		Endpoint *EndpointData
+		// Response is the successful response data for the streaming
+		// endpoint.
+		Response *ResponseData
+		// SendName is the name of the send function.
+		SendName string
+		// SendDesc is the description for the send function.
+		SendDesc string
+		// SendTypeRef is the fully qualified type ref sent through the
+		// stream.
+		SendTypeRef string
+		// RecvName is the name of the receive function.
+		RecvName string
+		// RecvDesc is the description for the recv function.
+		RecvDesc string
+		// RecvTypeName is the fully qualified type name received from
+		// the stream.
+		RecvTypeName string
+		// RecvTypeRef is the fully qualified type ref received from the
+		// stream.
+		RecvTypeRef string
+		// MustClose indicates whether to generate the Close() function
+		// for the stream.
+		MustClose bool
+		// PkgName is the service package name.
+		PkgName string
+		// Data describes the result attribute used to build the event
+		// "data" field, nil if the entire result is used.
+		Data *SSEFieldData
+		// ID describes the result attribute used to build the event "id"
+		// field if any.
+		ID *SSEFieldData
+		// Event describes the result attribute used to build the event
+		// "event" field if any.
+		Event *SSEFieldData
+		// Retry describes the result attribute used to build the event
+		// "retry" field if any.
+		Retry *SSEFieldData
+	}
+
+	// SSEFieldData describes a result attribute mapped to a Server-Sent
+	// Event field.
+	SSEFieldData struct {
+		// FieldName is the name of the result struct field.
+		FieldName string
+		// TypeRef is the reference to the field type without pointer.
+		TypeRef string
+		// Pointer is true if the struct field is a pointer.
+		Pointer bool
+		// IsString is true if the attribute type is String.
+		IsString bool
+	}
+)
+
+// initSSEData initializes the Server-Sent Events related data in ed.
+func initSSEData(ed *EndpointData, e *expr.HTTPEndpointExpr, sd *ServiceData) {
+	var (
+		md  = ed.Method
+		svc = sd.Service
+		sse = e.SSE
+		res = e.MethodExpr.Result
+	)
+	field := func(name string) *SSEFieldData {
+		if name == "" {
+			return nil
+		}
+		att := res.Find(name)
+		return &SSEFieldData{
+			FieldName: codegen.GoifyAtt(att, name, true),
+			TypeRef:   svc.Scope.GoTypeRef(att),
+			Pointer:   res.IsPrimitivePointer(name, true),
+			IsString:  att.Type == expr.String,
+		}
+	}
+	var (
+		data  = field(sse.DataField)
+		id    = field(sse.IDField)
+		event = field(sse.EventField)
+		retry = field(sse.RetryField)
+	)
+	ed.ServerSSE = &SSEData{
+		VarName:     md.ServerStream.VarName,
+		Type:        "server",
+		Interface:   fmt.Sprintf("%s.%s", svc.PkgName, md.ServerStream.Interface),
+		Endpoint:    ed,
+		Response:    ed.Result.Responses[0],
+		SendName:    md.ServerStream.SendName,
+		SendDesc:    fmt.Sprintf("%s streams instances of %q to the %q endpoint server-sent events stream.", md.ServerStream.SendName, ed.Result.Name, md.Name),
+		SendTypeRef: ed.Result.Ref,
+		MustClose:   md.ServerStream.MustClose,
+		PkgName:     svc.PkgName,
+		Data:        data,
+		ID:          id,
+		Event:       event,
+		Retry:       retry,
+	}
+	ed.ClientSSE = &SSEData{
+		VarName:      md.ClientStream.VarName,
+		Type:         "client",
+		Interface:    fmt.Sprintf("%s.%s", svc.PkgName, md.ClientStream.Interface),
+		Endpoint:     ed,
+		Response:     ed.Result.Responses[0],
+		RecvName:     md.ClientStream.RecvName,
+		RecvDesc:     fmt.Sprintf("%s reads instances of %q from the %q endpoint server-sent events stream.", md.ClientStream.RecvName, ed.Result.Name, md.Name),
+		RecvTypeName: ed.Result.Name,
+		RecvTypeRef:  ed.Result.Ref,
+		MustClose:    md.ClientStream.MustClose,
+		PkgName:      svc.PkgName,
+		Data:         data,
+		ID:           id,
+		Event:        event,
+		Retry:        retry,
+	}
+}
+
+// sseServerFile returns the file implementing the Server-Sent Events server
+// streaming implementation if any.
+func sseServerFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
+	data := HTTPServices.Get(svc.Name())
+	if !hasSSE(data) {
+		return nil
+	}
+	svcName := data.Service.PathName
+	title := fmt.Sprintf("%s server-sent events server streaming", svc.Name())
+	imports := []*codegen.ImportSpec{
+		{Path: "encoding/json"},
+		codegen.GoaNamedImport("http", "goahttp"),
+		{Path: genpkg + "/" + svcName, Name: data.Service.PkgName},
+		{Path: genpkg + "/" + svcName + "/" + "views", Name: data.Service.ViewsPkg},
+	}
+	imports = append(imports, data.Service.UserTypeImports...)
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(title, "server", imports),
+	}
+	for _, e := range data.Endpoints {
+		if e.ServerSSE == nil {
+			continue
+		}
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "server-sse-struct-type",
+			Source: readTemplate("sse_struct_type"),
+			Data:   e.ServerSSE,
+		})
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:    "server-sse-send",
+			Source:  readTemplate("sse_send"),
+			Data:    e.ServerSSE,
+			FuncMap: map[string]any{"viewedServerBody": viewedServerBody},
+		})
+		if e.ServerSSE.MustClose {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "server-sse-close",
+				Source: readTemplate("sse_close"),
+				Data:   e.ServerSSE,
+			})
+		}
+	}
+
+	return &codegen.File{
+		Path:             filepath.Join(codegen.Gendir, "http", svcName, "server", "sse.go"),
+		SectionTemplates: sections,
+	}
+}
+
+// sseClientFile returns the file implementing the Server-Sent Events client
+// streaming implementation if any.
+func sseClientFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
+	data := HTTPServices.Get(svc.Name())
+	if !hasSSE(data) {
+		return nil
+	}
+	svcName := data.Service.PathName
+	title := fmt.Sprintf("%s server-sent events client streaming", svc.Name())
+	imports := []*codegen.ImportSpec{
+		{Path: "encoding/json"},
+		{Path: "io"},
+		codegen.GoaNamedImport("http", "goahttp"),
+		{Path: genpkg + "/" + svcName + "/" + "views", Name: data.Service.ViewsPkg},
+		{Path: genpkg + "/" + svcName, Name: data.Service.PkgName},
+	}
+	imports = append(imports, data.Service.UserTypeImports...)
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(title, "client", imports),
+	}
+	for _, e := range data.Endpoints {
+		if e.ClientSSE == nil {
+			continue
+		}
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "client-sse-struct-type",
+			Source: readTemplate("sse_struct_type"),
+			Data:   e.ClientSSE,
+		})
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "client-sse-recv",
+			Source: readTemplate("sse_recv"),
+			Data:   e.ClientSSE,
+		})
+	}
+
+	return &codegen.File{
+		Path:             filepath.Join(codegen.Gendir, "http", svcName, "client", "sse.go"),
+		SectionTemplates: sections,
+	}
+}
+
+// hasSSE returns true if at least one of the endpoints in the service streams
+// results using Server-Sent Events.
+func hasSSE(sd *ServiceData) bool {
+	for _, e := range sd.Endpoints {
+		if isSSEEndpoint(e) {
+			return true
+		}
+	}
+	return false
+}
+
+// isSSEEndpoint returns true if the endpoint streams results using Server-Sent
+// Events.
+func isSSEEndpoint(ed *EndpointData) bool {
+	return ed.ServerSSE != nil || ed.ClientSSE != nil
+}
@@ -0,0 +1,58 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestServerSSE(t *testing.T) {
+	cases := []*testCase{
+		{"sse-result", testdata.SSEResultDSL, []*sectionExpectation{
+			{"server-handler-init", &testdata.SSEResultServerHandlerInitCode},
+			{"server-sse-struct-type", &testdata.SSEResultServerStreamStructTypeCode},
+			{"server-sse-send", &testdata.SSEResultServerStreamSendCode},
+			{"server-sse-close", &testdata.SSEResultServerStreamCloseCode},
+		}},
+		{"sse-result-primitive", testdata.SSEResultPrimitiveDSL, []*sectionExpectation{
+			{"server-sse-send", &testdata.SSEResultPrimitiveServerStreamSendCode},
+		}},
+		{"sse-result-with-explicit-view", testdata.SSEResultWithExplicitViewDSL, []*sectionExpectation{
+			{"server-sse-send", &testdata.SSEResultWithExplicitViewServerStreamSendCode},
+		}},
+		{"sse-result-fields", testdata.SSEResultFieldsDSL, []*sectionExpectation{
+			{"server-sse-send", &testdata.SSEResultFieldsServerStreamSendCode},
+		}},
+		{"sse-result-object-data", testdata.SSEResultObjectDataDSL, []*sectionExpectation{
+			{"server-sse-send", &testdata.SSEResultObjectDataServerStreamSendCode},
+		}},
+	}
+	filesFn := func() []*codegen.File { return ServerFiles("", expr.Root) }
+	runTests(t, cases, filesFn)
+}
+
+func TestClientSSE(t *testing.T) {
+	cases := []*testCase{
+		{"client-sse-result", testdata.SSEResultDSL, []*sectionExpectation{
+			{"client-endpoint-init", &testdata.SSEResultClientEndpointInitCode},
+			{"client-sse-struct-type", &testdata.SSEResultClientStreamStructTypeCode},
+			{"client-sse-recv", &testdata.SSEResultClientStreamRecvCode},
+		}},
+		{"client-sse-result-primitive", testdata.SSEResultPrimitiveDSL, []*sectionExpectation{
+			{"client-sse-recv", &testdata.SSEResultPrimitiveClientStreamRecvCode},
+		}},
+		{"client-sse-result-with-explicit-view", testdata.SSEResultWithExplicitViewDSL, []*sectionExpectation{
+			{"client-sse-recv", &testdata.SSEResultWithExplicitViewClientStreamRecvCode},
+		}},
+		{"client-sse-result-fields", testdata.SSEResultFieldsDSL, []*sectionExpectation{
+			{"client-sse-recv", &testdata.SSEResultFieldsClientStreamRecvCode},
+		}},
+		{"client-sse-result-object-data", testdata.SSEResultObjectDataDSL, []*sectionExpectation{
+			{"client-sse-recv", &testdata.SSEResultObjectDataClientStreamRecvCode},
+		}},
+	}
+	filesFn := func() []*codegen.File { return ClientFiles("", expr.Root) }
+	runTests(t, cases, filesFn)
+}
@@ -4,6 +4,7 @@ func doHTTP(scheme, host string, timeout int, debug bool) (goa.Endpoint, any, er
 	)
 	{
 		doer = &http.Client{Timeout: time.Duration(timeout) * time.Second}
+		doer = goahttp.NewCompressionDoer(doer)
 		if debug {
 			doer = goahttp.NewDebugDoer(doer)
 		}
@@ -1,4 +1,4 @@
-{{ printf "New%s instantiates HTTP clients for all the %s service servers." .ClientStruct .Service.Name | comment }}
+{{ printf "New%s instantiates HTTP clients for all the %s service servers.%s" .ClientStruct .Service.Name (or (and .Compression " The clients decompress the response bodies encoded with gzip or deflate.") "") | comment }}
 func New{{ .ClientStruct }}(
 	scheme string,
 	host string,
@@ -11,6 +11,9 @@ func New{{ .ClientStruct }}(
 	cfn *ConnConfigurer,
 	{{- end }}
 ) *{{ .ClientStruct }} {
+{{- if .Compression }}
+	doer = goahttp.NewCompressionDoer(doer)
+{{- end }}
 {{- if hasWebSocket . }}
 	if cfn == nil {
 		cfn = &ConnConfigurer{}
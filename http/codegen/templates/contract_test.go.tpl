@@ -0,0 +1,160 @@
+{{ printf "TestContract serves the %q service mock with the generated HTTP server and calls each endpoint with the generated HTTP client using the design examples." .Service.Service.Name | comment }}
+func TestContract(t *testing.T) {
+{{- range $e := .Endpoints }}
+	t.Run({{ printf "%q" .Method.Name }}, func(t *testing.T) {
+	{{- if .Skip }}
+		t.Skip({{ printf "%q" .Skip }})
+	{{- else }}
+		var (
+			m      = {{ $.MockPkg }}.New()
+			c, doer = newContractClient(t, m)
+			ctx    = context.Background()
+		{{- if .Mock.PayloadVar }}
+			p      = {{ .Mock.PayloadValue }}
+		{{- end }}
+		)
+		{{ if .Mock.ResultRef }}res{{ else }}_{{ end }}, err := c.{{ .EndpointInit }}()(ctx, {{ if .Mock.PayloadVar }}p{{ else }}nil{{ end }})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		assertCall(t, m, {{ printf "%q" .Method.Name }}, {{ if .Mock.PayloadVar }}p{{ else }}nil{{ end }})
+		{{- if .Mock.ResultRef }}
+		{{- if and .Method.ViewedResult (not .Method.ViewedResult.ViewName) }}
+		expected, view, _ := {{ $.MockPkg }}.New().{{ .Method.VarName }}({{ .Mock.Args }})
+		{{- else }}
+		expected, _ := {{ $.MockPkg }}.New().{{ .Method.VarName }}({{ .Mock.Args }})
+		{{- end }}
+		{{- if .Method.ViewedResult }}
+		expected = {{ $.Service.Service.PkgName }}.{{ .Method.ViewedResult.ResultInit.Name }}({{ $.Service.Service.PkgName }}.{{ .Method.ViewedResult.Init.Name }}(expected, {{ if .Method.ViewedResult.ViewName }}{{ printf "%q" .Method.ViewedResult.ViewName }}{{ else }}view{{ end }}))
+		{{- end }}
+		assertEqual(t, "result", res, expected)
+		{{- end }}
+		assertResponse(t, doer.resp, []int{ {{- range $i, $c := .StatusCodes }}{{ if $i }}, {{ end }}{{ $c }}{{ end }}}{{ range .Headers }}, {{ printf "%q" . }}{{ end }})
+		{{- range .Errors }}
+
+		t.Run({{ printf "%q" .Name }}, func(t *testing.T) {
+			m := {{ $.MockPkg }}.New()
+			m.Set{{ $e.Method.VarName }}(func({{ $e.Mock.Params }}) ({{ $e.Mock.Results }}) {
+				err = {{ $.MockPkg }}.NewError({{ printf "%q" $e.Method.Name }}, {{ printf "%q" .Name }})
+				return
+			})
+			c, doer := newContractClient(t, m)
+			_, err := c.{{ $e.EndpointInit }}()(ctx, {{ if $e.Mock.PayloadVar }}p{{ else }}nil{{ end }})
+			assertError(t, err, {{ $.MockPkg }}.NewError({{ printf "%q" $e.Method.Name }}, {{ printf "%q" .Name }}))
+			assertResponse(t, doer.resp, []int{ {{- .StatusCode }}})
+		})
+		{{- end }}
+	{{- end }}
+	})
+{{- end }}
+}
+
+// contractDoer records the last response received by the client.
+type contractDoer struct {
+	goahttp.Doer
+	resp *http.Response
+}
+
+// Do sends the request and records the response.
+func (d *contractDoer) Do(req *http.Request) (*http.Response, error) {
+	resp, err := d.Doer.Do(req)
+	d.resp = resp
+	return resp, err
+}
+
+{{ printf "newContractClient starts a test HTTP server that serves the endpoints of the %q service implemented by m and returns a client connected to the server together with the doer used to send the requests." .Service.Service.Name | comment }}
+func newContractClient(t *testing.T, m *{{ .MockPkg }}.Mock) (*{{ .ClientPkg }}.{{ .Service.ClientStruct }}, *contractDoer) {
+	t.Helper()
+	var (
+		mux = goahttp.NewMuxer()
+		eh  = func(ctx context.Context, w http.ResponseWriter, err error) {
+			t.Errorf("failed to encode response: %v", err)
+		}
+		e = {{ .Service.Service.PkgName }}.NewEndpoints(m{{ if .ServerInterceptors }}, {{ .MockPkg }}.ServerInterceptors{}{{ end }})
+	)
+	// Multipart decoders, websocket upgraders and file systems are not
+	// needed as the corresponding endpoints are not tested.
+	srv := {{ .ServerPkg }}.{{ .Service.ServerInit }}(e, mux, goahttp.RequestDecoder, goahttp.ResponseEncoder, eh, nil{{ if hasWebSocket .Service }}, nil, nil{{ end }}{{ range .Service.Endpoints }}{{ if .MultipartRequestDecoder }}, nil{{ end }}{{ end }}{{ range .Service.FileServers }}, nil{{ end }})
+	{{ .ServerPkg }}.{{ .Service.MountServer }}(mux, srv)
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("invalid test server URL: %v", err)
+	}
+	doer := &contractDoer{Doer: ts.Client()}
+	return {{ .ClientPkg }}.New{{ .Service.ClientStruct }}(u.Scheme, u.Host, doer, goahttp.RequestEncoder, goahttp.ResponseDecoder, false{{ if hasWebSocket .Service }}, nil, nil{{ end }}), doer
+}
+
+// assertCall checks that the mock method was called once with the expected
+// payload.
+func assertCall(t *testing.T, m *{{ .MockPkg }}.Mock, method string, expected any) {
+	t.Helper()
+	calls := m.Calls()
+	if len(calls) != 1 || calls[0].Method != method {
+		t.Fatalf("expected a single call to %q, got %d calls", method, len(calls))
+	}
+	assertEqual(t, "payload", calls[0].Payload, expected)
+}
+
+// assertEqual checks that the value received on the other side of the
+// transport is equal to the value sent.
+func assertEqual(t *testing.T, name string, got, expected any) {
+	t.Helper()
+	if reflect.DeepEqual(got, expected) {
+		return
+	}
+	g, _ := json.Marshal(got)
+	e, _ := json.Marshal(expected)
+	t.Errorf("%s mismatch:\ngot:      %s\nexpected: %s", name, g, e)
+}
+
+// assertError checks that the error returned by the client matches the error
+// returned by the service.
+func assertError(t *testing.T, err, expected error) {
+	t.Helper()
+	if err == nil {
+		t.Fatal("expected an error, got none")
+	}
+	var se *goa.ServiceError
+	if errors.As(expected, &se) {
+		var got *goa.ServiceError
+		if !errors.As(err, &got) {
+			t.Fatalf("expected a service error, got %v", err)
+		}
+		assertEqual(t, "error", got.Name+": "+got.Message, se.Name+": "+se.Message)
+		return
+	}
+	assertEqual(t, "error", err, expected)
+}
+
+// assertResponse checks the status code and the headers of the response.
+func assertResponse(t *testing.T, resp *http.Response, codes []int, headers ...string) {
+	t.Helper()
+	if resp == nil {
+		t.Fatal("no response received")
+	}
+	found := false
+	for _, c := range codes {
+		if resp.StatusCode == c {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Errorf("unexpected status code %d, expected one of %v", resp.StatusCode, codes)
+	}
+	for _, h := range headers {
+		if resp.Header.Get(h) == "" {
+			t.Errorf("missing response header %q", h)
+		}
+	}
+}
+{{- if hasPtr .Endpoints }}
+
+// ptr returns a pointer to v.
+func ptr[T any](v T) *T {
+	return &v
+}
+{{- end }}
@@ -1,5 +1,8 @@
 {{ printf "%s configures the mux to serve GET request made to %q." .MountHandler (join .RequestPaths ", ") | comment }}
 func {{ .MountHandler }}(mux goahttp.Muxer, h http.Handler) {
+	{{- if .OriginHandler }}
+	h = {{ .OriginHandler }}(h)
+	{{- end }}
 	{{- if .IsDir }}
 		{{- range .RequestPaths }}
 	mux.Handle("GET", "{{ . }}{{if ne . "/"}}/{{end}}", h.ServeHTTP)
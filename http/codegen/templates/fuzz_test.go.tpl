@@ -0,0 +1,45 @@
+{{ printf "FuzzDecode%s checks that the %q endpoint request decoder does not panic when decoding arbitrary requests and that the payloads it accepts are accepted again once encoded by the generated client.%s" .Endpoint.Method.VarName .Endpoint.Method.Name (or (and .Endpoint.PayloadValue " The corpus is seeded with the request built from the design example.") "") | comment }}
+func FuzzDecode{{ .Endpoint.Method.VarName }}(f *testing.F) {
+{{- if .Endpoint.PayloadValue }}
+	seed, err := fuzzEncode{{ .Endpoint.Method.VarName }}({{ .Endpoint.PayloadValue }})
+	if err != nil {
+		f.Fatalf("failed to encode the seed request: %v", err)
+	}
+	if _, err := fuzzDecode(seed, {{ .Endpoint.MountHandler }}, {{ .Endpoint.RequestDecoder }}); err != nil {
+		f.Fatalf("failed to decode the seed request: %v\n%s", err, seed)
+	}
+	f.Add(seed)
+{{- end }}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		p, err := fuzzDecode(data, {{ .Endpoint.MountHandler }}, {{ .Endpoint.RequestDecoder }})
+		if err != nil {
+			return
+		}
+		data, err = fuzzEncode{{ .Endpoint.Method.VarName }}(p)
+		if err != nil {
+			t.Fatalf("failed to encode the decoded payload: %v", err)
+		}
+		if _, err := fuzzDecode(data, {{ .Endpoint.MountHandler }}, {{ .Endpoint.RequestDecoder }}); err != nil {
+			t.Errorf("failed to decode the request encoded from the decoded payload: %v\n%s", err, data)
+		}
+	})
+}
+
+{{ printf "fuzzEncode%s returns the %q endpoint request built for p by the generated client in wire format." .Endpoint.Method.VarName .Endpoint.Method.Name | comment }}
+func fuzzEncode{{ .Endpoint.Method.VarName }}(p any) ([]byte, error) {
+	c := {{ .ClientPkg }}.New{{ .Service.ClientStruct }}("http", "localhost", http.DefaultClient, goahttp.RequestEncoder, goahttp.ResponseDecoder, false{{ if hasWebSocket .Service }}, nil, nil{{ end }})
+	req, err := c.{{ .Endpoint.RequestInit.Name }}(context.Background(), p)
+	if err != nil {
+		return nil, err
+	}
+{{- if .Endpoint.RequestEncoder }}
+	if err := {{ .ClientPkg }}.{{ .Endpoint.RequestEncoder }}(goahttp.RequestEncoder)(req, p); err != nil {
+		return nil, err
+	}
+{{- end }}
+	var buf bytes.Buffer
+	if err := req.Write(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
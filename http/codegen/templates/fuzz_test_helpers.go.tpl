@@ -0,0 +1,35 @@
+// errFuzzNoMatch is returned by fuzzDecode when the data is not a request sent
+// to the fuzzed endpoint.
+var errFuzzNoMatch = errors.New("not a request sent to the endpoint")
+
+// fuzzDecode parses data as a HTTP request and decodes it with the request
+// decoder created by newDecoder if the request is routed to the endpoint
+// mounted by mount.
+func fuzzDecode(
+	data []byte,
+	mount func(goahttp.Muxer, http.Handler),
+	newDecoder func(goahttp.Muxer, func(*http.Request) goahttp.Decoder) func(*http.Request) (any, error),
+) (any, error) {
+	req, err := http.ReadRequest(bufio.NewReader(bytes.NewReader(data)))
+	if err != nil {
+		return nil, errFuzzNoMatch
+	}
+	var (
+		mux    = goahttp.NewMuxer()
+		decode = newDecoder(mux, goahttp.RequestDecoder)
+		p      any
+	)
+	err = errFuzzNoMatch
+	mount(mux, http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		p, err = decode(r)
+	}))
+	mux.ServeHTTP(httptest.NewRecorder(), req)
+	return p, err
+}
+{{- if hasPtr .Endpoints }}
+
+// ptr returns a pointer to v.
+func ptr[T any](v T) *T {
+	return &v
+}
+{{- end }}
@@ -0,0 +1,73 @@
+{{ comment "handleHTTPServer starts the mock HTTP server listening on addr and shuts it down when ctx is canceled." }}
+func handleHTTPServer(ctx context.Context, addr string{{ range .Services }}{{ if .Service.Methods }}, {{ .Service.VarName }}Endpoints *{{ .Service.PkgName }}.Endpoints{{ end }}{{ end }}, wg *sync.WaitGroup, errc chan error) {
+	var (
+		dec = goahttp.RequestDecoder
+		enc = goahttp.ResponseEncoder
+		mux = goahttp.NewMuxer()
+		eh  = func(ctx context.Context, w http.ResponseWriter, err error) {
+			log.Printf("ERROR: %v", err)
+		}
+	)
+{{- if needStream .Services }}
+	upgrader := &websocket.Upgrader{}
+{{- end }}
+
+	// Wrap the endpoints with the generated HTTP servers so that requests
+	// are decoded and validated as defined in the design. Multipart
+	// requests and file servers are not supported.
+	var (
+{{- range .Services }}
+		{{ .Service.VarName }}Server *{{ .Service.PkgName }}svr.Server
+{{- end }}
+	)
+	{
+{{- range $svc := .Services }}
+	{{- if .Endpoints }}
+		{{ .Service.VarName }}Server = {{ .Service.PkgName }}svr.New({{ .Service.VarName }}Endpoints, mux, dec, enc, eh, nil{{ if hasWebSocket $svc }}, upgrader, nil{{ end }}{{ range .Endpoints }}{{ if .MultipartRequestDecoder }}, func(*multipart.Reader, *{{ .MultipartRequestDecoder.Payload.Ref }}) error {
+			return goa.DecodePayloadError("multipart requests are not supported by the mock server")
+		}{{ end }}{{ end }}{{ range .FileServers }}, nil{{ end }})
+	{{- else }}
+		{{ .Service.VarName }}Server = {{ .Service.PkgName }}svr.New(nil, mux, dec, enc, eh, nil{{ range .FileServers }}, nil{{ end }})
+	{{- end }}
+{{- end }}
+	}
+{{- range .Services }}
+	{{ .Service.PkgName }}svr.Mount(mux, {{ .Service.VarName }}Server)
+{{- end }}
+{{- range .Services }}
+	for _, m := range {{ .Service.VarName }}Server.Mounts {
+		log.Printf("HTTP %q mounted on %s %s", m.Method, m.Verb, m.Pattern)
+	}
+{{- end }}
+
+	srv := &http.Server{Addr: addr, Handler: withMockHeaders(mux), ReadHeaderTimeout: time.Minute}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		// Start HTTP server in a separate goroutine.
+		go func() {
+			log.Printf("HTTP mock server listening on %q", addr)
+			errc <- srv.ListenAndServe()
+		}()
+
+		<-ctx.Done()
+		log.Printf("shutting down HTTP server at %q", addr)
+
+		// Shutdown gracefully with a 30s timeout.
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		if err := srv.Shutdown(ctx); err != nil {
+			log.Printf("failed to shutdown: %v", err)
+		}
+	}()
+}
+
+{{ comment "withMockHeaders returns a HTTP handler that records the names of the error and of the scenario requested with the X-Goa-Mock-Error and X-Goa-Mock-Scenario headers in the request context." }}
+func withMockHeaders(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := withMock(r.Context(), r.Header.Get("X-Goa-Mock-Error"), r.Header.Get("X-Goa-Mock-Scenario"))
+		h.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -51,6 +51,11 @@ func ParseEndpoint(
 				}
 				{{- end }}
 			{{- end }}
+			{{- if .AllEndpoint }}
+				if *{{ .FullName }}AllFlag {
+					endpoint = {{ .AllEndpoint }}(endpoint)
+				}
+			{{- end }}
 		{{- end }}
 			}
 	{{- end }}
@@ -18,7 +18,7 @@
     {{ .VarName }} := strconv.FormatFloat({{ if .IsAliased }}float64({{ end }}{{ .Target }}{{ if .IsAliased }}){{ end }}, 'f', -1, 64)
 	{{- else if eq .Type.Name "string" -}}
     {{ .VarName }} := {{ if .IsAliased }}string({{ end }}{{ .Target }}{{ if .IsAliased }}){{ end }}
-  {{- else if eq .Type.Name "bytes" -}}
+  {{- else if or (eq .Type.Name "bytes") (eq .Type.Name "decimal") (eq .Type.Name "bigint") -}}
     {{ .VarName }} := string({{ .Target }})
   {{- else if eq .Type.Name "any" -}}
     {{ .VarName }} := fmt.Sprintf("%v", {{ .Target }})
@@ -20,6 +20,8 @@
 		{{ .VarName }} := {{ .Target }}
 	{{- else if eq .Type.Name "bytes" -}}
 		{{ .VarName }} := string({{ .Target }})
+	{{- else if or (eq .Type.Name "decimal") (eq .Type.Name "bigint") -}}
+		{{ .VarName }} := string({{ if not .Required }}*{{ end }}{{ .Target }})
 	{{- else if eq .Type.Name "any" -}}
 		{{ .VarName }} := fmt.Sprintf("%v", {{ .Target }})
 	{{- else if eq .Type.Name "array" -}}
@@ -79,6 +79,17 @@
 			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .Name }}, {{ .VarName}}Raw, "boolean"))
 		}
 		{{ if and (ne .TypeRef nil) (and (ne .TypeRef "bool") (ne .TypeRef "*bool")) }}{{ .VarName }} = ({{.TypeRef}})({{ if .Pointer }}&{{ end }}v){{ else }}{{ .VarName }} = {{ if .Pointer }}&{{ end }}v{{ end }}
+	{{- else if or (eq .Type.Name "decimal") (eq .Type.Name "bigint") }}
+		v, err2 := goa.Parse{{ if eq .Type.Name "decimal" }}Decimal{{ else }}BigInt{{ end }}({{ .VarName }}Raw)
+		if err2 != nil {
+			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .Name }}, {{ .VarName}}Raw, "{{ if eq .Type.Name "decimal" }}decimal{{ else }}integer{{ end }}"))
+		}
+		{{- if .Pointer }}
+		pv := {{ slice .TypeRef 1 (len .TypeRef) }}(v)
+		{{ .VarName }} = &pv
+		{{- else }}
+		{{ .VarName }} = {{ .TypeRef }}(v)
+		{{- end }}
 	{{- else }}
 		// unsupported type {{ .Type.Name }} for var {{ .VarName }}
 	{{- end }}
\ No newline at end of file
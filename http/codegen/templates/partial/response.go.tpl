@@ -34,7 +34,9 @@
 	if res{{ if .FieldName }}.{{ end }}{{ if $.ViewedResult }}Projected.{{ end }}{{ if .FieldName }}{{ .FieldName }}{{ end }} != nil {
 		{{- end }}
 
-		{{- if and (eq .Type.Name "string") (not (isAliased .FieldType)) }}
+		{{- if .FieldTime }}
+	w.Header().Set("{{ .CanonicalName }}", {{ timeFormat .FieldTime (printf "res%s%s" (or (and $.ViewedResult ".Projected") "") (or (and .FieldName (printf ".%s" .FieldName)) "")) }})
+		{{- else if and (eq .Type.Name "string") (not (isAliased .FieldType)) }}
 	w.Header().Set("{{ .CanonicalName }}", {{ if or .FieldPointer $.ViewedResult }}*{{ end }}res{{ if $.ViewedResult }}.Projected{{ end }}{{ if .FieldName }}.{{ .FieldName }}{{ end }})
 		{{- else }}
 {{- if not $checkNil }}
@@ -71,7 +73,9 @@
 	if res.{{ if $.ViewedResult }}Projected.{{ end }}{{ .FieldName }} != nil {
 		{{- end }}
 
-		{{- if eq .Type.Name "string" }}
+		{{- if .FieldTime }}
+	{{ .VarName }} := {{ timeFormat .FieldTime (printf "res%s%s" (or (and $.ViewedResult ".Projected") "") (or (and .FieldName (printf ".%s" .FieldName)) "")) }}
+		{{- else if eq .Type.Name "string" }}
 	{{ .VarName }} := {{ if or .FieldPointer $.ViewedResult }}*{{ end }}res{{ if $.ViewedResult }}.Projected{{ end }}{{ if .FieldName }}.{{ .FieldName }}{{ end }}
 		{{- else }}
 			{{- if isAliased .FieldType }}
@@ -56,6 +56,12 @@
 				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .Name }}, {{ .VarName}}Raw, "array of booleans"))
 			}
 			{{ .VarName }}[i] = v
+		{{- else if or (eq .Type.ElemType.Type.Name "decimal") (eq .Type.ElemType.Type.Name "bigint") }}
+			v, err2 := goa.Parse{{ if eq .Type.ElemType.Type.Name "decimal" }}Decimal{{ else }}BigInt{{ end }}(rv)
+			if err2 != nil {
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .Name }}, {{ .VarName}}Raw, "array of {{ if eq .Type.ElemType.Type.Name "decimal" }}decimals{{ else }}integers{{ end }}"))
+			}
+			{{ .VarName }}[i] = {{ goTypeRef .Type.ElemType.Type }}(v)
 		{{- else if eq .Type.ElemType.Type.Name "any" }}
 			{{ .VarName }}[i] = rv
 		{{- else }}
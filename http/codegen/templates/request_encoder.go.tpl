@@ -20,7 +20,11 @@ func {{ .RequestEncoder }}(encoder func(*http.Request) goahttp.Encoder) func(*ht
 			{{- else }}
 			{
 			{{- end }}
+			{{- if .FieldTime }}
+			head := {{ timeFormat .FieldTime (printf "p.%s" .FieldName) }}
+			{{- else }}
 			head := {{ if .FieldPointer }}*{{ end }}p.{{ .FieldName }}
+			{{- end }}
 			{{- if (and (eq .HTTPName "Authorization") (isBearer $.HeaderSchemes)) }}
 		if !strings.Contains(head, " ") {
 			req.Header.Set({{ printf "%q" .HTTPName }}, "Bearer "+head)
@@ -58,7 +62,11 @@ func {{ .RequestEncoder }}(encoder func(*http.Request) goahttp.Encoder) func(*ht
 			{{- else }}
 			{
 			{{- end }}
+			{{- if .FieldTime }}
+			v := {{ timeFormat .FieldTime (printf "p.%s" .FieldName) }}
+			{{- else }}
 			v{{ if not (eq .Type.Name "string") }}raw{{ end }} := {{ if .FieldPointer }}*{{ end }}p.{{ .FieldName }}
+			{{- end }}
 			{{- if not (eq .Type.Name "string" ) }}
 			{{ template "partial_client_type_conversion" (typeConversionData .Type .FieldType "vraw" "v") }}
 			{{- end }}
@@ -119,6 +127,9 @@ func {{ .RequestEncoder }}(encoder func(*http.Request) goahttp.Encoder) func(*ht
 			{{- if .FieldPointer }}
 		if p.{{ .FieldName }} != nil {
 			{{- end }}
+			{{- if .FieldTime }}
+		values.Add("{{ .HTTPName }}", {{ timeFormat .FieldTime (printf "p.%s" .FieldName) }})
+			{{- else }}
 		values.Add("{{ .HTTPName }}",
 			{{- if or (eq .Type.Name "bytes") (and (isAlias .FieldType) (eq (underlyingType .FieldType).Name "string")) }} string(
 			{{- else if not (eq .Type.Name "string") }} fmt.Sprintf("%v",
@@ -126,6 +137,7 @@ func {{ .RequestEncoder }}(encoder func(*http.Request) goahttp.Encoder) func(*ht
 			{{- if .FieldPointer }}*{{ end }}p.{{ .FieldName }}
 			{{- if or (eq .Type.Name "bytes") (not (eq .Type.Name "string")) (and (isAlias .FieldType) (eq (underlyingType .FieldType).Name "string")) }})
 			{{- end }})
+			{{- end }}
 			{{- if .FieldPointer }}
 		}
 			{{- end }}
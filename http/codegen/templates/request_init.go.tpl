@@ -28,7 +28,9 @@
 		{{- if .Pointer }}
 		if p{{ if $.HasFields }}.{{ .FieldName }}{{ end }} != nil {
 		{{- end }}
-			{{- if (isAliased .FieldType) }}
+			{{- if .FieldTime }}
+			{{ .VarName }} = {{ timeFormat .FieldTime (printf "p%s" (or (and $.HasFields (printf ".%s" .FieldName)) "")) }}
+			{{- else if (isAliased .FieldType) }}
 			{{ .VarName }} = {{ goTypeRef .Type $.ServiceName }}({{ if .Pointer }}*{{ end }}p{{ if $.HasFields }}.{{ .FieldName }}{{ end }})
 			{{- else }}
 			{{ .VarName }} = {{ if .Pointer }}*{{ end }}p{{ if $.HasFields }}.{{ .FieldName }}{{ end }}
@@ -0,0 +1,44 @@
+{{ printf "%s configures the mux to serve the CORS preflight requests made to the %s service endpoints." .CORS.MountHandler .Service.Name | comment }}
+func {{ .CORS.MountHandler }}(mux goahttp.Muxer, h http.Handler) {
+	h = {{ .CORS.OriginHandler }}(h)
+	{{- range .CORS.Paths }}
+	mux.Handle("OPTIONS", "{{ . }}", h.ServeHTTP)
+	{{- end }}
+}
+
+{{ printf "%s creates a HTTP handler which returns a simple 204 response." .CORS.HandlerInit | comment }}
+func {{ .CORS.HandlerInit }}() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(204)
+	})
+}
+
+{{ printf "%s applies the CORS response headers corresponding to the origins allowed by the %s service." .CORS.OriginHandler .Service.Name | comment }}
+func {{ .CORS.OriginHandler }}(h http.Handler) http.Handler {
+	return goahttp.HandleCORS(h,
+	{{- range .CORS.Origins }}
+		&goahttp.CORSPolicy{
+		{{- if .Regexp }}
+			OriginRegexp: regexp.MustCompile({{ printf "%q" .Origin }}),
+		{{- else }}
+			Origin: {{ printf "%q" .Origin }},
+		{{- end }}
+		{{- if .Methods }}
+			Methods: []string{ {{ range $i, $m := .Methods }}{{ if $i }}, {{ end }}{{ printf "%q" $m }}{{ end }} },
+		{{- end }}
+		{{- if .Headers }}
+			Headers: []string{ {{ range $i, $h := .Headers }}{{ if $i }}, {{ end }}{{ printf "%q" $h }}{{ end }} },
+		{{- end }}
+		{{- if .Exposed }}
+			Exposed: []string{ {{ range $i, $h := .Exposed }}{{ if $i }}, {{ end }}{{ printf "%q" $h }}{{ end }} },
+		{{- end }}
+		{{- if .MaxAge }}
+			MaxAge: {{ .MaxAge }},
+		{{- end }}
+		{{- if .Credentials }}
+			Credentials: true,
+		{{- end }}
+		},
+	{{- end }}
+	)
+}
@@ -1,5 +1,8 @@
 {{ printf "%s configures the mux to serve the %q service %q endpoint." .MountHandler .ServiceName .Method.Name | comment }}
 func {{ .MountHandler }}(mux goahttp.Muxer, h http.Handler) {
+	{{- if .OriginHandler }}
+	h = {{ .OriginHandler }}(h)
+	{{- end }}
 	f, ok := h.(http.HandlerFunc)
 	if !ok {
 		f = func(w http.ResponseWriter, r *http.Request) {
@@ -1,4 +1,4 @@
-{{ printf "%s instantiates HTTP handlers for all the %s service endpoints using the provided encoder and decoder. The handlers are mounted on the given mux using the HTTP verb and path defined in the design. errhandler is called whenever a response fails to be encoded. formatter is used to format errors returned by the service methods prior to encoding. Both errhandler and formatter are optional and can be nil." .ServerInit .Service.Name | comment }}
+{{ printf "%s instantiates HTTP handlers for all the %s service endpoints using the provided encoder and decoder. The handlers are mounted on the given mux using the HTTP verb and path defined in the design. errhandler is called whenever a response fails to be encoded. formatter is used to format errors returned by the service methods prior to encoding. Both errhandler and formatter are optional and can be nil.%s" .ServerInit .Service.Name (or (and .Compression " The handlers compress the response bodies and decompress the request bodies using gzip or deflate.") "") | comment }}
 func {{ .ServerInit }}(
 	e *{{ .Service.PkgName }}.Endpoints,
 	mux goahttp.Muxer,
@@ -29,7 +29,7 @@ func {{ .ServerInit }}(
 		{{ .ArgName }} = http.Dir(".")
 	}
 	{{- end }}
-	return &{{ .ServerStruct }}{
+	{{ if .Compression }}s :={{ else }}return{{ end }} &{{ .ServerStruct }}{
 		Mounts: []*{{ .MountPointStruct }}{
 			{{- range $e := .Endpoints }}
 				{{- range $e.Routes }}
@@ -42,6 +42,11 @@ func {{ .ServerInit }}(
 			{"{{ $filepath }}", "GET", "{{ . }}"},
 				{{- end }}
 			{{- end }}
+			{{- if .CORS }}
+				{{- range .CORS.Paths }}
+			{"{{ $.CORS.VarName }}", "OPTIONS", "{{ . }}"},
+				{{- end }}
+			{{- end }}
 		},
 		{{- range .Endpoints }}
 		{{ .Method.VarName }}: {{ .HandlerInit }}(e.{{ .Method.VarName }}, mux, {{ if .MultipartRequestDecoder }}{{ .MultipartRequestDecoder.InitName }}(mux, {{ .MultipartRequestDecoder.VarName }}){{ else }}decoder{{ end }}, encoder, errhandler, formatter{{ if isWebSocketEndpoint . }}, upgrader, configurer.{{ .Method.VarName }}Fn{{ end }}),
@@ -49,5 +54,12 @@ func {{ .ServerInit }}(
 		{{- range .FileServers }}
 		{{ .VarName }}: http.FileServer({{ .ArgName }}),
 		{{- end }}
+		{{- if .CORS }}
+		{{ .CORS.VarName }}: {{ .CORS.HandlerInit }}(),
+		{{- end }}
 	}
+{{- if .Compression }}
+	s.Use(goahttp.Compress())
+	return s
+{{- end }}
 }
@@ -5,3 +5,5 @@
 		handler = debug.HTTP()(handler)
 	}
 	handler = log.HTTP(ctx)(handler)
+	// Compress responses and decompress requests using gzip or deflate.
+	handler = goahttp.Compress()(handler)
@@ -16,6 +16,9 @@ func {{ .MountServer }}(mux goahttp.Muxer, h *{{ .ServerStruct }}) {
 	{{ .MountHandler }}(mux, {{ range .RequestPaths }}{{if ne . $filepath }}goahttp.Replace("", "{{ $filepath }}", {{ end }}{{ end }}h.{{ .VarName }}){{ range .RequestPaths }}{{ if ne . $filepath }}){{ end}}{{ end }}
 		{{- end }}
 	{{- end }}
+	{{- if .CORS }}
+	{{ .CORS.MountHandler }}(mux, h.{{ .CORS.VarName }})
+	{{- end }}
 }
 
 {{ printf "%s configures the mux to serve the %s endpoints." .MountServer .Service.Name | comment }}
@@ -7,4 +7,7 @@ type {{ .ServerStruct }} struct {
 	{{- range .FileServers }}
 	{{ .VarName }} http.Handler
 	{{- end }}
+	{{- if .CORS }}
+	{{ .CORS.VarName }} http.Handler
+	{{- end }}
 }
@@ -3,4 +3,7 @@ func (s *{{ .ServerStruct }}) Use(m func(http.Handler) http.Handler) {
 {{- range .Endpoints }}
 	s.{{ .Method.VarName }} = m(s.{{ .Method.VarName }})
 {{- end }}
+{{- if .CORS }}
+	s.{{ .CORS.VarName }} = m(s.{{ .CORS.VarName }})
+{{- end }}
 }
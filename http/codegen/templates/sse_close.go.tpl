@@ -0,0 +1,4 @@
+{{ printf "Close ends the %q endpoint server-sent events stream. It makes sure the response headers are written so that clients receive an empty stream if no event was sent." .Endpoint.Method.Name | comment }}
+func (s *{{ .VarName }}) Close() error {
+	return s.writer.Open()
+}
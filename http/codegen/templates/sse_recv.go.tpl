@@ -0,0 +1,79 @@
+{{ comment .RecvDesc }}
+func (s *{{ .VarName }}) {{ .RecvName }}() ({{ .RecvTypeRef }}, error) {
+	var rv {{ .RecvTypeRef }}
+	ev, err := s.reader.Next()
+	if err != nil {
+		s.body.Close()
+		return rv, err
+	}
+{{- if .Data }}
+	res := &{{ .RecvTypeName }}{}
+	{{- if .Data.IsString }}
+		{{- if .Data.Pointer }}
+	data := string(ev.Data)
+	res.{{ .Data.FieldName }} = &data
+		{{- else }}
+	res.{{ .Data.FieldName }} = string(ev.Data)
+		{{- end }}
+	{{- else }}
+	if err := json.Unmarshal(ev.Data, &res.{{ .Data.FieldName }}); err != nil {
+		return rv, goahttp.ErrDecodingError({{ printf "%q" .Endpoint.ServiceName }}, {{ printf "%q" .Endpoint.Method.Name }}, err)
+	}
+	{{- end }}
+	{{- with .ID }}
+	if ev.ID != "" {
+		{{- if .Pointer }}
+		res.{{ .FieldName }} = &ev.ID
+		{{- else }}
+		res.{{ .FieldName }} = ev.ID
+		{{- end }}
+	}
+	{{- end }}
+	{{- with .Event }}
+	if ev.Event != "" {
+		{{- if .Pointer }}
+		res.{{ .FieldName }} = &ev.Event
+		{{- else }}
+		res.{{ .FieldName }} = ev.Event
+		{{- end }}
+	}
+	{{- end }}
+	{{- with .Retry }}
+	if ev.Retry > 0 {
+		retry := {{ .TypeRef }}(ev.Retry)
+		{{- if .Pointer }}
+		res.{{ .FieldName }} = &retry
+		{{- else }}
+		res.{{ .FieldName }} = retry
+		{{- end }}
+	}
+	{{- end }}
+	return res, nil
+{{- else }}
+	var body {{ .Response.ClientBody.VarName }}
+	if err := json.Unmarshal(ev.Data, &body); err != nil {
+		return rv, goahttp.ErrDecodingError({{ printf "%q" .Endpoint.ServiceName }}, {{ printf "%q" .Endpoint.Method.Name }}, err)
+	}
+	{{- if and .Response.ClientBody.ValidateRef (not .Endpoint.Method.ViewedResult) }}
+	{{ .Response.ClientBody.ValidateRef }}
+	if err != nil {
+		return rv, goahttp.ErrValidationError({{ printf "%q" .Endpoint.ServiceName }}, {{ printf "%q" .Endpoint.Method.Name }}, err)
+	}
+	{{- end }}
+	{{- if .Response.ResultInit }}
+	res := {{ .Response.ResultInit.Name }}({{ range .Response.ResultInit.ClientArgs }}{{ .Ref }},{{ end }})
+		{{- if .Endpoint.Method.ViewedResult }}{{ with .Endpoint.Method.ViewedResult }}
+	vres := {{ if not .IsCollection }}&{{ end }}{{ .ViewsPkg }}.{{ .VarName }}{res, {{ printf "%q" .ViewName }} }
+	if err := {{ .ViewsPkg }}.Validate{{ $.Endpoint.Method.Result }}(vres); err != nil {
+		return rv, goahttp.ErrValidationError({{ printf "%q" $.Endpoint.ServiceName }}, {{ printf "%q" $.Endpoint.Method.Name }}, err)
+	}
+	return {{ $.PkgName }}.{{ .ResultInit.Name }}(vres), nil
+		{{- end }}
+		{{- else }}
+	return res, nil
+		{{- end }}
+	{{- else }}
+	return body, nil
+	{{- end }}
+{{- end }}
+}
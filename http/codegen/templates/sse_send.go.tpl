@@ -0,0 +1,70 @@
+{{ comment .SendDesc }}
+func (s *{{ .VarName }}) {{ .SendName }}(v {{ .SendTypeRef }}) error {
+{{- if .Data }}
+	{{- if .Data.IsString }}
+	var data []byte
+		{{- if .Data.Pointer }}
+	if v.{{ .Data.FieldName }} != nil {
+		data = []byte(*v.{{ .Data.FieldName }})
+	}
+		{{- else }}
+	data = []byte(v.{{ .Data.FieldName }})
+		{{- end }}
+	{{- else }}
+	data, err := json.Marshal(v.{{ .Data.FieldName }})
+	if err != nil {
+		return err
+	}
+	{{- end }}
+{{- else }}
+	{{- if .Endpoint.Method.ViewedResult }}
+	res := {{ .PkgName }}.{{ .Endpoint.Method.ViewedResult.Init.Name }}(v, {{ printf "%q" .Endpoint.Method.ViewedResult.ViewName }})
+	{{- else }}
+	res := v
+	{{- end }}
+	{{- $servBodyLen := len .Response.ServerBody }}
+	{{- if and (gt $servBodyLen 0) (index .Response.ServerBody 0).Init }}
+		{{- if .Endpoint.Method.ViewedResult }}
+			{{- $vsb := (viewedServerBody $.Response.ServerBody .Endpoint.Method.ViewedResult.ViewName) }}
+	body := {{ $vsb.Init.Name }}({{ range $vsb.Init.ServerArgs }}{{ .Ref }}, {{ end }})
+		{{- else }}
+	body := {{ (index .Response.ServerBody 0).Init.Name }}({{ range (index .Response.ServerBody 0).Init.ServerArgs }}{{ .Ref }}, {{ end }})
+		{{- end }}
+	{{- else }}
+	body := res
+	{{- end }}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+{{- end }}
+	ev := &goahttp.ServerSentEvent{Data: data}
+{{- with .ID }}
+	{{- if .Pointer }}
+	if v.{{ .FieldName }} != nil {
+		ev.ID = *v.{{ .FieldName }}
+	}
+	{{- else }}
+	ev.ID = v.{{ .FieldName }}
+	{{- end }}
+{{- end }}
+{{- with .Event }}
+	{{- if .Pointer }}
+	if v.{{ .FieldName }} != nil {
+		ev.Event = *v.{{ .FieldName }}
+	}
+	{{- else }}
+	ev.Event = v.{{ .FieldName }}
+	{{- end }}
+{{- end }}
+{{- with .Retry }}
+	{{- if .Pointer }}
+	if v.{{ .FieldName }} != nil {
+		ev.Retry = int(*v.{{ .FieldName }})
+	}
+	{{- else }}
+	ev.Retry = int(v.{{ .FieldName }})
+	{{- end }}
+{{- end }}
+	return s.writer.Write(ev)
+}
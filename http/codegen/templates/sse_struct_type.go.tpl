@@ -0,0 +1,12 @@
+{{ printf "%s implements the %s interface using server-sent events." .VarName .Interface | comment }}
+type {{ .VarName }} struct {
+{{- if eq .Type "server" }}
+	{{ comment "writer is the server-sent events writer." }}
+	writer *goahttp.ServerSentEventWriter
+{{- else }}
+	{{ comment "reader is the server-sent events reader." }}
+	reader *goahttp.ServerSentEventReader
+	{{ comment "body is the HTTP response body closed once the stream ends." }}
+	body io.ReadCloser
+{{- end }}
+}
@@ -51,3 +51,26 @@ func NewClient(
 }
 `
 )
+
+const CompressionClientInitCode = `// NewClient instantiates HTTP clients for all the ServiceCompression service
+// servers. The clients decompress the response bodies encoded with gzip or
+// deflate.
+func NewClient(
+	scheme string,
+	host string,
+	doer goahttp.Doer,
+	enc func(*http.Request) goahttp.Encoder,
+	dec func(*http.Response) goahttp.Decoder,
+	restoreBody bool,
+) *Client {
+	doer = goahttp.NewCompressionDoer(doer)
+	return &Client{
+		MethodCompressionDoer: doer,
+		RestoreResponseBody:   restoreBody,
+		scheme:                scheme,
+		host:                  host,
+		decoder:               dec,
+		encoder:               enc,
+	}
+}
+`
@@ -0,0 +1,192 @@
+package testdata
+
+var CORSServiceServerStructCode = `// Server lists the CORSService service endpoint HTTP handlers.
+type Server struct {
+	Mounts           []*MountPoint
+	Method           http.Handler
+	WwwData          http.Handler
+	WwwDataIndexHTML http.Handler
+	CORS             http.Handler
+}
+`
+
+var CORSServiceServerInitCode = `// New instantiates HTTP handlers for all the CORSService service endpoints
+// using the provided encoder and decoder. The handlers are mounted on the
+// given mux using the HTTP verb and path defined in the design. errhandler is
+// called whenever a response fails to be encoded. formatter is used to format
+// errors returned by the service methods prior to encoding. Both errhandler
+// and formatter are optional and can be nil.
+func New(
+	e *corsservice.Endpoints,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+	fileSystemWwwData http.FileSystem,
+	fileSystemWwwDataIndexHTML http.FileSystem,
+) *Server {
+	if fileSystemWwwData == nil {
+		fileSystemWwwData = http.Dir(".")
+	}
+	if fileSystemWwwDataIndexHTML == nil {
+		fileSystemWwwDataIndexHTML = http.Dir(".")
+	}
+	return &Server{
+		Mounts: []*MountPoint{
+			{"Method", "GET", "/{id}"},
+			{"Method", "POST", "/{id}"},
+			{"Method", "PUT", "/{id}/update"},
+			{"/www/data", "GET", "/static"},
+			{"/www/data/index.html", "GET", "/index.html"},
+			{"CORS", "OPTIONS", "/{id}"},
+			{"CORS", "OPTIONS", "/{id}/update"},
+			{"CORS", "OPTIONS", "/static/"},
+			{"CORS", "OPTIONS", "/static/{*path}"},
+			{"CORS", "OPTIONS", "/index.html"},
+		},
+		Method:           NewMethodHandler(e.Method, mux, decoder, encoder, errhandler, formatter),
+		WwwData:          http.FileServer(fileSystemWwwData),
+		WwwDataIndexHTML: http.FileServer(fileSystemWwwDataIndexHTML),
+		CORS:             NewCORSHandler(),
+	}
+}
+`
+
+var CORSServiceServerUseCode = `// Use wraps the server handlers with the given middleware.
+func (s *Server) Use(m func(http.Handler) http.Handler) {
+	s.Method = m(s.Method)
+	s.CORS = m(s.CORS)
+}
+`
+
+var CORSServiceServerMountCode = `// Mount configures the mux to serve the CORSService endpoints.
+func Mount(mux goahttp.Muxer, h *Server) {
+	MountMethodHandler(mux, h.Method)
+	MountWwwData(mux, goahttp.Replace("/static", "/www/data", h.WwwData))
+	MountWwwDataIndexHTML(mux, goahttp.Replace("", "/www/data/", h.WwwDataIndexHTML))
+	MountCORSHandler(mux, h.CORS)
+}
+
+// Mount configures the mux to serve the CORSService endpoints.
+func (s *Server) Mount(mux goahttp.Muxer) {
+	Mount(mux, s)
+}
+`
+
+var CORSServiceServerHandlerCode = `// MountMethodHandler configures the mux to serve the "CORSService" service
+// "Method" endpoint.
+func MountMethodHandler(mux goahttp.Muxer, h http.Handler) {
+	h = HandleCORSServiceOrigin(h)
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/{id}", f)
+	mux.Handle("POST", "/{id}", f)
+	mux.Handle("PUT", "/{id}/update", f)
+}
+`
+
+var CORSServiceServerFilesCode = `// MountWwwData configures the mux to serve GET request made to "/static".
+func MountWwwData(mux goahttp.Muxer, h http.Handler) {
+	h = HandleCORSServiceOrigin(h)
+	mux.Handle("GET", "/static/", h.ServeHTTP)
+	mux.Handle("GET", "/static/{*path}", h.ServeHTTP)
+}
+`
+
+var CORSServiceServerCORSCode = `// MountCORSHandler configures the mux to serve the CORS preflight requests
+// made to the CORSService service endpoints.
+func MountCORSHandler(mux goahttp.Muxer, h http.Handler) {
+	h = HandleCORSServiceOrigin(h)
+	mux.Handle("OPTIONS", "/{id}", h.ServeHTTP)
+	mux.Handle("OPTIONS", "/{id}/update", h.ServeHTTP)
+	mux.Handle("OPTIONS", "/static/", h.ServeHTTP)
+	mux.Handle("OPTIONS", "/static/{*path}", h.ServeHTTP)
+	mux.Handle("OPTIONS", "/index.html", h.ServeHTTP)
+}
+
+// NewCORSHandler creates a HTTP handler which returns a simple 204 response.
+func NewCORSHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(204)
+	})
+}
+
+// HandleCORSServiceOrigin applies the CORS response headers corresponding to
+// the origins allowed by the CORSService service.
+func HandleCORSServiceOrigin(h http.Handler) http.Handler {
+	return goahttp.HandleCORS(h,
+		&goahttp.CORSPolicy{
+			Origin:      "http://localhost",
+			Methods:     []string{"GET", "POST"},
+			Headers:     []string{"X-Shared-Secret"},
+			Exposed:     []string{"X-Time"},
+			MaxAge:      600,
+			Credentials: true,
+		},
+		&goahttp.CORSPolicy{
+			OriginRegexp: regexp.MustCompile("(api|swagger)[.]goa[.]design"),
+		},
+	)
+}
+`
+
+var CORSAPIServerInitCode = `// New instantiates HTTP handlers for all the CORSAPIService service endpoints
+// using the provided encoder and decoder. The handlers are mounted on the
+// given mux using the HTTP verb and path defined in the design. errhandler is
+// called whenever a response fails to be encoded. formatter is used to format
+// errors returned by the service methods prior to encoding. Both errhandler
+// and formatter are optional and can be nil.
+func New(
+	e *corsapiservice.Endpoints,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) *Server {
+	return &Server{
+		Mounts: []*MountPoint{
+			{"Method", "GET", "/"},
+			{"Options", "OPTIONS", "/options"},
+			{"CORS", "OPTIONS", "/"},
+		},
+		Method:  NewMethodHandler(e.Method, mux, decoder, encoder, errhandler, formatter),
+		Options: NewOptionsHandler(e.Options, mux, decoder, encoder, errhandler, formatter),
+		CORS:    NewCORSHandler(),
+	}
+}
+`
+
+var CORSAPIServerCORSCode = `// MountCORSHandler configures the mux to serve the CORS preflight requests
+// made to the CORSAPIService service endpoints.
+func MountCORSHandler(mux goahttp.Muxer, h http.Handler) {
+	h = HandleCORSAPIServiceOrigin(h)
+	mux.Handle("OPTIONS", "/", h.ServeHTTP)
+}
+
+// NewCORSHandler creates a HTTP handler which returns a simple 204 response.
+func NewCORSHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(204)
+	})
+}
+
+// HandleCORSAPIServiceOrigin applies the CORS response headers corresponding
+// to the origins allowed by the CORSAPIService service.
+func HandleCORSAPIServiceOrigin(h http.Handler) http.Handler {
+	return goahttp.HandleCORS(h,
+		&goahttp.CORSPolicy{
+			Origin:  "https://*.goa.design",
+			Methods: []string{"GET"},
+		},
+		&goahttp.CORSPolicy{
+			Origin: "*",
+		},
+	)
+}
+`
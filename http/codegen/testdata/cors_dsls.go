@@ -0,0 +1,55 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var CORSServiceDSL = func() {
+	Service("CORSService", func() {
+		HTTP(func() {
+			Origin("http://localhost", func() {
+				AllowMethods("GET", "POST")
+				AllowHeaders("X-Shared-Secret")
+				ExposeHeaders("X-Time")
+				MaxAge(600)
+				AllowCredentials()
+			})
+			Origin("/(api|swagger)[.]goa[.]design/")
+		})
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("id", String)
+			})
+			HTTP(func() {
+				GET("/{id}")
+				POST("/{id}")
+				PUT("/{id}/update")
+			})
+		})
+		Files("/static/{*path}", "/www/data")
+		Files("/index.html", "/www/data/index.html")
+	})
+}
+
+var CORSAPIDSL = func() {
+	API("CORSAPI", func() {
+		Origin("*")
+	})
+	Service("CORSAPIService", func() {
+		HTTP(func() {
+			Origin("https://*.goa.design", func() {
+				AllowMethods("GET")
+			})
+		})
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+		Method("Options", func() {
+			HTTP(func() {
+				OPTIONS("/options")
+			})
+		})
+	})
+}
@@ -308,3 +308,46 @@ func NewMethodSkipResponseBodyEncodeDecodeHandler(
 	})
 }
 `
+
+var ServerPaginatedHandlerConstructorCode = `// NewMethodPaginatedHandler creates a HTTP handler which loads the HTTP
+// request and calls the "ServicePaginated" service "MethodPaginated" endpoint.
+func NewMethodPaginatedHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeMethodPaginatedRequest(mux, decoder)
+		encodeResponse = EncodeMethodPaginatedResponse(encoder)
+		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodPaginated")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServicePaginated")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if pres, ok := res.(*servicepaginated.MethodPaginatedResult); ok && pres.NextCursor != nil && *pres.NextCursor != "" {
+			w.Header().Set("Link", goahttp.NextPageLink(r, "page", *pres.NextCursor))
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+`
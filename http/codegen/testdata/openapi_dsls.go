@@ -522,6 +522,99 @@ var WithMapDSL = func() {
 	})
 }
 
+var DiscriminatedUnionDSL = func() {
+	var Card = Type("Card", func() {
+		Attribute("number", String, func() {
+			Example("4111111111111111")
+		})
+		Required("number")
+	})
+	var Bank = Type("Bank", func() {
+		Attribute("iban", String, func() {
+			Example("DE89370400440532013000")
+		})
+		Required("iban")
+	})
+	Service("test service", func() {
+		Method("test endpoint", func() {
+			Payload(func() {
+				OneOf("method", func() {
+					Discriminator("kind")
+					Attribute("card", Card)
+					Attribute("bank", Bank)
+				})
+			})
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
+var NullableDSL = func() {
+	Service("test service", func() {
+		Method("test endpoint", func() {
+			Payload(func() {
+				Attribute("id", Int)
+				Attribute("nickname", String, func() {
+					Nullable()
+					Example("goa")
+				})
+				Attribute("age", Int, func() {
+					Nullable()
+					Example(42)
+				})
+				Required("id")
+			})
+			HTTP(func() {
+				PATCH("/{id}")
+			})
+		})
+	})
+}
+
+var ValidationRulesDSL = func() {
+	Service("test service", func() {
+		Method("test endpoint", func() {
+			Payload(func() {
+				Attribute("email", String, func() {
+					Example("user@example.com")
+				})
+				Attribute("phone", String, func() {
+					Example("+15551234567")
+				})
+				Attribute("start", String, func() {
+					Format(FormatDate)
+					Example("2024-01-01")
+				})
+				Attribute("end", String, func() {
+					Format(FormatDate)
+					Example("2024-01-31")
+				})
+				Attribute("status", String, func() {
+					Enum("approved", "rejected")
+					Example("approved")
+				})
+				Attribute("reason", String, func() {
+					Example("duplicate")
+				})
+				ExactlyOneOf("email", "phone")
+				After("end", "start")
+				RequiredIf("status", "rejected", "reason")
+				Example(map[string]any{
+					"email":  "user@example.com",
+					"start":  "2024-01-01",
+					"end":    "2024-01-31",
+					"status": "approved",
+				})
+			})
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
 var PathWithWildcardDSL = func() {
 	Service("test service", func() {
 		Method("test endpoint", func() {
@@ -911,3 +1004,59 @@ var JSONPrefixIndentDSL = func() {
 		})
 	})
 }
+
+var DecimalDSL = func() {
+	Service("test service", func() {
+		Method("test endpoint", func() {
+			Payload(func() {
+				Attribute("price", Decimal, func() {
+					Minimum(0.01)
+				})
+				Attribute("amount", Decimal, func() {
+					Meta("struct:field:encoding", "string")
+				})
+				Attribute("count", BigInt)
+			})
+			Result(Decimal)
+			HTTP(func() {
+				POST("/")
+				Param("count")
+			})
+		})
+	})
+}
+
+var ScenarioDSL = func() {
+	Service("test service", func() {
+		Error("not_found")
+		Method("test endpoint", func() {
+			Payload(func() {
+				Attribute("id", Int)
+				Attribute("version", String)
+				Attribute("name", String)
+				Required("id")
+			})
+			Result(func() {
+				Attribute("name", String)
+				Attribute("etag", String)
+			})
+			Scenario("existing", func() {
+				Description("Retrieve an existing resource")
+				Payload(Val{"id": 1, "version": "v1", "name": "foo"})
+				Result(Val{"name": "foo", "etag": "abc"})
+			})
+			Scenario("missing", func() {
+				Payload(Val{"id": 42, "name": "bar"})
+				Error("not_found", Val{"message": "resource 42 not found"})
+			})
+			HTTP(func() {
+				POST("/{id}")
+				Header("version:X-Version")
+				Response(StatusOK, func() {
+					Header("etag:ETag")
+				})
+				Response("not_found", StatusNotFound)
+			})
+		})
+	})
+}
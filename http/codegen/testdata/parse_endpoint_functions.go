@@ -1359,6 +1359,39 @@ func BuildMethodQueryStringValidatePayload(serviceQueryStringValidateMethodQuery
 }
 `
 
+var QueryTypedEnumBuildCode = `// BuildMethodQueryTypedEnumPayload builds the payload for the
+// ServiceQueryTypedEnum MethodQueryTypedEnum endpoint from CLI flags.
+func BuildMethodQueryTypedEnumPayload(serviceQueryTypedEnumMethodQueryTypedEnumColor string, serviceQueryTypedEnumMethodQueryTypedEnumPriority string) (*servicequerytypedenum.MethodQueryTypedEnumPayload, error) {
+	var err error
+	var color servicequerytypedenum.Color
+	{
+		color, err = servicequerytypedenum.ParseColor(serviceQueryTypedEnumMethodQueryTypedEnumColor)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var priority *servicequerytypedenum.Priority
+	{
+		if serviceQueryTypedEnumMethodQueryTypedEnumPriority != "" {
+			var val servicequerytypedenum.Priority
+			val, err = servicequerytypedenum.ParsePriority(serviceQueryTypedEnumMethodQueryTypedEnumPriority)
+			priority = &val
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	v := &servicequerytypedenum.MethodQueryTypedEnumPayload{}
+	v.Color = servicequerytypedenum.Color(color)
+	if priority != nil {
+		tmppriority := servicequerytypedenum.Priority(*priority)
+		v.Priority = &tmppriority
+	}
+
+	return v, nil
+}
+`
+
 var QueryStringDefaultBuildCode = `// BuildMethodQueryStringDefaultPayload builds the payload for the
 // ServiceQueryStringDefault MethodQueryStringDefault endpoint from CLI flags.
 func BuildMethodQueryStringDefaultPayload(serviceQueryStringDefaultMethodQueryStringDefaultQ string) (*servicequerystringdefault.MethodQueryStringDefaultPayload, error) {
@@ -1543,3 +1576,12 @@ func BuildMethodCookieCustomNamePayload(serviceCookieCustomNameMethodCookieCusto
 	return v, nil
 }
 `
+
+var ScenarioUsageExamplesCode = `// UsageExamples produces an example of a valid invocation of the CLI tool.
+func UsageExamples() string {
+	return os.Args[0] + ` + "`" + ` test-service test-endpoint --body '{
+      "name": "foo"
+   }' --id 1 --version "v1"` + "`" + ` + "\n" +
+		""
+}
+`
@@ -320,6 +320,31 @@ var PayloadQueryStringValidateDSL = func() {
 	})
 }
 
+var PayloadQueryTypedEnumDSL = func() {
+	var Color = Type("Color", String, func() {
+		Enum("red", "green")
+		Meta("type:enum")
+	})
+	var Priority = Type("Priority", Int, func() {
+		Enum(1, 2)
+		Meta("type:enum")
+	})
+	Service("ServiceQueryTypedEnum", func() {
+		Method("MethodQueryTypedEnum", func() {
+			Payload(func() {
+				Attribute("color", Color)
+				Attribute("priority", Priority)
+				Required("color")
+			})
+			HTTP(func() {
+				GET("/")
+				Param("color")
+				Param("priority")
+			})
+		})
+	})
+}
+
 var PayloadQueryStringNotRequiredValidateDSL = func() {
 	Service("ServiceQueryStringNotRequiredValidate", func() {
 		Method("MethodQueryStringNotRequiredValidate", func() {
@@ -3583,3 +3608,76 @@ var PayloadCookieCustomNameDSL = func() {
 		})
 	})
 }
+
+var PayloadTimeDSL = func() {
+	Service("ServiceTime", func() {
+		Method("MethodTime", func() {
+			Payload(func() {
+				Attribute("since", String, func() {
+					Format(FormatDateTime)
+					Meta("struct:field:time")
+				})
+				Attribute("until", String, func() {
+					Format(FormatDate)
+					Meta("struct:field:time")
+				})
+				Attribute("timeout", String, func() {
+					Format(FormatDuration)
+					Meta("struct:field:time")
+				})
+				Required("since")
+			})
+			HTTP(func() {
+				POST("/{since}")
+				Header("timeout:X-Timeout")
+			})
+		})
+	})
+}
+
+var PayloadNullableDSL = func() {
+	Service("ServiceNullable", func() {
+		Method("MethodNullable", func() {
+			Payload(func() {
+				Attribute("id", Int)
+				Attribute("nickname", String, func() {
+					Nullable()
+					MinLength(2)
+				})
+				Attribute("age", Int, func() {
+					Nullable()
+				})
+				Required("id")
+			})
+			HTTP(func() {
+				PATCH("/{id}")
+			})
+		})
+	})
+}
+
+var PayloadDiscriminatedUnionDSL = func() {
+	var Bank = Type("Bank", func() {
+		Attribute("iban", String)
+		Required("iban")
+	})
+	var Card = Type("Card", func() {
+		Attribute("number", String)
+		Required("number")
+	})
+	Service("ServiceDiscriminatedUnion", func() {
+		Method("MethodDiscriminatedUnion", func() {
+			Payload(func() {
+				OneOf("payment", func() {
+					Discriminator("kind")
+					Attribute("bank", Bank)
+					Attribute("card", Card)
+				})
+				Required("payment")
+			})
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
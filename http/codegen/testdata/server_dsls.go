@@ -144,6 +144,22 @@ var ServerMultiEndpointsDSL = func() {
 	})
 }
 
+var ServerCompressionDSL = func() {
+	API("CompressionAPI", func() {
+		Meta("http:compression")
+	})
+	Service("ServiceCompression", func() {
+		Method("MethodCompression", func() {
+			Payload(func() {
+				Attribute("id", String)
+			})
+			HTTP(func() {
+				GET("/{id}")
+			})
+		})
+	})
+}
+
 var ServerFileServerDSL = func() {
 	Service("ServiceFileServer", func() {
 		HTTP(func() {
@@ -282,3 +298,269 @@ var ServerSkipResponseBodyEncodeDecodeDSL = func() {
 		})
 	})
 }
+
+var ServerPaginatedDSL = func() {
+	Service("ServicePaginated", func() {
+		Method("MethodPaginated", func() {
+			Payload(func() {
+				Attribute("cursor", String)
+			})
+			Result(func() {
+				Attribute("items", ArrayOf(String))
+				Attribute("next_cursor", String)
+			})
+			Paginated()
+			HTTP(func() {
+				GET("/")
+				Param("cursor:page")
+			})
+		})
+	})
+}
+
+var ServerContractDSL = func() {
+	API("ContractAPI", func() {
+		Meta("test:contract")
+	})
+	var ContractResult = ResultType("application/vnd.contract.result", func() {
+		TypeName("ContractResult")
+		Attributes(func() {
+			Attribute("id", Int)
+			Attribute("name", String)
+			Required("id")
+		})
+		View("default", func() {
+			Attribute("id")
+			Attribute("name")
+		})
+		View("tiny", func() {
+			Attribute("id")
+		})
+	})
+	Service("ServiceContract", func() {
+		Method("MethodContract", func() {
+			Payload(func() {
+				Attribute("id", Int)
+				Attribute("filter", String)
+				Attribute("auth", String)
+				Required("id", "auth")
+			})
+			Result(ContractResult)
+			Error("not_found")
+			HTTP(func() {
+				GET("/{id}")
+				Param("filter")
+				Header("auth:Authorization")
+				Response(StatusOK)
+				Response("not_found", StatusNotFound)
+			})
+		})
+		Method("MethodContractNoPayload", func() {
+			Result(func() {
+				Attribute("count", Int)
+				Required("count")
+			})
+			HTTP(func() {
+				POST("/")
+				Response(StatusCreated, func() {
+					Header("count:X-Count")
+				})
+			})
+		})
+		Method("MethodContractStreaming", func() {
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/stream")
+			})
+		})
+	})
+}
+
+var ServerContractRulesDSL = func() {
+	API("ContractAPI", func() {
+		Meta("test:contract")
+	})
+	var Color = Type("Color", String, func() {
+		Enum("red", "green", "blue")
+	})
+	var Phone = CustomFormat("phone", func() {
+		Validator("example.com/formats", "ValidatePhone")
+		Example("+15551234567")
+	})
+	var Contact = Type("Contact", func() {
+		Attribute("email", String, func() {
+			Format(FormatEmail)
+		})
+		Attribute("sms", String)
+		Attribute("color", Color)
+		Attribute("start", String, func() {
+			Format(FormatDate)
+		})
+		Attribute("end", String, func() {
+			Format(FormatDate)
+		})
+		Attribute("status", String, func() {
+			Enum("ok", "rejected")
+		})
+		Attribute("reason", String)
+		Attribute("phone", String, func() {
+			Format(Phone)
+		})
+		Required("color")
+		ExactlyOneOf("email", "sms")
+		After("end", "start")
+		RequiredIf("status", "rejected", "reason")
+	})
+	Service("ServiceContract", func() {
+		Method("MethodContractEnum", func() {
+			Payload(func() {
+				Attribute("id", Int)
+				Attribute("color", Color)
+				Required("id", "color")
+			})
+			Result(Contact)
+			HTTP(func() {
+				GET("/{id}")
+				Param("color")
+			})
+		})
+		Method("MethodContractRules", func() {
+			Payload(Contact)
+			Result(Contact)
+			HTTP(func() {
+				POST("/")
+			})
+		})
+		Method("MethodContractInvalidExample", func() {
+			Payload(func() {
+				Attribute("email", String)
+				Attribute("sms", String)
+				ExactlyOneOf("email", "sms")
+				Example(map[string]any{"email": "me@example.com", "sms": "555-0100"})
+			})
+			HTTP(func() {
+				PUT("/")
+			})
+		})
+		Method("MethodContractUnion", func() {
+			Payload(func() {
+				OneOf("value", func() {
+					Attribute("s", String)
+					Attribute("i", Int)
+				})
+				Required("value")
+			})
+			HTTP(func() {
+				PATCH("/")
+			})
+		})
+	})
+}
+
+var ServerContractDisabledDSL = func() {
+	API("ContractAPI", func() {
+		Meta("test:contract")
+	})
+	Service("ServiceContractDisabled", func() {
+		Meta("test:contract", "false")
+		Method("MethodContractDisabled", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var ServerFuzzDSL = func() {
+	API("FuzzAPI", func() {
+		Meta("test:fuzz")
+	})
+	Service("ServiceFuzz", func() {
+		Method("MethodFuzz", func() {
+			Payload(func() {
+				Attribute("id", Int)
+				Attribute("filter", String, func() {
+					MaxLength(10)
+				})
+				Attribute("auth", String)
+				Attribute("body", String)
+				Required("id", "auth")
+			})
+			HTTP(func() {
+				POST("/{id}")
+				Param("filter")
+				Header("auth:Authorization")
+				Body(func() {
+					Attribute("body")
+				})
+			})
+		})
+		Method("MethodFuzzNoPayload", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+		Method("MethodFuzzStreaming", func() {
+			Payload(String)
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/stream")
+				Param("p")
+			})
+		})
+	})
+}
+
+var ServerFuzzEnumDSL = func() {
+	API("FuzzAPI", func() {
+		Meta("test:fuzz")
+	})
+	var Color = Type("Color", String, func() {
+		Enum("red", "green", "blue")
+	})
+	var Phone = CustomFormat("phone", func() {
+		Validator("example.com/formats", "ValidatePhone")
+		Example("+15551234567")
+	})
+	Service("ServiceFuzz", func() {
+		Method("MethodFuzzEnum", func() {
+			Payload(func() {
+				Attribute("color", Color)
+				Attribute("email", String)
+				Attribute("sms", String)
+				Attribute("phone", String, func() {
+					Format(Phone)
+				})
+				Required("color")
+				ExactlyOneOf("email", "sms")
+			})
+			HTTP(func() {
+				POST("/")
+			})
+		})
+		Method("MethodFuzzInvalidExample", func() {
+			Payload(func() {
+				Attribute("color", Color)
+				Example(map[string]any{"color": "purple"})
+			})
+			HTTP(func() {
+				PUT("/")
+			})
+		})
+	})
+}
+
+var ServerFuzzDisabledDSL = func() {
+	API("FuzzAPI", func() {
+		Meta("test:fuzz")
+	})
+	Service("ServiceFuzzDisabled", func() {
+		Meta("test:fuzz", "false")
+		Method("MethodFuzzDisabled", func() {
+			Payload(String)
+			HTTP(func() {
+				GET("/{p}")
+			})
+		})
+	})
+}
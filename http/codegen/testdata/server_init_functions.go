@@ -273,3 +273,30 @@ func MountServerTrailingSlashRoutingHandler(mux goahttp.Muxer, h http.Handler) {
 	mux.Handle("GET", "/trailing/slash/", f)
 }
 `
+
+const ServerCompressionConstructorCode = `// New instantiates HTTP handlers for all the ServiceCompression service
+// endpoints using the provided encoder and decoder. The handlers are mounted
+// on the given mux using the HTTP verb and path defined in the design.
+// errhandler is called whenever a response fails to be encoded. formatter is
+// used to format errors returned by the service methods prior to encoding.
+// Both errhandler and formatter are optional and can be nil. The handlers
+// compress the response bodies and decompress the request bodies using gzip or
+// deflate.
+func New(
+	e *servicecompression.Endpoints,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) *Server {
+	s := &Server{
+		Mounts: []*MountPoint{
+			{"MethodCompression", "GET", "/{id}"},
+		},
+		MethodCompression: NewMethodCompressionHandler(e.MethodCompression, mux, decoder, encoder, errhandler, formatter),
+	}
+	s.Use(goahttp.Compress())
+	return s
+}
+`
@@ -0,0 +1,281 @@
+package testdata
+
+var SSEResultServerHandlerInitCode = `// NewSSEResultMethodHandler creates a HTTP handler which loads the HTTP
+// request and calls the "SSEResultService" service "SSEResultMethod" endpoint.
+func NewSSEResultMethodHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest = DecodeSSEResultMethodRequest(mux, decoder)
+		encodeError   = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "SSEResultMethod")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "SSEResultService")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		v := &sseresultservice.SSEResultMethodEndpointInput{
+			Stream: &SSEResultMethodServerStream{
+				writer: goahttp.NewServerSentEventWriter(w),
+			},
+			Payload: payload.(*sseresultservice.Request),
+		}
+		_, err = endpoint(ctx, v)
+		if err != nil {
+			if v.Stream.(*SSEResultMethodServerStream).writer.Opened() {
+				// Response has been written already, do not encode the error
+				errhandler(ctx, w, err)
+				return
+			}
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+	})
+}
+`
+
+var SSEResultServerStreamStructTypeCode = `// SSEResultMethodServerStream implements the
+// sseresultservice.SSEResultMethodServerStream interface using server-sent
+// events.
+type SSEResultMethodServerStream struct {
+	// writer is the server-sent events writer.
+	writer *goahttp.ServerSentEventWriter
+}
+`
+
+var SSEResultServerStreamSendCode = `// Send streams instances of "sseresultservice.UserType" to the
+// "SSEResultMethod" endpoint server-sent events stream.
+func (s *SSEResultMethodServerStream) Send(v *sseresultservice.UserType) error {
+	res := v
+	body := NewSSEResultMethodResponseBody(res)
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	ev := &goahttp.ServerSentEvent{Data: data}
+	return s.writer.Write(ev)
+}
+`
+
+var SSEResultServerStreamCloseCode = `// Close ends the "SSEResultMethod" endpoint server-sent events stream. It
+// makes sure the response headers are written so that clients receive an empty
+// stream if no event was sent.
+func (s *SSEResultMethodServerStream) Close() error {
+	return s.writer.Open()
+}
+`
+
+var SSEResultPrimitiveServerStreamSendCode = `// Send streams instances of "string" to the "SSEResultPrimitiveMethod"
+// endpoint server-sent events stream.
+func (s *SSEResultPrimitiveMethodServerStream) Send(v string) error {
+	res := v
+	body := res
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	ev := &goahttp.ServerSentEvent{Data: data}
+	return s.writer.Write(ev)
+}
+`
+
+var SSEResultWithExplicitViewServerStreamSendCode = `// Send streams instances of "sseresultwithexplicitviewservice.Resulttype" to
+// the "SSEResultWithExplicitViewMethod" endpoint server-sent events stream.
+func (s *SSEResultWithExplicitViewMethodServerStream) Send(v *sseresultwithexplicitviewservice.Resulttype) error {
+	res := sseresultwithexplicitviewservice.NewViewedResulttype(v, "tiny")
+	body := NewSSEResultWithExplicitViewMethodResponseBodyTiny(res.Projected)
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	ev := &goahttp.ServerSentEvent{Data: data}
+	return s.writer.Write(ev)
+}
+`
+
+var SSEResultFieldsServerStreamSendCode = `// Send streams instances of "sseresultfieldsservice.Event" to the
+// "SSEResultFieldsMethod" endpoint server-sent events stream.
+func (s *SSEResultFieldsMethodServerStream) Send(v *sseresultfieldsservice.Event) error {
+	var data []byte
+	if v.Message != nil {
+		data = []byte(*v.Message)
+	}
+	ev := &goahttp.ServerSentEvent{Data: data}
+	if v.ID != nil {
+		ev.ID = *v.ID
+	}
+	ev.Event = v.Type
+	if v.Retry != nil {
+		ev.Retry = int(*v.Retry)
+	}
+	return s.writer.Write(ev)
+}
+`
+
+var SSEResultObjectDataServerStreamSendCode = `// Send streams instances of "sseresultobjectdataservice.Event" to the
+// "SSEResultObjectDataMethod" endpoint server-sent events stream.
+func (s *SSEResultObjectDataMethodServerStream) Send(v *sseresultobjectdataservice.Event) error {
+	data, err := json.Marshal(v.Data)
+	if err != nil {
+		return err
+	}
+	ev := &goahttp.ServerSentEvent{Data: data}
+	ev.ID = v.ID
+	return s.writer.Write(ev)
+}
+`
+
+var SSEResultClientEndpointInitCode = `// SSEResultMethod returns an endpoint that makes HTTP requests to the
+// SSEResultService service SSEResultMethod server.
+func (c *Client) SSEResultMethod() goa.Endpoint {
+	var (
+		decodeResponse = DecodeSSEResultMethodResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v any) (any, error) {
+		req, err := c.BuildSSEResultMethodRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", goahttp.EventStreamContentType)
+		resp, err := c.SSEResultMethodDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("SSEResultService", "SSEResultMethod", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return decodeResponse(resp)
+		}
+		return &SSEResultMethodClientStream{reader: goahttp.NewServerSentEventReader(resp.Body), body: resp.Body}, nil
+	}
+}
+`
+
+var SSEResultClientStreamStructTypeCode = `// SSEResultMethodClientStream implements the
+// sseresultservice.SSEResultMethodClientStream interface using server-sent
+// events.
+type SSEResultMethodClientStream struct {
+	// reader is the server-sent events reader.
+	reader *goahttp.ServerSentEventReader
+	// body is the HTTP response body closed once the stream ends.
+	body io.ReadCloser
+}
+`
+
+var SSEResultClientStreamRecvCode = `// Recv reads instances of "sseresultservice.UserType" from the
+// "SSEResultMethod" endpoint server-sent events stream.
+func (s *SSEResultMethodClientStream) Recv() (*sseresultservice.UserType, error) {
+	var rv *sseresultservice.UserType
+	ev, err := s.reader.Next()
+	if err != nil {
+		s.body.Close()
+		return rv, err
+	}
+	var body SSEResultMethodResponseBody
+	if err := json.Unmarshal(ev.Data, &body); err != nil {
+		return rv, goahttp.ErrDecodingError("SSEResultService", "SSEResultMethod", err)
+	}
+	err = ValidateSSEResultMethodResponseBody(&body)
+	if err != nil {
+		return rv, goahttp.ErrValidationError("SSEResultService", "SSEResultMethod", err)
+	}
+	res := NewSSEResultMethodUserTypeOK(&body)
+	return res, nil
+}
+`
+
+var SSEResultPrimitiveClientStreamRecvCode = `// Recv reads instances of "string" from the "SSEResultPrimitiveMethod"
+// endpoint server-sent events stream.
+func (s *SSEResultPrimitiveMethodClientStream) Recv() (string, error) {
+	var rv string
+	ev, err := s.reader.Next()
+	if err != nil {
+		s.body.Close()
+		return rv, err
+	}
+	var body string
+	if err := json.Unmarshal(ev.Data, &body); err != nil {
+		return rv, goahttp.ErrDecodingError("SSEResultPrimitiveService", "SSEResultPrimitiveMethod", err)
+	}
+	return body, nil
+}
+`
+
+var SSEResultWithExplicitViewClientStreamRecvCode = `// Recv reads instances of "sseresultwithexplicitviewservice.Resulttype" from
+// the "SSEResultWithExplicitViewMethod" endpoint server-sent events stream.
+func (s *SSEResultWithExplicitViewMethodClientStream) Recv() (*sseresultwithexplicitviewservice.Resulttype, error) {
+	var rv *sseresultwithexplicitviewservice.Resulttype
+	ev, err := s.reader.Next()
+	if err != nil {
+		s.body.Close()
+		return rv, err
+	}
+	var body SSEResultWithExplicitViewMethodResponseBody
+	if err := json.Unmarshal(ev.Data, &body); err != nil {
+		return rv, goahttp.ErrDecodingError("SSEResultWithExplicitViewService", "SSEResultWithExplicitViewMethod", err)
+	}
+	res := NewSSEResultWithExplicitViewMethodResulttypeOK(&body)
+	vres := &sseresultwithexplicitviewserviceviews.Resulttype{res, "tiny"}
+	if err := sseresultwithexplicitviewserviceviews.ValidateResulttype(vres); err != nil {
+		return rv, goahttp.ErrValidationError("SSEResultWithExplicitViewService", "SSEResultWithExplicitViewMethod", err)
+	}
+	return sseresultwithexplicitviewservice.NewResulttype(vres), nil
+}
+`
+
+var SSEResultFieldsClientStreamRecvCode = `// Recv reads instances of "sseresultfieldsservice.Event" from the
+// "SSEResultFieldsMethod" endpoint server-sent events stream.
+func (s *SSEResultFieldsMethodClientStream) Recv() (*sseresultfieldsservice.Event, error) {
+	var rv *sseresultfieldsservice.Event
+	ev, err := s.reader.Next()
+	if err != nil {
+		s.body.Close()
+		return rv, err
+	}
+	res := &sseresultfieldsservice.Event{}
+	data := string(ev.Data)
+	res.Message = &data
+	if ev.ID != "" {
+		res.ID = &ev.ID
+	}
+	if ev.Event != "" {
+		res.Type = ev.Event
+	}
+	if ev.Retry > 0 {
+		retry := int64(ev.Retry)
+		res.Retry = &retry
+	}
+	return res, nil
+}
+`
+
+var SSEResultObjectDataClientStreamRecvCode = `// Recv reads instances of "sseresultobjectdataservice.Event" from the
+// "SSEResultObjectDataMethod" endpoint server-sent events stream.
+func (s *SSEResultObjectDataMethodClientStream) Recv() (*sseresultobjectdataservice.Event, error) {
+	var rv *sseresultobjectdataservice.Event
+	ev, err := s.reader.Next()
+	if err != nil {
+		s.body.Close()
+		return rv, err
+	}
+	res := &sseresultobjectdataservice.Event{}
+	if err := json.Unmarshal(ev.Data, &res.Data); err != nil {
+		return rv, goahttp.ErrDecodingError("SSEResultObjectDataService", "SSEResultObjectDataMethod", err)
+	}
+	if ev.ID != "" {
+		res.ID = ev.ID
+	}
+	return res, nil
+}
+`
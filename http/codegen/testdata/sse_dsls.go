@@ -0,0 +1,115 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var SSEResultDSL = func() {
+	var Request = Type("Request", func() {
+		Attribute("x", String)
+	})
+	var Result = Type("UserType", func() {
+		Attribute("a", String)
+		Required("a")
+	})
+	Service("SSEResultService", func() {
+		Method("SSEResultMethod", func() {
+			Payload(Request)
+			StreamingResult(Result)
+			HTTP(func() {
+				GET("/{x}")
+				ServerSentEvents()
+			})
+		})
+	})
+}
+
+var SSEResultPrimitiveDSL = func() {
+	Service("SSEResultPrimitiveService", func() {
+		Method("SSEResultPrimitiveMethod", func() {
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/")
+				ServerSentEvents()
+			})
+		})
+	})
+}
+
+var SSEResultWithExplicitViewDSL = func() {
+	var Result = ResultType("ResultType", func() {
+		Attributes(func() {
+			Attribute("a", String)
+			Attribute("b", Int)
+		})
+		View("default", func() {
+			Attribute("a")
+			Attribute("b")
+		})
+		View("tiny", func() {
+			Attribute("a")
+		})
+	})
+	Service("SSEResultWithExplicitViewService", func() {
+		Method("SSEResultWithExplicitViewMethod", func() {
+			StreamingResult(Result, func() {
+				View("tiny")
+			})
+			HTTP(func() {
+				GET("/")
+				ServerSentEvents()
+			})
+		})
+	})
+}
+
+var SSEResultFieldsDSL = func() {
+	var Event = Type("Event", func() {
+		Attribute("id", String)
+		Attribute("type", String)
+		Attribute("retry", Int64)
+		Attribute("message", String)
+		Required("type")
+	})
+	Service("SSEResultFieldsService", func() {
+		Method("SSEResultFieldsMethod", func() {
+			Payload(func() {
+				Attribute("start_id", String)
+			})
+			StreamingResult(Event)
+			HTTP(func() {
+				GET("/events")
+				ServerSentEvents(func() {
+					SSEEventData("message")
+					SSEEventID("id")
+					SSEEventType("type")
+					SSEEventRetry("retry")
+					SSERequestID("start_id")
+				})
+			})
+		})
+	})
+}
+
+var SSEResultObjectDataDSL = func() {
+	var Data = Type("Data", func() {
+		Attribute("value", Int)
+	})
+	var Event = Type("Event", func() {
+		Attribute("id", String)
+		Attribute("data", Data)
+		Required("id")
+	})
+	Service("SSEResultObjectDataService", func() {
+		Method("SSEResultObjectDataMethod", func() {
+			StreamingResult(Event)
+			HTTP(func() {
+				GET("/events")
+				ServerSentEvents(func() {
+					SSEEventData("data")
+					SSEEventID("id")
+				})
+			})
+		})
+	})
+}
@@ -13,6 +13,7 @@ func RunHTTPDSL(t *testing.T, dsl func()) *expr.RootExpr {
 	// reset all roots and codegen data structures
 	service.Services = make(service.ServicesData)
 	HTTPServices = make(ServicesData)
+	JSONRPCServices = make(JSONRPCServicesData)
 	return expr.RunDSL(t, dsl)
 }
 
@@ -62,7 +62,7 @@ func goTypeDef(scope *codegen.NameScope, att *expr.AttributeExpr, ptr, useDefaul
 				fn = codegen.GoifyAtt(at, name, true)
 				tdef = goTypeDef(scope, at, ptr, useDefault)
 				if expr.IsPrimitive(at.Type) {
-					if (ptr || mat.IsPrimitivePointer(name, useDefault)) && at.Type != expr.Bytes && at.Type != expr.Any {
+					if (ptr || mat.IsPrimitivePointer(name, useDefault)) && at.Type != expr.Bytes && at.Type != expr.Any && !at.IsNullable() {
 						tdef = "*" + tdef
 					}
 				} else if expr.IsObject(at.Type) {
@@ -102,7 +102,10 @@ func attributeTags(parent, att *expr.AttributeExpr, t string, optional bool) str
 		return tags
 	}
 	var o string
-	if optional {
+	if att.IsNullable() {
+		// Nullable fields must be omitted when absent but not when null.
+		o = ",omitzero"
+	} else if optional {
 		o = ",omitempty"
 	}
 	return fmt.Sprintf(" `form:\"%s%s\" json:\"%s%s\" xml:\"%s%s\"`", t, o, t, o, t, o)
@@ -0,0 +1,486 @@
+package http
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// DefaultCompressionMinSize is the default minimum size in bytes of the bodies
+// compressed by the Compress middleware and the compression doer.
+const DefaultCompressionMinSize = 1024
+
+type (
+	// ContentCoding describes a HTTP content coding such as gzip. Additional
+	// codings, for example brotli or zstd, may be provided to Compress and
+	// NewCompressionDoer with WithCodings.
+	ContentCoding struct {
+		// Name is the coding token used in the Accept-Encoding and
+		// Content-Encoding headers.
+		Name string
+		// NewWriter returns a writer that compresses the data written to
+		// it into w. The writer should implement Flush() error so that
+		// streamed responses can be flushed.
+		NewWriter func(w io.Writer) (io.WriteCloser, error)
+		// NewReader returns a reader that decompresses the data read from
+		// r.
+		NewReader func(r io.Reader) (io.ReadCloser, error)
+	}
+
+	// CompressionOption configures the Compress middleware and the
+	// compression doer.
+	CompressionOption func(*compressionOptions)
+
+	// compressionOptions is the compression configuration.
+	compressionOptions struct {
+		// codings lists the supported codings in order of preference.
+		codings []*ContentCoding
+		// minSize is the minimum size of compressed bodies.
+		minSize int
+		// requests indicates whether the doer compresses request bodies.
+		requests bool
+	}
+
+	// compressWriter is the response writer used by the Compress middleware.
+	// It buffers the response body until it is large enough to be
+	// compressed, the response is flushed or the handler returns.
+	compressWriter struct {
+		http.ResponseWriter
+		coding  *ContentCoding
+		minSize int
+		// status is the status code given to WriteHeader if any.
+		status int
+		// buf holds the response body until the encoding is decided.
+		buf []byte
+		// decided is true once the response headers have been written.
+		decided bool
+		// cw is the compressing writer if the response is compressed.
+		cw io.WriteCloser
+	}
+
+	// compressionDoer is the doer returned by NewCompressionDoer.
+	compressionDoer struct {
+		Doer
+		opts   *compressionOptions
+		accept string
+	}
+
+	// decompressReader decompresses a body lazily so that empty bodies do
+	// not cause errors.
+	decompressReader struct {
+		body   io.ReadCloser
+		coding *ContentCoding
+		r      io.ReadCloser
+		err    error
+	}
+)
+
+var (
+	// GzipCoding is the gzip content coding implemented with package
+	// compress/gzip.
+	GzipCoding = &ContentCoding{
+		Name: "gzip",
+		NewWriter: func(w io.Writer) (io.WriteCloser, error) {
+			return gzip.NewWriter(w), nil
+		},
+		NewReader: func(r io.Reader) (io.ReadCloser, error) {
+			return gzip.NewReader(r)
+		},
+	}
+
+	// DeflateCoding is the deflate content coding, that is the zlib format
+	// as defined by RFC 9110, implemented with package compress/zlib.
+	DeflateCoding = &ContentCoding{
+		Name: "deflate",
+		NewWriter: func(w io.Writer) (io.WriteCloser, error) {
+			return zlib.NewWriter(w), nil
+		},
+		NewReader: func(r io.Reader) (io.ReadCloser, error) {
+			return zlib.NewReader(r)
+		},
+	}
+)
+
+// WithCodings sets the supported content codings in order of preference. The
+// default is GzipCoding followed by DeflateCoding.
+func WithCodings(codings ...*ContentCoding) CompressionOption {
+	return func(o *compressionOptions) {
+		o.codings = codings
+	}
+}
+
+// WithMinSize sets the minimum size in bytes of the compressed bodies, smaller
+// bodies are sent uncompressed. The default is DefaultCompressionMinSize.
+func WithMinSize(n int) CompressionOption {
+	return func(o *compressionOptions) {
+		o.minSize = n
+	}
+}
+
+// WithRequestCompression makes the compression doer compress the request
+// bodies using the preferred coding. Request compression is disabled by
+// default as servers may not support it.
+func WithRequestCompression() CompressionOption {
+	return func(o *compressionOptions) {
+		o.requests = true
+	}
+}
+
+// Compress returns a HTTP middleware that compresses the response bodies using
+// the coding negotiated with the request Accept-Encoding header and that
+// decompresses the request bodies according to their Content-Encoding header.
+// Requests using an unsupported coding are rejected with 415 Unsupported Media
+// Type.
+//
+// Responses smaller than the minimum size are sent uncompressed. Responses are
+// always compressed once flushed so that streamed bodies, such as server-sent
+// events or bodies written directly when SkipResponseBodyEncodeDecode is used,
+// are compressed and flushed incrementally. Responses whose Content-Encoding
+// header is set by the handler are left untouched. Websocket upgrade requests
+// are passed through.
+func Compress(opts ...CompressionOption) func(http.Handler) http.Handler {
+	o := newCompressionOptions(opts)
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Upgrade") != "" {
+				h.ServeHTTP(w, r)
+				return
+			}
+			if ce := r.Header.Get("Content-Encoding"); ce != "" && !strings.EqualFold(ce, "identity") {
+				c := o.coding(ce)
+				if c == nil {
+					w.Header().Set("Accept-Encoding", o.names())
+					w.WriteHeader(http.StatusUnsupportedMediaType)
+					return
+				}
+				r.Body = &decompressReader{body: r.Body, coding: c}
+				r.Header.Del("Content-Encoding")
+				r.Header.Del("Content-Length")
+				r.ContentLength = -1
+			}
+			addVary(w.Header(), "Accept-Encoding")
+			c := o.negotiate(r.Header.Get("Accept-Encoding"))
+			if c == nil || r.Method == http.MethodHead {
+				h.ServeHTTP(w, r)
+				return
+			}
+			cw := &compressWriter{ResponseWriter: w, coding: c, minSize: o.minSize}
+			defer cw.close()
+			h.ServeHTTP(cw, r)
+		})
+	}
+}
+
+// NewCompressionDoer wraps the given doer so that it advertises the supported
+// codings in the request Accept-Encoding header and decompresses the response
+// bodies accordingly. The doer also compresses the request bodies if the
+// WithRequestCompression option is given.
+func NewCompressionDoer(d Doer, opts ...CompressionOption) Doer {
+	o := newCompressionOptions(opts)
+	return &compressionDoer{Doer: d, opts: o, accept: o.names()}
+}
+
+// Do sends the request and decompresses the response body.
+func (d *compressionDoer) Do(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("Accept-Encoding") == "" {
+		req.Header.Set("Accept-Encoding", d.accept)
+	}
+	if d.opts.requests && req.Body != nil && req.Body != http.NoBody && req.Header.Get("Content-Encoding") == "" {
+		if err := d.compressRequest(req); err != nil {
+			return nil, err
+		}
+	}
+	resp, err := d.Doer.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if c := d.opts.coding(resp.Header.Get("Content-Encoding")); c != nil {
+		resp.Body = &decompressReader{body: resp.Body, coding: c}
+		resp.Header.Del("Content-Encoding")
+		resp.Header.Del("Content-Length")
+		resp.ContentLength = -1
+		resp.Uncompressed = true
+	}
+	return resp, nil
+}
+
+// compressRequest compresses the request body with the preferred coding if it
+// is larger than the minimum size.
+func (d *compressionDoer) compressRequest(req *http.Request) error {
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close() // nolint: errcheck
+	if err != nil {
+		return err
+	}
+	if len(body) < d.opts.minSize || len(d.opts.codings) == 0 {
+		setBody(req, body)
+		return nil
+	}
+	c := d.opts.codings[0]
+	var buf bytes.Buffer
+	cw, err := c.NewWriter(&buf)
+	if err != nil {
+		return err
+	}
+	if _, err := cw.Write(body); err != nil {
+		return err
+	}
+	if err := cw.Close(); err != nil {
+		return err
+	}
+	setBody(req, buf.Bytes())
+	req.Header.Set("Content-Encoding", c.Name)
+	return nil
+}
+
+// WriteHeader records the status code, the headers are written once the
+// response encoding is decided.
+func (w *compressWriter) WriteHeader(code int) {
+	if w.decided || w.status != 0 {
+		return
+	}
+	if code < 200 {
+		// Informational responses are not compressed.
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	w.status = code
+	switch {
+	case code == http.StatusNoContent || code == http.StatusNotModified || code == http.StatusPartialContent:
+		w.decide(false)
+	case w.Header().Get("Content-Encoding") != "":
+		w.decide(false)
+	default:
+		if cl := w.Header().Get("Content-Length"); cl != "" {
+			if n, err := strconv.Atoi(cl); err == nil {
+				w.decide(n >= w.minSize)
+			}
+		}
+	}
+}
+
+// Write buffers or compresses p.
+func (w *compressWriter) Write(p []byte) (int, error) {
+	if !w.decided {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.decided {
+		return w.write(p)
+	}
+	w.buf = append(w.buf, p...)
+	if len(w.buf) >= w.minSize {
+		w.decide(true)
+		if _, err := w.flushBuffer(); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// Flush compresses and sends the buffered data to the client.
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		w.WriteHeader(http.StatusOK)
+	}
+	if !w.decided {
+		w.decide(true)
+	}
+	if _, err := w.flushBuffer(); err != nil {
+		return
+	}
+	if f, ok := w.cw.(interface{ Flush() error }); ok {
+		if err := f.Flush(); err != nil {
+			return
+		}
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack implements http.Hijacker.
+func (w *compressWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer does not support hijacking")
+	}
+	return h.Hijack()
+}
+
+// Unwrap returns the underlying response writer so that it may be used with
+// http.ResponseController.
+func (w *compressWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// decide writes the response headers, compress indicates whether the body is
+// compressed.
+func (w *compressWriter) decide(compress bool) {
+	w.decided = true
+	if compress {
+		if cw, err := w.coding.NewWriter(w.ResponseWriter); err == nil {
+			w.cw = cw
+			w.Header().Set("Content-Encoding", w.coding.Name)
+			w.Header().Del("Content-Length")
+		}
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+}
+
+// write writes p to the compressing writer if the response is compressed, to
+// the underlying response writer otherwise.
+func (w *compressWriter) write(p []byte) (int, error) {
+	if w.cw != nil {
+		return w.cw.Write(p)
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+// flushBuffer writes the buffered data if any.
+func (w *compressWriter) flushBuffer() (int, error) {
+	if len(w.buf) == 0 {
+		return 0, nil
+	}
+	buf := w.buf
+	w.buf = nil
+	return w.write(buf)
+}
+
+// close writes the buffered data if any and terminates the compressed stream.
+func (w *compressWriter) close() {
+	if !w.decided {
+		if w.status == 0 {
+			// The handler did not write a response.
+			return
+		}
+		w.decide(false)
+	}
+	w.flushBuffer() // nolint: errcheck
+	if w.cw != nil {
+		w.cw.Close() // nolint: errcheck
+	}
+}
+
+// Read reads decompressed data from the body.
+func (r *decompressReader) Read(p []byte) (int, error) {
+	if r.r == nil && r.err == nil {
+		r.r, r.err = r.coding.NewReader(r.body)
+	}
+	if r.err != nil {
+		return 0, r.err
+	}
+	return r.r.Read(p)
+}
+
+// Close closes the decompressor and the body.
+func (r *decompressReader) Close() error {
+	if r.r != nil {
+		r.r.Close() // nolint: errcheck
+	}
+	return r.body.Close()
+}
+
+// newCompressionOptions returns the compression configuration given the
+// options.
+func newCompressionOptions(opts []CompressionOption) *compressionOptions {
+	o := &compressionOptions{
+		codings: []*ContentCoding{GzipCoding, DeflateCoding},
+		minSize: DefaultCompressionMinSize,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// coding returns the supported coding with the given name, nil if there is
+// none.
+func (o *compressionOptions) coding(name string) *ContentCoding {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil
+	}
+	for _, c := range o.codings {
+		if strings.EqualFold(c.Name, name) {
+			return c
+		}
+	}
+	return nil
+}
+
+// names returns the comma separated names of the supported codings.
+func (o *compressionOptions) names() string {
+	names := make([]string, len(o.codings))
+	for i, c := range o.codings {
+		names[i] = c.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// negotiate returns the supported coding with the highest quality value in the
+// given Accept-Encoding header value. Ties are broken using the order of
+// preference of the supported codings. negotiate returns nil if no coding is
+// acceptable.
+func (o *compressionOptions) negotiate(accept string) *ContentCoding {
+	if accept == "" {
+		return nil
+	}
+	qs := make(map[string]float64)
+	for _, part := range strings.Split(accept, ",") {
+		name, params, _ := strings.Cut(part, ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		q := 1.0
+		if p := strings.TrimSpace(params); strings.HasPrefix(p, "q=") {
+			if v, err := strconv.ParseFloat(p[2:], 64); err == nil {
+				q = v
+			}
+		}
+		qs[name] = q
+	}
+	var (
+		best  *ContentCoding
+		bestQ float64
+	)
+	for _, c := range o.codings {
+		q, ok := qs[strings.ToLower(c.Name)]
+		if !ok {
+			q, ok = qs["*"]
+		}
+		if ok && q > bestQ {
+			best, bestQ = c, q
+		}
+	}
+	return best
+}
+
+// addVary adds the given header name to the Vary header unless already
+// present.
+func addVary(h http.Header, name string) {
+	for _, v := range h.Values("Vary") {
+		for _, n := range strings.Split(v, ",") {
+			if n = strings.TrimSpace(n); n == "*" || strings.EqualFold(n, name) {
+				return
+			}
+		}
+	}
+	h.Add("Vary", name)
+}
+
+// setBody sets the request body to b.
+func setBody(req *http.Request, b []byte) {
+	req.Body = io.NopCloser(bytes.NewReader(b))
+	req.ContentLength = int64(len(b))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(b)), nil
+	}
+}
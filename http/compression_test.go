@@ -0,0 +1,233 @@
+package http
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegotiateCoding(t *testing.T) {
+	o := newCompressionOptions(nil)
+	cases := []struct {
+		name   string
+		accept string
+		want   string
+	}{
+		{"empty", "", ""},
+		{"gzip", "gzip", "gzip"},
+		{"deflate", "deflate", "deflate"},
+		{"preference", "deflate, gzip", "gzip"},
+		{"quality", "gzip;q=0.5, deflate", "deflate"},
+		{"excluded", "gzip;q=0", ""},
+		{"wildcard", "*", "gzip"},
+		{"wildcard excluded", "gzip;q=0, *", "deflate"},
+		{"unsupported", "br", ""},
+		{"case", "GZIP", "gzip"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var got string
+			if cc := o.negotiate(c.accept); cc != nil {
+				got = cc.Name
+			}
+			assert.Equal(t, c.want, got)
+		})
+	}
+}
+
+func TestCompress(t *testing.T) {
+	large := strings.Repeat("goa", 1000)
+	cases := []struct {
+		name     string
+		accept   string
+		body     string
+		status   int
+		encoding string
+	}{
+		{"small", "gzip", "small", http.StatusOK, ""},
+		{"gzip", "gzip", large, http.StatusOK, "gzip"},
+		{"deflate", "deflate", large, http.StatusOK, "deflate"},
+		{"not accepted", "", large, http.StatusOK, ""},
+		{"status", "gzip", large, http.StatusCreated, "gzip"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			h := Compress()(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(c.status)
+				// Write in chunks to exercise buffering.
+				for i := 0; i < len(c.body); i += 100 {
+					_, err := w.Write([]byte(c.body[i:min(i+100, len(c.body))]))
+					require.NoError(t, err)
+				}
+			}))
+			req := httptest.NewRequest("GET", "/", nil)
+			if c.accept != "" {
+				req.Header.Set("Accept-Encoding", c.accept)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			assert.Equal(t, c.status, rec.Code)
+			assert.Equal(t, "Accept-Encoding", rec.Header().Get("Vary"))
+			assert.Equal(t, c.encoding, rec.Header().Get("Content-Encoding"))
+			assert.Equal(t, c.body, decompress(t, c.encoding, rec.Body.Bytes()))
+		})
+	}
+}
+
+func TestCompressFlush(t *testing.T) {
+	h := Compress()(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, err := w.Write([]byte("data: 1\n\n"))
+		require.NoError(t, err)
+		w.(http.Flusher).Flush()
+		_, err = w.Write([]byte("data: 2\n\n"))
+		require.NoError(t, err)
+	}))
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	assert.True(t, rec.Flushed)
+	assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+	assert.Equal(t, "data: 1\n\ndata: 2\n\n", decompress(t, "gzip", rec.Body.Bytes()))
+}
+
+func TestCompressPreEncoded(t *testing.T) {
+	large := strings.Repeat("goa", 1000)
+	h := Compress()(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Encoding", "br")
+		_, err := w.Write([]byte(large))
+		require.NoError(t, err)
+	}))
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	assert.Equal(t, "br", rec.Header().Get("Content-Encoding"))
+	assert.Equal(t, large, rec.Body.String())
+}
+
+func TestCompressRequest(t *testing.T) {
+	cases := []struct {
+		name     string
+		encoding string
+		status   int
+	}{
+		{"identity", "", http.StatusOK},
+		{"gzip", "gzip", http.StatusOK},
+		{"deflate", "deflate", http.StatusOK},
+		{"unsupported", "br", http.StatusUnsupportedMediaType},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var got string
+			h := Compress()(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				assert.Empty(t, r.Header.Get("Content-Encoding"))
+				b, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				got = string(b)
+			}))
+			body := []byte("payload")
+			if c.encoding == "gzip" || c.encoding == "deflate" {
+				body = compress(t, c.encoding, body)
+			}
+			req := httptest.NewRequest("POST", "/", bytes.NewReader(body))
+			if c.encoding != "" {
+				req.Header.Set("Content-Encoding", c.encoding)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			assert.Equal(t, c.status, rec.Code)
+			if c.status == http.StatusOK {
+				assert.Equal(t, "payload", got)
+			} else {
+				assert.Equal(t, "gzip, deflate", rec.Header().Get("Accept-Encoding"))
+			}
+		})
+	}
+}
+
+func TestCompressionDoer(t *testing.T) {
+	large := strings.Repeat("goa", 1000)
+	srv := httptest.NewServer(Compress()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		_, err = w.Write(b)
+		require.NoError(t, err)
+	})))
+	defer srv.Close()
+
+	var encoding string
+	capture := doerFunc(func(req *http.Request) (*http.Response, error) {
+		encoding = req.Header.Get("Content-Encoding")
+		resp, err := http.DefaultClient.Do(req)
+		if err == nil {
+			assert.Equal(t, "gzip", resp.Header.Get("Content-Encoding"))
+		}
+		return resp, err
+	})
+	doer := NewCompressionDoer(capture, WithRequestCompression(), WithMinSize(10))
+	req, err := http.NewRequest("POST", srv.URL, strings.NewReader(large))
+	require.NoError(t, err)
+	resp, err := doer.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close() // nolint: errcheck
+	b, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, "gzip", encoding)
+	assert.Empty(t, resp.Header.Get("Content-Encoding"))
+	assert.Equal(t, large, string(b))
+}
+
+// doerFunc is a function that implements Doer.
+type doerFunc func(*http.Request) (*http.Response, error)
+
+// Do calls f(req).
+func (f doerFunc) Do(req *http.Request) (*http.Response, error) { return f(req) }
+
+func compress(t *testing.T, encoding string, b []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	var w io.WriteCloser
+	switch encoding {
+	case "gzip":
+		w = gzip.NewWriter(&buf)
+	case "deflate":
+		w = zlib.NewWriter(&buf)
+	}
+	_, err := w.Write(b)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func decompress(t *testing.T, encoding string, b []byte) string {
+	t.Helper()
+	var (
+		r   io.Reader = bytes.NewReader(b)
+		err error
+	)
+	switch encoding {
+	case "gzip":
+		r, err = gzip.NewReader(r)
+	case "deflate":
+		r, err = zlib.NewReader(r)
+	}
+	require.NoError(t, err)
+	res, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return string(res)
+}
@@ -0,0 +1,121 @@
+package http
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type (
+	// CORSPolicy describes the CORS policy that applies to the requests made
+	// from a given origin.
+	CORSPolicy struct {
+		// Origin is the allowed origin. It may be "*" to allow all
+		// origins or contain a single "*" wildcard. Origin is ignored
+		// if OriginRegexp is set.
+		Origin string
+		// OriginRegexp matches the allowed origins if not nil.
+		OriginRegexp *regexp.Regexp
+		// Methods lists the methods allowed in preflight requests.
+		Methods []string
+		// Headers lists the headers allowed in preflight requests.
+		Headers []string
+		// Exposed lists the response headers exposed to the client.
+		Exposed []string
+		// MaxAge is the number of seconds the preflight response may be
+		// cached, 0 means not set.
+		MaxAge int
+		// Credentials indicates whether the response may be exposed
+		// when the request credentials mode is "include".
+		Credentials bool
+	}
+
+	// corsHeaders contains the CORS header values computed once from a
+	// policy.
+	corsHeaders struct {
+		policy  *CORSPolicy
+		methods string
+		headers string
+		exposed string
+		maxAge  string
+	}
+)
+
+// HandleCORS returns a handler that sets the CORS response headers defined by
+// the first policy matching the request origin before calling h. Requests that
+// do not have an Origin header or whose origin is not matched by any policy
+// are passed through to h without setting any CORS header.
+func HandleCORS(h http.Handler, policies ...*CORSPolicy) http.Handler {
+	hs := make([]*corsHeaders, len(policies))
+	for i, p := range policies {
+		hs[i] = &corsHeaders{
+			policy:  p,
+			methods: strings.Join(p.Methods, ", "),
+			headers: strings.Join(p.Headers, ", "),
+			exposed: strings.Join(p.Exposed, ", "),
+		}
+		if p.MaxAge > 0 {
+			hs[i].maxAge = strconv.Itoa(p.MaxAge)
+		}
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			// Not a CORS request
+			h.ServeHTTP(w, r)
+			return
+		}
+		for _, c := range hs {
+			if !c.policy.Match(origin) {
+				continue
+			}
+			header := w.Header()
+			header.Set("Access-Control-Allow-Origin", origin)
+			header.Add("Vary", "Origin")
+			if c.exposed != "" {
+				header.Set("Access-Control-Expose-Headers", c.exposed)
+			}
+			if c.policy.Credentials {
+				header.Set("Access-Control-Allow-Credentials", "true")
+			}
+			if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+				// We are handling a preflight request
+				if c.methods != "" {
+					header.Set("Access-Control-Allow-Methods", c.methods)
+				}
+				if c.headers != "" {
+					header.Set("Access-Control-Allow-Headers", c.headers)
+				}
+				if c.maxAge != "" {
+					header.Set("Access-Control-Max-Age", c.maxAge)
+				}
+			}
+			break
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// Match returns true if the policy applies to the given origin.
+func (p *CORSPolicy) Match(origin string) bool {
+	if p.OriginRegexp != nil {
+		return p.OriginRegexp.MatchString(origin)
+	}
+	return MatchOrigin(origin, p.Origin)
+}
+
+// MatchOrigin returns true if the given origin matches spec. spec may be "*"
+// to match any origin or contain a single "*" wildcard that matches any
+// sequence of characters, for example "https://*.goa.design".
+func MatchOrigin(origin, spec string) bool {
+	if spec == "*" || origin == spec {
+		return true
+	}
+	prefix, suffix, ok := strings.Cut(spec, "*")
+	if !ok {
+		return false
+	}
+	return len(origin) >= len(prefix)+len(suffix) &&
+		strings.HasPrefix(origin, prefix) && strings.HasSuffix(origin, suffix)
+}
@@ -0,0 +1,95 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchOrigin(t *testing.T) {
+	cases := []struct {
+		name   string
+		origin string
+		spec   string
+		want   bool
+	}{
+		{"any", "https://goa.design", "*", true},
+		{"exact", "https://goa.design", "https://goa.design", true},
+		{"different", "https://goa.design", "https://example.com", false},
+		{"wildcard", "https://api.goa.design", "https://*.goa.design", true},
+		{"wildcard prefix mismatch", "http://api.goa.design", "https://*.goa.design", false},
+		{"wildcard suffix mismatch", "https://api.goa.io", "https://*.goa.design", false},
+		{"wildcard overlap", "https://goa.design", "https://goa.design*goa.design", false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.want, MatchOrigin(c.origin, c.spec))
+		})
+	}
+}
+
+func TestHandleCORS(t *testing.T) {
+	policies := []*CORSPolicy{
+		{
+			Origin:      "https://goa.design",
+			Methods:     []string{"GET", "POST"},
+			Headers:     []string{"X-Shared-Secret"},
+			Exposed:     []string{"X-Time"},
+			MaxAge:      600,
+			Credentials: true,
+		},
+		{OriginRegexp: regexp.MustCompile(`.*\.example\.com`)},
+	}
+	cases := []struct {
+		name    string
+		method  string
+		origin  string
+		acrm    string
+		headers map[string]string
+	}{
+		{"no origin", "GET", "", "", map[string]string{"Access-Control-Allow-Origin": ""}},
+		{"no match", "GET", "https://other.com", "", map[string]string{"Access-Control-Allow-Origin": ""}},
+		{"simple", "GET", "https://goa.design", "", map[string]string{
+			"Access-Control-Allow-Origin":      "https://goa.design",
+			"Access-Control-Expose-Headers":    "X-Time",
+			"Access-Control-Allow-Credentials": "true",
+			"Access-Control-Allow-Methods":     "",
+			"Vary":                             "Origin",
+		}},
+		{"preflight", "OPTIONS", "https://goa.design", "POST", map[string]string{
+			"Access-Control-Allow-Origin":  "https://goa.design",
+			"Access-Control-Allow-Methods": "GET, POST",
+			"Access-Control-Allow-Headers": "X-Shared-Secret",
+			"Access-Control-Max-Age":       "600",
+		}},
+		{"regexp", "OPTIONS", "https://api.example.com", "GET", map[string]string{
+			"Access-Control-Allow-Origin":      "https://api.example.com",
+			"Access-Control-Allow-Methods":     "",
+			"Access-Control-Allow-Credentials": "",
+		}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			called := false
+			h := HandleCORS(http.HandlerFunc(func(http.ResponseWriter, *http.Request) { called = true }), policies...)
+			req := httptest.NewRequest(c.method, "/", nil)
+			if c.origin != "" {
+				req.Header.Set("Origin", c.origin)
+			}
+			if c.acrm != "" {
+				req.Header.Set("Access-Control-Request-Method", c.acrm)
+			}
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, req)
+
+			assert.True(t, called)
+			for k, v := range c.headers {
+				assert.Equal(t, v, w.Header().Get(k), k)
+			}
+		})
+	}
+}
@@ -6,10 +6,12 @@ import (
 	"encoding/gob"
 	"encoding/json"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
 	"mime"
 	"net/http"
+	"strconv"
 	"strings"
 
 	goa "goa.design/goa/v3/pkg"
@@ -217,6 +219,8 @@ func ResponseDecoder(resp *http.Response) Decoder {
 // provided encoder. If the error is not a goa ServiceError struct then it is
 // encoded as a permanent internal server error. This behavior as well as the
 // shape of the response can be overridden by providing a non-nil formatter.
+// The encoder sets the Retry-After header of the responses to requests rejected
+// because of a rate or concurrency limit.
 func ErrorEncoder(encoder func(context.Context, http.ResponseWriter) Encoder, formatter func(ctx context.Context, err error) Statuser) func(context.Context, http.ResponseWriter, error) error {
 	return func(ctx context.Context, w http.ResponseWriter, err error) error {
 		enc := encoder(ctx, w)
@@ -224,6 +228,10 @@ func ErrorEncoder(encoder func(context.Context, http.ResponseWriter) Encoder, fo
 			formatter = NewErrorResponse
 		}
 		resp := formatter(ctx, err)
+		var rerr *goa.RateLimitError
+		if errors.As(err, &rerr) && rerr.RetryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(rerr.RetryAfterSeconds()))
+		}
 		w.WriteHeader(resp.StatusCode())
 		return enc.Encode(resp)
 	}
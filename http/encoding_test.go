@@ -10,6 +10,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -103,6 +104,17 @@ func TestUnsupportedDecoder(t *testing.T) {
 	assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
 }
 
+func TestRateLimitErrorEncoder(t *testing.T) {
+	w := httptest.NewRecorder()
+	encoder := ErrorEncoder(ResponseEncoder, nil)
+
+	err := encoder(context.Background(), w, goa.NewRateLimitError(1500*time.Millisecond, "rate limit exceeded"))
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "2", w.Header().Get("Retry-After"))
+}
+
 func TestResponseEncoder(t *testing.T) {
 	cases := []struct {
 		name        string
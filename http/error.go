@@ -86,6 +86,9 @@ func (resp *ErrorResponse) StatusCode() int {
 	if resp.Name == goa.UnsupportedMediaType {
 		return http.StatusUnsupportedMediaType
 	}
+	if resp.Name == goa.RateLimited {
+		return http.StatusTooManyRequests
+	}
 	if resp.Fault {
 		return http.StatusInternalServerError
 	}
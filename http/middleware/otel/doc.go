@@ -0,0 +1,20 @@
+/*
+Package otel contains HTTP middleware that instruments requests and responses
+with OpenTelemetry spans and metrics.
+
+The server middleware extracts the W3C trace context from the incoming request
+headers, starts a server span named after the request method and route pattern
+and records the request duration as well as the request and response body
+sizes. The route pattern is resolved using the goa muxer ResolvePattern method.
+The goa.design/goa/v3/middleware/otel Endpoint middleware renames the span
+after the goa service and method once the request has been routed.
+
+The client middleware wraps the client Doer: it starts a client span, injects
+the W3C trace context in the request headers and records the request duration
+as well as the request and response body sizes.
+
+Both middlewares default to the global OpenTelemetry providers, use the
+goa.design/goa/v3/middleware/otel/oteltest package to record the spans and
+metrics in memory in tests.
+*/
+package otel
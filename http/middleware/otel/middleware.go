@@ -0,0 +1,203 @@
+package otel
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	goahttp "goa.design/goa/v3/http"
+	"goa.design/goa/v3/middleware/otel"
+)
+
+type (
+	// responseCapture is a http.ResponseWriter which captures the response
+	// status code and body size.
+	responseCapture struct {
+		http.ResponseWriter
+		statusCode int
+		size       int64
+	}
+
+	// bodyCounter is a io.ReadCloser which counts the number of bytes read.
+	bodyCounter struct {
+		io.ReadCloser
+		size int64
+	}
+)
+
+// New returns a server middleware that creates a span for each request and
+// records the request duration and the request and response body sizes.
+//
+// mux is the goa muxer used to serve the requests, it is used to resolve the
+// route pattern that matches the request. The middleware must be mounted on
+// the muxer with its Use method for the pattern to be resolved:
+//
+//	mux := goahttp.NewMuxer()
+//	mux.Use(otel.New(mux))
+//
+// The span is named after the request method and route pattern. Use the
+// goa.design/goa/v3/middleware/otel Endpoint middleware to rename it after the
+// goa service and method and to add the corresponding metric attributes.
+func New(mux goahttp.ResolverMuxer, opts ...otel.Option) func(http.Handler) http.Handler {
+	var (
+		c        = otel.NewConfig(opts...)
+		tracer   = c.Tracer()
+		duration = c.Float64Histogram(semconv.HTTPServerRequestDurationName, semconv.HTTPServerRequestDurationUnit, semconv.HTTPServerRequestDurationDescription)
+		reqSize  = c.Int64Histogram(semconv.HTTPServerRequestBodySizeName, semconv.HTTPServerRequestBodySizeUnit, semconv.HTTPServerRequestBodySizeDescription)
+		respSize = c.Int64Histogram(semconv.HTTPServerResponseBodySizeName, semconv.HTTPServerResponseBodySizeUnit, semconv.HTTPServerResponseBodySizeDescription)
+	)
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var (
+				start   = time.Now()
+				route   = resolvePattern(mux, r)
+				labeler = &otel.Labeler{}
+				ctx     = c.Propagators.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+				span    trace.Span
+			)
+			ctx, span = tracer.Start(ctx, spanName(r.Method, route),
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(serverAttributes(r, route)...))
+			defer span.End()
+			ctx = otel.ContextWithLabeler(ctx, labeler)
+
+			var body *bodyCounter
+			if r.Body != nil && r.Body != http.NoBody {
+				body = &bodyCounter{ReadCloser: r.Body}
+				r.Body = body
+			}
+			rw := &responseCapture{ResponseWriter: w}
+			h.ServeHTTP(rw, r.WithContext(ctx))
+
+			if route == "" {
+				// The route may only be known once chi has routed the
+				// request.
+				if route = resolvePattern(mux, r); route != "" {
+					span.SetName(spanName(r.Method, route))
+					span.SetAttributes(semconv.HTTPRoute(route))
+				}
+			}
+			status := rw.status()
+			span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+			if status >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(status))
+			}
+
+			attrs := []attribute.KeyValue{
+				semconv.HTTPRequestMethodKey.String(r.Method),
+				semconv.HTTPResponseStatusCode(status),
+			}
+			if route != "" {
+				attrs = append(attrs, semconv.HTTPRoute(route))
+			}
+			set := metric.WithAttributes(append(attrs, labeler.Get()...)...)
+			duration.Record(ctx, time.Since(start).Seconds(), set)
+			var n int64
+			if body != nil {
+				n = body.size
+			}
+			reqSize.Record(ctx, n, set)
+			respSize.Record(ctx, rw.size, set)
+		})
+	}
+}
+
+// resolvePattern returns the route pattern that matches the request if any.
+func resolvePattern(mux goahttp.ResolverMuxer, r *http.Request) string {
+	if mux == nil {
+		return ""
+	}
+	return mux.ResolvePattern(r)
+}
+
+// spanName returns the name of the server span for the given request method
+// and route pattern.
+func spanName(method, route string) string {
+	if route == "" {
+		return method
+	}
+	return method + " " + route
+}
+
+// serverAttributes returns the attributes recorded in the server span when it
+// starts.
+func serverAttributes(r *http.Request, route string) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		semconv.HTTPRequestMethodKey.String(r.Method),
+		semconv.URLPath(r.URL.Path),
+	}
+	if route != "" {
+		attrs = append(attrs, semconv.HTTPRoute(route))
+	}
+	if r.Host != "" {
+		attrs = append(attrs, semconv.ServerAddress(r.Host))
+	}
+	if ua := r.UserAgent(); ua != "" {
+		attrs = append(attrs, semconv.UserAgentOriginal(ua))
+	}
+	return attrs
+}
+
+// WriteHeader records the value of the status code before writing it.
+func (w *responseCapture) WriteHeader(code int) {
+	if w.statusCode == 0 {
+		w.statusCode = code
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Write records the number of bytes written.
+func (w *responseCapture) Write(b []byte) (int, error) {
+	if w.statusCode == 0 {
+		w.statusCode = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(b)
+	w.size += int64(n)
+	return n, err
+}
+
+// Flush implements the http.Flusher interface if the underlying response
+// writer supports it.
+func (w *responseCapture) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack supports the http.Hijacker interface.
+func (w *responseCapture) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if h, ok := w.ResponseWriter.(http.Hijacker); ok {
+		return h.Hijack()
+	}
+	return nil, nil, fmt.Errorf("response writer does not support hijacking: %T", w.ResponseWriter)
+}
+
+// Unwrap returns the underlying response writer so that
+// http.ResponseController can access its features.
+func (w *responseCapture) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// status returns the response status code.
+func (w *responseCapture) status() int {
+	if w.statusCode == 0 {
+		return http.StatusOK
+	}
+	return w.statusCode
+}
+
+// Read records the number of bytes read.
+func (b *bodyCounter) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.size += int64(n)
+	return n, err
+}
@@ -0,0 +1,131 @@
+package otel
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	goahttp "goa.design/goa/v3/http"
+	"goa.design/goa/v3/middleware/otel"
+	"goa.design/goa/v3/middleware/otel/oteltest"
+	goa "goa.design/goa/v3/pkg"
+)
+
+const (
+	traceID     = "4bf92f3577b34da6a3ce929d0e0e4736"
+	spanID      = "00f067aa0ba902b7"
+	traceparent = "00-" + traceID + "-" + spanID + "-01"
+)
+
+func TestNew(t *testing.T) {
+	cases := []struct {
+		Name         string
+		Path         string
+		Traceparent  string
+		Endpoint     bool
+		Status       int
+		ExpectedName string
+		ExpectedErr  bool
+	}{
+		{"route", "/users/1", "", false, http.StatusOK, "POST /users/{id}", false},
+		{"endpoint", "/users/1", "", true, http.StatusOK, "svc/meth", false},
+		{"traceparent", "/users/1", traceparent, true, http.StatusOK, "svc/meth", false},
+		{"server error", "/users/1", "", false, http.StatusInternalServerError, "POST /users/{id}", true},
+		{"not found", "/unknown", "", false, http.StatusNotFound, "POST", false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			rec := oteltest.NewRecorder()
+			mux := goahttp.NewMuxer()
+			mux.Use(New(mux, rec.Options()...))
+			mux.Handle("POST", "/users/{id}", func(w http.ResponseWriter, r *http.Request) {
+				ctx := context.WithValue(r.Context(), goa.ServiceKey, "svc")
+				ctx = context.WithValue(ctx, goa.MethodKey, "meth")
+				ep := func(context.Context, any) (any, error) { return nil, nil }
+				if tc.Endpoint {
+					ep = otel.Endpoint(ep)
+				}
+				if _, err := ep(ctx, nil); err != nil {
+					t.Fatal(err)
+				}
+				if _, err := io.ReadAll(r.Body); err != nil {
+					t.Fatal(err)
+				}
+				w.WriteHeader(tc.Status)
+				w.Write([]byte("response")) // nolint: errcheck
+			})
+			req := httptest.NewRequest("POST", tc.Path, strings.NewReader("body"))
+			if tc.Traceparent != "" {
+				req.Header.Set("traceparent", tc.Traceparent)
+			}
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			spans := rec.Spans()
+			if len(spans) != 1 {
+				t.Fatalf("got %d spans, expected 1", len(spans))
+			}
+			span := spans[0]
+			if span.Name != tc.ExpectedName {
+				t.Errorf("got span name %q, expected %q", span.Name, tc.ExpectedName)
+			}
+			if span.SpanKind != trace.SpanKindServer {
+				t.Errorf("got span kind %v, expected server", span.SpanKind)
+			}
+			if tc.Traceparent != "" {
+				if got := span.SpanContext.TraceID().String(); got != traceID {
+					t.Errorf("got trace ID %q, expected %q", got, traceID)
+				}
+				if got := span.Parent.SpanID().String(); got != spanID {
+					t.Errorf("got parent span ID %q, expected %q", got, spanID)
+				}
+			}
+			attrs := attribute.NewSet(span.Attributes...)
+			if v, _ := attrs.Value(semconv.HTTPResponseStatusCodeKey); v.AsInt64() != int64(tc.Status) {
+				t.Errorf("got status code %d, expected %d", v.AsInt64(), tc.Status)
+			}
+			if isErr := span.Status.Code == codes.Error; isErr != tc.ExpectedErr {
+				t.Errorf("got error status %v, expected %v", isErr, tc.ExpectedErr)
+			}
+
+			m := rec.Metric(semconv.HTTPServerRequestDurationName)
+			if m == nil {
+				t.Fatal("request duration metric not recorded")
+			}
+			dps := m.Data.(metricdata.Histogram[float64]).DataPoints
+			if len(dps) != 1 || dps[0].Count != 1 {
+				t.Fatalf("got %d request duration data points, expected 1", len(dps))
+			}
+			if _, ok := dps[0].Attributes.Value(otel.ServiceKey); ok != tc.Endpoint {
+				t.Errorf("got service attribute %v, expected %v", ok, tc.Endpoint)
+			}
+			if tc.Status == http.StatusOK {
+				assertSize(t, rec, semconv.HTTPServerRequestBodySizeName, 4)
+				assertSize(t, rec, semconv.HTTPServerResponseBodySizeName, 8)
+			}
+		})
+	}
+}
+
+// assertSize checks that the histogram with the given name recorded a single
+// value equal to expected.
+func assertSize(t *testing.T, rec *oteltest.Recorder, name string, expected int64) {
+	t.Helper()
+	m := rec.Metric(name)
+	if m == nil {
+		t.Fatalf("metric %q not recorded", name)
+	}
+	dps := m.Data.(metricdata.Histogram[int64]).DataPoints
+	if len(dps) != 1 || dps[0].Sum != expected {
+		t.Errorf("got %+v for metric %q, expected a single value of %d", dps, name, expected)
+	}
+}
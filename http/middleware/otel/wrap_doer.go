@@ -0,0 +1,81 @@
+package otel
+
+import (
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	goahttp "goa.design/goa/v3/http"
+	"goa.design/goa/v3/middleware/otel"
+)
+
+// otelDoer is a goahttp.Doer middleware that creates client spans and records
+// metrics for the requests it makes.
+type otelDoer struct {
+	wrapped     goahttp.Doer
+	tracer      trace.Tracer
+	propagators propagation.TextMapPropagator
+	duration    metric.Float64Histogram
+	reqSize     metric.Int64Histogram
+	respSize    metric.Int64Histogram
+}
+
+// WrapDoer wraps a goa HTTP Doer, creates a client span for each request,
+// injects the W3C trace context in the request headers and records the request
+// duration and the request and response body sizes.
+func WrapDoer(doer goahttp.Doer, opts ...otel.Option) goahttp.Doer {
+	c := otel.NewConfig(opts...)
+	return &otelDoer{
+		wrapped:     doer,
+		tracer:      c.Tracer(),
+		propagators: c.Propagators,
+		duration:    c.Float64Histogram(semconv.HTTPClientRequestDurationName, semconv.HTTPClientRequestDurationUnit, semconv.HTTPClientRequestDurationDescription),
+		reqSize:     c.Int64Histogram(semconv.HTTPClientRequestBodySizeName, semconv.HTTPClientRequestBodySizeUnit, semconv.HTTPClientRequestBodySizeDescription),
+		respSize:    c.Int64Histogram(semconv.HTTPClientResponseBodySizeName, semconv.HTTPClientResponseBodySizeUnit, semconv.HTTPClientResponseBodySizeDescription),
+	}
+}
+
+// Do calls through to the wrapped Doer, creating a client span and recording
+// the request metrics.
+func (d *otelDoer) Do(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	attrs := []attribute.KeyValue{
+		semconv.HTTPRequestMethodKey.String(req.Method),
+		semconv.ServerAddress(req.URL.Hostname()),
+	}
+	ctx, span := d.tracer.Start(req.Context(), req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(append(attrs, semconv.URLFull(req.URL.String()))...))
+	defer span.End()
+
+	// Clone the request so that injecting the trace context does not modify
+	// the caller headers.
+	req = req.Clone(ctx)
+	d.propagators.Inject(ctx, propagation.HeaderCarrier(req.Header))
+	resp, err := d.wrapped.Do(req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	} else {
+		attrs = append(attrs, semconv.HTTPResponseStatusCode(resp.StatusCode))
+		span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+		if resp.StatusCode >= http.StatusBadRequest {
+			span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+		}
+	}
+
+	set := metric.WithAttributes(attrs...)
+	d.duration.Record(ctx, time.Since(start).Seconds(), set)
+	if req.ContentLength > 0 {
+		d.reqSize.Record(ctx, req.ContentLength, set)
+	}
+	if resp != nil && resp.ContentLength >= 0 {
+		d.respSize.Record(ctx, resp.ContentLength, set)
+	}
+	return resp, err
+}
@@ -0,0 +1,99 @@
+package otel
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"goa.design/goa/v3/middleware/otel/oteltest"
+)
+
+// testDoer records the request and returns the configured response.
+type testDoer struct {
+	req  *http.Request
+	code int
+	err  error
+}
+
+func TestWrapDoer(t *testing.T) {
+	cases := []struct {
+		Name        string
+		StatusCode  int
+		Error       error
+		ExpectedErr bool
+	}{
+		{"success", http.StatusOK, nil, false},
+		{"failed request", http.StatusBadRequest, nil, true},
+		{"error", 0, errors.New("error"), true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			rec := oteltest.NewRecorder()
+			ctx, parent := rec.TracerProvider.Tracer("test").Start(context.Background(), "parent")
+			req, err := http.NewRequestWithContext(ctx, "POST", "http://somehost/path", strings.NewReader("body"))
+			if err != nil {
+				t.Fatalf("error creating HTTP request: %v", err)
+			}
+			doer := &testDoer{code: tc.StatusCode, err: tc.Error}
+
+			resp, err := WrapDoer(doer, rec.Options()...).Do(req)
+			parent.End()
+
+			if (err != nil) != (tc.Error != nil) {
+				t.Fatalf("got error %v, expected %v", err, tc.Error)
+			}
+			if resp != nil {
+				resp.Body.Close() // nolint: errcheck
+			}
+			if req.Header.Get("traceparent") != "" {
+				t.Error("original request headers modified")
+			}
+			spans := rec.Spans()
+			if len(spans) != 2 {
+				t.Fatalf("got %d spans, expected 2", len(spans))
+			}
+			span := spans[0]
+			if span.Name != "POST" {
+				t.Errorf("got span name %q, expected %q", span.Name, "POST")
+			}
+			if span.SpanKind != trace.SpanKindClient {
+				t.Errorf("got span kind %v, expected client", span.SpanKind)
+			}
+			if span.Parent.SpanID() != parent.SpanContext().SpanID() {
+				t.Errorf("got parent span %s, expected %s", span.Parent.SpanID(), parent.SpanContext().SpanID())
+			}
+			expected := "00-" + span.SpanContext.TraceID().String() + "-" + span.SpanContext.SpanID().String() + "-01"
+			if got := doer.req.Header.Get("traceparent"); got != expected {
+				t.Errorf("got traceparent %q, expected %q", got, expected)
+			}
+			if isErr := span.Status.Code == codes.Error; isErr != tc.ExpectedErr {
+				t.Errorf("got error status %v, expected %v", isErr, tc.ExpectedErr)
+			}
+			if rec.Metric(semconv.HTTPClientRequestDurationName) == nil {
+				t.Error("request duration metric not recorded")
+			}
+			assertSize(t, rec, semconv.HTTPClientRequestBodySizeName, 4)
+			if tc.Error == nil {
+				assertSize(t, rec, semconv.HTTPClientResponseBodySizeName, 8)
+			}
+		})
+	}
+}
+
+func (d *testDoer) Do(req *http.Request) (*http.Response, error) {
+	d.req = req
+	if d.err != nil {
+		return nil, d.err
+	}
+	return &http.Response{
+		StatusCode:    d.code,
+		ContentLength: 8,
+		Body:          io.NopCloser(strings.NewReader("response")),
+	}, nil
+}
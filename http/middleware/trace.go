@@ -32,9 +32,9 @@ const (
 
 // Trace returns a trace middleware that initializes the trace information in
 // the request context.
-// Deprecated: use OpenTelemetry instead, see for example
-// https://github.com/goadesign/clue. This function will be removed in a future
-// version of Goa.
+// Deprecated: use OpenTelemetry instead, see the
+// goa.design/goa/v3/http/middleware/otel package. This function will be
+// removed in a future version of Goa.
 func Trace(opts ...middleware.TraceOption) func(http.Handler) http.Handler {
 	o := middleware.NewTraceOptions(opts...)
 	sampler := o.NewSampler()
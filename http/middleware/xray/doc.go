@@ -12,8 +12,8 @@ The client middleware wraps the client Doer and works by extracing the
 segment from the request context. It creates a new sub-segment and updates
 the request context with the latest segment before making the request.
 
-Deprecated: use OpenTelemetry instead, see for example
-https://github.com/goadesign/clue. This package will be removed in a future
-version of Goa.
+Deprecated: use OpenTelemetry instead, see the
+goa.design/goa/v3/http/middleware/otel package. This package will be removed
+in a future version of Goa.
 */
 package xray
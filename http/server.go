@@ -67,3 +67,14 @@ func Replace(old, nw string, h http.Handler) http.Handler {
 		}
 	})
 }
+
+// NextPageLink returns the value of the "Link" header that refers to the next
+// page of the results of a paginated endpoint. The link is the URL of the
+// request r with the query string parameter param set to cursor.
+func NextPageLink(r *http.Request, param, cursor string) string {
+	u := url.URL{Path: r.URL.Path, RawPath: r.URL.RawPath}
+	q := r.URL.Query()
+	q.Set(param, cursor)
+	u.RawQuery = q.Encode()
+	return "<" + u.RequestURI() + `>; rel="next"`
+}
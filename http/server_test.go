@@ -61,3 +61,24 @@ func TestReplace(t *testing.T) {
 		})
 	}
 }
+
+func TestNextPageLink(t *testing.T) {
+	cases := []struct {
+		name   string
+		url    string
+		cursor string
+		link   string
+	}{
+		{"no query", "http://localhost/items", "abc", `</items?cursor=abc>; rel="next"`},
+		{"replace cursor", "/items?cursor=abc&limit=10", "def", `</items?cursor=def&limit=10>; rel="next"`},
+		{"escape", "/items/a%2Fb", "a b&c", `</items/a%2Fb?cursor=a+b%26c>; rel="next"`},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", c.url, nil)
+			if got := NextPageLink(r, "cursor", c.cursor); got != c.link {
+				t.Errorf("got %q, want %q", got, c.link)
+			}
+		})
+	}
+}
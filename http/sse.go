@@ -0,0 +1,187 @@
+package http
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	// EventStreamContentType is the content type of Server-Sent Events
+	// streams.
+	EventStreamContentType = "text/event-stream"
+
+	// LastEventIDHeader is the name of the HTTP header used by Server-Sent
+	// Events clients to resume a stream after the last event they received.
+	LastEventIDHeader = "Last-Event-ID"
+)
+
+type (
+	// ServerSentEvent is a single event sent over a Server-Sent Events
+	// stream as described in
+	// https://html.spec.whatwg.org/multipage/server-sent-events.html.
+	ServerSentEvent struct {
+		// ID is the event identifier. It is sent back to the server by
+		// clients via the Last-Event-ID header when resuming a stream.
+		ID string
+		// Event is the event type.
+		Event string
+		// Retry is the reconnection time in milliseconds. Zero means the
+		// field is not sent.
+		Retry int
+		// Data is the event payload. Multiline payloads are split into
+		// multiple "data" fields.
+		Data []byte
+	}
+
+	// ServerSentEventWriter writes Server-Sent Events to a HTTP response
+	// flushing the response after each event.
+	ServerSentEventWriter struct {
+		w      http.ResponseWriter
+		opened bool
+	}
+
+	// ServerSentEventReader reads Server-Sent Events from a stream.
+	ServerSentEventReader struct {
+		r *bufio.Reader
+		// lastID is the ID of the last event read from the stream.
+		lastID string
+	}
+)
+
+// ErrSSEStreamingUnsupported is returned by ServerSentEventWriter when the
+// underlying response writer does not support flushing.
+var ErrSSEStreamingUnsupported = errors.New("response writer does not support flushing, cannot stream server-sent events")
+
+// NewServerSentEventWriter returns a writer that streams Server-Sent Events to
+// w.
+func NewServerSentEventWriter(w http.ResponseWriter) *ServerSentEventWriter {
+	return &ServerSentEventWriter{w: w}
+}
+
+// Opened returns true if the stream response headers have been written.
+func (sw *ServerSentEventWriter) Opened() bool {
+	return sw.opened
+}
+
+// Open writes the stream response headers if not already written.
+func (sw *ServerSentEventWriter) Open() error {
+	if sw.opened {
+		return nil
+	}
+	if _, ok := sw.w.(http.Flusher); !ok {
+		return ErrSSEStreamingUnsupported
+	}
+	h := sw.w.Header()
+	h.Set("Content-Type", EventStreamContentType)
+	h.Set("Cache-Control", "no-cache")
+	h.Set("Connection", "keep-alive")
+	sw.w.WriteHeader(http.StatusOK)
+	sw.opened = true
+	sw.w.(http.Flusher).Flush()
+	return nil
+}
+
+// Write writes ev to the stream and flushes the response.
+func (sw *ServerSentEventWriter) Write(ev *ServerSentEvent) error {
+	if err := sw.Open(); err != nil {
+		return err
+	}
+	if err := WriteServerSentEvent(sw.w, ev); err != nil {
+		return err
+	}
+	sw.w.(http.Flusher).Flush()
+	return nil
+}
+
+// WriteServerSentEvent writes the wire representation of ev to w.
+func WriteServerSentEvent(w io.Writer, ev *ServerSentEvent) error {
+	var buf bytes.Buffer
+	if ev.ID != "" {
+		buf.WriteString("id: " + sanitizeSSEField(ev.ID) + "\n")
+	}
+	if ev.Event != "" {
+		buf.WriteString("event: " + sanitizeSSEField(ev.Event) + "\n")
+	}
+	if ev.Retry > 0 {
+		buf.WriteString("retry: " + strconv.Itoa(ev.Retry) + "\n")
+	}
+	data := strings.ReplaceAll(string(ev.Data), "\r\n", "\n")
+	for _, line := range strings.Split(data, "\n") {
+		buf.WriteString("data: " + line + "\n")
+	}
+	buf.WriteString("\n")
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// NewServerSentEventReader returns a reader that parses the Server-Sent Events
+// written to r.
+func NewServerSentEventReader(r io.Reader) *ServerSentEventReader {
+	return &ServerSentEventReader{r: bufio.NewReader(r)}
+}
+
+// LastEventID returns the ID of the last event read from the stream. Clients
+// may use it to set the Last-Event-ID header when reconnecting.
+func (sr *ServerSentEventReader) LastEventID() string {
+	return sr.lastID
+}
+
+// Next reads the next event from the stream. It returns io.EOF when the stream
+// ends. Comments and events without data are skipped and incomplete events
+// at the end of the stream are discarded as mandated by the specification. The
+// event type and retry fields of skipped events do not carry over to the next
+// event.
+func (sr *ServerSentEventReader) Next() (*ServerSentEvent, error) {
+	var (
+		ev   = ServerSentEvent{ID: sr.lastID}
+		data []string
+	)
+	for {
+		line, err := sr.r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			if data == nil {
+				// Discard the pending event, only the last event ID
+				// persists across events.
+				sr.lastID = ev.ID
+				ev = ServerSentEvent{ID: sr.lastID}
+				continue
+			}
+			ev.Data = []byte(strings.Join(data, "\n"))
+			sr.lastID = ev.ID
+			return &ev, nil
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "id":
+			if !strings.Contains(value, "\x00") {
+				ev.ID = value
+			}
+		case "event":
+			ev.Event = value
+		case "retry":
+			if n, err := strconv.Atoi(value); err == nil {
+				ev.Retry = n
+			}
+		case "data":
+			data = append(data, value)
+		}
+	}
+}
+
+// sanitizeSSEField removes line breaks from single line event fields.
+func sanitizeSSEField(v string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(v)
+}
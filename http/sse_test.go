@@ -0,0 +1,102 @@
+package http
+
+import (
+	"bytes"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteServerSentEvent(t *testing.T) {
+	cases := []struct {
+		name  string
+		event *ServerSentEvent
+		want  string
+	}{
+		{"data only", &ServerSentEvent{Data: []byte("hello")}, "data: hello\n\n"},
+		{"empty data", &ServerSentEvent{}, "data: \n\n"},
+		{"all fields", &ServerSentEvent{ID: "1", Event: "update", Retry: 1000, Data: []byte(`{"a":1}`)}, "id: 1\nevent: update\nretry: 1000\ndata: {\"a\":1}\n\n"},
+		{"multiline data", &ServerSentEvent{Data: []byte("a\nb\r\nc")}, "data: a\ndata: b\ndata: c\n\n"},
+		{"sanitized fields", &ServerSentEvent{ID: "1\n2", Event: "a\r\nb", Data: []byte("x")}, "id: 12\nevent: ab\ndata: x\n\n"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, WriteServerSentEvent(&buf, c.event))
+			assert.Equal(t, c.want, buf.String())
+		})
+	}
+}
+
+func TestServerSentEventReader(t *testing.T) {
+	stream := ": comment\n" +
+		"retry: 500\n" +
+		"data: first\n\n" +
+		"id: 42\n" +
+		"event: update\n" +
+		"data: line 1\n" +
+		"data: line 2\n\n" +
+		"\n" +
+		"data:no space\r\n\r\n" +
+		"data: incomplete"
+
+	r := NewServerSentEventReader(strings.NewReader(stream))
+
+	ev, err := r.Next()
+	require.NoError(t, err)
+	assert.Equal(t, &ServerSentEvent{Retry: 500, Data: []byte("first")}, ev)
+
+	ev, err = r.Next()
+	require.NoError(t, err)
+	assert.Equal(t, &ServerSentEvent{ID: "42", Event: "update", Data: []byte("line 1\nline 2")}, ev)
+	assert.Equal(t, "42", r.LastEventID())
+
+	ev, err = r.Next()
+	require.NoError(t, err)
+	assert.Equal(t, &ServerSentEvent{ID: "42", Data: []byte("no space")}, ev)
+
+	_, err = r.Next()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestServerSentEventReaderSkippedEvent(t *testing.T) {
+	stream := "event: ignored\n" +
+		"retry: 100\n" +
+		"\n" +
+		"id: 7\n" +
+		"event: ignored\n" +
+		"\n" +
+		"data: plain\n\n"
+
+	r := NewServerSentEventReader(strings.NewReader(stream))
+
+	ev, err := r.Next()
+	require.NoError(t, err)
+	assert.Equal(t, &ServerSentEvent{ID: "7", Data: []byte("plain")}, ev)
+	assert.Equal(t, "7", r.LastEventID())
+}
+
+func TestServerSentEventWriter(t *testing.T) {
+	w := httptest.NewRecorder()
+	sw := NewServerSentEventWriter(w)
+	assert.False(t, sw.Opened())
+
+	require.NoError(t, sw.Write(&ServerSentEvent{ID: "1", Data: []byte("a")}))
+	require.NoError(t, sw.Write(&ServerSentEvent{ID: "2", Data: []byte("b")}))
+
+	assert.True(t, sw.Opened())
+	assert.True(t, w.Flushed)
+	assert.Equal(t, EventStreamContentType, w.Header().Get("Content-Type"))
+	assert.Equal(t, "no-cache", w.Header().Get("Cache-Control"))
+
+	r := NewServerSentEventReader(w.Body)
+	for _, want := range []string{"a", "b"} {
+		ev, err := r.Next()
+		require.NoError(t, err)
+		assert.Equal(t, want, string(ev.Data))
+	}
+}
@@ -0,0 +1,205 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+
+	goahttp "goa.design/goa/v3/http"
+	goa "goa.design/goa/v3/pkg"
+)
+
+type (
+	// Client makes JSON-RPC requests to a single HTTP endpoint.
+	Client struct {
+		doer goahttp.Doer
+		url  string
+		ids  atomic.Uint64
+	}
+
+	// Call describes a single request made as part of a batch.
+	Call struct {
+		// Method is the name of the method to be invoked.
+		Method string
+		// Params holds the parameter values, encoded to JSON.
+		Params any
+		// Notification indicates that the request does not expect a
+		// response.
+		Notification bool
+		// Result is set to the response result after the batch request
+		// completes successfully.
+		Result json.RawMessage
+		// Error is set to the response error after the batch request
+		// completes successfully.
+		Error error
+	}
+
+	// clientResponse is the response object decoded by the client. The
+	// error data is kept raw so it can be decoded into a Goa error.
+	clientResponse struct {
+		ID     json.RawMessage `json:"id"`
+		Result json.RawMessage `json:"result"`
+		Error  *struct {
+			Code    int             `json:"code"`
+			Message string          `json:"message"`
+			Data    json.RawMessage `json:"data"`
+		} `json:"error"`
+	}
+)
+
+// NewClient returns a client that sends JSON-RPC requests to the given URL
+// using doer.
+func NewClient(doer goahttp.Doer, url string) *Client {
+	return &Client{doer: doer, url: url}
+}
+
+// Call invokes the given method and returns the raw response result. Error
+// responses are returned as Goa service errors when the error data contains
+// the Goa error name and as *Error otherwise.
+func (c *Client) Call(ctx context.Context, method string, params any) (json.RawMessage, error) {
+	req, err := c.request(method, params, false)
+	if err != nil {
+		return nil, err
+	}
+	var resp clientResponse
+	found, err := c.send(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("jsonrpc: empty response to %q request", method)
+	}
+	if resp.Error != nil {
+		return nil, decodeError(resp.Error.Code, resp.Error.Message, resp.Error.Data)
+	}
+	return resp.Result, nil
+}
+
+// Notify sends a notification for the given method, the server does not send
+// back a response.
+func (c *Client) Notify(ctx context.Context, method string, params any) error {
+	req, err := c.request(method, params, true)
+	if err != nil {
+		return err
+	}
+	_, err = c.send(ctx, req, nil)
+	return err
+}
+
+// Batch sends the given calls in a single batch request. It sets the Result
+// or Error field of each call that is not a notification with the
+// corresponding response.
+func (c *Client) Batch(ctx context.Context, calls ...*Call) error {
+	if len(calls) == 0 {
+		return nil
+	}
+	reqs := make([]*Request, len(calls))
+	byID := make(map[string]*Call, len(calls))
+	for i, call := range calls {
+		req, err := c.request(call.Method, call.Params, call.Notification)
+		if err != nil {
+			return err
+		}
+		reqs[i] = req
+		if !call.Notification {
+			byID[string(req.ID)] = call
+		}
+	}
+	var resps []*clientResponse
+	if _, err := c.send(ctx, reqs, &resps); err != nil {
+		return err
+	}
+	for _, resp := range resps {
+		call, ok := byID[string(resp.ID)]
+		if !ok {
+			if resp.Error != nil {
+				return decodeError(resp.Error.Code, resp.Error.Message, resp.Error.Data)
+			}
+			continue
+		}
+		if resp.Error != nil {
+			call.Error = decodeError(resp.Error.Code, resp.Error.Message, resp.Error.Data)
+			continue
+		}
+		call.Result = resp.Result
+	}
+	return nil
+}
+
+// request builds a request object for the given method and params.
+func (c *Client) request(method string, params any, notification bool) (*Request, error) {
+	req := &Request{JSONRPC: Version, Method: method}
+	if params != nil {
+		p, err := json.Marshal(params)
+		if err != nil {
+			return nil, fmt.Errorf("jsonrpc: failed to encode %q params: %w", method, err)
+		}
+		req.Params = p
+	}
+	if !notification {
+		req.ID = json.RawMessage(strconv.FormatUint(c.ids.Add(1), 10))
+	}
+	return req, nil
+}
+
+// send posts body to the server and decodes the response into v if any. It
+// returns false if the server did not send back a response.
+func (c *Client) send(ctx context.Context, body, v any) (bool, error) {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return false, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(b))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.doer.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("jsonrpc: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return false, err
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return false, fmt.Errorf("jsonrpc: invalid response status %d: %s", resp.StatusCode, string(data))
+	}
+	if len(bytes.TrimSpace(data)) == 0 || v == nil {
+		return false, nil
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return false, fmt.Errorf("jsonrpc: failed to decode response: %w", err)
+	}
+	return true, nil
+}
+
+// decodeError returns the error corresponding to the given error object. It
+// returns a Goa service error if data contains the Goa error name.
+func decodeError(code int, msg string, data json.RawMessage) error {
+	if len(data) > 0 {
+		var ed ErrorData
+		if err := json.Unmarshal(data, &ed); err == nil && ed.Name != "" {
+			return &goa.ServiceError{
+				Name:      ed.Name,
+				ID:        ed.ID,
+				Field:     ed.Field,
+				Message:   msg,
+				Temporary: ed.Temporary,
+				Timeout:   ed.Timeout,
+				Fault:     ed.Fault,
+			}
+		}
+	}
+	jerr := &Error{Code: code, Message: msg}
+	if len(data) > 0 {
+		jerr.Data = data
+	}
+	return jerr
+}
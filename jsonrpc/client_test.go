@@ -0,0 +1,58 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestClient(t *testing.T) {
+	srv := httptest.NewServer(NewHandler(testDispatch))
+	defer srv.Close()
+	c := NewClient(srv.Client(), srv.URL)
+	ctx := context.Background()
+
+	t.Run("call", func(t *testing.T) {
+		res, err := c.Call(ctx, "echo", "hello")
+		require.NoError(t, err)
+		assert.JSONEq(t, `"hello"`, string(res))
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		_, err := c.Call(ctx, "fail", nil)
+		var gerr *goa.ServiceError
+		require.ErrorAs(t, err, &gerr)
+		assert.Equal(t, "bad", gerr.Name)
+		assert.Equal(t, "failed", gerr.Message)
+	})
+
+	t.Run("jsonrpc error", func(t *testing.T) {
+		_, err := c.Call(ctx, "foo", nil)
+		var jerr *Error
+		require.ErrorAs(t, err, &jerr)
+		assert.Equal(t, MethodNotFound, jerr.Code)
+	})
+
+	t.Run("notify", func(t *testing.T) {
+		assert.NoError(t, c.Notify(ctx, "echo", "hello"))
+	})
+
+	t.Run("batch", func(t *testing.T) {
+		calls := []*Call{
+			{Method: "echo", Params: "a"},
+			{Method: "echo", Params: "b", Notification: true},
+			{Method: "fail"},
+		}
+		require.NoError(t, c.Batch(ctx, calls...))
+		assert.Equal(t, json.RawMessage(`"a"`), calls[0].Result)
+		assert.NoError(t, calls[0].Error)
+		assert.Nil(t, calls[1].Result)
+		assert.Error(t, calls[2].Error)
+	})
+}
@@ -0,0 +1,109 @@
+package codegen
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	httpcodegen "goa.design/goa/v3/http/codegen"
+)
+
+// ClientFiles returns the client files for every JSON-RPC service. The files
+// contain the client that makes JSON-RPC requests to the service methods and
+// the corresponding encoders and decoders.
+func ClientFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
+	var fw []*codegen.File
+	for _, svc := range root.API.JSONRPC.Services {
+		if f := clientFile(genpkg, svc); f != nil {
+			fw = append(fw, f)
+		}
+	}
+	for _, svc := range root.API.JSONRPC.Services {
+		if f := clientEncodeDecodeFile(genpkg, svc); f != nil {
+			fw = append(fw, f)
+		}
+	}
+	return fw
+}
+
+// clientFile returns the file implementing the JSON-RPC client.
+func clientFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
+	data := httpcodegen.JSONRPCServices.Get(svc.Name())
+	if len(data.Endpoints) == 0 {
+		return nil
+	}
+	svcName := data.Service.PathName
+	fpath := filepath.Join(codegen.Gendir, "jsonrpc", svcName, "client", "client.go")
+	title := fmt.Sprintf("%s JSON-RPC client", svc.Name())
+	imports := []*codegen.ImportSpec{
+		{Path: "context"},
+		{Path: "net/url"},
+		codegen.GoaImport(""),
+		codegen.GoaNamedImport("http", "goahttp"),
+		codegen.GoaImport("jsonrpc"),
+		{Path: path.Join(genpkg, svcName), Name: data.Service.PkgName},
+	}
+	imports = append(imports, data.Service.UserTypeImports...)
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(title, "client", imports),
+		{Name: "client-struct", Source: readTemplate("client_struct"), Data: data},
+		{Name: "client-init", Source: readTemplate("client_init"), Data: data},
+	}
+	for _, e := range data.Endpoints {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "client-endpoint-init",
+			Source: readTemplate("client_endpoint_init"),
+			Data:   e,
+		})
+	}
+	return &codegen.File{Path: fpath, SectionTemplates: sections}
+}
+
+// clientEncodeDecodeFile returns the file defining the JSON-RPC client
+// encoding and decoding logic.
+func clientEncodeDecodeFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
+	data := httpcodegen.JSONRPCServices.Get(svc.Name())
+	if len(data.Endpoints) == 0 {
+		return nil
+	}
+	svcName := data.Service.PathName
+	fpath := filepath.Join(codegen.Gendir, "jsonrpc", svcName, "client", "encode_decode.go")
+	title := fmt.Sprintf("%s JSON-RPC client encoders and decoders", svc.Name())
+	imports := []*codegen.ImportSpec{
+		{Path: "encoding/json"},
+		{Path: "unicode/utf8"},
+		codegen.GoaImport(""),
+		codegen.GoaNamedImport("http", "goahttp"),
+		{Path: path.Join(genpkg, svcName), Name: data.Service.PkgName},
+		{Path: path.Join(genpkg, svcName, "views"), Name: data.Service.ViewsPkg},
+	}
+	imports = append(imports, data.Service.UserTypeImports...)
+	sections := []*codegen.SectionTemplate{codegen.Header(title, "client", imports)}
+
+	for _, e := range data.Endpoints {
+		if e.Payload.Ref != "" {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "request-encoder",
+				Source: readTemplate("request_encoder"),
+				Data:   e,
+			})
+		}
+		if e.Result.Ref != "" {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "response-decoder",
+				Source: readTemplate("response_decoder"),
+				Data:   e,
+			})
+		}
+	}
+	for _, h := range data.ClientTransformHelpers {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "client-transform-helper",
+			Source: readTemplate("transform_helper"),
+			Data:   h,
+		})
+	}
+	return &codegen.File{Path: fpath, SectionTemplates: sections}
+}
@@ -0,0 +1,196 @@
+package codegen
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/cli"
+	"goa.design/goa/v3/expr"
+	httpcodegen "goa.design/goa/v3/http/codegen"
+)
+
+// ClientCLIFiles returns the CLI files to generate a command-line client that
+// makes JSON-RPC requests.
+func ClientCLIFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
+	var (
+		data []*cli.CommandData
+		svcs []*expr.HTTPServiceExpr
+	)
+	for _, svc := range root.API.JSONRPC.Services {
+		sd := httpcodegen.JSONRPCServices.Get(svc.Name())
+		if len(sd.Endpoints) == 0 {
+			continue
+		}
+		command := cli.BuildCommandData(sd.Service)
+		for _, e := range sd.Endpoints {
+			flags, buildFunction := buildFlags(e)
+			subcmd := cli.BuildSubcommandData(sd.Service.Name, e.Method, buildFunction, flags)
+			command.Subcommands = append(command.Subcommands, subcmd)
+		}
+		command.Example = command.Subcommands[0].Example
+		data = append(data, command)
+		svcs = append(svcs, svc)
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	var files []*codegen.File
+	for _, svr := range root.API.Servers {
+		var svrData []*cli.CommandData
+		for _, name := range svr.Services {
+			for i, svc := range svcs {
+				if svc.Name() == name {
+					svrData = append(svrData, data[i])
+				}
+			}
+		}
+		if len(svrData) > 0 {
+			files = append(files, endpointParser(genpkg, svr, svrData))
+		}
+	}
+	for i, svc := range svcs {
+		files = append(files, payloadBuilders(genpkg, svc, data[i]))
+	}
+	return files
+}
+
+// endpointParser returns the file that implements the command line parser that
+// builds the client endpoint and payload necessary to perform a request.
+func endpointParser(genpkg string, svr *expr.ServerExpr, data []*cli.CommandData) *codegen.File {
+	pkg := codegen.SnakeCase(codegen.Goify(svr.Name, true))
+	fpath := filepath.Join(codegen.Gendir, "jsonrpc", "cli", pkg, "cli.go")
+	title := fmt.Sprintf("%s JSON-RPC client CLI support package", svr.Name)
+	specs := []*codegen.ImportSpec{
+		{Path: "encoding/json"},
+		{Path: "flag"},
+		{Path: "fmt"},
+		{Path: "os"},
+		{Path: "strconv"},
+		{Path: "unicode/utf8"},
+		codegen.GoaImport(""),
+		codegen.GoaNamedImport("http", "goahttp"),
+	}
+	for _, cmd := range data {
+		sd := httpcodegen.JSONRPCServices.Get(cmd.Name)
+		specs = append(specs, &codegen.ImportSpec{
+			Path: path.Join(genpkg, "jsonrpc", sd.Service.PathName, "client"),
+			Name: sd.Service.PkgName + "c",
+		})
+	}
+
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(title, "cli", specs),
+		cli.UsageCommands(data),
+		cli.UsageExamples(data),
+		{
+			Name:   "parse-endpoint",
+			Source: readTemplate("parse_endpoint"),
+			Data: struct {
+				FlagsCode string
+				Commands  []*cli.CommandData
+			}{
+				cli.FlagsCode(data),
+				data,
+			},
+		},
+	}
+	for _, cmd := range data {
+		sections = append(sections, cli.CommandUsage(cmd))
+	}
+	return &codegen.File{Path: fpath, SectionTemplates: sections}
+}
+
+// payloadBuilders returns the file that contains the payload constructors that
+// use flag values as arguments.
+func payloadBuilders(genpkg string, svc *expr.HTTPServiceExpr, data *cli.CommandData) *codegen.File {
+	sd := httpcodegen.JSONRPCServices.Get(svc.Name())
+	fpath := filepath.Join(codegen.Gendir, "jsonrpc", sd.Service.PathName, "client", "cli.go")
+	title := fmt.Sprintf("%s JSON-RPC client CLI support package", svc.Name())
+	specs := []*codegen.ImportSpec{
+		{Path: "encoding/json"},
+		{Path: "fmt"},
+		{Path: "strconv"},
+		{Path: "unicode/utf8"},
+		codegen.GoaImport(""),
+		{Path: path.Join(genpkg, sd.Service.PathName), Name: sd.Service.PkgName},
+	}
+	specs = append(specs, sd.Service.UserTypeImports...)
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(title, "client", specs),
+	}
+	for _, sub := range data.Subcommands {
+		if sub.BuildFunction != nil {
+			sections = append(sections, cli.PayloadBuilderSection(sub.BuildFunction))
+		}
+	}
+	return &codegen.File{Path: fpath, SectionTemplates: sections}
+}
+
+// buildFlags returns the flags and payload builder used by the CLI to build
+// the method payload. JSON-RPC payloads are read from a single "params" flag
+// unless they are primitives in which case the generic "p" flag is used.
+func buildFlags(e *httpcodegen.EndpointData) ([]*cli.FlagData, *cli.BuildFunctionData) {
+	if e.Payload.Ref == "" {
+		return nil, nil
+	}
+	init := e.Payload.Request.PayloadInit
+	if init == nil {
+		f := cli.NewFlagData(e.ServiceName, e.Method.Name, "p", e.Method.PayloadRef, e.Method.PayloadDesc, true, e.Method.PayloadEx, e.Method.PayloadDefault)
+		return []*cli.FlagData{f}, nil
+	}
+	var (
+		flags  []*cli.FlagData
+		params []string
+		fdata  []*cli.FieldData
+		args   []*codegen.InitArgData
+		check  bool
+	)
+	for _, arg := range init.ClientArgs {
+		f := cli.NewFlagData(e.ServiceName, e.Method.Name, "params", arg.TypeName, arg.Description, arg.Required, arg.Example, arg.DefaultValue)
+		flags = append(flags, f)
+		params = append(params, f.FullName)
+		code, chek := cli.FieldLoadCode(f, arg.VarName, arg.TypeName, arg.Validate, arg.DefaultValue, e.Payload.Request.PayloadType, e.Payload.Ref)
+		check = check || chek
+		tn := arg.TypeRef
+		if f.Type == "JSON" {
+			// We need to declare the variable without a pointer to
+			// be able to unmarshal the JSON using its address.
+			tn = arg.TypeName
+		}
+		fdata = append(fdata, &cli.FieldData{
+			Name:    arg.VarName,
+			VarName: arg.VarName,
+			TypeRef: tn,
+			Init:    code,
+		})
+		args = append(args, &codegen.InitArgData{
+			Name:         arg.VarName,
+			Pointer:      arg.Pointer,
+			FieldName:    arg.FieldName,
+			FieldPointer: arg.FieldPointer,
+			FieldType:    arg.FieldType,
+			Type:         arg.Type,
+		})
+	}
+	return flags, &cli.BuildFunctionData{
+		Name:         "Build" + e.Method.VarName + "Payload",
+		ActualParams: params,
+		FormalParams: params,
+		ServiceName:  e.ServiceName,
+		MethodName:   e.Method.Name,
+		ResultType:   e.Payload.Ref,
+		Fields:       fdata,
+		PayloadInit: &cli.PayloadInitData{
+			Code:                       init.ClientCode,
+			ReturnTypeAttribute:        init.ReturnTypeAttribute,
+			ReturnTypeAttributePointer: init.ReturnIsPrimitivePointer,
+			ReturnIsStruct:             init.ReturnIsStruct,
+			ReturnTypeName:             init.ReturnTypeName,
+			ReturnTypePkg:              init.ReturnTypePkg,
+			Args:                       args,
+		},
+		CheckErr: check,
+	}
+}
@@ -0,0 +1,96 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/jsonrpc/codegen/testdata"
+)
+
+func TestClientEndpointInit(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+		Code string
+	}{
+		{"unary", testdata.UnaryDSL, testdata.UnaryClientEndpointInitCode},
+		{"primitive", testdata.PrimitiveDSL, testdata.PrimitiveClientEndpointInitCode},
+		{"no-payload-no-result", testdata.NoPayloadNoResultDSL, testdata.NoPayloadNoResultClientEndpointInitCode},
+		{"viewed-result", testdata.ViewedResultDSL, testdata.ViewedResultClientEndpointInitCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunJSONRPCDSL(t, c.DSL)
+			fs := ClientFiles("", expr.Root)
+			if len(fs) != 2 {
+				t.Fatalf("got %d files, expected two", len(fs))
+			}
+			sections := fs[0].Section("client-endpoint-init")
+			if len(sections) == 0 {
+				t.Fatalf("got zero sections, expected at least one")
+			}
+			code := codegen.SectionsCode(t, sections)
+			if code != c.Code {
+				t.Errorf("%s: got\n%s\ngot vs. expected:\n%s", c.Name, code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
+
+func TestRequestEncoder(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+		Code string
+	}{
+		{"unary", testdata.UnaryDSL, testdata.UnaryRequestEncoderCode},
+		{"primitive", testdata.PrimitiveDSL, testdata.PrimitiveRequestEncoderCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunJSONRPCDSL(t, c.DSL)
+			fs := ClientFiles("", expr.Root)
+			if len(fs) != 2 {
+				t.Fatalf("got %d files, expected two", len(fs))
+			}
+			sections := fs[1].Section("request-encoder")
+			if len(sections) == 0 {
+				t.Fatalf("got zero sections, expected at least one")
+			}
+			code := codegen.SectionsCode(t, sections)
+			if code != c.Code {
+				t.Errorf("%s: got\n%s\ngot vs. expected:\n%s", c.Name, code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
+
+func TestResponseDecoder(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+		Code string
+	}{
+		{"unary", testdata.UnaryDSL, testdata.UnaryResponseDecoderCode},
+		{"primitive", testdata.PrimitiveDSL, testdata.PrimitiveResponseDecoderCode},
+		{"viewed-result", testdata.ViewedResultDSL, testdata.ViewedResultResponseDecoderCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunJSONRPCDSL(t, c.DSL)
+			fs := ClientFiles("", expr.Root)
+			if len(fs) != 2 {
+				t.Fatalf("got %d files, expected two", len(fs))
+			}
+			sections := fs[1].Section("response-decoder")
+			if len(sections) == 0 {
+				t.Fatalf("got zero sections, expected at least one")
+			}
+			code := codegen.SectionsCode(t, sections)
+			if code != c.Code {
+				t.Errorf("%s: got\n%s\ngot vs. expected:\n%s", c.Name, code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package codegen
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	httpcodegen "goa.design/goa/v3/http/codegen"
+)
+
+// ClientTypeFiles returns the JSON-RPC transport client types files.
+func ClientTypeFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
+	var fw []*codegen.File
+	for _, svc := range root.API.JSONRPC.Services {
+		if f := clientType(genpkg, svc); f != nil {
+			fw = append(fw, f)
+		}
+	}
+	return fw
+}
+
+// clientType returns the file containing the type definitions used by the
+// JSON-RPC client to encode the request params and decode the response
+// results. The types follow the same rules as the HTTP request and response
+// body types.
+func clientType(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
+	data := httpcodegen.JSONRPCServices.Get(svc.Name())
+	if len(data.Endpoints) == 0 {
+		return nil
+	}
+	svcName := data.Service.PathName
+	fpath := filepath.Join(codegen.Gendir, "jsonrpc", svcName, "client", "types.go")
+	imports := []*codegen.ImportSpec{
+		{Path: "encoding/json"},
+		{Path: "unicode/utf8"},
+		{Path: path.Join(genpkg, svcName), Name: data.Service.PkgName},
+		{Path: path.Join(genpkg, svcName, "views"), Name: data.Service.ViewsPkg},
+		codegen.GoaImport(""),
+	}
+	imports = append(imports, data.Service.UserTypeImports...)
+
+	var (
+		initData       []*httpcodegen.InitData
+		validatedTypes []*httpcodegen.TypeData
+		seen           = make(map[string]struct{})
+
+		sections = []*codegen.SectionTemplate{
+			codegen.Header(fmt.Sprintf("%s JSON-RPC client types", svc.Name()), "client", imports),
+		}
+	)
+
+	// request params types
+	for _, e := range data.Endpoints {
+		if tdata := e.Payload.Request.ClientBody; tdata != nil {
+			if _, ok := seen[tdata.Name]; ok {
+				continue
+			}
+			seen[tdata.Name] = struct{}{}
+			if tdata.Def != "" {
+				sections = append(sections, &codegen.SectionTemplate{
+					Name:   "client-request-params",
+					Source: readTemplate("type_decl"),
+					Data:   tdata,
+				})
+			}
+			if tdata.Init != nil {
+				initData = append(initData, tdata.Init)
+			}
+			if tdata.ValidateDef != "" {
+				validatedTypes = append(validatedTypes, tdata)
+			}
+		}
+	}
+
+	// response result types
+	for _, e := range data.Endpoints {
+		for _, resp := range e.Result.Responses {
+			if tdata := resp.ClientBody; tdata != nil {
+				if _, ok := seen[tdata.Name]; ok {
+					continue
+				}
+				seen[tdata.Name] = struct{}{}
+				if tdata.Def != "" {
+					sections = append(sections, &codegen.SectionTemplate{
+						Name:   "client-response-result",
+						Source: readTemplate("type_decl"),
+						Data:   tdata,
+					})
+				}
+				if tdata.ValidateDef != "" {
+					validatedTypes = append(validatedTypes, tdata)
+				}
+			}
+		}
+	}
+
+	// attribute types
+	for _, tdata := range data.ClientBodyAttributeTypes {
+		if tdata.Def != "" {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "client-body-attributes",
+				Source: readTemplate("type_decl"),
+				Data:   tdata,
+			})
+		}
+		if tdata.ValidateDef != "" {
+			validatedTypes = append(validatedTypes, tdata)
+		}
+	}
+
+	// params constructors
+	for _, init := range initData {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "client-body-init",
+			Source: readTemplate("client_body_init"),
+			Data:   init,
+		})
+	}
+
+	// result constructors
+	for _, e := range data.Endpoints {
+		for _, resp := range e.Result.Responses {
+			if init := resp.ResultInit; init != nil {
+				sections = append(sections, &codegen.SectionTemplate{
+					Name:   "client-result-init",
+					Source: readTemplate("client_type_init"),
+					Data:   init,
+				})
+			}
+		}
+	}
+
+	// validate methods
+	for _, tdata := range validatedTypes {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "client-validate",
+			Source: readTemplate("validate"),
+			Data:   tdata,
+		})
+	}
+
+	return &codegen.File{Path: fpath, SectionTemplates: sections}
+}
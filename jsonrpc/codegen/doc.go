@@ -0,0 +1,14 @@
+/*
+Package codegen contains the code generation logic to generate JSON-RPC 2.0
+servers and clients from the design DSLs.
+
+JSON-RPC services are served over HTTP and the code generator reuses the HTTP
+transport data structures to produce the types used to encode and decode the
+JSON-RPC request params and response results:
+
+  - It generates a server that serves all the service methods via a single HTTP endpoint and dispatches the requests on the JSON-RPC method.
+  - It generates a client that makes JSON-RPC requests to the service methods.
+  - It generates encoders and decoders that transform the JSON-RPC request params and response results into goa types and vice versa.
+  - It generates a command line client that makes JSON-RPC requests.
+*/
+package codegen
@@ -0,0 +1,106 @@
+package codegen
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	httpcodegen "goa.design/goa/v3/http/codegen"
+)
+
+// ServerFiles returns the server files for every JSON-RPC service. The files
+// contain the server that dispatches the JSON-RPC requests to the service
+// endpoints and the corresponding encoders and decoders.
+func ServerFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
+	var fw []*codegen.File
+	for _, svc := range root.API.JSONRPC.Services {
+		if f := serverFile(genpkg, svc); f != nil {
+			fw = append(fw, f)
+		}
+	}
+	for _, svc := range root.API.JSONRPC.Services {
+		if f := serverEncodeDecodeFile(genpkg, svc); f != nil {
+			fw = append(fw, f)
+		}
+	}
+	return fw
+}
+
+// serverFile returns the file implementing the JSON-RPC server.
+func serverFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
+	data := httpcodegen.JSONRPCServices.Get(svc.Name())
+	if len(data.Endpoints) == 0 {
+		return nil
+	}
+	svcName := data.Service.PathName
+	fpath := filepath.Join(codegen.Gendir, "jsonrpc", svcName, "server", "server.go")
+	title := fmt.Sprintf("%s JSON-RPC server", svc.Name())
+	imports := []*codegen.ImportSpec{
+		{Path: "context"},
+		{Path: "net/http"},
+		codegen.GoaImport(""),
+		codegen.GoaNamedImport("http", "goahttp"),
+		codegen.GoaImport("jsonrpc"),
+		{Path: path.Join(genpkg, svcName), Name: data.Service.PkgName},
+	}
+	imports = append(imports, data.Service.UserTypeImports...)
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(title, "server", imports),
+		{Name: "server-struct", Source: readTemplate("server_struct"), Data: data},
+		{Name: "server-mountpoint", Source: readTemplate("mount_point_struct"), Data: data},
+		{Name: "server-init", Source: readTemplate("server_init"), Data: data},
+		{Name: "server-service", Source: readTemplate("server_service"), Data: data},
+		{Name: "server-mount", Source: readTemplate("server_mount"), Data: data},
+		{Name: "server-dispatch", Source: readTemplate("server_dispatch"), Data: data},
+	}
+	return &codegen.File{Path: fpath, SectionTemplates: sections}
+}
+
+// serverEncodeDecodeFile returns the file defining the JSON-RPC server
+// encoding and decoding logic.
+func serverEncodeDecodeFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
+	data := httpcodegen.JSONRPCServices.Get(svc.Name())
+	if len(data.Endpoints) == 0 {
+		return nil
+	}
+	svcName := data.Service.PathName
+	fpath := filepath.Join(codegen.Gendir, "jsonrpc", svcName, "server", "encode_decode.go")
+	title := fmt.Sprintf("%s JSON-RPC server encoders and decoders", svc.Name())
+	imports := []*codegen.ImportSpec{
+		{Path: "encoding/json"},
+		{Path: "unicode/utf8"},
+		codegen.GoaImport(""),
+		codegen.GoaImport("jsonrpc"),
+		{Path: path.Join(genpkg, svcName), Name: data.Service.PkgName},
+		{Path: path.Join(genpkg, svcName, "views"), Name: data.Service.ViewsPkg},
+	}
+	imports = append(imports, data.Service.UserTypeImports...)
+	sections := []*codegen.SectionTemplate{codegen.Header(title, "server", imports)}
+
+	for _, e := range data.Endpoints {
+		if e.Payload.Ref != "" {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "request-decoder",
+				Source: readTemplate("request_decoder"),
+				Data:   e,
+			})
+		}
+		if e.Result.Ref != "" {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "response-encoder",
+				Source: readTemplate("response_encoder"),
+				Data:   e,
+			})
+		}
+	}
+	for _, h := range data.ServerTransformHelpers {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "server-transform-helper",
+			Source: readTemplate("transform_helper"),
+			Data:   h,
+		})
+	}
+	return &codegen.File{Path: fpath, SectionTemplates: sections}
+}
@@ -0,0 +1,143 @@
+package codegen
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	httpcodegen "goa.design/goa/v3/http/codegen"
+)
+
+// ServerTypeFiles returns the JSON-RPC transport type files.
+func ServerTypeFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
+	var fw []*codegen.File
+	for _, svc := range root.API.JSONRPC.Services {
+		if f := serverType(genpkg, svc); f != nil {
+			fw = append(fw, f)
+		}
+	}
+	return fw
+}
+
+// serverType returns the file containing the type definitions used by the
+// JSON-RPC server to decode the request params and encode the response
+// results. The types follow the same rules as the HTTP request and response
+// body types.
+func serverType(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
+	data := httpcodegen.JSONRPCServices.Get(svc.Name())
+	if len(data.Endpoints) == 0 {
+		return nil
+	}
+	svcName := data.Service.PathName
+	fpath := filepath.Join(codegen.Gendir, "jsonrpc", svcName, "server", "types.go")
+	imports := []*codegen.ImportSpec{
+		{Path: "encoding/json"},
+		{Path: "unicode/utf8"},
+		{Path: path.Join(genpkg, svcName), Name: data.Service.PkgName},
+		codegen.GoaImport(""),
+		{Path: path.Join(genpkg, svcName, "views"), Name: data.Service.ViewsPkg},
+	}
+	imports = append(imports, data.Service.UserTypeImports...)
+
+	var (
+		initData       []*httpcodegen.InitData
+		validatedTypes []*httpcodegen.TypeData
+		seen           = make(map[string]struct{})
+
+		sections = []*codegen.SectionTemplate{
+			codegen.Header(fmt.Sprintf("%s JSON-RPC server types", svc.Name()), "server", imports),
+		}
+	)
+
+	// request params types
+	for _, e := range data.Endpoints {
+		if tdata := e.Payload.Request.ServerBody; tdata != nil {
+			if _, ok := seen[tdata.Name]; ok {
+				continue
+			}
+			seen[tdata.Name] = struct{}{}
+			if tdata.Def != "" {
+				sections = append(sections, &codegen.SectionTemplate{
+					Name:   "request-params-type-decl",
+					Source: readTemplate("type_decl"),
+					Data:   tdata,
+				})
+			}
+			if tdata.ValidateDef != "" {
+				validatedTypes = append(validatedTypes, tdata)
+			}
+		}
+	}
+
+	// response result types
+	for _, e := range data.Endpoints {
+		for _, resp := range e.Result.Responses {
+			for _, tdata := range resp.ServerBody {
+				if _, ok := seen[tdata.Name]; ok {
+					continue
+				}
+				seen[tdata.Name] = struct{}{}
+				if tdata.Def != "" {
+					sections = append(sections, &codegen.SectionTemplate{
+						Name:   "response-result-type-decl",
+						Source: readTemplate("type_decl"),
+						Data:   tdata,
+					})
+				}
+				if tdata.Init != nil {
+					initData = append(initData, tdata.Init)
+				}
+				if tdata.ValidateDef != "" {
+					validatedTypes = append(validatedTypes, tdata)
+				}
+			}
+		}
+	}
+
+	// attribute types
+	for _, tdata := range data.ServerBodyAttributeTypes {
+		if tdata.Def != "" {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "server-body-attributes",
+				Source: readTemplate("type_decl"),
+				Data:   tdata,
+			})
+		}
+		if tdata.ValidateDef != "" {
+			validatedTypes = append(validatedTypes, tdata)
+		}
+	}
+
+	// result constructors
+	for _, init := range initData {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "server-body-init",
+			Source: readTemplate("server_body_init"),
+			Data:   init,
+		})
+	}
+
+	// payload constructors
+	for _, e := range data.Endpoints {
+		if init := e.Payload.Request.PayloadInit; init != nil {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "server-payload-init",
+				Source: readTemplate("server_type_init"),
+				Data:   init,
+			})
+		}
+	}
+
+	// validate methods
+	for _, tdata := range validatedTypes {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "server-validate",
+			Source: readTemplate("validate"),
+			Data:   tdata,
+		})
+	}
+
+	return &codegen.File{Path: fpath, SectionTemplates: sections}
+}
@@ -0,0 +1,18 @@
+package codegen
+
+import (
+	"embed"
+	"path"
+)
+
+//go:embed templates/*
+var tmplFS embed.FS
+
+// readTemplate returns the service template with the given name.
+func readTemplate(name string) string {
+	data, err := tmplFS.ReadFile(path.Join("templates", name) + ".go.tpl")
+	if err != nil {
+		panic("failed to load template " + name + ": " + err.Error()) // Should never happen, bug if it does
+	}
+	return string(data)
+}
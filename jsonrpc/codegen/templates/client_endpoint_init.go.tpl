@@ -0,0 +1,20 @@
+{{ printf "%s returns an endpoint that makes JSON-RPC requests to the %s service %s method." .Method.VarName .ServiceName .Method.Name | comment }}
+func (c *{{ .ClientStruct }}) {{ .Method.VarName }}() goa.Endpoint {
+	return func(ctx context.Context, v any) (any, error) {
+	{{- if .Payload.Ref }}
+		params, err := {{ .RequestEncoder }}(v)
+		if err != nil {
+			return nil, err
+		}
+	{{- end }}
+		{{ if .Result.Ref }}res{{ else }}_{{ end }}, err {{ if and .Payload.Ref (not .Result.Ref) }}={{ else }}:={{ end }} c.client.Call(ctx, {{ printf "%q" .Method.Name }}, {{ if .Payload.Ref }}params{{ else }}nil{{ end }})
+		if err != nil {
+			return nil, err
+		}
+	{{- if .Result.Ref }}
+		return {{ .ResponseDecoder }}(res)
+	{{- else }}
+		return nil, nil
+	{{- end }}
+	}
+}
@@ -0,0 +1,12 @@
+{{ printf "New%s instantiates JSON-RPC clients for all the %s service servers." .ClientStruct .Service.Name | comment }}
+func New{{ .ClientStruct }}(scheme string, host string, doer goahttp.Doer) *{{ .ClientStruct }} {
+	u := &url.URL{Scheme: scheme, Host: host, Path: {{ printf "%q" (index (index .Endpoints 0).Routes 0).Path }}}
+	return &{{ .ClientStruct }}{
+		client: jsonrpc.NewClient(doer, u.String()),
+	}
+}
+
+{{ printf "JSONRPCClient returns the underlying JSON-RPC client, it may be used to make batch requests." | comment }}
+func (c *{{ .ClientStruct }}) JSONRPCClient() *jsonrpc.Client {
+	return c.client
+}
@@ -0,0 +1,4 @@
+{{ printf "%s lists the %s service endpoint JSON-RPC clients." .ClientStruct .Service.Name | comment }}
+type {{ .ClientStruct }} struct {
+	client *jsonrpc.Client
+}
@@ -0,0 +1,14 @@
+{{ comment .Description }}
+func {{ .Name }}({{- range .ClientArgs }}{{ .VarName }} {{ .TypeRef }}, {{ end }}) {{ .ReturnTypeRef }} {
+{{- if .ClientCode }}
+	{{ .ClientCode }}
+	{{- if .ReturnTypeAttribute }}
+		res := &{{ .ReturnTypeName }}{
+			{{ .ReturnTypeAttribute }}: {{ if .ReturnIsPrimitivePointer }}&{{ end }}v,
+		}
+	{{- end }}
+{{- else if .ReturnIsStruct }}
+	{{ if .ReturnTypeAttribute }}res{{ else }}v{{ end }} := &{{ .ReturnTypeName }}{}
+{{- end }}
+	return {{ if .ReturnTypeAttribute }}res{{ else }}v{{ end }}
+}
@@ -0,0 +1,34 @@
+{{ printf "%s decodes the params of the %s %s JSON-RPC requests into the method payload." .RequestDecoder .ServiceName .Method.Name | comment }}
+func {{ .RequestDecoder }}(params json.RawMessage) (any, error) {
+	var (
+		body {{ .Payload.Request.ServerBody.VarName }}
+		err  error
+	)
+{{- if .Payload.Request.MustHaveBody }}
+	err = jsonrpc.DecodeParams(params, &body)
+	if err != nil {
+		return nil, err
+	}
+{{- else }}
+	if len(params) > 0 {
+		err = jsonrpc.DecodeParams(params, &body)
+		if err != nil {
+			return nil, err
+		}
+	}
+{{- end }}
+{{- if .Payload.Request.ServerBody.ValidateRef }}
+	{{ .Payload.Request.ServerBody.ValidateRef }}
+	if err != nil {
+		return nil, err
+	}
+{{- end }}
+{{- if .Payload.Request.PayloadInit }}
+	payload := {{ .Payload.Request.PayloadInit.Name }}({{ range .Payload.Request.PayloadInit.ServerArgs }}{{ .Ref }}, {{ end }})
+{{- else if .Payload.DecoderReturnValue }}
+	payload := {{ .Payload.DecoderReturnValue }}
+{{- else }}
+	payload := body
+{{- end }}
+	return payload, nil
+}
@@ -0,0 +1,13 @@
+{{ printf "%s builds the params of the %s %s JSON-RPC requests from the method payload." .RequestEncoder .ServiceName .Method.Name | comment }}
+func {{ .RequestEncoder }}(v any) (any, error) {
+	p, ok := v.({{ .Payload.Ref }})
+	if !ok {
+		return nil, goahttp.ErrInvalidType("{{ .ServiceName }}", "{{ .Method.Name }}", "{{ .Payload.Ref }}", v)
+	}
+{{- if .Payload.Request.ClientBody.Init }}
+	body := {{ .Payload.Request.ClientBody.Init.Name }}({{ range .Payload.Request.ClientBody.Init.ClientArgs }}{{ if .FieldPointer }}&{{ end }}{{ .VarName }}, {{ end }})
+{{- else }}
+	body := p
+{{- end }}
+	return body, nil
+}
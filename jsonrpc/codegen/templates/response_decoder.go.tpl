@@ -0,0 +1,35 @@
+{{ printf "%s decodes the result of the %s %s JSON-RPC responses into the method result." .ResponseDecoder .ServiceName .Method.Name | comment }}
+func {{ .ResponseDecoder }}(result json.RawMessage) (any, error) {
+{{- with (index .Result.Responses 0) }}
+	var (
+		body {{ .ClientBody.VarName }}
+		err  error
+	)
+	err = json.Unmarshal(result, &body)
+	if err != nil {
+		return nil, goahttp.ErrDecodingError("{{ $.ServiceName }}", "{{ $.Method.Name }}", err)
+	}
+	{{- if .ClientBody.ValidateRef }}
+	{{ .ClientBody.ValidateRef }}
+	if err != nil {
+		return nil, goahttp.ErrValidationError("{{ $.ServiceName }}", "{{ $.Method.Name }}", err)
+	}
+	{{- end }}
+	{{- if .ResultInit }}
+		{{- if .ViewedResult }}
+	p := {{ .ResultInit.Name }}({{ range .ResultInit.ClientArgs }}{{ .Ref }}, {{ end }})
+	view := {{ if $.Method.ViewedResult.ViewName }}{{ printf "%q" $.Method.ViewedResult.ViewName }}{{ else }}"default"{{ end }}
+	vres := {{ if not $.Method.ViewedResult.IsCollection }}&{{ end }}{{ $.Method.ViewedResult.ViewsPkg }}.{{ $.Method.ViewedResult.VarName }}{Projected: p, View: view}
+	if err = {{ $.Method.ViewedResult.ViewsPkg }}.Validate{{ $.Method.Result }}(vres); err != nil {
+		return nil, goahttp.ErrValidationError("{{ $.ServiceName }}", "{{ $.Method.Name }}", err)
+	}
+	res := {{ $.ServicePkgName }}.{{ $.Method.ViewedResult.ResultInit.Name }}(vres)
+		{{- else }}
+	res := {{ .ResultInit.Name }}({{ range .ResultInit.ClientArgs }}{{ .Ref }}, {{ end }})
+		{{- end }}
+	return res, nil
+	{{- else }}
+	return body, nil
+	{{- end }}
+{{- end }}
+}
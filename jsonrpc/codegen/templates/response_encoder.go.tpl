@@ -0,0 +1,16 @@
+{{ printf "%s builds the result of the %s %s JSON-RPC responses from the method result." .ResponseEncoder .ServiceName .Method.Name | comment }}
+func {{ .ResponseEncoder }}(v any) any {
+{{- with (index .Result.Responses 0) }}
+	{{- if $.Method.ViewedResult }}
+	res := v.({{ $.Method.ViewedResult.FullRef }})
+	{{- else }}
+	res, _ := v.({{ $.Result.Ref }})
+	{{- end }}
+	{{- if (index .ServerBody 0).Init }}
+	body := {{ (index .ServerBody 0).Init.Name }}({{ range (index .ServerBody 0).Init.ServerArgs }}{{ .Ref }}, {{ end }})
+	{{- else }}
+	body := res{{ if $.Method.ViewedResult }}.Projected{{ end }}
+	{{- end }}
+	return body
+{{- end }}
+}
@@ -0,0 +1,28 @@
+{{ printf "dispatch calls the %s service endpoint corresponding to the JSON-RPC request method." .Service.Name | comment }}
+func (s *{{ .ServerStruct }}) dispatch(ctx context.Context, req *jsonrpc.Request) (any, error) {
+	ctx = context.WithValue(ctx, goa.ServiceKey, {{ printf "%q" .Service.Name }})
+	switch req.Method {
+{{- range .Endpoints }}
+	case {{ printf "%q" .Method.Name }}:
+		ctx = context.WithValue(ctx, goa.MethodKey, {{ printf "%q" .Method.Name }})
+	{{- if .Payload.Ref }}
+		payload, err := {{ .RequestDecoder }}(req.Params)
+		if err != nil {
+			return nil, err
+		}
+	{{- end }}
+	{{- if .Result.Ref }}
+		res, err := s.endpoints.{{ .Method.VarName }}(ctx, {{ if .Payload.Ref }}payload{{ else }}nil{{ end }})
+		if err != nil {
+			return nil, err
+		}
+		return {{ .ResponseEncoder }}(res), nil
+	{{- else }}
+		_, err {{ if not .Payload.Ref }}:{{ end }}= s.endpoints.{{ .Method.VarName }}(ctx, {{ if .Payload.Ref }}payload{{ else }}nil{{ end }})
+		return nil, err
+	{{- end }}
+{{- end }}
+	default:
+		return nil, jsonrpc.MethodNotFoundError(req.Method)
+	}
+}
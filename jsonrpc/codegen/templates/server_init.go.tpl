@@ -0,0 +1,15 @@
+{{ printf "%s instantiates the JSON-RPC handler for all the %s service endpoints. The handler dispatches the requests to the service endpoints using the JSON-RPC request method." .ServerInit .Service.Name | comment }}
+func {{ .ServerInit }}(e *{{ .Service.PkgName }}.Endpoints) *{{ .ServerStruct }} {
+	s := &{{ .ServerStruct }}{
+		Mounts: []*{{ .MountPointStruct }}{
+			{{- range $e := .Endpoints }}
+				{{- range $e.Routes }}
+			{"{{ $e.Method.VarName }}", "{{ .Verb }}", "{{ .Path }}"},
+				{{- end }}
+			{{- end }}
+		},
+		endpoints: e,
+	}
+	s.Handler = jsonrpc.NewHandler(s.dispatch)
+	return s
+}
@@ -0,0 +1,11 @@
+{{ printf "%s configures the mux to serve the %s JSON-RPC endpoint." .MountServer .Service.Name | comment }}
+func {{ .MountServer }}(mux goahttp.Muxer, h *{{ .ServerStruct }}) {
+	{{- range (index .Endpoints 0).Routes }}
+	mux.Handle("{{ .Verb }}", "{{ .Path }}", h.Handler.ServeHTTP)
+	{{- end }}
+}
+
+{{ printf "%s configures the mux to serve the %s JSON-RPC endpoint." .MountServer .Service.Name | comment }}
+func (s *{{ .ServerStruct }}) {{ .MountServer }}(mux goahttp.Muxer) {
+	{{ .MountServer }}(mux, s)
+}
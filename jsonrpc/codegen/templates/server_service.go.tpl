@@ -0,0 +1,10 @@
+{{ printf "%s returns the name of the service served." .ServerService | comment }}
+func (s *{{ .ServerStruct }}) {{ .ServerService }}() string { return "{{ .Service.Name }}" }
+
+{{ printf "MethodNames returns the methods served." | comment }}
+func (s *{{ .ServerStruct }}) MethodNames() []string { return {{ .Service.PkgName }}.MethodNames[:] }
+
+{{ printf "Use wraps the server handler with the given middleware." | comment }}
+func (s *{{ .ServerStruct }}) Use(m func(http.Handler) http.Handler) {
+	s.Handler = m(s.Handler)
+}
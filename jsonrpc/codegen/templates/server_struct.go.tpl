@@ -0,0 +1,6 @@
+{{ printf "%s lists the %s service endpoint JSON-RPC handler." .ServerStruct .Service.Name | comment }}
+type {{ .ServerStruct }} struct {
+	Mounts []*{{ .MountPointStruct }}
+	Handler http.Handler
+	endpoints *{{ .Service.PkgName }}.Endpoints
+}
@@ -0,0 +1,14 @@
+{{ comment .Description }}
+func {{ .Name }}({{- range .ServerArgs }}{{ .VarName }} {{ .TypeRef }}, {{ end }}) {{ .ReturnTypeRef }} {
+{{- if .ServerCode }}
+	{{ .ServerCode }}
+	{{- if .ReturnTypeAttribute }}
+		res := &{{ .ReturnTypeName }}{
+			{{ .ReturnTypeAttribute }}: {{ if .ReturnIsPrimitivePointer }}&{{ end }}v,
+		}
+	{{- end }}
+{{- else if .ReturnIsStruct }}
+	{{ if .ReturnTypeAttribute }}res{{ else }}v{{ end }} := &{{ .ReturnTypeName }}{}
+{{- end }}
+	return {{ if .ReturnTypeAttribute }}res{{ else }}v{{ end }}
+}
@@ -0,0 +1,2 @@
+{{ comment .Description }}
+type {{ .VarName }} {{ .Def }}
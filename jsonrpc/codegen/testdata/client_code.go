@@ -0,0 +1,143 @@
+package testdata
+
+const UnaryClientEndpointInitCode = `// MethodUnary returns an endpoint that makes JSON-RPC requests to the
+// ServiceUnary service MethodUnary method.
+func (c *Client) MethodUnary() goa.Endpoint {
+	return func(ctx context.Context, v any) (any, error) {
+		params, err := EncodeMethodUnaryRequest(v)
+		if err != nil {
+			return nil, err
+		}
+		res, err := c.client.Call(ctx, "MethodUnary", params)
+		if err != nil {
+			return nil, err
+		}
+		return DecodeMethodUnaryResponse(res)
+	}
+}
+`
+
+const UnaryRequestEncoderCode = `// EncodeMethodUnaryRequest builds the params of the ServiceUnary MethodUnary
+// JSON-RPC requests from the method payload.
+func EncodeMethodUnaryRequest(v any) (any, error) {
+	p, ok := v.(*serviceunary.Operands)
+	if !ok {
+		return nil, goahttp.ErrInvalidType("ServiceUnary", "MethodUnary", "*serviceunary.Operands", v)
+	}
+	body := NewMethodUnaryRequestBody(p)
+	return body, nil
+}
+`
+
+const UnaryResponseDecoderCode = `// DecodeMethodUnaryResponse decodes the result of the ServiceUnary MethodUnary
+// JSON-RPC responses into the method result.
+func DecodeMethodUnaryResponse(result json.RawMessage) (any, error) {
+	var (
+		body MethodUnaryResponseBody
+		err  error
+	)
+	err = json.Unmarshal(result, &body)
+	if err != nil {
+		return nil, goahttp.ErrDecodingError("ServiceUnary", "MethodUnary", err)
+	}
+	err = ValidateMethodUnaryResponseBody(&body)
+	if err != nil {
+		return nil, goahttp.ErrValidationError("ServiceUnary", "MethodUnary", err)
+	}
+	res := NewMethodUnarySumOK(&body)
+	return res, nil
+}
+`
+
+const PrimitiveClientEndpointInitCode = `// MethodPrimitive returns an endpoint that makes JSON-RPC requests to the
+// ServicePrimitive service MethodPrimitive method.
+func (c *Client) MethodPrimitive() goa.Endpoint {
+	return func(ctx context.Context, v any) (any, error) {
+		params, err := EncodeMethodPrimitiveRequest(v)
+		if err != nil {
+			return nil, err
+		}
+		res, err := c.client.Call(ctx, "MethodPrimitive", params)
+		if err != nil {
+			return nil, err
+		}
+		return DecodeMethodPrimitiveResponse(res)
+	}
+}
+`
+
+const PrimitiveRequestEncoderCode = `// EncodeMethodPrimitiveRequest builds the params of the ServicePrimitive
+// MethodPrimitive JSON-RPC requests from the method payload.
+func EncodeMethodPrimitiveRequest(v any) (any, error) {
+	p, ok := v.(string)
+	if !ok {
+		return nil, goahttp.ErrInvalidType("ServicePrimitive", "MethodPrimitive", "string", v)
+	}
+	body := p
+	return body, nil
+}
+`
+
+const PrimitiveResponseDecoderCode = `// DecodeMethodPrimitiveResponse decodes the result of the ServicePrimitive
+// MethodPrimitive JSON-RPC responses into the method result.
+func DecodeMethodPrimitiveResponse(result json.RawMessage) (any, error) {
+	var (
+		body int
+		err  error
+	)
+	err = json.Unmarshal(result, &body)
+	if err != nil {
+		return nil, goahttp.ErrDecodingError("ServicePrimitive", "MethodPrimitive", err)
+	}
+	return body, nil
+}
+`
+
+const NoPayloadNoResultClientEndpointInitCode = `// MethodNoPayloadNoResult returns an endpoint that makes JSON-RPC requests to
+// the ServiceNoPayloadNoResult service MethodNoPayloadNoResult method.
+func (c *Client) MethodNoPayloadNoResult() goa.Endpoint {
+	return func(ctx context.Context, v any) (any, error) {
+		_, err := c.client.Call(ctx, "MethodNoPayloadNoResult", nil)
+		if err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+}
+`
+
+const ViewedResultClientEndpointInitCode = `// MethodViewedResult returns an endpoint that makes JSON-RPC requests to the
+// ServiceViewedResult service MethodViewedResult method.
+func (c *Client) MethodViewedResult() goa.Endpoint {
+	return func(ctx context.Context, v any) (any, error) {
+		res, err := c.client.Call(ctx, "MethodViewedResult", nil)
+		if err != nil {
+			return nil, err
+		}
+		return DecodeMethodViewedResultResponse(res)
+	}
+}
+`
+
+const ViewedResultResponseDecoderCode = `// DecodeMethodViewedResultResponse decodes the result of the
+// ServiceViewedResult MethodViewedResult JSON-RPC responses into the method
+// result.
+func DecodeMethodViewedResultResponse(result json.RawMessage) (any, error) {
+	var (
+		body MethodViewedResultResponseBody
+		err  error
+	)
+	err = json.Unmarshal(result, &body)
+	if err != nil {
+		return nil, goahttp.ErrDecodingError("ServiceViewedResult", "MethodViewedResult", err)
+	}
+	p := NewMethodViewedResultRTOK(&body)
+	view := "tiny"
+	vres := &serviceviewedresultviews.RT{Projected: p, View: view}
+	if err = serviceviewedresultviews.ValidateRT(vres); err != nil {
+		return nil, goahttp.ErrValidationError("ServiceViewedResult", "MethodViewedResult", err)
+	}
+	res := serviceviewedresult.NewRT(vres)
+	return res, nil
+}
+`
@@ -0,0 +1,87 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var UnaryDSL = func() {
+	var Operands = Type("Operands", func() {
+		Attribute("a", Int)
+		Attribute("b", Int, func() {
+			Minimum(1)
+		})
+		Required("a", "b")
+	})
+	var Sum = Type("Sum", func() {
+		Attribute("value", Int)
+		Required("value")
+	})
+	Service("ServiceUnary", func() {
+		JSONRPC(func() {
+			Path("/unary")
+		})
+		Method("MethodUnary", func() {
+			Payload(Operands)
+			Result(Sum)
+			JSONRPC(func() {})
+		})
+	})
+}
+
+var PrimitiveDSL = func() {
+	Service("ServicePrimitive", func() {
+		Method("MethodPrimitive", func() {
+			Payload(String)
+			Result(Int)
+			JSONRPC(func() {})
+		})
+	})
+}
+
+var NoPayloadNoResultDSL = func() {
+	Service("ServiceNoPayloadNoResult", func() {
+		Method("MethodNoPayloadNoResult", func() {
+			JSONRPC(func() {})
+		})
+	})
+}
+
+var ViewedResultDSL = func() {
+	var RT = ResultType("application/vnd.rt", func() {
+		TypeName("RT")
+		Attributes(func() {
+			Attribute("a", String)
+			Attribute("b", Int)
+			Required("a")
+		})
+		View("default", func() {
+			Attribute("a")
+			Attribute("b")
+		})
+		View("tiny", func() {
+			Attribute("a")
+		})
+	})
+	Service("ServiceViewedResult", func() {
+		Method("MethodViewedResult", func() {
+			Result(RT, func() {
+				View("tiny")
+			})
+			JSONRPC(func() {})
+		})
+	})
+}
+
+var APIPathDSL = func() {
+	API("api", func() {
+		JSONRPC(func() {
+			Path("/rpc")
+		})
+	})
+	Service("ServiceAPIPath", func() {
+		Method("MethodAPIPath", func() {
+			Result(String)
+			JSONRPC(func() {})
+		})
+	})
+}
@@ -0,0 +1,164 @@
+package testdata
+
+const UnaryServerDispatchCode = `// dispatch calls the ServiceUnary service endpoint corresponding to the
+// JSON-RPC request method.
+func (s *Server) dispatch(ctx context.Context, req *jsonrpc.Request) (any, error) {
+	ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceUnary")
+	switch req.Method {
+	case "MethodUnary":
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodUnary")
+		payload, err := DecodeMethodUnaryRequest(req.Params)
+		if err != nil {
+			return nil, err
+		}
+		res, err := s.endpoints.MethodUnary(ctx, payload)
+		if err != nil {
+			return nil, err
+		}
+		return EncodeMethodUnaryResponse(res), nil
+	default:
+		return nil, jsonrpc.MethodNotFoundError(req.Method)
+	}
+}
+`
+
+const UnaryRequestDecoderCode = `// DecodeMethodUnaryRequest decodes the params of the ServiceUnary MethodUnary
+// JSON-RPC requests into the method payload.
+func DecodeMethodUnaryRequest(params json.RawMessage) (any, error) {
+	var (
+		body MethodUnaryRequestBody
+		err  error
+	)
+	err = jsonrpc.DecodeParams(params, &body)
+	if err != nil {
+		return nil, err
+	}
+	err = ValidateMethodUnaryRequestBody(&body)
+	if err != nil {
+		return nil, err
+	}
+	payload := NewMethodUnaryOperands(&body)
+	return payload, nil
+}
+`
+
+const UnaryResponseEncoderCode = `// EncodeMethodUnaryResponse builds the result of the ServiceUnary MethodUnary
+// JSON-RPC responses from the method result.
+func EncodeMethodUnaryResponse(v any) any {
+	res, _ := v.(*serviceunary.Sum)
+	body := NewMethodUnaryResponseBody(res)
+	return body
+}
+`
+
+const PrimitiveServerDispatchCode = `// dispatch calls the ServicePrimitive service endpoint corresponding to the
+// JSON-RPC request method.
+func (s *Server) dispatch(ctx context.Context, req *jsonrpc.Request) (any, error) {
+	ctx = context.WithValue(ctx, goa.ServiceKey, "ServicePrimitive")
+	switch req.Method {
+	case "MethodPrimitive":
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodPrimitive")
+		payload, err := DecodeMethodPrimitiveRequest(req.Params)
+		if err != nil {
+			return nil, err
+		}
+		res, err := s.endpoints.MethodPrimitive(ctx, payload)
+		if err != nil {
+			return nil, err
+		}
+		return EncodeMethodPrimitiveResponse(res), nil
+	default:
+		return nil, jsonrpc.MethodNotFoundError(req.Method)
+	}
+}
+`
+
+const PrimitiveRequestDecoderCode = `// DecodeMethodPrimitiveRequest decodes the params of the ServicePrimitive
+// MethodPrimitive JSON-RPC requests into the method payload.
+func DecodeMethodPrimitiveRequest(params json.RawMessage) (any, error) {
+	var (
+		body string
+		err  error
+	)
+	err = jsonrpc.DecodeParams(params, &body)
+	if err != nil {
+		return nil, err
+	}
+	payload := body
+	return payload, nil
+}
+`
+
+const PrimitiveResponseEncoderCode = `// EncodeMethodPrimitiveResponse builds the result of the ServicePrimitive
+// MethodPrimitive JSON-RPC responses from the method result.
+func EncodeMethodPrimitiveResponse(v any) any {
+	res, _ := v.(int)
+	body := res
+	return body
+}
+`
+
+const NoPayloadNoResultServerDispatchCode = `// dispatch calls the ServiceNoPayloadNoResult service endpoint corresponding
+// to the JSON-RPC request method.
+func (s *Server) dispatch(ctx context.Context, req *jsonrpc.Request) (any, error) {
+	ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceNoPayloadNoResult")
+	switch req.Method {
+	case "MethodNoPayloadNoResult":
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodNoPayloadNoResult")
+		_, err := s.endpoints.MethodNoPayloadNoResult(ctx, nil)
+		return nil, err
+	default:
+		return nil, jsonrpc.MethodNotFoundError(req.Method)
+	}
+}
+`
+
+const ViewedResultServerDispatchCode = `// dispatch calls the ServiceViewedResult service endpoint corresponding to the
+// JSON-RPC request method.
+func (s *Server) dispatch(ctx context.Context, req *jsonrpc.Request) (any, error) {
+	ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceViewedResult")
+	switch req.Method {
+	case "MethodViewedResult":
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodViewedResult")
+		res, err := s.endpoints.MethodViewedResult(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		return EncodeMethodViewedResultResponse(res), nil
+	default:
+		return nil, jsonrpc.MethodNotFoundError(req.Method)
+	}
+}
+`
+
+const ViewedResultResponseEncoderCode = `// EncodeMethodViewedResultResponse builds the result of the
+// ServiceViewedResult MethodViewedResult JSON-RPC responses from the method
+// result.
+func EncodeMethodViewedResultResponse(v any) any {
+	res := v.(*serviceviewedresultviews.RT)
+	body := NewMethodViewedResultResponseBodyTiny(res.Projected)
+	return body
+}
+`
+
+const UnaryServerMountCode = `// Mount configures the mux to serve the ServiceUnary JSON-RPC endpoint.
+func Mount(mux goahttp.Muxer, h *Server) {
+	mux.Handle("POST", "/unary", h.Handler.ServeHTTP)
+}
+
+// Mount configures the mux to serve the ServiceUnary JSON-RPC endpoint.
+func (s *Server) Mount(mux goahttp.Muxer) {
+	Mount(mux, s)
+}
+`
+
+const APIPathServerMountCode = `// Mount configures the mux to serve the ServiceAPIPath JSON-RPC endpoint.
+func Mount(mux goahttp.Muxer, h *Server) {
+	mux.Handle("POST", "/rpc", h.Handler.ServeHTTP)
+}
+
+// Mount configures the mux to serve the ServiceAPIPath JSON-RPC endpoint.
+func (s *Server) Mount(mux goahttp.Muxer) {
+	Mount(mux, s)
+}
+`
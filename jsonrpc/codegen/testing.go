@@ -0,0 +1,19 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/codegen/service"
+	"goa.design/goa/v3/expr"
+	httpcodegen "goa.design/goa/v3/http/codegen"
+)
+
+// RunJSONRPCDSL returns the JSON-RPC DSL root resulting from running the given
+// DSL. It is used only in tests.
+func RunJSONRPCDSL(t *testing.T, dsl func()) *expr.RootExpr {
+	// reset all roots and codegen data structures
+	service.Services = make(service.ServicesData)
+	httpcodegen.HTTPServices = make(httpcodegen.ServicesData)
+	httpcodegen.JSONRPCServices = make(httpcodegen.JSONRPCServicesData)
+	return expr.RunDSL(t, dsl)
+}
@@ -0,0 +1,17 @@
+/*
+Package jsonrpc contains code generation logic to produce a server that serves
+JSON-RPC 2.0 requests and a client that encodes requests to and decodes
+responses from a JSON-RPC server. JSON-RPC services are served over HTTP: each
+service is exposed via a single HTTP endpoint that dispatches the requests to
+the service methods using the JSON-RPC request "method" member.
+
+In addition to the code generation logic, the jsonrpc package contains:
+
+  - The JSON-RPC request, response and error object definitions.
+  - A HTTP handler that decodes single and batch requests, dispatches them and encodes the responses.
+  - A client that makes single, notification and batch requests.
+  - Error helpers that map Goa service errors to JSON-RPC error objects and back.
+
+See https://www.jsonrpc.org/specification for the JSON-RPC 2.0 specification.
+*/
+package jsonrpc
@@ -0,0 +1,172 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+type (
+	// Request is a JSON-RPC request object.
+	Request struct {
+		// JSONRPC is the version of the protocol, must be "2.0".
+		JSONRPC string `json:"jsonrpc"`
+		// ID identifies the request, a request without ID is a
+		// notification.
+		ID json.RawMessage `json:"id,omitempty"`
+		// Method is the name of the method to be invoked.
+		Method string `json:"method"`
+		// Params holds the parameter values used to invoke the method.
+		Params json.RawMessage `json:"params,omitempty"`
+	}
+
+	// Response is a JSON-RPC response object.
+	Response struct {
+		// JSONRPC is the version of the protocol, always "2.0".
+		JSONRPC string `json:"jsonrpc"`
+		// ID is the identifier of the corresponding request, null if
+		// it could not be determined.
+		ID json.RawMessage `json:"id"`
+		// Result holds the result of the method invocation, omitted
+		// when the request failed.
+		Result json.RawMessage `json:"result,omitempty"`
+		// Error describes the error that occurred when handling the
+		// request if any.
+		Error *Error `json:"error,omitempty"`
+	}
+
+	// Error is a JSON-RPC error object.
+	Error struct {
+		// Code is the error code.
+		Code int `json:"code"`
+		// Message is a short description of the error.
+		Message string `json:"message"`
+		// Data contains additional information about the error.
+		Data any `json:"data,omitempty"`
+	}
+
+	// ErrorData is the data included in the error objects built from Goa
+	// service errors.
+	ErrorData struct {
+		// Name is the name of the error as defined in the design.
+		Name string `json:"name"`
+		// ID is the unique error instance identifier.
+		ID string `json:"id,omitempty"`
+		// Field is the name of the field that caused the error if any.
+		Field *string `json:"field,omitempty"`
+		// Temporary indicates whether the error is temporary.
+		Temporary bool `json:"temporary,omitempty"`
+		// Timeout indicates whether the error is a timeout.
+		Timeout bool `json:"timeout,omitempty"`
+		// Fault indicates whether the error is a server-side fault.
+		Fault bool `json:"fault,omitempty"`
+	}
+)
+
+// Version is the version of the JSON-RPC protocol implemented by this package.
+const Version = "2.0"
+
+// Standard JSON-RPC error codes.
+const (
+	// ParseError indicates that the server received invalid JSON.
+	ParseError = -32700
+	// InvalidRequest indicates that the JSON sent is not a valid request
+	// object.
+	InvalidRequest = -32600
+	// MethodNotFound indicates that the method does not exist.
+	MethodNotFound = -32601
+	// InvalidParams indicates that the method parameters are invalid.
+	InvalidParams = -32602
+	// InternalError indicates an internal server error.
+	InternalError = -32603
+	// ServerError is the code used for errors returned by the service
+	// methods.
+	ServerError = -32000
+)
+
+// invalidParamsErrors lists the names of the errors produced by the generated
+// code when decoding or validating the request params.
+var invalidParamsErrors = map[string]struct{}{
+	"missing_payload":    {},
+	"decode_payload":     {},
+	goa.InvalidFieldType: {},
+	goa.MissingField:     {},
+	goa.InvalidEnumValue: {},
+	goa.InvalidFormat:    {},
+	goa.InvalidPattern:   {},
+	goa.InvalidRange:     {},
+	goa.InvalidLength:    {},
+}
+
+// NewError creates a JSON-RPC error object from the given error:
+//
+//   - Errors that are already JSON-RPC error objects are returned as is.
+//   - Goa errors produced when decoding or validating the request params use
+//     the InvalidParams code.
+//   - Goa errors that are server-side faults use the InternalError code.
+//   - Other Goa errors and errors defined in the design use the ServerError
+//     code.
+//   - All other errors use the InternalError code.
+//
+// The error object data contains the Goa error name and properties if any.
+func NewError(err error) *Error {
+	var jerr *Error
+	if errors.As(err, &jerr) {
+		return jerr
+	}
+	var gerr *goa.ServiceError
+	if errors.As(err, &gerr) {
+		code := ServerError
+		if _, ok := invalidParamsErrors[gerr.Name]; ok {
+			code = InvalidParams
+		} else if gerr.Fault {
+			code = InternalError
+		}
+		return &Error{
+			Code:    code,
+			Message: gerr.Message,
+			Data: &ErrorData{
+				Name:      gerr.Name,
+				ID:        gerr.ID,
+				Field:     gerr.Field,
+				Temporary: gerr.Temporary,
+				Timeout:   gerr.Timeout,
+				Fault:     gerr.Fault,
+			},
+		}
+	}
+	var namer goa.GoaErrorNamer
+	if errors.As(err, &namer) {
+		return &Error{
+			Code:    ServerError,
+			Message: err.Error(),
+			Data:    &ErrorData{Name: namer.GoaErrorName()},
+		}
+	}
+	return &Error{Code: InternalError, Message: err.Error()}
+}
+
+// MethodNotFoundError returns the error produced by the generated servers when
+// the request method does not exist.
+func MethodNotFoundError(method string) *Error {
+	return &Error{Code: MethodNotFound, Message: fmt.Sprintf("method %q not found", method)}
+}
+
+// DecodeParams decodes the request params into v. It returns a Goa error
+// if the params are missing or cannot be decoded.
+func DecodeParams(params json.RawMessage, v any) error {
+	if len(params) == 0 {
+		return goa.MissingPayloadError()
+	}
+	if err := json.Unmarshal(params, v); err != nil {
+		return goa.DecodePayloadError(err.Error())
+	}
+	return nil
+}
+
+// Error returns the error message.
+func (e *Error) Error() string {
+	return fmt.Sprintf("jsonrpc error %d: %s", e.Code, e.Message)
+}
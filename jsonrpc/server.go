@@ -0,0 +1,99 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// DispatchFunc handles a single JSON-RPC request and returns the value encoded
+// in the response "result" member. Generated servers implement DispatchFunc
+// by switching on the request method.
+type DispatchFunc func(ctx context.Context, req *Request) (any, error)
+
+// NewHandler returns a HTTP handler that serves JSON-RPC requests. The handler
+// decodes single and batch requests, calls dispatch for each request and
+// encodes the corresponding responses. Errors returned by dispatch are mapped
+// to JSON-RPC error objects using NewError. Notifications - requests without
+// ID - do not produce a response, the handler writes a 204 No Content HTTP
+// response if there is no response to write.
+func NewHandler(dispatch DispatchFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeJSON(w, errorResponse(nil, &Error{Code: ParseError, Message: err.Error()}))
+			return
+		}
+		body = bytes.TrimSpace(body)
+		if len(body) == 0 || body[0] != '[' {
+			resp := handle(r.Context(), dispatch, body)
+			if resp == nil {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			writeJSON(w, resp)
+			return
+		}
+		var batch []json.RawMessage
+		if err := json.Unmarshal(body, &batch); err != nil {
+			writeJSON(w, errorResponse(nil, &Error{Code: ParseError, Message: err.Error()}))
+			return
+		}
+		if len(batch) == 0 {
+			writeJSON(w, errorResponse(nil, &Error{Code: InvalidRequest, Message: "empty batch"}))
+			return
+		}
+		var resps []*Response
+		for _, raw := range batch {
+			if resp := handle(r.Context(), dispatch, raw); resp != nil {
+				resps = append(resps, resp)
+			}
+		}
+		if len(resps) == 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		writeJSON(w, resps)
+	})
+}
+
+// handle decodes and dispatches a single JSON-RPC request. It returns nil if
+// the request is a notification.
+func handle(ctx context.Context, dispatch DispatchFunc, raw json.RawMessage) *Response {
+	var req Request
+	if err := json.Unmarshal(raw, &req); err != nil {
+		if _, ok := err.(*json.SyntaxError); ok {
+			return errorResponse(nil, &Error{Code: ParseError, Message: err.Error()})
+		}
+		return errorResponse(nil, &Error{Code: InvalidRequest, Message: err.Error()})
+	}
+	if req.JSONRPC != Version || req.Method == "" {
+		return errorResponse(req.ID, &Error{Code: InvalidRequest, Message: "invalid JSON-RPC 2.0 request"})
+	}
+	res, err := dispatch(ctx, &req)
+	if len(req.ID) == 0 {
+		return nil
+	}
+	if err != nil {
+		return errorResponse(req.ID, NewError(err))
+	}
+	result, err := json.Marshal(res)
+	if err != nil {
+		return errorResponse(req.ID, &Error{Code: InternalError, Message: err.Error()})
+	}
+	return &Response{JSONRPC: Version, ID: req.ID, Result: result}
+}
+
+// errorResponse returns a response object that wraps the given error.
+func errorResponse(id json.RawMessage, err *Error) *Response {
+	return &Response{JSONRPC: Version, ID: id, Error: err}
+}
+
+// writeJSON writes the JSON representation of v to w.
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(v)
+}
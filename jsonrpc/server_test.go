@@ -0,0 +1,131 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func testDispatch(_ context.Context, req *Request) (any, error) {
+	switch req.Method {
+	case "echo":
+		var s string
+		if err := DecodeParams(req.Params, &s); err != nil {
+			return nil, err
+		}
+		return s, nil
+	case "fail":
+		return nil, goa.PermanentError("bad", "failed")
+	case "crash":
+		return nil, errors.New("crash")
+	default:
+		return nil, MethodNotFoundError(req.Method)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	cases := []struct {
+		name       string
+		body       string
+		wantStatus int
+		want       string
+	}{
+		{"result", `{"jsonrpc":"2.0","id":1,"method":"echo","params":"hi"}`, http.StatusOK, `{"jsonrpc":"2.0","id":1,"result":"hi"}`},
+		{"string id", `{"jsonrpc":"2.0","id":"a","method":"echo","params":"hi"}`, http.StatusOK, `{"jsonrpc":"2.0","id":"a","result":"hi"}`},
+		{"notification", `{"jsonrpc":"2.0","method":"echo","params":"hi"}`, http.StatusNoContent, ``},
+		{"parse error", `{"jsonrpc"`, http.StatusOK, `{"jsonrpc":"2.0","id":null,"error":{"code":-32700,"message":"unexpected end of JSON input"}}`},
+		{"invalid request", `{"jsonrpc":"1.0","id":1,"method":"echo"}`, http.StatusOK, `{"jsonrpc":"2.0","id":1,"error":{"code":-32600,"message":"invalid JSON-RPC 2.0 request"}}`},
+		{"method not found", `{"jsonrpc":"2.0","id":1,"method":"foo"}`, http.StatusOK, `{"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"method \"foo\" not found"}}`},
+		{"invalid params", `{"jsonrpc":"2.0","id":1,"method":"echo","params":1}`, http.StatusOK, `{"jsonrpc":"2.0","id":1,"error":{"code":-32602,"message":"json: cannot unmarshal number into Go value of type string","data":{"name":"decode_payload"}}}`},
+		{"server error", `{"jsonrpc":"2.0","id":1,"method":"fail"}`, http.StatusOK, `{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"failed","data":{"name":"bad"}}}`},
+		{"internal error", `{"jsonrpc":"2.0","id":1,"method":"crash"}`, http.StatusOK, `{"jsonrpc":"2.0","id":1,"error":{"code":-32603,"message":"crash"}}`},
+		{"empty batch", `[]`, http.StatusOK, `{"jsonrpc":"2.0","id":null,"error":{"code":-32600,"message":"empty batch"}}`},
+		{"batch", `[{"jsonrpc":"2.0","id":1,"method":"echo","params":"a"},{"jsonrpc":"2.0","method":"echo","params":"b"},1]`, http.StatusOK, `[{"jsonrpc":"2.0","id":1,"result":"a"},{"jsonrpc":"2.0","id":null,"error":{"code":-32600,"message":"json: cannot unmarshal number into Go value of type jsonrpc.Request"}}]`},
+		{"batch of notifications", `[{"jsonrpc":"2.0","method":"echo","params":"a"}]`, http.StatusNoContent, ``},
+	}
+	h := NewHandler(testDispatch)
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest("POST", "/", strings.NewReader(c.body)))
+			assert.Equal(t, c.wantStatus, w.Code)
+			if c.want == "" {
+				assert.Empty(t, w.Body.String())
+				return
+			}
+			assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+			assert.JSONEq(t, c.want, stripErrorIDs(t, w.Body.String()))
+		})
+	}
+}
+
+// stripErrorIDs removes the randomly generated error IDs from the given JSON
+// response.
+func stripErrorIDs(t *testing.T, body string) string {
+	t.Helper()
+	var v any
+	require.NoError(t, json.Unmarshal([]byte(body), &v))
+	resps, ok := v.([]any)
+	if !ok {
+		resps = []any{v}
+	}
+	for _, resp := range resps {
+		if e, ok := resp.(map[string]any)["error"].(map[string]any); ok {
+			if data, ok := e["data"].(map[string]any); ok {
+				delete(data, "id")
+			}
+		}
+	}
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+	return string(b)
+}
+
+func TestNewError(t *testing.T) {
+	field := "a"
+	cases := []struct {
+		name string
+		err  error
+		want *Error
+	}{
+		{"jsonrpc", &Error{Code: 1, Message: "m"}, &Error{Code: 1, Message: "m"}},
+		{"validation", goa.MissingFieldError("a", "body"), &Error{Code: InvalidParams, Message: `"a" is missing from body`, Data: &ErrorData{Name: goa.MissingField, Field: &field}}},
+		{"fault", goa.Fault("boom"), &Error{Code: InternalError, Message: "boom", Data: &ErrorData{Name: "fault", Fault: true}}},
+		{"service", goa.TemporaryError("busy", "try again"), &Error{Code: ServerError, Message: "try again", Data: &ErrorData{Name: "busy", Temporary: true}}},
+		{"other", errors.New("oops"), &Error{Code: InternalError, Message: "oops"}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := NewError(c.err)
+			if data, ok := got.Data.(*ErrorData); ok {
+				data.ID = "" // IDs are random
+			}
+			assert.Equal(t, c.want, got)
+		})
+	}
+}
+
+func TestDecodeParams(t *testing.T) {
+	var v struct{ A int }
+	var gerr *goa.ServiceError
+
+	err := DecodeParams(nil, &v)
+	require.ErrorAs(t, err, &gerr)
+	assert.Equal(t, "missing_payload", gerr.Name)
+
+	err = DecodeParams(json.RawMessage(`"a"`), &v)
+	require.ErrorAs(t, err, &gerr)
+	assert.Equal(t, "decode_payload", gerr.Name)
+
+	require.NoError(t, DecodeParams(json.RawMessage(`{"A":1}`), &v))
+	assert.Equal(t, 1, v.A)
+}
@@ -0,0 +1,202 @@
+// Package otel contains the transport agnostic parts of the OpenTelemetry
+// middlewares: the configuration shared by the HTTP and gRPC middlewares and
+// a goa endpoint middleware that names the request spans after the goa
+// service and method.
+//
+// The transport specific middlewares are implemented in the
+// goa.design/goa/v3/http/middleware/otel and goa.design/goa/v3/grpc/middleware/otel
+// packages. The oteltest package provides in-memory exporters that can be used
+// to test the instrumentation.
+package otel
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	goa "goa.design/goa/v3/pkg"
+)
+
+const (
+	// InstrumentationName is the name of the instrumentation scope used
+	// to create the tracers and meters.
+	InstrumentationName = "goa.design/goa/v3/middleware/otel"
+
+	// ServiceKey is the attribute key used to record the name of the goa
+	// service in spans and metrics.
+	ServiceKey = attribute.Key("goa.service")
+
+	// MethodKey is the attribute key used to record the name of the goa
+	// method in spans and metrics.
+	MethodKey = attribute.Key("goa.method")
+)
+
+type (
+	// Config holds the OpenTelemetry providers used by the middlewares.
+	Config struct {
+		// TracerProvider is used to create the request spans.
+		TracerProvider trace.TracerProvider
+		// MeterProvider is used to create the request metrics.
+		MeterProvider metric.MeterProvider
+		// Propagators is used to extract and inject the trace context
+		// from and to the requests.
+		Propagators propagation.TextMapPropagator
+	}
+
+	// Option configures the OpenTelemetry middlewares.
+	Option func(*Config)
+
+	// Labeler collects attributes added to the request metrics by the
+	// handlers downstream of the transport middleware.
+	Labeler struct {
+		mu    sync.Mutex
+		attrs []attribute.KeyValue
+	}
+
+	// private type used to define context keys.
+	ctxKey int
+)
+
+const (
+	// labelerKey is the context key used to store the request labeler.
+	labelerKey ctxKey = iota + 1
+)
+
+// NewConfig returns the configuration resulting from applying the given
+// options. The providers default to the global OpenTelemetry providers and
+// the propagator defaults to the W3C trace context propagator.
+func NewConfig(opts ...Option) *Config {
+	c := &Config{
+		TracerProvider: otel.GetTracerProvider(),
+		MeterProvider:  otel.GetMeterProvider(),
+		Propagators:    propagation.TraceContext{},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// WithTracerProvider sets the tracer provider used to create spans.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *Config) {
+		if tp != nil {
+			c.TracerProvider = tp
+		}
+	}
+}
+
+// WithMeterProvider sets the meter provider used to create metrics.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *Config) {
+		if mp != nil {
+			c.MeterProvider = mp
+		}
+	}
+}
+
+// WithPropagators sets the propagators used to extract and inject the trace
+// context.
+func WithPropagators(p propagation.TextMapPropagator) Option {
+	return func(c *Config) {
+		if p != nil {
+			c.Propagators = p
+		}
+	}
+}
+
+// Tracer returns the tracer used by the middlewares.
+func (c *Config) Tracer() trace.Tracer {
+	return c.TracerProvider.Tracer(InstrumentationName)
+}
+
+// Meter returns the meter used by the middlewares.
+func (c *Config) Meter() metric.Meter {
+	return c.MeterProvider.Meter(InstrumentationName)
+}
+
+// Float64Histogram creates a histogram with the given name, unit and
+// description. Errors are reported to the global OpenTelemetry error handler
+// and result in a no-op histogram.
+func (c *Config) Float64Histogram(name, unit, desc string) metric.Float64Histogram {
+	h, err := c.Meter().Float64Histogram(name, metric.WithUnit(unit), metric.WithDescription(desc))
+	if err != nil {
+		otel.Handle(err)
+	}
+	return h
+}
+
+// Int64Histogram creates a histogram with the given name, unit and
+// description. Errors are reported to the global OpenTelemetry error handler
+// and result in a no-op histogram.
+func (c *Config) Int64Histogram(name, unit, desc string) metric.Int64Histogram {
+	h, err := c.Meter().Int64Histogram(name, metric.WithUnit(unit), metric.WithDescription(desc))
+	if err != nil {
+		otel.Handle(err)
+	}
+	return h
+}
+
+// Endpoint is a goa endpoint middleware that renames the current span after
+// the goa service and method stored in the context by the generated transport
+// handlers under goa.ServiceKey and goa.MethodKey. It also records the names
+// as span attributes and as attributes of the request metrics. Endpoint must
+// be applied to the endpoints of services served with the HTTP or gRPC
+// OpenTelemetry middleware, for example:
+//
+//	endpoints := genservice.NewEndpoints(svc)
+//	endpoints.Use(otel.Endpoint)
+func Endpoint(e goa.Endpoint) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		svc, _ := ctx.Value(goa.ServiceKey).(string)
+		meth, _ := ctx.Value(goa.MethodKey).(string)
+		if svc == "" || meth == "" {
+			return e(ctx, req)
+		}
+		attrs := []attribute.KeyValue{ServiceKey.String(svc), MethodKey.String(meth)}
+		span := trace.SpanFromContext(ctx)
+		span.SetName(SpanName(svc, meth))
+		span.SetAttributes(attrs...)
+		if l, ok := LabelerFromContext(ctx); ok {
+			l.Add(attrs...)
+		}
+		return e(ctx, req)
+	}
+}
+
+// SpanName returns the name of the span created for the given goa service
+// method.
+func SpanName(svc, meth string) string {
+	return svc + "/" + meth
+}
+
+// Add adds attributes to the labeler.
+func (l *Labeler) Add(attrs ...attribute.KeyValue) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.attrs = append(l.attrs, attrs...)
+}
+
+// Get returns a copy of the attributes added to the labeler.
+func (l *Labeler) Get() []attribute.KeyValue {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	res := make([]attribute.KeyValue, len(l.attrs))
+	copy(res, l.attrs)
+	return res
+}
+
+// ContextWithLabeler returns a copy of ctx that holds l.
+func ContextWithLabeler(ctx context.Context, l *Labeler) context.Context {
+	return context.WithValue(ctx, labelerKey, l)
+}
+
+// LabelerFromContext returns the labeler stored in ctx if any.
+func LabelerFromContext(ctx context.Context) (*Labeler, bool) {
+	l, ok := ctx.Value(labelerKey).(*Labeler)
+	return l, ok
+}
@@ -0,0 +1,82 @@
+package otel_test
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"goa.design/goa/v3/middleware/otel"
+	"goa.design/goa/v3/middleware/otel/oteltest"
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestEndpoint(t *testing.T) {
+	cases := []struct {
+		Name         string
+		Service      string
+		Method       string
+		ExpectedName string
+		ExpectedAttr bool
+	}{
+		{"no goa keys", "", "", "span", false},
+		{"goa keys", "svc", "meth", "svc/meth", true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			rec := oteltest.NewRecorder()
+			ctx := context.Background()
+			if tc.Service != "" {
+				ctx = context.WithValue(ctx, goa.ServiceKey, tc.Service)
+				ctx = context.WithValue(ctx, goa.MethodKey, tc.Method)
+			}
+			labeler := &otel.Labeler{}
+			ctx = otel.ContextWithLabeler(ctx, labeler)
+			ctx, span := rec.TracerProvider.Tracer("test").Start(ctx, "span")
+			called := false
+			ep := otel.Endpoint(func(context.Context, any) (any, error) {
+				called = true
+				return nil, nil
+			})
+			if _, err := ep(ctx, nil); err != nil {
+				t.Fatal(err)
+			}
+			span.End()
+
+			if !called {
+				t.Error("endpoint not called")
+			}
+			spans := rec.Spans()
+			if len(spans) != 1 {
+				t.Fatalf("got %d spans, expected 1", len(spans))
+			}
+			if spans[0].Name != tc.ExpectedName {
+				t.Errorf("got span name %q, expected %q", spans[0].Name, tc.ExpectedName)
+			}
+			attrs := attribute.NewSet(labeler.Get()...)
+			if v, ok := attrs.Value(otel.ServiceKey); ok != tc.ExpectedAttr || (ok && v.AsString() != tc.Service) {
+				t.Errorf("got service attribute %q (%v), expected %q", v.AsString(), ok, tc.Service)
+			}
+			if v, ok := attrs.Value(otel.MethodKey); ok != tc.ExpectedAttr || (ok && v.AsString() != tc.Method) {
+				t.Errorf("got method attribute %q (%v), expected %q", v.AsString(), ok, tc.Method)
+			}
+		})
+	}
+}
+
+func TestNewConfig(t *testing.T) {
+	rec := oteltest.NewRecorder()
+	c := otel.NewConfig(rec.Options()...)
+	if c.TracerProvider != rec.TracerProvider {
+		t.Error("tracer provider not set")
+	}
+	if c.MeterProvider != rec.MeterProvider {
+		t.Error("meter provider not set")
+	}
+	if c.Propagators == nil {
+		t.Error("propagators not set")
+	}
+	def := otel.NewConfig(otel.WithTracerProvider(nil))
+	if def.TracerProvider == nil || def.MeterProvider == nil || def.Propagators == nil {
+		t.Error("expected default providers")
+	}
+}
@@ -0,0 +1,87 @@
+// Package oteltest contains test helpers that record the spans and metrics
+// produced by the OpenTelemetry middlewares in memory.
+package oteltest
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"goa.design/goa/v3/middleware/otel"
+)
+
+// Recorder records spans and metrics in memory.
+type Recorder struct {
+	// TracerProvider is the tracer provider that records the spans.
+	TracerProvider *sdktrace.TracerProvider
+	// MeterProvider is the meter provider that records the metrics.
+	MeterProvider *sdkmetric.MeterProvider
+	// Exporter holds the ended spans.
+	Exporter *tracetest.InMemoryExporter
+	// Reader collects the metrics on demand.
+	Reader *sdkmetric.ManualReader
+}
+
+// NewRecorder returns a recorder that records all the spans and metrics
+// produced with its providers. Spans are exported synchronously when they end.
+func NewRecorder() *Recorder {
+	var (
+		exp    = tracetest.NewInMemoryExporter()
+		reader = sdkmetric.NewManualReader()
+	)
+	return &Recorder{
+		TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSyncer(exp)),
+		MeterProvider:  sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+		Exporter:       exp,
+		Reader:         reader,
+	}
+}
+
+// Options returns the middleware options that configure the middlewares to
+// use the recorder providers and the W3C trace context propagator.
+func (r *Recorder) Options() []otel.Option {
+	return []otel.Option{
+		otel.WithTracerProvider(r.TracerProvider),
+		otel.WithMeterProvider(r.MeterProvider),
+		otel.WithPropagators(propagation.TraceContext{}),
+	}
+}
+
+// Spans returns the spans that have ended so far.
+func (r *Recorder) Spans() tracetest.SpanStubs {
+	return r.Exporter.GetSpans()
+}
+
+// Metrics collects and returns the metrics recorded so far.
+func (r *Recorder) Metrics() (*metricdata.ResourceMetrics, error) {
+	var rm metricdata.ResourceMetrics
+	if err := r.Reader.Collect(context.Background(), &rm); err != nil {
+		return nil, err
+	}
+	return &rm, nil
+}
+
+// Metric returns the metric with the given name or nil if no such metric has
+// been recorded.
+func (r *Recorder) Metric(name string) *metricdata.Metrics {
+	rm, err := r.Metrics()
+	if err != nil {
+		return nil
+	}
+	for _, sm := range rm.ScopeMetrics {
+		for i := range sm.Metrics {
+			if sm.Metrics[i].Name == name {
+				return &sm.Metrics[i]
+			}
+		}
+	}
+	return nil
+}
+
+// Reset removes the spans recorded so far.
+func (r *Recorder) Reset() {
+	r.Exporter.Reset()
+}
@@ -0,0 +1,239 @@
+package goa
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type (
+	// Decimal is the type of the fields generated for Decimal attributes. It
+	// holds the text of an arbitrary-precision decimal number using the JSON
+	// number syntax, for example "-12.50", so that values are carried
+	// across transports without loss of precision. The zero value is
+	// equivalent to "0". Decimal values are encoded as JSON numbers and may
+	// be decoded from JSON numbers or strings.
+	Decimal string
+
+	// DecimalString is identical to Decimal except that values are encoded
+	// as JSON strings.
+	DecimalString string
+
+	// BigInt is the type of the fields generated for BigInt attributes. It
+	// holds the decimal text of an arbitrary-precision integer, for example
+	// "-12345678901234567890". The zero value is equivalent to "0". BigInt
+	// values are encoded as JSON numbers and may be decoded from JSON
+	// numbers or strings.
+	BigInt string
+
+	// BigIntString is identical to BigInt except that values are encoded as
+	// JSON strings.
+	BigIntString string
+)
+
+var (
+	decimalRegex = regexp.MustCompile(`^-?(?:0|[1-9][0-9]*)(?:\.[0-9]+)?(?:[eE][+-]?[0-9]+)?$`)
+	bigIntRegex  = regexp.MustCompile(`^-?(?:0|[1-9][0-9]*)$`)
+)
+
+// maxDecimalExponent is the largest magnitude of the exponent of a decimal
+// number once its fractional digits are moved into the exponent. It matches
+// the limit of big.Rat so that all valid decimals can be compared.
+const maxDecimalExponent = 1_000_000
+
+// ParseDecimal returns the Decimal corresponding to s or an error if s is not
+// a decimal number using the JSON number syntax or if its exponent is too
+// large.
+func ParseDecimal(s string) (Decimal, error) {
+	if !isDecimal(s) {
+		return "", fmt.Errorf("invalid decimal %q", s)
+	}
+	return Decimal(s), nil
+}
+
+// ParseBigInt returns the BigInt corresponding to s or an error if s is not a
+// decimal integer.
+func ParseBigInt(s string) (BigInt, error) {
+	if !bigIntRegex.MatchString(s) {
+		return "", fmt.Errorf("invalid big integer %q", s)
+	}
+	return BigInt(s), nil
+}
+
+// String returns the decimal text of d.
+func (d Decimal) String() string { return numberText(string(d)) }
+
+// Rat returns the value of d as a big.Rat, nil if d is not a valid decimal.
+func (d Decimal) Rat() *big.Rat { return parseRat(string(d)) }
+
+// MarshalJSON encodes d as a JSON number.
+func (d Decimal) MarshalJSON() ([]byte, error) { return []byte(d.String()), nil }
+
+// MarshalYAML encodes d as a YAML number. The precision of the value is limited
+// to that of a float64.
+func (d Decimal) MarshalYAML() (any, error) {
+	f, err := strconv.ParseFloat(d.String(), 64)
+	if err != nil {
+		return d.String(), nil
+	}
+	return f, nil
+}
+
+// UnmarshalJSON decodes a JSON number or string into d.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	return unmarshalNumber(data, isDecimal, "decimal", (*string)(d))
+}
+
+// String returns the decimal text of d.
+func (d DecimalString) String() string { return numberText(string(d)) }
+
+// Rat returns the value of d as a big.Rat, nil if d is not a valid decimal.
+func (d DecimalString) Rat() *big.Rat { return parseRat(string(d)) }
+
+// MarshalJSON encodes d as a JSON string.
+func (d DecimalString) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(d.String())), nil
+}
+
+// UnmarshalJSON decodes a JSON number or string into d.
+func (d *DecimalString) UnmarshalJSON(data []byte) error {
+	return unmarshalNumber(data, isDecimal, "decimal", (*string)(d))
+}
+
+// String returns the decimal text of i.
+func (i BigInt) String() string { return numberText(string(i)) }
+
+// Int returns the value of i as a big.Int, nil if i is not a valid integer.
+func (i BigInt) Int() *big.Int { return parseInt(string(i)) }
+
+// MarshalJSON encodes i as a JSON number.
+func (i BigInt) MarshalJSON() ([]byte, error) { return []byte(i.String()), nil }
+
+// MarshalYAML encodes i as a YAML number. The precision of the value is limited
+// to that of an int64 or float64 if it does not fit in an int64.
+func (i BigInt) MarshalYAML() (any, error) {
+	if n, err := strconv.ParseInt(i.String(), 10, 64); err == nil {
+		return n, nil
+	}
+	f, err := strconv.ParseFloat(i.String(), 64)
+	if err != nil {
+		return i.String(), nil
+	}
+	return f, nil
+}
+
+// UnmarshalJSON decodes a JSON number or string into i.
+func (i *BigInt) UnmarshalJSON(data []byte) error {
+	return unmarshalNumber(data, bigIntRegex.MatchString, "big integer", (*string)(i))
+}
+
+// String returns the decimal text of i.
+func (i BigIntString) String() string { return numberText(string(i)) }
+
+// Int returns the value of i as a big.Int, nil if i is not a valid integer.
+func (i BigIntString) Int() *big.Int { return parseInt(string(i)) }
+
+// MarshalJSON encodes i as a JSON string.
+func (i BigIntString) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(i.String())), nil
+}
+
+// UnmarshalJSON decodes a JSON number or string into i.
+func (i *BigIntString) UnmarshalJSON(data []byte) error {
+	return unmarshalNumber(data, bigIntRegex.MatchString, "big integer", (*string)(i))
+}
+
+// CompareDecimal compares the decimal numbers x and y and returns -1 if x is
+// less than y, 0 if they are equal and +1 if x is greater than y. Invalid
+// numbers compare as zero.
+func CompareDecimal(x, y string) int {
+	rx, ry := parseRat(x), parseRat(y)
+	if rx == nil {
+		rx = new(big.Rat)
+	}
+	if ry == nil {
+		ry = new(big.Rat)
+	}
+	return rx.Cmp(ry)
+}
+
+// numberText returns s or "0" if s is empty.
+func numberText(s string) string {
+	if s == "" {
+		return "0"
+	}
+	return s
+}
+
+// isDecimal returns true if s is a decimal number using the JSON number syntax
+// whose exponent does not exceed maxDecimalExponent.
+func isDecimal(s string) bool {
+	if !decimalRegex.MatchString(s) {
+		return false
+	}
+	mantissa, exponent, _ := strings.Cut(strings.ToLower(s), "e")
+	var exp int64
+	if exponent != "" {
+		var err error
+		if exp, err = strconv.ParseInt(exponent, 10, 64); err != nil {
+			return false
+		}
+	}
+	var frac int64
+	if _, f, ok := strings.Cut(mantissa, "."); ok {
+		frac = int64(len(f))
+	}
+	return exp <= maxDecimalExponent+frac && exp >= frac-maxDecimalExponent
+}
+
+// parseRat returns the big.Rat corresponding to the decimal number s, nil if
+// s is not a valid decimal.
+func parseRat(s string) *big.Rat {
+	s = numberText(s)
+	if !isDecimal(s) {
+		return nil
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil
+	}
+	return r
+}
+
+// parseInt returns the big.Int corresponding to the decimal integer s, nil if
+// s is not a valid integer.
+func parseInt(s string) *big.Int {
+	s = numberText(s)
+	if !bigIntRegex.MatchString(s) {
+		return nil
+	}
+	i, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return nil
+	}
+	return i
+}
+
+// unmarshalNumber decodes the JSON number or string held by data into v
+// making sure the result is valid. JSON null leaves v unchanged.
+func unmarshalNumber(data []byte, valid func(string) bool, kind string, v *string) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	s := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		var err error
+		if s, err = strconv.Unquote(s); err != nil {
+			return fmt.Errorf("invalid %s %s", kind, data)
+		}
+	}
+	if !valid(s) {
+		return fmt.Errorf("invalid %s %q", kind, s)
+	}
+	*v = s
+	return nil
+}
@@ -0,0 +1,161 @@
+package goa
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type decimalPayload struct {
+	Price  Decimal       `json:"price"`
+	Amount DecimalString `json:"amount"`
+	Count  BigInt        `json:"count"`
+	ID     BigIntString  `json:"id"`
+}
+
+func TestDecimalUnmarshal(t *testing.T) {
+	cases := []struct {
+		Name     string
+		JSON     string
+		Expected decimalPayload
+	}{
+		{"numbers", `{"price":12.50,"amount":-0.1e-3,"count":123456789012345678901234567890,"id":0}`,
+			decimalPayload{Price: "12.50", Amount: "-0.1e-3", Count: "123456789012345678901234567890", ID: "0"}},
+		{"strings", `{"price":"12.50","amount":"1","count":"-42","id":"7"}`,
+			decimalPayload{Price: "12.50", Amount: "1", Count: "-42", ID: "7"}},
+		{"null", `{"price":null}`, decimalPayload{}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var p decimalPayload
+			if err := json.Unmarshal([]byte(c.JSON), &p); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if p != c.Expected {
+				t.Errorf("got %+v, expected %+v", p, c.Expected)
+			}
+		})
+	}
+}
+
+func TestDecimalUnmarshalInvalid(t *testing.T) {
+	cases := map[string]string{
+		"not a number":    `{"price":"foo"}`,
+		"leading zero":    `{"price":"012"}`,
+		"fractional int":  `{"count":1.5}`,
+		"exponent int":    `{"id":"1e3"}`,
+		"boolean decimal": `{"amount":true}`,
+		"huge exponent":   `{"price":1e9999999}`,
+		"tiny exponent":   `{"amount":"1e-9999999"}`,
+	}
+	for n, j := range cases {
+		t.Run(n, func(t *testing.T) {
+			var p decimalPayload
+			if err := json.Unmarshal([]byte(j), &p); err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
+
+func TestParseDecimalExponent(t *testing.T) {
+	cases := []struct {
+		Name  string
+		Val   string
+		Valid bool
+	}{
+		{"max exponent", "1e1000000", true},
+		{"min exponent", "1e-1000000", true},
+		{"max exponent with fraction", "1.5e1000001", true},
+		{"exponent too large", "1e1000001", false},
+		{"exponent too small", "0.1e-1000000", false},
+		{"exponent overflow", "1e99999999999999999999", false},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			_, err := ParseDecimal(c.Val)
+			if c.Valid && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+			if !c.Valid && err == nil {
+				t.Errorf("expected an error")
+			}
+			if valid := Decimal(c.Val).Rat() != nil; valid != c.Valid {
+				t.Errorf("got Rat valid %v, expected %v", valid, c.Valid)
+			}
+		})
+	}
+}
+
+func TestDecimalMarshal(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Payload  decimalPayload
+		Expected string
+	}{
+		{"zero", decimalPayload{}, `{"price":0,"amount":"0","count":0,"id":"0"}`},
+		{"values", decimalPayload{Price: "12.50", Amount: "-1.25", Count: "123456789012345678901234567890", ID: "42"},
+			`{"price":12.50,"amount":"-1.25","count":123456789012345678901234567890,"id":"42"}`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			b, err := json.Marshal(c.Payload)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if string(b) != c.Expected {
+				t.Errorf("got %s, expected %s", b, c.Expected)
+			}
+		})
+	}
+}
+
+func TestCompareDecimal(t *testing.T) {
+	cases := []struct {
+		X, Y     string
+		Expected int
+	}{
+		{"1", "1.0", 0},
+		{"1.01", "1.1", -1},
+		{"123456789012345678901234567890", "123456789012345678901234567889", 1},
+		{"-1e2", "-99.5", -1},
+		{"", "0", 0},
+	}
+	for _, c := range cases {
+		if actual := CompareDecimal(c.X, c.Y); actual != c.Expected {
+			t.Errorf("CompareDecimal(%q, %q): got %d, expected %d", c.X, c.Y, actual, c.Expected)
+		}
+	}
+}
+
+func TestValidateDecimalRange(t *testing.T) {
+	cases := []struct {
+		Name      string
+		Val       string
+		Exclusive bool
+		Valid     bool
+	}{
+		{"in range", "5.5", false, true},
+		{"min", "0.01", false, true},
+		{"exclusive min", "0.01", true, false},
+		{"below min", "0.009", false, false},
+		{"max", "10", false, true},
+		{"exclusive max", "10.00", true, false},
+		{"above max", "10.000001", false, false},
+		{"invalid", "foo", false, false},
+		{"huge exponent", "1e9999999", false, false},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			err := MergeErrors(
+				ValidateDecimalMinimum("val", c.Val, "0.01", c.Exclusive),
+				ValidateDecimalMaximum("val", c.Val, "10", c.Exclusive),
+			)
+			if c.Valid && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+			if !c.Valid && err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
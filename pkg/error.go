@@ -56,6 +56,12 @@ const (
 	InvalidRange = "invalid_range"
 	// InvalidLength is the error name for invalid length errors.
 	InvalidLength = "invalid_length"
+	// InvalidExactlyOneOf is the error name for errors produced when not
+	// exactly one field of an ExactlyOneOf rule is set.
+	InvalidExactlyOneOf = "invalid_exactly_one_of"
+	// InvalidOrder is the error name for errors produced when the value of
+	// a field is not after the value of another field.
+	InvalidOrder = "invalid_order"
 	// UnsupportedMediaType is the error name returned by the Goa decoder
 	// when the content type of the HTTP request body is not supported.
 	UnsupportedMediaType = "unsupported_media_type"
@@ -176,7 +182,7 @@ func InvalidRangeError(name string, target any, value any, min bool) error {
 		comp = "lesser or equal"
 	}
 	return withField(name, PermanentError(
-		InvalidRange, "%s must be %s than %d but got value %#v", name, comp, value, target))
+		InvalidRange, "%s must be %s than %v but got value %#v", name, comp, value, target))
 }
 
 // InvalidLengthError is the error produced by the generated code when the value
@@ -191,6 +197,23 @@ func InvalidLengthError(name string, target any, ln, value int, min bool) error
 		InvalidLength, "length of %s must be %s than %d but got value %#v (len=%d)", name, comp, value, target, ln))
 }
 
+// InvalidExactlyOneOfError is the error produced by the generated code when a
+// payload does not set exactly one of the fields listed in an ExactlyOneOf
+// validation rule. context is the name of the object holding the fields and
+// count is the number of fields that are set.
+func InvalidExactlyOneOfError(context string, names []string, count int) error {
+	return withField(context, PermanentError(
+		InvalidExactlyOneOf, "exactly one of %s must be set in %s but got %d", strings.Join(names, ", "), context, count))
+}
+
+// InvalidOrderError is the error produced by the generated code when the value
+// of a payload field is not after the value of another field as required by an
+// After validation rule.
+func InvalidOrderError(name string, target any, other string, value any) error {
+	return withField(name, PermanentError(
+		InvalidOrder, "%s must be after %s (%v) but got value %v", name, other, value, target))
+}
+
 // NewErrorID creates a unique 8 character ID that is well suited to use as an
 // error identifier.
 func NewErrorID() string {
@@ -0,0 +1,66 @@
+package goa
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Nullable is the type of the fields generated for attributes that use the
+// Nullable DSL. It distinguishes three states: the field is absent (Set is
+// false), the field is explicitly null (Set and Null are true) or the field
+// holds a value (Set is true and Null is false).
+//
+// Nullable fields must be tagged with "omitzero" so that absent fields are
+// omitted when marshaling to JSON.
+type Nullable[T any] struct {
+	// Value is the field value, it is only meaningful when Set is true and
+	// Null is false.
+	Value T
+	// Set is true if the field is present, either with a value or null.
+	Set bool
+	// Null is true if the field is explicitly null.
+	Null bool
+}
+
+// NewNullable returns a Nullable holding the given value.
+func NewNullable[T any](v T) Nullable[T] {
+	return Nullable[T]{Value: v, Set: true}
+}
+
+// Null returns a Nullable that is explicitly null.
+func Null[T any]() Nullable[T] {
+	return Nullable[T]{Set: true, Null: true}
+}
+
+// IsValue returns true if n holds a value, that is if it is neither absent
+// nor null.
+func (n Nullable[T]) IsValue() bool {
+	return n.Set && !n.Null
+}
+
+// IsZero returns true if n is absent. It makes it possible for fields tagged
+// with "omitzero" to be omitted when marshaling.
+func (n Nullable[T]) IsZero() bool {
+	return !n.Set
+}
+
+// MarshalJSON encodes the value held by n or null if n is null or absent.
+func (n Nullable[T]) MarshalJSON() ([]byte, error) {
+	if !n.IsValue() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.Value)
+}
+
+// UnmarshalJSON decodes data into n. The JSON value null results in an
+// explicitly null Nullable.
+func (n *Nullable[T]) UnmarshalJSON(data []byte) error {
+	var zero T
+	n.Value = zero
+	n.Set = true
+	n.Null = bytes.Equal(bytes.TrimSpace(data), []byte("null"))
+	if n.Null {
+		return nil
+	}
+	return json.Unmarshal(data, &n.Value)
+}
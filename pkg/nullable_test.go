@@ -0,0 +1,64 @@
+package goa
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type nullablePayload struct {
+	Name Nullable[string] `json:"name,omitzero"`
+	Age  Nullable[int]    `json:"age,omitzero"`
+}
+
+func TestNullableUnmarshal(t *testing.T) {
+	cases := []struct {
+		Name     string
+		JSON     string
+		Expected nullablePayload
+	}{
+		{"absent", `{}`, nullablePayload{}},
+		{"null", `{"name":null}`, nullablePayload{Name: Null[string]()}},
+		{"value", `{"name":"goa","age":0}`, nullablePayload{Name: NewNullable("goa"), Age: NewNullable(0)}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var p nullablePayload
+			if err := json.Unmarshal([]byte(c.JSON), &p); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if p != c.Expected {
+				t.Errorf("got %+v, expected %+v", p, c.Expected)
+			}
+		})
+	}
+}
+
+func TestNullableMarshal(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Payload  nullablePayload
+		Expected string
+	}{
+		{"absent", nullablePayload{}, `{}`},
+		{"null", nullablePayload{Name: Null[string]()}, `{"name":null}`},
+		{"value", nullablePayload{Name: NewNullable("goa"), Age: NewNullable(0)}, `{"name":"goa","age":0}`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			b, err := json.Marshal(c.Payload)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if string(b) != c.Expected {
+				t.Errorf("got %s, expected %s", b, c.Expected)
+			}
+		})
+	}
+}
+
+func TestNullableInvalid(t *testing.T) {
+	var p nullablePayload
+	if err := json.Unmarshal([]byte(`{"age":"foo"}`), &p); err == nil {
+		t.Errorf("expected an error")
+	}
+}
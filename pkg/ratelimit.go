@@ -0,0 +1,161 @@
+package goa
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+type (
+	// RateLimitKeyFunc returns the key of the token bucket used to rate limit
+	// the given request. Requests that share the same key share the same
+	// bucket.
+	RateLimitKeyFunc func(ctx context.Context, req any) string
+
+	// RateLimitError is the error returned by the endpoints of methods that
+	// define rate or concurrency limits when a request is rejected. It wraps
+	// a temporary ServiceError named RateLimited.
+	RateLimitError struct {
+		*ServiceError
+		// RetryAfter is the duration after which the request may succeed.
+		RetryAfter time.Duration
+	}
+
+	// rateLimiter implements a token bucket rate limiter with one bucket
+	// per key.
+	rateLimiter struct {
+		// rate is the number of tokens added to each bucket per second.
+		rate float64
+		// burst is the capacity of the buckets.
+		burst float64
+		// now returns the current time.
+		now func() time.Time
+
+		mu      sync.Mutex
+		buckets map[string]*bucket
+	}
+
+	// bucket is a token bucket.
+	bucket struct {
+		tokens float64
+		last   time.Time
+	}
+)
+
+// RateLimited is the name of the errors returned when a request is rejected
+// because of a rate or concurrency limit.
+const RateLimited = "rate_limited"
+
+// maxIdleBuckets is the number of buckets above which full buckets are
+// discarded.
+const maxIdleBuckets = 1024
+
+// concurrencyRetryAfter is the retry delay advertised when a request is
+// rejected because the maximum number of concurrent requests is reached.
+const concurrencyRetryAfter = time.Second
+
+// RateLimit returns an endpoint middleware that allows at most limit requests
+// per period using token buckets. The buckets hold at most burst tokens so
+// that up to burst requests may be handled at once, burst defaults to limit
+// if not strictly positive. key computes the bucket of each request, all
+// requests share the same bucket if key is nil. Rejected requests fail with a
+// RateLimitError.
+func RateLimit(limit int, period time.Duration, burst int, key RateLimitKeyFunc) func(Endpoint) Endpoint {
+	if burst <= 0 {
+		burst = limit
+	}
+	l := &rateLimiter{
+		rate:    float64(limit) / period.Seconds(),
+		burst:   float64(burst),
+		now:     time.Now,
+		buckets: make(map[string]*bucket),
+	}
+	return func(e Endpoint) Endpoint {
+		return func(ctx context.Context, req any) (any, error) {
+			var k string
+			if key != nil {
+				k = key(ctx, req)
+			}
+			if wait := l.take(k); wait > 0 {
+				return nil, NewRateLimitError(wait, "rate limit exceeded, retry in %s", wait)
+			}
+			return e(ctx, req)
+		}
+	}
+}
+
+// MaxConcurrency returns an endpoint middleware that rejects requests with a
+// RateLimitError when max requests are already being handled.
+func MaxConcurrency(max int) func(Endpoint) Endpoint {
+	sem := make(chan struct{}, max)
+	return func(e Endpoint) Endpoint {
+		return func(ctx context.Context, req any) (any, error) {
+			select {
+			case sem <- struct{}{}:
+			default:
+				return nil, NewRateLimitError(concurrencyRetryAfter, "too many concurrent requests")
+			}
+			defer func() { <-sem }()
+			return e(ctx, req)
+		}
+	}
+}
+
+// NewRateLimitError creates a RateLimitError given the retry delay and a
+// format and values a la fmt.Printf.
+func NewRateLimitError(retryAfter time.Duration, format string, v ...any) *RateLimitError {
+	return &RateLimitError{
+		ServiceError: newError(RateLimited, false, true, false, format, v...),
+		RetryAfter:   retryAfter,
+	}
+}
+
+// Unwrap returns the underlying ServiceError.
+func (e *RateLimitError) Unwrap() error { return e.ServiceError }
+
+// RetryAfterSeconds returns the retry delay rounded up to the second as used
+// by the HTTP Retry-After header.
+func (e *RateLimitError) RetryAfterSeconds() int {
+	return int(math.Ceil(e.RetryAfter.Seconds()))
+}
+
+// take removes a token from the bucket with the given key. It returns zero if
+// a token was available, the duration after which a token becomes available
+// otherwise.
+func (l *rateLimiter) take(key string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	b, ok := l.buckets[key]
+	if !ok {
+		if len(l.buckets) >= maxIdleBuckets {
+			l.discardFull(now)
+		}
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[key] = b
+	}
+	b.refill(now, l.rate, l.burst)
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+}
+
+// discardFull deletes the buckets that are full, such buckets are equivalent
+// to new buckets.
+func (l *rateLimiter) discardFull(now time.Time) {
+	for k, b := range l.buckets {
+		b.refill(now, l.rate, l.burst)
+		if b.tokens >= l.burst {
+			delete(l.buckets, k)
+		}
+	}
+}
+
+// refill adds the tokens accumulated since the last refill.
+func (b *bucket) refill(now time.Time, rate, burst float64) {
+	b.tokens = math.Min(burst, b.tokens+now.Sub(b.last).Seconds()*rate)
+	b.last = now
+}
@@ -0,0 +1,99 @@
+package goa
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterTake(t *testing.T) {
+	now := time.Now()
+	l := &rateLimiter{rate: 2, burst: 2, now: func() time.Time { return now }, buckets: make(map[string]*bucket)}
+	cases := []struct {
+		Name    string
+		Advance time.Duration
+		Key     string
+		Wait    time.Duration
+	}{
+		{"first", 0, "a", 0},
+		{"burst", 0, "a", 0},
+		{"exhausted", 0, "a", 500 * time.Millisecond},
+		{"other key", 0, "b", 0},
+		{"partially refilled", 250 * time.Millisecond, "a", 250 * time.Millisecond},
+		{"refilled", 250 * time.Millisecond, "a", 0},
+	}
+	for _, c := range cases {
+		now = now.Add(c.Advance)
+		if wait := l.take(c.Key); wait != c.Wait {
+			t.Errorf("%s: got wait %s, expected %s", c.Name, wait, c.Wait)
+		}
+	}
+}
+
+func TestRateLimiterDiscardFull(t *testing.T) {
+	now := time.Now()
+	l := &rateLimiter{rate: 1, burst: 1, now: func() time.Time { return now }, buckets: make(map[string]*bucket)}
+	for i := 0; i < maxIdleBuckets; i++ {
+		l.take(string(rune(i)))
+	}
+	now = now.Add(time.Second)
+	l.take("new")
+	if len(l.buckets) != 1 {
+		t.Errorf("got %d buckets, expected 1", len(l.buckets))
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	ep := RateLimit(1, time.Hour, 1, func(_ context.Context, req any) string { return req.(string) })(
+		func(context.Context, any) (any, error) { return "ok", nil })
+	if _, err := ep(context.Background(), "a"); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if _, err := ep(context.Background(), "b"); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	_, err := ep(context.Background(), "a")
+	var rerr *RateLimitError
+	if !errors.As(err, &rerr) {
+		t.Fatalf("got error %#v, expected a RateLimitError", err)
+	}
+	if rerr.RetryAfterSeconds() != 3600 {
+		t.Errorf("got retry after %d, expected 3600", rerr.RetryAfterSeconds())
+	}
+	var serr *ServiceError
+	if !errors.As(err, &serr) || serr.Name != RateLimited || !serr.Temporary {
+		t.Errorf("got service error %#v, expected a temporary %q error", serr, RateLimited)
+	}
+}
+
+func TestMaxConcurrency(t *testing.T) {
+	var (
+		started = make(chan struct{})
+		release = make(chan struct{})
+	)
+	ep := MaxConcurrency(1)(func(context.Context, any) (any, error) {
+		started <- struct{}{}
+		<-release
+		return nil, nil
+	})
+	done := make(chan error)
+	go func() {
+		_, err := ep(context.Background(), nil)
+		done <- err
+	}()
+	<-started
+	_, err := ep(context.Background(), nil)
+	var rerr *RateLimitError
+	if !errors.As(err, &rerr) {
+		t.Errorf("got error %#v, expected a RateLimitError", err)
+	}
+	close(release)
+	if err := <-done; err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	go func() { <-started }()
+	if _, err := ep(context.Background(), nil); err != nil {
+		t.Errorf("unexpected error %v after release", err)
+	}
+}
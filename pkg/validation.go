@@ -1,6 +1,7 @@
 package goa
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"net"
@@ -58,13 +59,53 @@ const (
 
 	// FormatRFC1123 describes RFC1123 date time values.
 	FormatRFC1123 = "rfc1123"
+
+	// FormatDuration describes duration values using the syntax of Go
+	// time.ParseDuration, for example "1h30m".
+	FormatDuration = "duration"
+
+	// FormatE164 describes ITU-T E.164 international phone numbers, for
+	// example "+15551234567".
+	FormatE164 = "e164"
+
+	// FormatULID describes ULID values, for example
+	// "01ARZ3NDEKTSV4RRFFQ69G5FAV".
+	FormatULID = "ulid"
+
+	// FormatSemver describes semantic version 2.0.0 values, for example
+	// "1.2.3-beta.1".
+	FormatSemver = "semver"
+
+	// FormatCurrency describes ISO 4217 alphabetic currency codes, for
+	// example "USD".
+	FormatCurrency = "iso4217"
+
+	// FormatBase64 describes RFC4648 standard base64 encoded values.
+	FormatBase64 = "base64"
 )
 
 var (
 	hostnameRegex = regexp.MustCompile(`^[[:alnum:]][[:alnum:]\-]{0,61}[[:alnum:]]|[[:alpha:]]$`)
 	ipv4Regex     = regexp.MustCompile(`^(?:[0-9]{1,3}\.){3}[0-9]{1,3}$`)
+	e164Regex     = regexp.MustCompile(`^\+[1-9][0-9]{1,14}$`)
+	ulidRegex     = regexp.MustCompile(`^[0-7][0-9A-HJKMNP-TV-Za-hjkmnp-tv-z]{25}$`)
+	semverRegex   = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+	currencyRegex = regexp.MustCompile(`^[A-Z]{3}$`)
 )
 
+// customFormats records the validators of the formats registered with
+// RegisterFormat.
+var customFormats sync.Map
+
+// RegisterFormat registers the function used by ValidateFormat to validate
+// values of the custom format f. The generated code registers the validators
+// of the custom formats defined in the design with the CustomFormat DSL.
+// Registering a validator for a format that is already registered replaces
+// the previous validator.
+func RegisterFormat(f Format, validator func(string) error) {
+	customFormats.Store(f, validator)
+}
+
 // ValidateFormat validates val against f. It returns nil if the string conforms
 // to the format, an error otherwise. name is the name of the variable used in
 // error messages. where in a data structure the error occurred if any. The
@@ -82,6 +123,16 @@ var (
 //   - "cidr": RFC4632 and RFC4291 CIDR notation IP address value
 //   - "regexp": Regular expression syntax accepted by RE2
 //   - "rfc1123": RFC1123 date time value
+//   - "duration": Go duration value, for example "1h30m"
+//   - "e164": ITU-T E.164 international phone number
+//   - "ulid": ULID value
+//   - "semver": semantic version 2.0.0 value
+//   - "iso4217": ISO 4217 alphabetic currency code, only the shape of the code
+//     is validated
+//   - "base64": RFC4648 standard base64 encoded value
+//
+// ValidateFormat also supports the custom formats registered with
+// RegisterFormat.
 func ValidateFormat(name string, val string, f Format) error {
 	var err error
 	switch f {
@@ -127,8 +178,32 @@ func ValidateFormat(name string, val string, f Format) error {
 		}
 	case FormatRFC1123:
 		_, err = time.Parse(time.RFC1123, val)
+	case FormatDuration:
+		_, err = time.ParseDuration(val)
+	case FormatE164:
+		if !e164Regex.MatchString(val) {
+			err = fmt.Errorf("\"%s\" is an invalid E.164 phone number", val)
+		}
+	case FormatULID:
+		if !ulidRegex.MatchString(val) {
+			err = fmt.Errorf("\"%s\" is an invalid ULID", val)
+		}
+	case FormatSemver:
+		if !semverRegex.MatchString(val) {
+			err = fmt.Errorf("\"%s\" is an invalid semantic version", val)
+		}
+	case FormatCurrency:
+		if !currencyRegex.MatchString(val) {
+			err = fmt.Errorf("\"%s\" is an invalid ISO 4217 currency code", val)
+		}
+	case FormatBase64:
+		_, err = base64.StdEncoding.DecodeString(val)
 	default:
-		return fmt.Errorf("unknown format %#v", f)
+		v, ok := customFormats.Load(f)
+		if !ok {
+			return fmt.Errorf("unknown format %#v", f)
+		}
+		err = v.(func(string) error)(val)
 	}
 	if err != nil {
 		return InvalidFormatError(name, val, f, err)
@@ -163,6 +238,81 @@ func ValidatePattern(name, val, p string) error {
 	return nil
 }
 
+// ValidateExactlyOneOf returns an error if the number of true values in set is
+// not exactly one. context is the name of the object holding the fields and
+// names the names of the fields, set indicates whether each field is set.
+func ValidateExactlyOneOf(context string, names []string, set ...bool) error {
+	count := 0
+	for _, s := range set {
+		if s {
+			count++
+		}
+	}
+	if count != 1 {
+		return InvalidExactlyOneOfError(context, names, count)
+	}
+	return nil
+}
+
+// ValidateAfter returns an error if the date, date time or duration val is not
+// after value. name and other are the names of the variables holding val and
+// value used in error messages. ValidateAfter does not return an error if any
+// of the values cannot be parsed using the format f, format validations report
+// such errors.
+func ValidateAfter(name, val, other, value string, f Format) error {
+	var (
+		after bool
+		errv  error
+		erro  error
+	)
+	switch f {
+	case FormatDuration:
+		var v, o time.Duration
+		v, errv = time.ParseDuration(val)
+		o, erro = time.ParseDuration(value)
+		after = v > o
+	default:
+		layout := time.RFC3339
+		if f == FormatDate {
+			layout = time.DateOnly
+		}
+		var v, o time.Time
+		v, errv = time.Parse(layout, val)
+		o, erro = time.Parse(layout, value)
+		after = v.After(o)
+	}
+	if errv != nil || erro != nil || after {
+		return nil
+	}
+	return InvalidOrderError(name, val, other, value)
+}
+
+// ValidateDecimalMinimum returns an error if the decimal number val is less
+// than min or equal to min when exclusive is true. name is the name of the
+// variable used in error messages.
+func ValidateDecimalMinimum(name, val, min string, exclusive bool) error {
+	if parseRat(val) == nil {
+		return InvalidFieldTypeError(name, val, "decimal")
+	}
+	if c := CompareDecimal(val, min); c < 0 || exclusive && c == 0 {
+		return InvalidRangeError(name, val, min, true)
+	}
+	return nil
+}
+
+// ValidateDecimalMaximum returns an error if the decimal number val is greater
+// than max or equal to max when exclusive is true. name is the name of the
+// variable used in error messages.
+func ValidateDecimalMaximum(name, val, max string, exclusive bool) error {
+	if parseRat(val) == nil {
+		return InvalidFieldTypeError(name, val, "decimal")
+	}
+	if c := CompareDecimal(val, max); c > 0 || exclusive && c == 0 {
+		return InvalidRangeError(name, val, max, false)
+	}
+	return nil
+}
+
 // The following formats are supported:
 // "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
 // "6ba7b8109dad11d180b400c04fd430c8",
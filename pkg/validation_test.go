@@ -1,6 +1,7 @@
 package goa
 
 import (
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"net"
@@ -45,6 +46,16 @@ func TestValidateFormat(t *testing.T) {
 		invalidJSON     = "{"
 		validRFC1123    = "Mon, 04 Jun 2017 23:52:05 MST"
 		invalidRFC1123  = "Mon 04 Jun 2017 23:52:05 MST"
+		validE164       = "+15551234567"
+		invalidE164     = "5551234567"
+		validULID       = "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+		invalidULID     = "01ARZ3NDEKTSV4RRFFQ69G5FAU!"
+		validSemver     = "1.2.3-beta.1+build.5"
+		invalidSemver   = "1.2"
+		validCurrency   = "EUR"
+		invalidCurrency = "eur"
+		validBase64     = "Z29h"
+		invalidBase64   = "Z29"
 	)
 	cases := map[string]struct {
 		name     string
@@ -92,6 +103,16 @@ func TestValidateFormat(t *testing.T) {
 		"invalid json":       {"invalidJSON", invalidJSON, FormatJSON, InvalidFormatError("invalidJSON", invalidJSON, FormatJSON, fmt.Errorf("invalid JSON"))},
 		"valid rfc1123":      {"validRFC1123", validRFC1123, FormatRFC1123, nil},
 		"invalid rfc1123":    {"invalidRFC1123", invalidRFC1123, FormatRFC1123, InvalidFormatError("invalidRFC1123", invalidRFC1123, FormatRFC1123, &time.ParseError{Layout: time.RFC1123, Value: invalidRFC1123, LayoutElem: ", ", ValueElem: invalidRFC1123[3:]})},
+		"valid e164":         {"validE164", validE164, FormatE164, nil},
+		"invalid e164":       {"invalidE164", invalidE164, FormatE164, InvalidFormatError("invalidE164", invalidE164, FormatE164, fmt.Errorf("\"%s\" is an invalid E.164 phone number", invalidE164))},
+		"valid ulid":         {"validULID", validULID, FormatULID, nil},
+		"invalid ulid":       {"invalidULID", invalidULID, FormatULID, InvalidFormatError("invalidULID", invalidULID, FormatULID, fmt.Errorf("\"%s\" is an invalid ULID", invalidULID))},
+		"valid semver":       {"validSemver", validSemver, FormatSemver, nil},
+		"invalid semver":     {"invalidSemver", invalidSemver, FormatSemver, InvalidFormatError("invalidSemver", invalidSemver, FormatSemver, fmt.Errorf("\"%s\" is an invalid semantic version", invalidSemver))},
+		"valid currency":     {"validCurrency", validCurrency, FormatCurrency, nil},
+		"invalid currency":   {"invalidCurrency", invalidCurrency, FormatCurrency, InvalidFormatError("invalidCurrency", invalidCurrency, FormatCurrency, fmt.Errorf("\"%s\" is an invalid ISO 4217 currency code", invalidCurrency))},
+		"valid base64":       {"validBase64", validBase64, FormatBase64, nil},
+		"invalid base64":     {"invalidBase64", invalidBase64, FormatBase64, InvalidFormatError("invalidBase64", invalidBase64, FormatBase64, base64.CorruptInputError(0))},
 	}
 
 	for k, tc := range cases {
@@ -105,6 +126,26 @@ func TestValidateFormat(t *testing.T) {
 	}
 }
 
+func TestValidateCustomFormat(t *testing.T) {
+	const f Format = "even"
+	if err := ValidateFormat("val", "22", f); err == nil {
+		t.Fatal("expected an error for an unregistered format")
+	}
+	RegisterFormat(f, func(val string) error {
+		if len(val)%2 != 0 {
+			return fmt.Errorf("odd length")
+		}
+		return nil
+	})
+	if err := ValidateFormat("val", "22", f); err != nil {
+		t.Errorf("got error %q, expected none", err)
+	}
+	expected := InvalidFormatError("val", "222", f, fmt.Errorf("odd length"))
+	if err := ValidateFormat("val", "222", f); err == nil || err.Error() != expected.Error() {
+		t.Errorf("got error %v, expected %q", err, expected)
+	}
+}
+
 func TestValidatePattern(t *testing.T) {
 	var (
 		name      = "foo"
@@ -132,3 +173,59 @@ func TestValidatePattern(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateExactlyOneOf(t *testing.T) {
+	names := []string{"email", "phone"}
+	cases := map[string]struct {
+		set      []bool
+		expected error
+	}{
+		"one set":  {[]bool{false, true}, nil},
+		"none set": {[]bool{false, false}, InvalidExactlyOneOfError("body", names, 0)},
+		"both set": {[]bool{true, true}, InvalidExactlyOneOfError("body", names, 2)},
+	}
+
+	for k, tc := range cases {
+		actual := ValidateExactlyOneOf("body", names, tc.set...)
+		if tc.expected == nil {
+			if actual != nil {
+				t.Errorf("%s: got %#v, expected nil", k, actual)
+			}
+			continue
+		}
+		// Compare only the messages because the error has always a new error ID.
+		if actual == nil || actual.Error() != tc.expected.Error() {
+			t.Errorf("%s: got %#v, expected %#v", k, actual, tc.expected)
+		}
+	}
+}
+
+func TestValidateAfter(t *testing.T) {
+	cases := map[string]struct {
+		val      string
+		value    string
+		format   Format
+		expected error
+	}{
+		"date-time after":  {"2024-01-02T00:00:00Z", "2024-01-01T21:00:00-02:00", FormatDateTime, nil},
+		"date-time before": {"2024-01-01T01:00:00Z", "2024-01-01T00:00:00-02:00", FormatDateTime, InvalidOrderError("end", "2024-01-01T01:00:00Z", "start", "2024-01-01T00:00:00-02:00")},
+		"date equal":       {"2024-01-01", "2024-01-01", FormatDate, InvalidOrderError("end", "2024-01-01", "start", "2024-01-01")},
+		"duration after":   {"1h", "30m", FormatDuration, nil},
+		"duration before":  {"1m", "1h", FormatDuration, InvalidOrderError("end", "1m", "start", "1h")},
+		"invalid format":   {"foo", "2024-01-01", FormatDate, nil},
+	}
+
+	for k, tc := range cases {
+		actual := ValidateAfter("end", tc.val, "start", tc.value, tc.format)
+		if tc.expected == nil {
+			if actual != nil {
+				t.Errorf("%s: got %#v, expected nil", k, actual)
+			}
+			continue
+		}
+		// Compare only the messages because the error has always a new error ID.
+		if actual == nil || actual.Error() != tc.expected.Error() {
+			t.Errorf("%s: got %#v, expected %#v", k, actual, tc.expected)
+		}
+	}
+}